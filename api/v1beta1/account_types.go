@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AccountKind = "Account"
+)
+
+var (
+	AccountGVK        = GroupVersion.WithKind(AccountKind)
+	AccountAPIVersion = GroupVersion.String()
+)
+
+// AccountSpec defines the desired state of Account
+// +kubebuilder:validation:XValidation:rule="has(self.controllerRef) != has(self.accountingRef)",message="exactly one of controllerRef or accountingRef is required"
+type AccountSpec struct {
+	SlurmdbRef `json:",inline"`
+
+	// Description of the account. Defaults to the account name.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Description
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Organization of the account. Defaults to the parent account, or the
+	// account name if the parent is "root".
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Organization
+	// +optional
+	Organization string `json:"organization,omitempty"`
+
+	// ParentAccount is the name of the parent Slurm account.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Parent
+	// +optional
+	// +default:="root"
+	ParentAccount string `json:"parentAccount,omitempty"`
+
+	// DefaultQOS is the default QOS of jobs run under the account.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_DefaultQOS
+	// +optional
+	DefaultQOS string `json:"defaultQOS,omitempty"`
+
+	// QOS is the list of QOS which jobs run under the account may use.
+	// If empty, the QOS are inherited from the parent account.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_QosLevel
+	// +optional
+	// +listType=set
+	QOS []string `json:"qos,omitempty"`
+
+	// Limits of the account association.
+	// +optional
+	Limits AssociationLimits `json:"limits,omitzero"`
+}
+
+// AccountStatus defines the observed state of Account
+type AccountStatus struct {
+	// Limits are the limits of the account association, as observed in Slurm.
+	// +optional
+	Limits AssociationLimits `json:"limits,omitzero"`
+
+	// observedGeneration is the most recent generation observed for this Account.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the latest available observations of an Account's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=accounts;acct
+// +kubebuilder:printcolumn:name="PARENT",type="string",JSONPath=".spec.parentAccount",priority=0,description="The parent Slurm account."
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",priority=0,description="If the Slurm account is in sync."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Account is the Schema for the accounts API
+type Account struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccountSpec   `json:"spec,omitempty"`
+	Status AccountStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccountList contains a list of Account
+type AccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Account `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Account{}, &AccountList{})
+}
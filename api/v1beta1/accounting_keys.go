@@ -43,9 +43,31 @@ func (o *Accounting) ServiceFQDNShort() string {
 	return domainname.FqdnShort(s.Name, s.Namespace)
 }
 
+// StorageConfig returns the database configuration, which is derived from the
+// managed database, if set.
+func (o *Accounting) StorageConfig() StorageConfig {
+	managed := o.Spec.ManagedDatabase
+	if managed == nil {
+		return o.Spec.StorageConfig
+	}
+	key := o.MariaDBKey()
+	return StorageConfig{
+		Host:     domainname.Fqdn(key.Name, key.Namespace),
+		Port:     3306,
+		Database: managed.Database,
+		Username: managed.Username,
+		PasswordKeyRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: o.MariaDBPasswordKey().Name,
+			},
+			Key: MariaDBPasswordSecretKey,
+		},
+	}
+}
+
 func (o *Accounting) AuthStorageKey() types.NamespacedName {
 	return types.NamespacedName{
-		Name:      o.Spec.StorageConfig.PasswordKeyRef.Name,
+		Name:      o.StorageConfig().PasswordKeyRef.Name,
 		Namespace: o.Namespace,
 	}
 }
@@ -56,7 +78,37 @@ func (o *Accounting) AuthStorageRef() corev1.SecretKeySelector {
 		LocalObjectReference: corev1.LocalObjectReference{
 			Name: authKey.Name,
 		},
-		Key: o.Spec.StorageConfig.PasswordKeyRef.Key,
+		Key: o.StorageConfig().PasswordKeyRef.Key,
+	}
+}
+
+// MariaDBPasswordSecretKey is the key of the password in the secrets of the
+// managed database.
+const MariaDBPasswordSecretKey = "password"
+
+// MariaDBKey is the key of the MariaDB, and its Database, User, and Grant, of
+// the managed database.
+func (o *Accounting) MariaDBKey() types.NamespacedName {
+	key := o.Key()
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-mariadb", key.Name),
+		Namespace: o.Namespace,
+	}
+}
+
+func (o *Accounting) MariaDBPasswordKey() types.NamespacedName {
+	key := o.MariaDBKey()
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-password", key.Name),
+		Namespace: o.Namespace,
+	}
+}
+
+func (o *Accounting) MariaDBRootPasswordKey() types.NamespacedName {
+	key := o.MariaDBKey()
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-root", key.Name),
+		Namespace: o.Namespace,
 	}
 }
 
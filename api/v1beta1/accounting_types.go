@@ -5,6 +5,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -66,6 +67,23 @@ type AccountingSpec struct {
 	// +optional
 	StorageConfig StorageConfig `json:"storageConfig,omitzero"`
 
+	// ManagedDatabase provisions a MariaDB for accounting records with the
+	// mariadb-operator, instead of using an existing database.
+	// If set, then storageConfig is ignored.
+	// Ref: https://github.com/mariadb-operator/mariadb-operator
+	// +optional
+	ManagedDatabase *ManagedDatabase `json:"managedDatabase,omitempty"`
+
+	// Preflight checks that slurmdbd can use its database before changes to
+	// the slurmdbd configuration are rolled out.
+	// +optional
+	Preflight AccountingPreflight `json:"preflight,omitzero"`
+
+	// Retention defines the archive and purge policy of accounting records.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_PurgeJobAfter
+	// +optional
+	Retention *AccountingRetention `json:"retention,omitempty"`
+
 	// ExtraConf is appended onto the end of the `slurmdbd.conf` file.
 	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html
 	// +optional
@@ -76,6 +94,15 @@ type AccountingSpec struct {
 	Service ServiceSpec `json:"service,omitzero"`
 }
 
+// AccountingPreflight defines the database check of the slurmdbd configuration.
+type AccountingPreflight struct {
+	// Enabled controls if the database is checked. While the check fails,
+	// changes to the slurmdbd configuration are held back.
+	// +optional
+	// +default:=true
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
 // StorageConfig defines access to mysql/mariadb.
 type StorageConfig struct {
 	// Define the name of the host the database is running where we are going to
@@ -113,6 +140,131 @@ type StorageConfig struct {
 	PasswordKeyRef corev1.SecretKeySelector `json:"passwordKeyRef,omitzero"`
 }
 
+// ManagedDatabase defines a MariaDB provisioned by the mariadb-operator.
+type ManagedDatabase struct {
+	// Image is the MariaDB image to use.
+	// If empty, the mariadb-operator default is used.
+	// +optional
+	Image string `json:"image,omitzero"`
+
+	// Database is the name of the database created for accounting records.
+	// Default is "slurm_acct_db".
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_StorageLoc
+	// +optional
+	// +default:="slurm_acct_db"
+	Database string `json:"database,omitzero"`
+
+	// Username is the name of the user created to access the database.
+	// Default is "slurm".
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_StorageUser
+	// +optional
+	// +default:="slurm"
+	Username string `json:"username,omitzero"`
+
+	// Storage is the size of the volume holding the database.
+	// Default is "16Gi".
+	// +optional
+	Storage *resource.Quantity `json:"storage,omitempty"`
+
+	// StorageClassName is the name of the StorageClass of the volume.
+	// If empty, the default StorageClass is used.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitzero"`
+
+	// Resources are the MariaDB container resource limits and requests.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitzero"`
+
+	// MyCnf is the MariaDB server configuration.
+	// If empty, a configuration tuned for slurmdbd is used.
+	// Ref: https://slurm.schedmd.com/accounting.html#slurm-accounting-configuration-before-build
+	// +optional
+	MyCnf string `json:"myCnf,omitzero"`
+}
+
+// AccountingRetention defines the archive and purge policy of accounting records.
+// Purge durations are a number of months, optionally followed by `months`, or
+// a number followed by `hours` or `days` (e.g. "12months", "30days", "720hours").
+// Records are only archived when they are purged.
+type AccountingRetention struct {
+	// PurgeEventAfter is the age of node and cluster events to purge.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_PurgeEventAfter
+	// +optional
+	PurgeEventAfter string `json:"purgeEventAfter,omitzero"`
+
+	// PurgeJobAfter is the age of job records to purge.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_PurgeJobAfter
+	// +optional
+	PurgeJobAfter string `json:"purgeJobAfter,omitzero"`
+
+	// PurgeReservationAfter is the age of reservation records to purge.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_PurgeResvAfter
+	// +optional
+	PurgeReservationAfter string `json:"purgeReservationAfter,omitzero"`
+
+	// PurgeStepAfter is the age of job step records to purge.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_PurgeStepAfter
+	// +optional
+	PurgeStepAfter string `json:"purgeStepAfter,omitzero"`
+
+	// PurgeSuspendAfter is the age of job suspend records to purge.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_PurgeSuspendAfter
+	// +optional
+	PurgeSuspendAfter string `json:"purgeSuspendAfter,omitzero"`
+
+	// PurgeTXNAfter is the age of transaction records to purge.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_PurgeTXNAfter
+	// +optional
+	PurgeTXNAfter string `json:"purgeTXNAfter,omitzero"`
+
+	// PurgeUsageAfter is the age of usage records to purge.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_PurgeUsageAfter
+	// +optional
+	PurgeUsageAfter string `json:"purgeUsageAfter,omitzero"`
+
+	// ArchiveEvents archives node and cluster events when they are purged.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_ArchiveEvents
+	// +optional
+	ArchiveEvents bool `json:"archiveEvents,omitzero"`
+
+	// ArchiveJobs archives job records when they are purged.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_ArchiveJobs
+	// +optional
+	ArchiveJobs bool `json:"archiveJobs,omitzero"`
+
+	// ArchiveReservations archives reservation records when they are purged.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_ArchiveResvs
+	// +optional
+	ArchiveReservations bool `json:"archiveReservations,omitzero"`
+
+	// ArchiveSteps archives job step records when they are purged.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_ArchiveSteps
+	// +optional
+	ArchiveSteps bool `json:"archiveSteps,omitzero"`
+
+	// ArchiveSuspend archives job suspend records when they are purged.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_ArchiveSuspend
+	// +optional
+	ArchiveSuspend bool `json:"archiveSuspend,omitzero"`
+
+	// ArchiveTXN archives transaction records when they are purged.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_ArchiveTXN
+	// +optional
+	ArchiveTXN bool `json:"archiveTXN,omitzero"`
+
+	// ArchiveUsage archives usage records when they are purged.
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_ArchiveUsage
+	// +optional
+	ArchiveUsage bool `json:"archiveUsage,omitzero"`
+
+	// ArchiveVolume is the `PersistentVolumeClaim` that archives are written to,
+	// which is mounted into slurmdbd. It is required when records are archived.
+	// (e.g. `claimName: slurmdbd-archive`)
+	// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_ArchiveDir
+	// +optional
+	ArchiveVolume *corev1.PersistentVolumeClaimVolumeSource `json:"archiveVolume,omitempty"`
+}
+
 // AccountingStatus defines the observed state of Accounting
 type AccountingStatus struct {
 	// Represents the latest available observations of a Accounting's current state.
@@ -122,11 +274,19 @@ type AccountingStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Clusters are the clusters registered in slurmdbd.
+	// +optional
+	// +listType=set
+	Clusters []string `json:"clusters,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=slurmdbd
+// +kubebuilder:printcolumn:name="DATABASE",type="string",JSONPath=".status.conditions[?(@.type==\"DatabaseReady\")].status",priority=1,description="If the managed database is ready."
+// +kubebuilder:printcolumn:name="REACHABLE",type="string",JSONPath=".status.conditions[?(@.type==\"DatabaseReachable\")].status",description="If the database is reachable by slurmdbd."
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"SlurmdbdReady\")].status",description="If slurmdbd is ready."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Accounting is the Schema for the accountings API
@@ -9,6 +9,7 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // PodTemplate describes a template for creating copies of a predefined pod.
@@ -206,3 +207,84 @@ type ExternalConfig struct {
 	// +required
 	Port int `json:"port,omitzero"`
 }
+
+// SlurmdbRef references the Slurm cluster through which Slurm accounting
+// records are managed. Exactly one of `controllerRef` or `accountingRef` must be set.
+type SlurmdbRef struct {
+	// controllerRef is a reference to the Controller CR whose slurmrestd is used.
+	// +optional
+	ControllerRef *corev1.LocalObjectReference `json:"controllerRef,omitempty"`
+
+	// accountingRef is a reference to the Accounting CR whose slurmdbd stores
+	// the records. The slurmrestd of a Controller referencing the Accounting is used.
+	// +optional
+	AccountingRef *corev1.LocalObjectReference `json:"accountingRef,omitempty"`
+}
+
+// AssociationLimits defines the limits of a Slurm association.
+// Unset limits are cleared in Slurm (i.e. unlimited or inherited from the parent).
+// Ref: https://slurm.schedmd.com/sacctmgr.html#SECTION_GENERAL-SPECIFICATIONS-FOR-ASSOCIATION-BASED-ENTITIES
+type AssociationLimits struct {
+	// Fairshare is the number of shares used for fairshare scheduling.
+	// If unset, it is left to Slurm.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Fairshare
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Fairshare *int32 `json:"fairshare,omitempty"`
+
+	// Priority is added to the job priority of the association.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Priority
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Priority *int32 `json:"priority,omitempty"`
+
+	// GrpJobs is the maximum number of running jobs in aggregate for the association and its children.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_GrpJobs
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	GrpJobs *int32 `json:"grpJobs,omitempty"`
+
+	// GrpSubmitJobs is the maximum number of running and pending jobs in
+	// aggregate for the association and its children.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_GrpSubmitJobs
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	GrpSubmitJobs *int32 `json:"grpSubmitJobs,omitempty"`
+
+	// GrpTRES is the maximum TRES count in aggregate for the running jobs of
+	// the association and its children, by TRES (e.g. "cpu", "mem", "gres/gpu").
+	// Memory is in megabytes.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_GrpTRES
+	// +optional
+	GrpTRES map[string]int64 `json:"grpTRES,omitempty"`
+
+	// MaxJobs is the maximum number of running jobs for each user of the association.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxJobs
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxJobs *int32 `json:"maxJobs,omitempty"`
+
+	// MaxSubmitJobs is the maximum number of running and pending jobs for each user of the association.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxSubmitJobs
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxSubmitJobs *int32 `json:"maxSubmitJobs,omitempty"`
+
+	// MaxTRESPerJob is the maximum TRES count of each job, by TRES.
+	// Memory is in megabytes.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxTRES
+	// +optional
+	MaxTRESPerJob map[string]int64 `json:"maxTRESPerJob,omitempty"`
+
+	// MaxTRESPerNode is the maximum TRES count of each node of a job, by TRES.
+	// Memory is in megabytes.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxTRESPerNode
+	// +optional
+	MaxTRESPerNode map[string]int64 `json:"maxTRESPerNode,omitempty"`
+
+	// MaxWallDurationPerJob is the maximum wall clock time of each job.
+	// Slurm tracks this limit in minutes.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxWallDurationPerJob
+	// +optional
+	MaxWallDurationPerJob *metav1.Duration `json:"maxWallDurationPerJob,omitempty"`
+}
@@ -5,6 +5,7 @@ package v1beta1
 
 import (
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -35,6 +36,53 @@ func (o *Controller) PrimaryName() string {
 	return fmt.Sprintf("%s-0", key.Name)
 }
 
+// SlurmctldNames returns the host names of the slurmctld instances, with the
+// primary first and the backups after it.
+func (o *Controller) SlurmctldNames() []string {
+	if o.Spec.External {
+		return []string{o.PrimaryName()}
+	}
+	key := o.Key()
+	replicas := ptr.Deref(o.Spec.Replicas, 1)
+	names := make([]string, 0, replicas)
+	for i := range replicas {
+		names = append(names, fmt.Sprintf("%s-%d", key.Name, i))
+	}
+	return names
+}
+
+// SlurmctldServiceKey returns the key of the Service which addresses a single
+// slurmctld instance, by its host name.
+func (o *Controller) SlurmctldServiceKey(name string) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      name,
+		Namespace: o.Namespace,
+	}
+}
+
+func (o *Controller) SlurmctldServiceFQDNShort(name string) string {
+	s := o.SlurmctldServiceKey(name)
+	return domainname.FqdnShort(s.Name, s.Namespace)
+}
+
+func (o *Controller) StateSaveKey() types.NamespacedName {
+	key := o.Key()
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-statesave", key.Name),
+		Namespace: o.Namespace,
+	}
+}
+
+// BackupKey returns the key of the backup scheduled at the given time, which
+// names its VolumeSnapshot or archive.
+func (o *Controller) BackupKey(scheduled time.Time) types.NamespacedName {
+	key := o.Key()
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-backup-%s", key.Name, scheduled.UTC().Format("20060102150405")),
+		Namespace: o.Namespace,
+	}
+}
+
 func (o *Controller) PrimaryFQDN() string {
 	key := o.PrimaryName()
 	svc := o.ServiceFQDNShort()
@@ -60,6 +60,17 @@ type ControllerSpec struct {
 	// +optional
 	ExternalConfig ExternalConfig `json:"externalConfig,omitzero"`
 
+	// Replicas is the number of slurmctld instances. The first is the primary
+	// and the others are backups, in order, each with a `SlurmctldHost` entry.
+	// More than one replica requires persistence, which is then backed by a
+	// single ReadWriteMany claim shared by all replicas.
+	// If unspecified, defaults to 1.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SlurmctldHost
+	// +optional
+	// +default:=1
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
+
 	// The slurmctld container configuration.
 	// See corev1.Container spec.
 	// Ref: https://github.com/kubernetes/api/blob/master/core/v1/types.go#L2885
@@ -125,11 +136,32 @@ type ControllerSpec struct {
 	// +optional
 	EpilogSlurmctldScriptRefs []corev1.LocalObjectReference `json:"epilogSlurmctldScriptRefs,omitzero"`
 
+	// Topology defines the Slurm topology configuration, rendered into `topology.yaml`.
+	// Ref: https://slurm.schedmd.com/topology.yaml.html
+	// +optional
+	Topology ControllerTopology `json:"topology,omitzero"`
+
+	// Scheduling defines the Slurm scheduling and resource selection configuration,
+	// rendered into `slurm.conf`.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_SCHEDULING
+	// +optional
+	Scheduling ControllerScheduling `json:"scheduling,omitzero"`
+
 	// Persistence defines a persistent volume for the slurm controller to store its save-state.
 	// Used to recover from system failures or from pod upgrades.
 	// +optional
 	Persistence ControllerPersistence `json:"persistence,omitzero"`
 
+	// Backup defines scheduled backups of the slurmctld save-state.
+	// Requires persistence to be enabled.
+	// +optional
+	Backup *ControllerBackup `json:"backup,omitempty"`
+
+	// RestoreFrom is the backup that the slurmctld save-state is restored from
+	// when the Controller is created. It cannot be changed after deployment.
+	// +optional
+	RestoreFrom *ControllerRestore `json:"restoreFrom,omitempty"`
+
 	// Service defines a template for a Kubernetes Service object.
 	// +optional
 	Service ServiceSpec `json:"service,omitzero"`
@@ -139,6 +171,271 @@ type ControllerSpec struct {
 	Metrics Metrics `json:"metrics,omitzero"`
 }
 
+type ControllerTopology struct {
+	// Topologies is the list of topologies rendered into `topology.yaml`.
+	// If empty, then `topology.yaml` is not generated.
+	// Ref: https://slurm.schedmd.com/topology.yaml.html
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Topologies []Topology `json:"topologies,omitempty"`
+
+	// Params is the list of TopologyParam options.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_TopologyParam
+	// +optional
+	Params []string `json:"params,omitempty"`
+}
+
+// Topology defines a single topology of `topology.yaml`.
+// Exactly one of tree, block, or flat must be set.
+type Topology struct {
+	// Name is the name of the topology.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// ClusterDefault indicates that this topology is used by default.
+	// At most one topology may be the cluster default.
+	// +optional
+	ClusterDefault bool `json:"clusterDefault,omitzero"`
+
+	// Tree defines a `topology/tree` topology.
+	// Ref: https://slurm.schedmd.com/topology.html#tree
+	// +optional
+	Tree *TopologyTree `json:"tree,omitempty"`
+
+	// Block defines a `topology/block` topology.
+	// Ref: https://slurm.schedmd.com/topology.html#block
+	// +optional
+	Block *TopologyBlock `json:"block,omitempty"`
+
+	// Flat defines a `topology/flat` topology.
+	// +optional
+	Flat bool `json:"flat,omitzero"`
+}
+
+type TopologyTree struct {
+	// Switches is the list of switches of the tree.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	Switches []TopologySwitch `json:"switches"`
+}
+
+type TopologySwitch struct {
+	// Name is the name of the switch.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Children is a hostlist of child switches.
+	// +optional
+	Children string `json:"children,omitempty"`
+
+	// Nodes is a hostlist of Slurm nodes connected to the switch.
+	// +optional
+	Nodes string `json:"nodes,omitempty"`
+}
+
+type TopologyBlock struct {
+	// BlockSizes is the list of planning base block sizes.
+	// +optional
+	BlockSizes []int32 `json:"blockSizes,omitempty"`
+
+	// Blocks is the list of base blocks.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	Blocks []TopologyBlockEntry `json:"blocks"`
+}
+
+type TopologyBlockEntry struct {
+	// Name is the name of the block.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Nodes is a hostlist of Slurm nodes in the block.
+	// +optional
+	Nodes string `json:"nodes,omitempty"`
+}
+
+type ControllerScheduling struct {
+	// SchedulerType is the scheduler plugin.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SchedulerType
+	// +kubebuilder:validation:Enum=sched/backfill;sched/builtin
+	// +optional
+	SchedulerType string `json:"schedulerType,omitempty"`
+
+	// SchedulerParameters is the list of scheduler options.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SchedulerParameters
+	// +optional
+	SchedulerParameters []string `json:"schedulerParameters,omitempty"`
+
+	// SelectType is the resource selection plugin.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SelectType
+	// +kubebuilder:validation:Enum=select/cons_tres;select/linear
+	// +optional
+	SelectType string `json:"selectType,omitempty"`
+
+	// SelectTypeParameters is the list of resource selection options.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SelectTypeParameters
+	// +optional
+	SelectTypeParameters []string `json:"selectTypeParameters,omitempty"`
+
+	// Priority defines the job priority configuration.
+	// +optional
+	Priority SchedulingPriority `json:"priority,omitzero"`
+
+	// Preempt defines the job preemption configuration.
+	// +optional
+	Preempt SchedulingPreempt `json:"preempt,omitzero"`
+
+	// Limits defines the job and step limits.
+	// +optional
+	Limits SchedulingLimits `json:"limits,omitzero"`
+}
+
+type SchedulingPriority struct {
+	// Type is the job priority plugin.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityType
+	// +kubebuilder:validation:Enum=priority/basic;priority/multifactor
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Flags is the list of job priority flags.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityFlags
+	// +optional
+	Flags []string `json:"flags,omitempty"`
+
+	// DecayHalfLife is the half-life of historical usage, in Slurm time format.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityDecayHalfLife
+	// +optional
+	DecayHalfLife string `json:"decayHalfLife,omitempty"`
+
+	// MaxAge is the job age at which the age factor is maxed, in Slurm time format.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityMaxAge
+	// +optional
+	MaxAge string `json:"maxAge,omitempty"`
+
+	// Weights defines the multifactor priority weights.
+	// Ref: https://slurm.schedmd.com/priority_multifactor.html
+	// +optional
+	Weights PriorityWeights `json:"weights,omitzero"`
+}
+
+type PriorityWeights struct {
+	// Age is the PriorityWeightAge.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightAge
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Age *int32 `json:"age,omitempty"`
+
+	// Assoc is the PriorityWeightAssoc.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightAssoc
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Assoc *int32 `json:"assoc,omitempty"`
+
+	// Fairshare is the PriorityWeightFairshare.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightFairshare
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Fairshare *int32 `json:"fairshare,omitempty"`
+
+	// JobSize is the PriorityWeightJobSize.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightJobSize
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	JobSize *int32 `json:"jobSize,omitempty"`
+
+	// Partition is the PriorityWeightPartition.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightPartition
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Partition *int32 `json:"partition,omitempty"`
+
+	// QOS is the PriorityWeightQOS.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightQOS
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	QOS *int32 `json:"qos,omitempty"`
+
+	// TRES is the PriorityWeightTRES, keyed by TRES type (e.g. `CPU`, `Mem`, `GRES/gpu`).
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightTRES
+	// +optional
+	TRES map[string]int32 `json:"tres,omitempty"`
+}
+
+type SchedulingPreempt struct {
+	// Type is the job preemption plugin.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PreemptType
+	// +kubebuilder:validation:Enum=preempt/none;preempt/partition_prio;preempt/qos
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Mode is the list of job preemption modes.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PreemptMode
+	// +kubebuilder:validation:items:Enum=OFF;CANCEL;GANG;REQUEUE;SUSPEND;WITHIN
+	// +optional
+	Mode []string `json:"mode,omitempty"`
+
+	// ExemptTime is the minimum run time before a job can be preempted, in Slurm time format.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PreemptExemptTime
+	// +optional
+	ExemptTime string `json:"exemptTime,omitempty"`
+}
+
+type SchedulingLimits struct {
+	// MaxJobCount is the maximum number of jobs slurmctld can have in memory.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MaxJobCount
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxJobCount *int32 `json:"maxJobCount,omitempty"`
+
+	// MaxArraySize is the maximum job array task index value plus one.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MaxArraySize
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxArraySize *int32 `json:"maxArraySize,omitempty"`
+
+	// MaxStepCount is the maximum number of steps any job can initiate.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MaxStepCount
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxStepCount *int32 `json:"maxStepCount,omitempty"`
+
+	// MaxTasksPerNode is the maximum number of tasks a job step can spawn on a single node.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MaxTasksPerNode
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTasksPerNode *int32 `json:"maxTasksPerNode,omitempty"`
+
+	// DefMemPerCPU is the default real memory size available per allocated CPU, in megabytes.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_DefMemPerCPU
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DefMemPerCPU *int64 `json:"defMemPerCPU,omitempty"`
+
+	// DefMemPerNode is the default real memory size available per allocated node, in megabytes.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_DefMemPerNode
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DefMemPerNode *int64 `json:"defMemPerNode,omitempty"`
+
+	// MaxMemPerCPU is the maximum real memory size available per allocated CPU, in megabytes.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MaxMemPerCPU
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxMemPerCPU *int64 `json:"maxMemPerCPU,omitempty"`
+
+	// MaxMemPerNode is the maximum real memory size available per allocated node, in megabytes.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MaxMemPerNode
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxMemPerNode *int64 `json:"maxMemPerNode,omitempty"`
+}
+
 type ControllerPersistence struct {
 	// Enabled controls if the optional accounting subsystem is enabled.
 	// +default:=true
@@ -153,8 +450,114 @@ type ControllerPersistence struct {
 	corev1.PersistentVolumeClaimSpec `json:",inline"`
 }
 
+// ControllerBackup defines scheduled backups of the slurmctld save-state.
+// +kubebuilder:validation:XValidation:rule="has(self.volumeSnapshot) != has(self.persistentVolumeClaim)",message="exactly one of volumeSnapshot or persistentVolumeClaim is required"
+type ControllerBackup struct {
+	// Schedule is when backups are taken, in Cron format.
+	// Ref: https://en.wikipedia.org/wiki/Cron
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Retention is the number of backups to keep, older ones are deleted.
+	// +optional
+	// +default:=7
+	// +kubebuilder:validation:Minimum=1
+	Retention *int32 `json:"retention,omitempty"`
+
+	// VolumeSnapshot takes backups as a `VolumeSnapshot` of the save-state claim.
+	// Snapshots are not deleted with the Controller.
+	// Ref: https://kubernetes.io/docs/concepts/storage/volume-snapshots/
+	// +optional
+	VolumeSnapshot *ControllerBackupVolumeSnapshot `json:"volumeSnapshot,omitempty"`
+
+	// PersistentVolumeClaim takes backups as archives of the save-state, stored
+	// in an existing claim under a directory named after the Controller.
+	// +optional
+	PersistentVolumeClaim *ControllerBackupVolume `json:"persistentVolumeClaim,omitempty"`
+}
+
+type ControllerBackupVolumeSnapshot struct {
+	// VolumeSnapshotClassName is the name of the `VolumeSnapshotClass` of the snapshots.
+	// If empty, then the default class is used.
+	// +optional
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+type ControllerBackupVolume struct {
+	// ClaimName is the name of an existing `PersistentVolumeClaim`.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	ClaimName string `json:"claimName"`
+}
+
+// ControllerRestore defines the backup that the slurmctld save-state is restored from.
+// +kubebuilder:validation:XValidation:rule="has(self.volumeSnapshotName) != has(self.persistentVolumeClaim)",message="exactly one of volumeSnapshotName or persistentVolumeClaim is required"
+type ControllerRestore struct {
+	// VolumeSnapshotName is the name of a `VolumeSnapshot` that the save-state
+	// claim is created from.
+	// +optional
+	VolumeSnapshotName string `json:"volumeSnapshotName,omitempty"`
+
+	// PersistentVolumeClaim is a claim holding an archive that the save-state is
+	// extracted from, when the save-state is empty.
+	// +optional
+	PersistentVolumeClaim *ControllerRestoreVolume `json:"persistentVolumeClaim,omitempty"`
+}
+
+type ControllerRestoreVolume struct {
+	// ClaimName is the name of an existing `PersistentVolumeClaim`.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	ClaimName string `json:"claimName"`
+
+	// Path is the archive within the claim, or a directory of which the latest
+	// archive is used. If empty, then the directory named after the Controller.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// ControllerBackupStatus defines the observed state of the backups.
+type ControllerBackupStatus struct {
+	// LastScheduleTime is the time the last backup was scheduled.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastSuccessfulTime is the time the last successful backup completed.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// LastSuccessfulBackup is the name of the last successful backup, which is
+	// a `VolumeSnapshot` or an archive.
+	// +optional
+	LastSuccessfulBackup string `json:"lastSuccessfulBackup,omitempty"`
+}
+
 // ControllerStatus defines the observed state of Controller
 type ControllerStatus struct {
+	// ActiveController is the slurmctld host currently in control, as
+	// reported by ping.
+	// +optional
+	ActiveController string `json:"activeController,omitempty"`
+
+	// ConfigHash is the hash of the rendered Slurm configuration.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// AppliedConfigHash is the hash of the Slurm configuration that slurmctld
+	// has acknowledged. It equals ConfigHash once the configuration is live.
+	// +optional
+	AppliedConfigHash string `json:"appliedConfigHash,omitempty"`
+
+	// LastReconfigureTime is the time slurmctld last (re)loaded the Slurm
+	// configuration, as reported by slurmctld.
+	// +optional
+	LastReconfigureTime *metav1.Time `json:"lastReconfigureTime,omitempty"`
+
+	// Backup is the observed state of the backups.
+	// +optional
+	Backup *ControllerBackupStatus `json:"backup,omitempty"`
+
 	// Represents the latest available observations of a Controller's current state.
 	// +optional
 	// +patchMergeKey=type
@@ -167,6 +570,10 @@ type ControllerStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=slurmctld
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",priority=0,description="If slurmctld is reachable and running the rendered configuration."
+// +kubebuilder:printcolumn:name="CONFIG APPLIED",type="string",JSONPath=".status.conditions[?(@.type==\"ConfigApplied\")].status",priority=1,description="If slurmctld has acknowledged the rendered configuration."
+// +kubebuilder:printcolumn:name="ACTIVE",type="string",JSONPath=".status.activeController",priority=1,description="The slurmctld host currently in control."
+// +kubebuilder:printcolumn:name="LAST BACKUP",type="date",JSONPath=".status.backup.lastSuccessfulTime",priority=1,description="The time the last successful backup completed."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Controller is the Schema for the controllers API
@@ -48,3 +48,10 @@ func (o *NodeSet) SshConfigKey() types.NamespacedName {
 		Namespace: o.Namespace,
 	}
 }
+
+func (o *NodeSet) NodeConfigKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      fmt.Sprintf("%s-node-config", o.Name),
+		Namespace: o.Namespace,
+	}
+}
@@ -5,6 +5,8 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -65,6 +67,63 @@ type NodeSetSpec struct {
 	// +optional
 	ExtraConf string `json:"extraConf,omitzero"`
 
+	// ConfigFileRefs is a list of ConfigMap references containing node-scoped
+	// config files (e.g. `cgroup.conf`, `gres.conf`) for the Slurm nodes of this
+	// NodeSet. They are scoped to the Slurm nodes of this NodeSet in the config
+	// files served by the Controller, which include them only on the pods of
+	// this NodeSet.
+	// Ref: https://slurm.schedmd.com/configless_slurm.html
+	// +nullable
+	// +optional
+	ConfigFileRefs []corev1.LocalObjectReference `json:"configFileRefs,omitzero"`
+
+	// PrologScriptRefs is a list of prolog scripts to be run only on the Slurm
+	// nodes of this NodeSet, in addition to those of the Controller. They are
+	// mounted into the NodeSet pods and run by a dispatcher in the Controller config.
+	// Ref: https://slurm.schedmd.com/prolog_epilog.html
+	// +nullable
+	// +optional
+	PrologScriptRefs []corev1.LocalObjectReference `json:"prologScriptRefs,omitzero"`
+
+	// EpilogScriptRefs is a list of epilog scripts to be run only on the Slurm
+	// nodes of this NodeSet, in addition to those of the Controller. They are
+	// mounted into the NodeSet pods and run by a dispatcher in the Controller config.
+	// Ref: https://slurm.schedmd.com/prolog_epilog.html
+	// +nullable
+	// +optional
+	EpilogScriptRefs []corev1.LocalObjectReference `json:"epilogScriptRefs,omitzero"`
+
+	// FeatureLabels maps Kubernetes node labels to Slurm node features. The
+	// features are kept in sync with the labels of the node the pod is bound to.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_Features
+	// +optional
+	// +listType=map
+	// +listMapKey=key
+	FeatureLabels []NodeSetFeatureLabel `json:"featureLabels,omitempty"`
+
+	// TopologyLabels maps Kubernetes node labels to Slurm topology units. The
+	// Slurm node topology spec is derived from the labels of the node the pod is
+	// bound to. The `topology.slinky.slurm.net/spec` node annotation takes
+	// precedence for the topologies it contains.
+	// Ref: https://slurm.schedmd.com/topology.yaml.html
+	// +optional
+	// +listType=map
+	// +listMapKey=topology
+	TopologyLabels []NodeSetTopologyLabel `json:"topologyLabels,omitempty"`
+
+	// Gres maps extended resources of the slurmd container, or resource claims
+	// of the pod, to Slurm GRES.
+	// Ref: https://slurm.schedmd.com/gres.html
+	// +optional
+	// +listType=atomic
+	Gres []NodeSetGres `json:"gres,omitempty"`
+
+	// ResourceSpec derives the Slurm node CPU layout and specialized resources
+	// of slurmd from the pod resources and the hardware of the Kubernetes node
+	// the pod is bound to.
+	// +optional
+	ResourceSpec NodeSetResourceSpec `json:"resourceSpec,omitzero"`
+
 	// Partition defines the Slurm partition configuration for this NodeSet.
 	// +optional
 	Partition NodeSetPartition `json:"partition,omitzero"`
@@ -81,6 +140,17 @@ type NodeSetSpec struct {
 	// +kubebuilder:validation:Schemaless
 	VolumeClaimTemplates []corev1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
 
+	// ResourceClaimTemplates is a list of DRA resource claims that pods are
+	// allocated. Every pod gets its own ResourceClaim, named after the template
+	// and the pod identity like the claims of volumeClaimTemplates, and follows
+	// the same persistentVolumeClaimRetentionPolicy. The slurmd container is
+	// given access to all claims.
+	// Ref: https://kubernetes.io/docs/concepts/scheduling-eviction/dynamic-resource-allocation/
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ResourceClaimTemplates []NodeSetResourceClaimTemplate `json:"resourceClaimTemplates,omitempty"`
+
 	// updateStrategy indicates the NodeSetUpdateStrategy that will be
 	// employed to update Pods in the NodeSet when a revision is made to
 	// Template.
@@ -142,8 +212,242 @@ type NodeSetSpec struct {
 	// +optional
 	// +default:=false
 	OversubscribeNode bool `json:"oversubscribeNode,omitempty"`
+
+	// Autoscaling configures the built-in autoscaler, which scales replicas
+	// based on the Slurm job queue of the NodeSet partition.
+	// Used only when `scalingMode=StatefulSet`.
+	// +optional
+	Autoscaling NodeSetAutoscaling `json:"autoscaling,omitzero"`
+
+	// PowerSave configures Slurm power saving, which lets slurmctld drive the
+	// NodeSet capacity by resuming and suspending its Slurm nodes.
+	// This is used only when `scalingMode=StatefulSet`.
+	// +optional
+	PowerSave NodeSetPowerSave `json:"powerSave,omitzero"`
+
+	// DrainPolicy configures how long a Slurm node may take to drain before its
+	// pod is terminated (e.g. scale-in, updates), and what is done when it expires.
+	// +optional
+	DrainPolicy NodeSetDrainPolicy `json:"drainPolicy,omitzero"`
 }
 
+// NodeSetAutoscaling defines the built-in autoscaling policy for the NodeSet.
+type NodeSetAutoscaling struct {
+	// Enabled will allow the operator to manage the NodeSet replicas based on
+	// the pending jobs and idle nodes of the NodeSet partition.
+	// Requires `partition.enabled=true`.
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// MinReplicas is the lower limit for the number of replicas to which the
+	// autoscaler can scale in.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +default:=0
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas to which the
+	// autoscaler can scale out. It cannot be less than MinReplicas.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+
+	// ScaleUpStabilizationWindow is the duration for which past recommendations
+	// are considered when scaling out. The lowest recommendation is used.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +optional
+	// +kubebuilder:default:="0s"
+	ScaleUpStabilizationWindow *metav1.Duration `json:"scaleUpStabilizationWindow,omitempty"`
+
+	// ScaleDownStabilizationWindow is the duration for which past recommendations
+	// are considered when scaling in. The highest recommendation is used.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +optional
+	// +kubebuilder:default:="5m"
+	ScaleDownStabilizationWindow *metav1.Duration `json:"scaleDownStabilizationWindow,omitempty"`
+
+	// IdleTimeout is the duration a Slurm node must be idle before it is
+	// considered for scale-in.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +optional
+	// +kubebuilder:default:="5m"
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+}
+
+// NodeSetPowerSave defines the Slurm power saving configuration for the NodeSet.
+// Ref: https://slurm.schedmd.com/power_save.html
+type NodeSetPowerSave struct {
+	// Enabled will pre-register `replicas` Slurm nodes in the CLOUD state. Pods
+	// are created when Slurm resumes a node and deleted when Slurm suspends it,
+	// hence `replicas` is the maximum number of pods rather than the current.
+	// Requires `partition.enabled=true`.
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// SuspendTime is the duration a Slurm node must be idle before Slurm
+	// suspends it. It is applied to the NodeSet partition.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SuspendTime
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +optional
+	// +kubebuilder:default:="5m"
+	SuspendTime *metav1.Duration `json:"suspendTime,omitempty"`
+
+	// ResumeTimeout is the maximum duration for a resumed Slurm node to
+	// register, otherwise Slurm will set it DOWN. It is applied to the NodeSet
+	// partition.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_ResumeTimeout
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +optional
+	// +kubebuilder:default:="10m"
+	ResumeTimeout *metav1.Duration `json:"resumeTimeout,omitempty"`
+}
+
+// NodeSetFeatureLabel maps a Kubernetes node label to Slurm node features.
+type NodeSetFeatureLabel struct {
+	// Key is the Kubernetes node label key (e.g. `topology.kubernetes.io/zone`).
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Values maps label values to Slurm node features (e.g. `NVIDIA-H100-80GB-HBM3: h100`).
+	// Label values not in the map are used as the feature as-is.
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// NodeSetTopologyLabel maps a Kubernetes node label to a Slurm topology unit.
+type NodeSetTopologyLabel struct {
+	// Topology is the name of the topology in `topology.yaml` (e.g. `topo-block`).
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Topology string `json:"topology"`
+
+	// Key is the Kubernetes node label key whose value is the topology unit,
+	// the block or switch name (e.g. `topology.kubernetes.io/zone`).
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+}
+
+// NodeSetResourceSpec defines how the Slurm node resources are derived.
+type NodeSetResourceSpec struct {
+	// Enabled derives Sockets, CoresPerSocket, ThreadsPerCore, and RealMemory of
+	// the Slurm node from the slurmd CPU and memory limits, instead of CPUs and
+	// RealMemory. The `nodeset.slinky.slurm.net/node-hardware` annotation of
+	// the Kubernetes node describes its hardware (e.g.
+	// "Sockets=2 CoresPerSocket=32 ThreadsPerCore=2"). The CPU limit should be
+	// an integer in a Guaranteed pod, as allocated by the static CPU manager.
+	// Ref: https://kubernetes.io/docs/tasks/administer-cluster/cpu-management-policies/
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ReservedCpus is the number of CPUs reserved for slurmd and system use,
+	// set as the CpuSpecList of the Slurm node.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_CpuSpecList
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ReservedCpus int32 `json:"reservedCpus,omitempty"`
+
+	// ReservedMemory is the memory reserved for slurmd and system use, set as
+	// the MemSpecLimit of the Slurm node.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MemSpecLimit
+	// +optional
+	ReservedMemory resource.Quantity `json:"reservedMemory,omitzero"`
+}
+
+// NodeSetGres defines a Slurm GRES backed by an extended resource or a resource claim.
+// Exactly one of resourceName or resourceClaim must be set.
+type NodeSetGres struct {
+	// ResourceName is the extended resource backing the GRES (e.g. `nvidia.com/gpu`).
+	// The GRES count is the resource limit of the slurmd container.
+	// +optional
+	ResourceName corev1.ResourceName `json:"resourceName,omitempty"`
+
+	// ResourceClaim is the name of the resourceClaimTemplate backing the GRES.
+	// The GRES count is the number of devices requested by the claim.
+	// +optional
+	ResourceClaim string `json:"resourceClaim,omitempty"`
+
+	// Name is the GRES name (e.g. `gpu`).
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Type is the GRES type (e.g. `a100`).
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// AutoDetect is the hardware detection mechanism of the GRES (e.g. `nvml`).
+	// Ref: https://slurm.schedmd.com/gres.conf.html#OPT_AutoDetect
+	// +optional
+	AutoDetect string `json:"autoDetect,omitempty"`
+
+	// File is the device file path(s) of the GRES (e.g. `/dev/nvidia[0-3]`).
+	// Ref: https://slurm.schedmd.com/gres.conf.html#OPT_File
+	// +optional
+	File string `json:"file,omitempty"`
+}
+
+// NodeSetResourceClaimTemplate describes the ResourceClaim of each NodeSet pod.
+type NodeSetResourceClaimTemplate struct {
+	// Name is the name of the template, which is also the name of the pod
+	// resource claim.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Metadata is applied to the ResourceClaims.
+	// +optional
+	Metadata Metadata `json:"metadata,omitzero"`
+
+	// Spec is the spec of the ResourceClaims.
+	// Ref: https://kubernetes.io/docs/reference/kubernetes-api/workload-resources/resource-claim-v1/
+	// +required
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	Spec resourcev1.ResourceClaimSpec `json:"spec"`
+
+	// Features are added to the Slurm node once the claim is allocated.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_Features
+	// +optional
+	Features []string `json:"features,omitempty"`
+}
+
+// NodeSetDrainPolicy defines the drain policy of NodeSet pods pending termination.
+type NodeSetDrainPolicy struct {
+	// Timeout is the maximum duration to wait for a Slurm node to drain before
+	// the timeout action is taken. If unset, the operator waits indefinitely.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// TimeoutAction is the action taken when the timeout expires.
+	// +optional
+	// +kubebuilder:validation:Enum=Requeue;Cancel;ForceDelete
+	// +kubebuilder:default:="Requeue"
+	TimeoutAction DrainTimeoutActionType `json:"timeoutAction,omitempty"`
+}
+
+// DrainTimeoutActionType is a string enumeration of the actions taken when a
+// Slurm node does not drain in time.
+// +enum
+type DrainTimeoutActionType string
+
+const (
+	// DrainTimeoutActionRequeue sets the Slurm node DOWN, which cancels its
+	// running jobs. Slurm requeues the jobs which allow it, the others are
+	// terminated.
+	DrainTimeoutActionRequeue DrainTimeoutActionType = "Requeue"
+
+	// DrainTimeoutActionCancel cancels the running jobs of the Slurm node.
+	DrainTimeoutActionCancel DrainTimeoutActionType = "Cancel"
+
+	// DrainTimeoutActionForceDelete force deletes the pod, without waiting for
+	// the running jobs of the Slurm node.
+	DrainTimeoutActionForceDelete DrainTimeoutActionType = "ForceDelete"
+)
+
 // ScalingModeType is a string enumeration of how a NodeSet scales its pods.
 // +enum
 type ScalingModeType string
@@ -281,6 +585,27 @@ type RollingUpdateNodeSetStrategy struct {
 	// +optional
 	// +kubebuilder:default:="25%"
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// The maximum number of pods that can be scheduled above the desired number of
+	// pods during the update. Surge pods are created on the update revision with
+	// temporary ordinals, and old pods are only drained and deleted once the new
+	// pods are registered and IDLE in Slurm. This keeps capacity flat during the update.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	// Absolute number is calculated from percentage by rounding up.
+	// Not supported in DaemonSet mode or with power saving.
+	// Defaults to 0.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// Partition indicates the ordinal at which the NodeSet should be partitioned
+	// for updates. During a rolling update, all pods from ordinal Replicas-1 to
+	// Partition are updated. All pods from ordinal Partition-1 to 0 remain
+	// untouched. This is helpful in being able to do a canary based deployment.
+	// Not supported in DaemonSet mode.
+	// Defaults to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Partition *int32 `json:"partition,omitempty"`
 }
 
 // ScheduledUpdateNodeSetStrategy is used to communicate parameters for
@@ -387,6 +712,16 @@ type NodeSetStatus struct {
 	// latest version of the NodeSet.
 	NodeSetHash string `json:"nodeSetHash"`
 
+	// currentRevision, if not empty, indicates the version of the NodeSet
+	// used to generate pods below the update partition.
+	// +optional
+	CurrentRevision string `json:"currentRevision,omitempty"`
+
+	// updateRevision, if not empty, indicates the version of the NodeSet
+	// used to generate pods at or above the update partition.
+	// +optional
+	UpdateRevision string `json:"updateRevision,omitempty"`
+
 	// Count of hash collisions for the NodeSet. The NodeSet controller
 	// uses this field as a collision avoidance mechanism when it needs to
 	// create the name for the newest ControllerRevision.
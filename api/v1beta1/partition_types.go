@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	PartitionKind = "Partition"
+)
+
+var (
+	PartitionGVK        = GroupVersion.WithKind(PartitionKind)
+	PartitionAPIVersion = GroupVersion.String()
+)
+
+// PartitionState is the state of a Slurm partition.
+// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_State_1
+// +enum
+type PartitionState string
+
+const (
+	// PartitionStateUp allows jobs to be queued and scheduled.
+	PartitionStateUp PartitionState = "UP"
+	// PartitionStateDown allows jobs to be queued but not scheduled.
+	PartitionStateDown PartitionState = "DOWN"
+	// PartitionStateDrain rejects new jobs but schedules already queued jobs.
+	PartitionStateDrain PartitionState = "DRAIN"
+	// PartitionStateInactive rejects new jobs and does not schedule queued jobs.
+	PartitionStateInactive PartitionState = "INACTIVE"
+)
+
+// PartitionSpec defines the desired state of Partition
+type PartitionSpec struct {
+	// controllerRef is a reference to the Controller CR to which this has membership.
+	// +required
+	ControllerRef corev1.LocalObjectReference `json:"controllerRef"`
+
+	// NodeSets is a list of NodeSet names whose Slurm nodes are members of
+	// the partition. NodeSets must reference the same Controller.
+	// +optional
+	// +listType=set
+	NodeSets []string `json:"nodeSets,omitempty"`
+
+	// NodeSetSelector selects NodeSets, by label, whose Slurm nodes are
+	// members of the partition. NodeSets must reference the same Controller.
+	// Combined with `nodeSets` as a union.
+	// +optional
+	NodeSetSelector *metav1.LabelSelector `json:"nodeSetSelector,omitempty"`
+
+	// MaxTime is the maximum run time limit for jobs, in Slurm time format
+	// (e.g. "60", "1-00:00:00", "UNLIMITED").
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MaxTime
+	// +optional
+	MaxTime string `json:"maxTime,omitempty"`
+
+	// Default makes this the default partition for jobs that do not request one.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_Default
+	// +optional
+	Default bool `json:"default,omitempty"`
+
+	// PriorityTier orders partitions for scheduling and preemption. Higher
+	// values are considered first.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityTier
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65533
+	PriorityTier *int32 `json:"priorityTier,omitempty"`
+
+	// OverSubscribe controls the ability of the partition to execute more than
+	// one job at a time on each resource (e.g. "NO", "EXCLUSIVE", "YES:4", "FORCE:2").
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_OverSubscribe
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(NO|EXCLUSIVE|(YES|FORCE)(:[0-9]+)?)$`
+	OverSubscribe string `json:"overSubscribe,omitempty"`
+
+	// AllowAccounts is a list of Slurm accounts which may execute jobs in the partition.
+	// If empty, all accounts are allowed.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_AllowAccounts
+	// +optional
+	// +listType=set
+	AllowAccounts []string `json:"allowAccounts,omitempty"`
+
+	// State of the partition.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_State_1
+	// +optional
+	// +kubebuilder:validation:Enum=UP;DOWN;DRAIN;INACTIVE
+	State PartitionState `json:"state,omitempty"`
+
+	// ExtraConf is appended onto the partition line of the `slurm.conf`.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_PARTITION-CONFIGURATION
+	// +optional
+	ExtraConf string `json:"extraConf,omitempty"`
+}
+
+// PartitionStatus defines the observed state of Partition
+type PartitionStatus struct {
+	// NodeSets is the list of NodeSets resolved as members of the partition.
+	// +optional
+	// +listType=set
+	NodeSets []string `json:"nodeSets,omitempty"`
+
+	// The total number of Slurm nodes, across all member NodeSets.
+	// +optional
+	Nodes int32 `json:"nodes,omitempty"`
+
+	// The number of Slurm nodes, across all member NodeSets, in the IDLE state.
+	// +optional
+	SlurmIdle int32 `json:"slurmIdle,omitempty"`
+
+	// The number of Slurm nodes, across all member NodeSets, in the
+	// ALLOCATED or MIXED state.
+	// +optional
+	SlurmAllocated int32 `json:"slurmAllocated,omitempty"`
+
+	// The number of Slurm nodes, across all member NodeSets, in the DOWN state.
+	// +optional
+	SlurmDown int32 `json:"slurmDown,omitempty"`
+
+	// The number of Slurm nodes, across all member NodeSets, in the DRAIN state.
+	// +optional
+	SlurmDrain int32 `json:"slurmDrain,omitempty"`
+
+	// observedGeneration is the most recent generation observed for this Partition.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the latest available observations of a Partition's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=parts
+// +kubebuilder:printcolumn:name="NODES",type="integer",JSONPath=".status.nodes",priority=0,description="The number of Slurm nodes in the partition."
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".spec.state",priority=0,description="The configured partition state."
+// +kubebuilder:printcolumn:name="IDLE",type="integer",JSONPath=".status.slurmIdle",priority=1,description="The number of IDLE slurm nodes."
+// +kubebuilder:printcolumn:name="ALLOCATED",type="integer",JSONPath=".status.slurmAllocated",priority=1,description="The number of ALLOCATED/MIXED slurm nodes."
+// +kubebuilder:printcolumn:name="DOWN",type="integer",JSONPath=".status.slurmDown",priority=1,description="The number of DOWN slurm nodes."
+// +kubebuilder:printcolumn:name="DRAIN",type="integer",JSONPath=".status.slurmDrain",priority=1,description="The number of DRAIN slurm nodes."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Partition is the Schema for the partitions API
+type Partition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PartitionSpec   `json:"spec,omitempty"`
+	Status PartitionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PartitionList contains a list of Partition
+type PartitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Partition `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Partition{}, &PartitionList{})
+}
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	QOSKind = "QOS"
+)
+
+var (
+	QOSGVK        = GroupVersion.WithKind(QOSKind)
+	QOSAPIVersion = GroupVersion.String()
+)
+
+// QOSSpec defines the desired state of QOS
+// +kubebuilder:validation:XValidation:rule="has(self.controllerRef) != has(self.accountingRef)",message="exactly one of controllerRef or accountingRef is required"
+type QOSSpec struct {
+	SlurmdbRef `json:",inline"`
+
+	// Description of the QOS.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Description
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Priority is added to the job priority of jobs run with the QOS.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Priority_1
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Priority *int32 `json:"priority,omitempty"`
+
+	// List of flags to set on the QOS (e.g. "DenyOnLimit", "NoDecay").
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Flags_1
+	// +optional
+	// +listType=set
+	Flags []string `json:"flags,omitempty"`
+
+	// Preempt is the list of QOS which jobs run with the QOS may preempt.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Preempt
+	// +optional
+	// +listType=set
+	Preempt []string `json:"preempt,omitempty"`
+
+	// PreemptMode is the mechanism used to preempt jobs run with the QOS
+	// (e.g. "CANCEL", "REQUEUE", "SUSPEND").
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_PreemptMode
+	// +optional
+	PreemptMode string `json:"preemptMode,omitempty"`
+
+	// Limits of the QOS.
+	// +optional
+	Limits QOSLimits `json:"limits,omitzero"`
+}
+
+// QOSLimits defines the limits of a Slurm QOS.
+// Unset limits are cleared in Slurm (i.e. unlimited).
+// Ref: https://slurm.schedmd.com/resource_limits.html#qos
+type QOSLimits struct {
+	// GrpJobs is the maximum number of running jobs in aggregate for the QOS.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_GrpJobs_1
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	GrpJobs *int32 `json:"grpJobs,omitempty"`
+
+	// GrpTRES is the maximum TRES count in aggregate for the running jobs of
+	// the QOS, by TRES (e.g. "cpu", "mem", "gres/gpu"). Memory is in megabytes.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_GrpTRES_1
+	// +optional
+	GrpTRES map[string]int64 `json:"grpTRES,omitempty"`
+
+	// MaxJobsPerAccount is the maximum number of running jobs for each account.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxJobsPerAccount
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxJobsPerAccount *int32 `json:"maxJobsPerAccount,omitempty"`
+
+	// MaxJobsPerUser is the maximum number of running jobs for each user.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxJobsPerUser
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxJobsPerUser *int32 `json:"maxJobsPerUser,omitempty"`
+
+	// MaxSubmitJobsPerAccount is the maximum number of running and pending jobs for each account.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxSubmitJobsPerAccount
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxSubmitJobsPerAccount *int32 `json:"maxSubmitJobsPerAccount,omitempty"`
+
+	// MaxSubmitJobsPerUser is the maximum number of running and pending jobs for each user.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxSubmitJobsPerUser
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxSubmitJobsPerUser *int32 `json:"maxSubmitJobsPerUser,omitempty"`
+
+	// MaxTRESPerJob is the maximum TRES count of each job, by TRES.
+	// Memory is in megabytes.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxTRES_1
+	// +optional
+	MaxTRESPerJob map[string]int64 `json:"maxTRESPerJob,omitempty"`
+
+	// MaxTRESPerNode is the maximum TRES count of each node of a job, by TRES.
+	// Memory is in megabytes.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxTRESPerNode_1
+	// +optional
+	MaxTRESPerNode map[string]int64 `json:"maxTRESPerNode,omitempty"`
+
+	// MaxTRESPerUser is the maximum TRES count of the running jobs of each user, by TRES.
+	// Memory is in megabytes.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxTRESPerUser
+	// +optional
+	MaxTRESPerUser map[string]int64 `json:"maxTRESPerUser,omitempty"`
+
+	// MaxWallDurationPerJob is the maximum wall clock time of each job.
+	// Slurm tracks this limit in minutes.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxWallDurationPerJob_1
+	// +optional
+	MaxWallDurationPerJob *metav1.Duration `json:"maxWallDurationPerJob,omitempty"`
+}
+
+// QOSStatus defines the observed state of QOS
+type QOSStatus struct {
+	// Priority of the QOS, as observed in Slurm.
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+
+	// Limits are the limits of the QOS, as observed in Slurm.
+	// +optional
+	Limits QOSLimits `json:"limits,omitzero"`
+
+	// observedGeneration is the most recent generation observed for this QOS.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the latest available observations of a QOS's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=qoses,shortName=qos
+// +kubebuilder:printcolumn:name="PRIORITY",type="integer",JSONPath=".status.priority",priority=0,description="The priority of the QOS."
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",priority=0,description="If the Slurm QOS is in sync."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// QOS is the Schema for the qoses API
+type QOS struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   QOSSpec   `json:"spec,omitempty"`
+	Status QOSStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// QOSList contains a list of QOS
+type QOSList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []QOS `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&QOS{}, &QOSList{})
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ReservationKind = "Reservation"
+)
+
+var (
+	ReservationGVK        = GroupVersion.WithKind(ReservationKind)
+	ReservationAPIVersion = GroupVersion.String()
+)
+
+// ReservationSpec defines the desired state of Reservation
+// +kubebuilder:validation:XValidation:rule="!(has(self.duration) && has(self.endTime))",message="duration and endTime are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="has(self.duration) || has(self.endTime)",message="one of duration or endTime is required"
+type ReservationSpec struct {
+	// controllerRef is a reference to the Controller CR to which this has membership.
+	// +required
+	ControllerRef corev1.LocalObjectReference `json:"controllerRef"`
+
+	// An RFC3339 timestamp at which the reservation begins.
+	// Ref: https://datatracker.ietf.org/doc/html/rfc3339#section-5.8
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_StartTime_1
+	// +required
+	StartTime metav1.Time `json:"startTime"`
+
+	// The duration of the reservation. Mutually exclusive with `endTime`.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_Duration
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// An RFC3339 timestamp at which the reservation ends. Mutually exclusive with `duration`.
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_EndTime_1
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// Users is a list of Slurm users permitted to use the reservation.
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_Users_1
+	// +optional
+	// +listType=set
+	Users []string `json:"users,omitempty"`
+
+	// Accounts is a list of Slurm accounts permitted to use the reservation.
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_Accounts_1
+	// +optional
+	// +listType=set
+	Accounts []string `json:"accounts,omitempty"`
+
+	// List of flags to set on the Slurm reservation (e.g. "MAINT", "IGNORE_JOBS", "DAILY").
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_Flags
+	// +optional
+	// +listType=set
+	Flags []string `json:"flags,omitempty"`
+
+	// NodeSets is a list of NodeSet names whose Slurm nodes are reserved.
+	// NodeSets must reference the same Controller.
+	// +optional
+	// +listType=set
+	NodeSets []string `json:"nodeSets,omitempty"`
+
+	// NodeList is a Slurm hostlist expression of nodes to reserve (e.g. "node-[0-3]").
+	// Combined with `nodeSets` as a union.
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_Nodes_1
+	// +optional
+	NodeList string `json:"nodeList,omitempty"`
+}
+
+// ReservationStatus defines the observed state of Reservation
+type ReservationStatus struct {
+	// Active indicates if the reservation is currently in effect.
+	// +optional
+	Active bool `json:"active,omitempty"`
+
+	// NodeList is the Slurm hostlist expression of nodes resolved for the reservation.
+	// +optional
+	NodeList string `json:"nodeList,omitempty"`
+
+	// StartTime is the start time of the reservation, as reported by Slurm.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the end time of the reservation, as reported by Slurm.
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// observedGeneration is the most recent generation observed for this Reservation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the latest available observations of a Reservation's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=reservations;resv
+// +kubebuilder:printcolumn:name="ACTIVE",type="boolean",JSONPath=".status.active",priority=0,description="If the reservation is currently in effect."
+// +kubebuilder:printcolumn:name="NODES",type="string",JSONPath=".status.nodeList",priority=0,description="The Slurm nodes of the reservation."
+// +kubebuilder:printcolumn:name="START",type="string",JSONPath=".status.startTime",priority=1,description="The start time of the reservation."
+// +kubebuilder:printcolumn:name="END",type="string",JSONPath=".status.endTime",priority=1,description="The end time of the reservation."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Reservation is the Schema for the reservations API
+type Reservation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReservationSpec   `json:"spec,omitempty"`
+	Status ReservationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ReservationList contains a list of Reservation
+type ReservationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Reservation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Reservation{}, &ReservationList{})
+}
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	UserKind = "User"
+)
+
+var (
+	UserGVK        = GroupVersion.WithKind(UserKind)
+	UserAPIVersion = GroupVersion.String()
+)
+
+// AdminLevel is the administrative privilege level of a Slurm user.
+// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_AdminLevel
+// +enum
+type AdminLevel string
+
+const (
+	// AdminLevelNone has no administrative privileges.
+	AdminLevelNone AdminLevel = "None"
+	// AdminLevelOperator may perform operator actions (e.g. modify nodes, jobs, and reservations).
+	AdminLevelOperator AdminLevel = "Operator"
+	// AdminLevelAdministrator may perform all administrative actions.
+	AdminLevelAdministrator AdminLevel = "Administrator"
+)
+
+// UserSpec defines the desired state of User
+// +kubebuilder:validation:XValidation:rule="has(self.controllerRef) != has(self.accountingRef)",message="exactly one of controllerRef or accountingRef is required"
+type UserSpec struct {
+	SlurmdbRef `json:",inline"`
+
+	// DefaultAccount is the default Slurm account of the user, which must be
+	// one of the associations. Defaults to the account of the first association.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_DefaultAccount
+	// +optional
+	DefaultAccount string `json:"defaultAccount,omitempty"`
+
+	// AdminLevel is the administrative privilege level of the user.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_AdminLevel
+	// +optional
+	// +kubebuilder:validation:Enum=None;Operator;Administrator
+	// +default:="None"
+	AdminLevel AdminLevel `json:"adminLevel,omitempty"`
+
+	// Associations of the user with Slurm accounts, and optionally partitions.
+	// Associations in Slurm which are not listed are removed.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Associations []UserAssociation `json:"associations"`
+}
+
+// UserAssociation defines an association of a user with a Slurm account.
+type UserAssociation struct {
+	// Account is the name of the Slurm account.
+	// +required
+	Account string `json:"account"`
+
+	// Partition restricts the association to a Slurm partition.
+	// +optional
+	Partition string `json:"partition,omitempty"`
+
+	// DefaultQOS is the default QOS of jobs run under the association.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_DefaultQOS
+	// +optional
+	DefaultQOS string `json:"defaultQOS,omitempty"`
+
+	// QOS is the list of QOS which jobs run under the association may use.
+	// If empty, the QOS are inherited from the account.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_QosLevel
+	// +optional
+	// +listType=set
+	QOS []string `json:"qos,omitempty"`
+
+	// Limits of the association.
+	// +optional
+	Limits AssociationLimits `json:"limits,omitzero"`
+}
+
+// UserAssociationStatus defines the observed state of a user association.
+type UserAssociationStatus struct {
+	// Account is the name of the Slurm account.
+	Account string `json:"account"`
+
+	// Partition of the association, if any.
+	// +optional
+	Partition string `json:"partition,omitempty"`
+
+	// Limits are the limits of the association, as observed in Slurm.
+	// +optional
+	Limits AssociationLimits `json:"limits,omitzero"`
+}
+
+// UserStatus defines the observed state of User
+type UserStatus struct {
+	// DefaultAccount is the default Slurm account of the user, as observed in Slurm.
+	// +optional
+	DefaultAccount string `json:"defaultAccount,omitempty"`
+
+	// Associations of the user, as observed in Slurm.
+	// +optional
+	// +listType=atomic
+	Associations []UserAssociationStatus `json:"associations,omitempty"`
+
+	// observedGeneration is the most recent generation observed for this User.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the latest available observations of a User's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=slurmusers
+// +kubebuilder:printcolumn:name="DEFAULT ACCOUNT",type="string",JSONPath=".status.defaultAccount",priority=0,description="The default Slurm account of the user."
+// +kubebuilder:printcolumn:name="ADMIN",type="string",JSONPath=".spec.adminLevel",priority=1,description="The administrative privilege level of the user."
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",priority=0,description="If the Slurm user is in sync."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// User is the Schema for the users API
+type User struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UserSpec   `json:"spec,omitempty"`
+	Status UserStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// UserList contains a list of User
+type UserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []User `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&User{}, &UserList{})
+}
@@ -10,6 +10,9 @@ const (
 	NodeSetPrefix  = "nodeset." + SlinkyPrefix
 	LoginSetPrefix = "loginset." + SlinkyPrefix
 	TopologyPrefix = "topology." + SlinkyPrefix
+
+	ReservationPrefix = "reservation." + SlinkyPrefix
+	SlurmdbPrefix     = "slurmdb." + SlinkyPrefix
 )
 
 // Well Known Annotations
@@ -28,6 +31,16 @@ const (
 	// workload by. Pods with an earlier deadline are preferred to be deleted before pods with a later deadline.
 	// NOTE: this is honored on a best-effort basis, and does not offer guarantees on pod deletion order.
 	AnnotationPodDeadline = NodeSetPrefix + "pod-deadline"
+
+	// AnnotationPodDrainStartTime stores a time.RFC3339 timestamp, indicating when the Slurm node started draining
+	// for the pod to be terminated (e.g. scale-in, update). It is used to enforce the NodeSet drain policy.
+	// NOTE: Set by the NodeSet controller.
+	AnnotationPodDrainStartTime = NodeSetPrefix + "pod-drain-start-time"
+
+	// AnnotationPodResourceSpec stores the Slurm node resources (e.g. "Sockets=1 CoresPerSocket=4 ThreadsPerCore=2")
+	// derived from the pod resources and the hardware of the node the pod is bound to.
+	// NOTE: Set by the pod binding webhook when the NodeSet resourceSpec is enabled.
+	AnnotationPodResourceSpec = NodeSetPrefix + "pod-resource-spec"
 )
 
 // Well Known Annotations for Objects of type corev1.Node
@@ -44,6 +57,10 @@ const (
 	// pod scheduled on the node. When present, the value is used verbatim as the pod's spec.hostname
 	// (and therefore the Slurm node name) instead of the default derived from the node name.
 	AnnotationNodeHostnameOverride = NodeSetPrefix + "hostname-override"
+
+	// AnnotationNodeHardware describes the CPU hardware of the node (e.g. "Sockets=2 CoresPerSocket=32 ThreadsPerCore=2").
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_Sockets
+	AnnotationNodeHardware = NodeSetPrefix + "node-hardware"
 )
 
 // Well Known Labels
@@ -67,6 +84,10 @@ const (
 	// LabelNodeSetScalingMode indicates the scaling mode (DaemonSet or StatefulSet).
 	// NOTE: Set by the NodeSet controller.
 	LabelNodeSetScalingMode = NodeSetPrefix + "scaling-mode"
+
+	// LabelNodeSetPodSurge indicates the pod was created above the replicas for a rolling update.
+	// NOTE: Set by the NodeSet controller.
+	LabelNodeSetPodSurge = NodeSetPrefix + "pod-surge"
 )
 
 // Well Known Finalizers
@@ -75,4 +96,20 @@ const (
 	// FinalizerNodeSetReservation
 	// NOTE: Set by the NodeSet controller.
 	FinalizerNodeSetReservation = NodeSetPrefix + "reservation"
+
+	// FinalizerReservation
+	// NOTE: Set by the Reservation controller.
+	FinalizerReservation = ReservationPrefix + "reservation"
+
+	// FinalizerAccount
+	// NOTE: Set by the Account controller.
+	FinalizerAccount = SlurmdbPrefix + "account"
+
+	// FinalizerUser
+	// NOTE: Set by the User controller.
+	FinalizerUser = SlurmdbPrefix + "user"
+
+	// FinalizerQOS
+	// NOTE: Set by the QOS controller.
+	FinalizerQOS = SlurmdbPrefix + "qos"
 )
@@ -8,14 +8,14 @@
 package v1beta1
 
 import (
-	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Accounting) DeepCopyInto(out *Accounting) {
+func (in *Account) DeepCopyInto(out *Account) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -23,18 +23,18 @@ func (in *Accounting) DeepCopyInto(out *Accounting) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Accounting.
-func (in *Accounting) DeepCopy() *Accounting {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Account.
+func (in *Account) DeepCopy() *Account {
 	if in == nil {
 		return nil
 	}
-	out := new(Accounting)
+	out := new(Account)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Accounting) DeepCopyObject() runtime.Object {
+func (in *Account) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -42,31 +42,31 @@ func (in *Accounting) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AccountingList) DeepCopyInto(out *AccountingList) {
+func (in *AccountList) DeepCopyInto(out *AccountList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Accounting, len(*in))
+		*out = make([]Account, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountingList.
-func (in *AccountingList) DeepCopy() *AccountingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountList.
+func (in *AccountList) DeepCopy() *AccountList {
 	if in == nil {
 		return nil
 	}
-	out := new(AccountingList)
+	out := new(AccountList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AccountingList) DeepCopyObject() runtime.Object {
+func (in *AccountList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -74,71 +74,52 @@ func (in *AccountingList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AccountingSpec) DeepCopyInto(out *AccountingSpec) {
+func (in *AccountSpec) DeepCopyInto(out *AccountSpec) {
 	*out = *in
-	in.SlurmKeyRef.DeepCopyInto(&out.SlurmKeyRef)
-	if in.JwtHs256KeyRef != nil {
-		in, out := &in.JwtHs256KeyRef, &out.JwtHs256KeyRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.JwtKeyRef != nil {
-		in, out := &in.JwtKeyRef, &out.JwtKeyRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.JwksKeyRef != nil {
-		in, out := &in.JwksKeyRef, &out.JwksKeyRef
-		*out = new(v1.ConfigMapKeySelector)
-		(*in).DeepCopyInto(*out)
+	in.SlurmdbRef.DeepCopyInto(&out.SlurmdbRef)
+	if in.QOS != nil {
+		in, out := &in.QOS, &out.QOS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	out.ExternalConfig = in.ExternalConfig
-	in.Slurmdbd.DeepCopyInto(&out.Slurmdbd)
-	in.Template.DeepCopyInto(&out.Template)
-	in.StorageConfig.DeepCopyInto(&out.StorageConfig)
-	in.Service.DeepCopyInto(&out.Service)
+	in.Limits.DeepCopyInto(&out.Limits)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountingSpec.
-func (in *AccountingSpec) DeepCopy() *AccountingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountSpec.
+func (in *AccountSpec) DeepCopy() *AccountSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AccountingSpec)
+	out := new(AccountSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AccountingStatus) DeepCopyInto(out *AccountingStatus) {
+func (in *AccountStatus) DeepCopyInto(out *AccountStatus) {
 	*out = *in
+	in.Limits.DeepCopyInto(&out.Limits)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountingStatus.
-func (in *AccountingStatus) DeepCopy() *AccountingStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountStatus.
+func (in *AccountStatus) DeepCopy() *AccountStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(AccountingStatus)
+	out := new(AccountStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContainerWrapper) DeepCopyInto(out *ContainerWrapper) {
-	clone := in.DeepCopy()
-	*out = *clone
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Controller) DeepCopyInto(out *Controller) {
+func (in *Accounting) DeepCopyInto(out *Accounting) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -146,18 +127,18 @@ func (in *Controller) DeepCopyInto(out *Controller) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Controller.
-func (in *Controller) DeepCopy() *Controller {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Accounting.
+func (in *Accounting) DeepCopy() *Accounting {
 	if in == nil {
 		return nil
 	}
-	out := new(Controller)
+	out := new(Accounting)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Controller) DeepCopyObject() runtime.Object {
+func (in *Accounting) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -165,31 +146,31 @@ func (in *Controller) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerList) DeepCopyInto(out *ControllerList) {
+func (in *AccountingList) DeepCopyInto(out *AccountingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Controller, len(*in))
+		*out = make([]Accounting, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerList.
-func (in *ControllerList) DeepCopy() *ControllerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountingList.
+func (in *AccountingList) DeepCopy() *AccountingList {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerList)
+	out := new(AccountingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ControllerList) DeepCopyObject() runtime.Object {
+func (in *AccountingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -197,134 +178,198 @@ func (in *ControllerList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerPersistence) DeepCopyInto(out *ControllerPersistence) {
+func (in *AccountingPreflight) DeepCopyInto(out *AccountingPreflight) {
 	*out = *in
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	in.PersistentVolumeClaimSpec.DeepCopyInto(&out.PersistentVolumeClaimSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerPersistence.
-func (in *ControllerPersistence) DeepCopy() *ControllerPersistence {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountingPreflight.
+func (in *AccountingPreflight) DeepCopy() *AccountingPreflight {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerPersistence)
+	out := new(AccountingPreflight)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerSpec) DeepCopyInto(out *ControllerSpec) {
+func (in *AccountingRetention) DeepCopyInto(out *AccountingRetention) {
+	*out = *in
+	if in.ArchiveVolume != nil {
+		in, out := &in.ArchiveVolume, &out.ArchiveVolume
+		*out = new(corev1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountingRetention.
+func (in *AccountingRetention) DeepCopy() *AccountingRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountingRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountingSpec) DeepCopyInto(out *AccountingSpec) {
 	*out = *in
 	in.SlurmKeyRef.DeepCopyInto(&out.SlurmKeyRef)
 	if in.JwtHs256KeyRef != nil {
 		in, out := &in.JwtHs256KeyRef, &out.JwtHs256KeyRef
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.JwtKeyRef != nil {
 		in, out := &in.JwtKeyRef, &out.JwtKeyRef
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.JwksKeyRef != nil {
 		in, out := &in.JwksKeyRef, &out.JwksKeyRef
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.AccountingRef != nil {
-		in, out := &in.AccountingRef, &out.AccountingRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
-	}
 	out.ExternalConfig = in.ExternalConfig
-	in.Slurmctld.DeepCopyInto(&out.Slurmctld)
-	in.Reconfigure.DeepCopyInto(&out.Reconfigure)
-	in.LogFile.DeepCopyInto(&out.LogFile)
+	in.Slurmdbd.DeepCopyInto(&out.Slurmdbd)
 	in.Template.DeepCopyInto(&out.Template)
-	if in.ConfigFileRefs != nil {
-		in, out := &in.ConfigFileRefs, &out.ConfigFileRefs
-		*out = make([]v1.LocalObjectReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.PrologScriptRefs != nil {
-		in, out := &in.PrologScriptRefs, &out.PrologScriptRefs
-		*out = make([]v1.LocalObjectReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.EpilogScriptRefs != nil {
-		in, out := &in.EpilogScriptRefs, &out.EpilogScriptRefs
-		*out = make([]v1.LocalObjectReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.PrologSlurmctldScriptRefs != nil {
-		in, out := &in.PrologSlurmctldScriptRefs, &out.PrologSlurmctldScriptRefs
-		*out = make([]v1.LocalObjectReference, len(*in))
-		copy(*out, *in)
+	in.StorageConfig.DeepCopyInto(&out.StorageConfig)
+	if in.ManagedDatabase != nil {
+		in, out := &in.ManagedDatabase, &out.ManagedDatabase
+		*out = new(ManagedDatabase)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.EpilogSlurmctldScriptRefs != nil {
-		in, out := &in.EpilogSlurmctldScriptRefs, &out.EpilogSlurmctldScriptRefs
-		*out = make([]v1.LocalObjectReference, len(*in))
-		copy(*out, *in)
+	in.Preflight.DeepCopyInto(&out.Preflight)
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(AccountingRetention)
+		(*in).DeepCopyInto(*out)
 	}
-	in.Persistence.DeepCopyInto(&out.Persistence)
 	in.Service.DeepCopyInto(&out.Service)
-	in.Metrics.DeepCopyInto(&out.Metrics)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerSpec.
-func (in *ControllerSpec) DeepCopy() *ControllerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountingSpec.
+func (in *AccountingSpec) DeepCopy() *AccountingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerSpec)
+	out := new(AccountingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerStatus) DeepCopyInto(out *ControllerStatus) {
+func (in *AccountingStatus) DeepCopyInto(out *AccountingStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerStatus.
-func (in *ControllerStatus) DeepCopy() *ControllerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountingStatus.
+func (in *AccountingStatus) DeepCopy() *AccountingStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerStatus)
+	out := new(AccountingStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalConfig) DeepCopyInto(out *ExternalConfig) {
+func (in *AssociationLimits) DeepCopyInto(out *AssociationLimits) {
 	*out = *in
+	if in.Fairshare != nil {
+		in, out := &in.Fairshare, &out.Fairshare
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GrpJobs != nil {
+		in, out := &in.GrpJobs, &out.GrpJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GrpSubmitJobs != nil {
+		in, out := &in.GrpSubmitJobs, &out.GrpSubmitJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GrpTRES != nil {
+		in, out := &in.GrpTRES, &out.GrpTRES
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxJobs != nil {
+		in, out := &in.MaxJobs, &out.MaxJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSubmitJobs != nil {
+		in, out := &in.MaxSubmitJobs, &out.MaxSubmitJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTRESPerJob != nil {
+		in, out := &in.MaxTRESPerJob, &out.MaxTRESPerJob
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxTRESPerNode != nil {
+		in, out := &in.MaxTRESPerNode, &out.MaxTRESPerNode
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxWallDurationPerJob != nil {
+		in, out := &in.MaxWallDurationPerJob, &out.MaxWallDurationPerJob
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalConfig.
-func (in *ExternalConfig) DeepCopy() *ExternalConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssociationLimits.
+func (in *AssociationLimits) DeepCopy() *AssociationLimits {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalConfig)
+	out := new(AssociationLimits)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoginSet) DeepCopyInto(out *LoginSet) {
+func (in *ContainerWrapper) DeepCopyInto(out *ContainerWrapper) {
+	clone := in.DeepCopy()
+	*out = *clone
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Controller) DeepCopyInto(out *Controller) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -332,18 +377,18 @@ func (in *LoginSet) DeepCopyInto(out *LoginSet) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoginSet.
-func (in *LoginSet) DeepCopy() *LoginSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Controller.
+func (in *Controller) DeepCopy() *Controller {
 	if in == nil {
 		return nil
 	}
-	out := new(LoginSet)
+	out := new(Controller)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LoginSet) DeepCopyObject() runtime.Object {
+func (in *Controller) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -351,148 +396,1482 @@ func (in *LoginSet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoginSetList) DeepCopyInto(out *LoginSetList) {
+func (in *ControllerBackup) DeepCopyInto(out *ControllerBackup) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]LoginSet, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VolumeSnapshot != nil {
+		in, out := &in.VolumeSnapshot, &out.VolumeSnapshot
+		*out = new(ControllerBackupVolumeSnapshot)
+		**out = **in
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(ControllerBackupVolume)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoginSetList.
-func (in *LoginSetList) DeepCopy() *LoginSetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerBackup.
+func (in *ControllerBackup) DeepCopy() *ControllerBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(LoginSetList)
+	out := new(ControllerBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LoginSetList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoginSetSpec) DeepCopyInto(out *LoginSetSpec) {
+func (in *ControllerBackupStatus) DeepCopyInto(out *ControllerBackupStatus) {
 	*out = *in
-	out.ControllerRef = in.ControllerRef
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
 	}
-	in.Login.DeepCopyInto(&out.Login)
-	in.InitConf.DeepCopyInto(&out.InitConf)
-	in.Template.DeepCopyInto(&out.Template)
-	in.SssdConfRef.DeepCopyInto(&out.SssdConfRef)
-	in.Strategy.DeepCopyInto(&out.Strategy)
-	in.Service.DeepCopyInto(&out.Service)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoginSetSpec.
-func (in *LoginSetSpec) DeepCopy() *LoginSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerBackupStatus.
+func (in *ControllerBackupStatus) DeepCopy() *ControllerBackupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LoginSetSpec)
+	out := new(ControllerBackupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoginSetStatus) DeepCopyInto(out *LoginSetStatus) {
+func (in *ControllerBackupVolume) DeepCopyInto(out *ControllerBackupVolume) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoginSetStatus.
-func (in *LoginSetStatus) DeepCopy() *LoginSetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerBackupVolume.
+func (in *ControllerBackupVolume) DeepCopy() *ControllerBackupVolume {
 	if in == nil {
 		return nil
 	}
-	out := new(LoginSetStatus)
+	out := new(ControllerBackupVolume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Metadata) DeepCopyInto(out *Metadata) {
+func (in *ControllerBackupVolumeSnapshot) DeepCopyInto(out *ControllerBackupVolumeSnapshot) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metadata.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerBackupVolumeSnapshot.
+func (in *ControllerBackupVolumeSnapshot) DeepCopy() *ControllerBackupVolumeSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerBackupVolumeSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerList) DeepCopyInto(out *ControllerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Controller, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerList.
+func (in *ControllerList) DeepCopy() *ControllerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ControllerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerPersistence) DeepCopyInto(out *ControllerPersistence) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.PersistentVolumeClaimSpec.DeepCopyInto(&out.PersistentVolumeClaimSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerPersistence.
+func (in *ControllerPersistence) DeepCopy() *ControllerPersistence {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerPersistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerRestore) DeepCopyInto(out *ControllerRestore) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(ControllerRestoreVolume)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerRestore.
+func (in *ControllerRestore) DeepCopy() *ControllerRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerRestoreVolume) DeepCopyInto(out *ControllerRestoreVolume) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerRestoreVolume.
+func (in *ControllerRestoreVolume) DeepCopy() *ControllerRestoreVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerRestoreVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerScheduling) DeepCopyInto(out *ControllerScheduling) {
+	*out = *in
+	if in.SchedulerParameters != nil {
+		in, out := &in.SchedulerParameters, &out.SchedulerParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SelectTypeParameters != nil {
+		in, out := &in.SelectTypeParameters, &out.SelectTypeParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Priority.DeepCopyInto(&out.Priority)
+	in.Preempt.DeepCopyInto(&out.Preempt)
+	in.Limits.DeepCopyInto(&out.Limits)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerScheduling.
+func (in *ControllerScheduling) DeepCopy() *ControllerScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerSpec) DeepCopyInto(out *ControllerSpec) {
+	*out = *in
+	in.SlurmKeyRef.DeepCopyInto(&out.SlurmKeyRef)
+	if in.JwtHs256KeyRef != nil {
+		in, out := &in.JwtHs256KeyRef, &out.JwtHs256KeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JwtKeyRef != nil {
+		in, out := &in.JwtKeyRef, &out.JwtKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JwksKeyRef != nil {
+		in, out := &in.JwksKeyRef, &out.JwksKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccountingRef != nil {
+		in, out := &in.AccountingRef, &out.AccountingRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	out.ExternalConfig = in.ExternalConfig
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Slurmctld.DeepCopyInto(&out.Slurmctld)
+	in.Reconfigure.DeepCopyInto(&out.Reconfigure)
+	in.LogFile.DeepCopyInto(&out.LogFile)
+	in.Template.DeepCopyInto(&out.Template)
+	if in.ConfigFileRefs != nil {
+		in, out := &in.ConfigFileRefs, &out.ConfigFileRefs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrologScriptRefs != nil {
+		in, out := &in.PrologScriptRefs, &out.PrologScriptRefs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.EpilogScriptRefs != nil {
+		in, out := &in.EpilogScriptRefs, &out.EpilogScriptRefs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrologSlurmctldScriptRefs != nil {
+		in, out := &in.PrologSlurmctldScriptRefs, &out.PrologSlurmctldScriptRefs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.EpilogSlurmctldScriptRefs != nil {
+		in, out := &in.EpilogSlurmctldScriptRefs, &out.EpilogSlurmctldScriptRefs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	in.Topology.DeepCopyInto(&out.Topology)
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
+	in.Persistence.DeepCopyInto(&out.Persistence)
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(ControllerBackup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(ControllerRestore)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Service.DeepCopyInto(&out.Service)
+	in.Metrics.DeepCopyInto(&out.Metrics)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerSpec.
+func (in *ControllerSpec) DeepCopy() *ControllerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerStatus) DeepCopyInto(out *ControllerStatus) {
+	*out = *in
+	if in.LastReconfigureTime != nil {
+		in, out := &in.LastReconfigureTime, &out.LastReconfigureTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(ControllerBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerStatus.
+func (in *ControllerStatus) DeepCopy() *ControllerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerTopology) DeepCopyInto(out *ControllerTopology) {
+	*out = *in
+	if in.Topologies != nil {
+		in, out := &in.Topologies, &out.Topologies
+		*out = make([]Topology, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerTopology.
+func (in *ControllerTopology) DeepCopy() *ControllerTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalConfig) DeepCopyInto(out *ExternalConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalConfig.
+func (in *ExternalConfig) DeepCopy() *ExternalConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoginSet) DeepCopyInto(out *LoginSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoginSet.
+func (in *LoginSet) DeepCopy() *LoginSet {
+	if in == nil {
+		return nil
+	}
+	out := new(LoginSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LoginSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoginSetList) DeepCopyInto(out *LoginSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LoginSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoginSetList.
+func (in *LoginSetList) DeepCopy() *LoginSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(LoginSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LoginSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoginSetSpec) DeepCopyInto(out *LoginSetSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Login.DeepCopyInto(&out.Login)
+	in.InitConf.DeepCopyInto(&out.InitConf)
+	in.Template.DeepCopyInto(&out.Template)
+	in.SssdConfRef.DeepCopyInto(&out.SssdConfRef)
+	in.Strategy.DeepCopyInto(&out.Strategy)
+	in.Service.DeepCopyInto(&out.Service)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoginSetSpec.
+func (in *LoginSetSpec) DeepCopy() *LoginSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoginSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoginSetStatus) DeepCopyInto(out *LoginSetStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoginSetStatus.
+func (in *LoginSetStatus) DeepCopy() *LoginSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LoginSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedDatabase) DeepCopyInto(out *ManagedDatabase) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedDatabase.
+func (in *ManagedDatabase) DeepCopy() *ManagedDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Metadata) DeepCopyInto(out *Metadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metadata.
 func (in *Metadata) DeepCopy() *Metadata {
 	if in == nil {
 		return nil
 	}
-	out := new(Metadata)
+	out := new(Metadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricEndpoint) DeepCopyInto(out *MetricEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricEndpoint.
+func (in *MetricEndpoint) DeepCopy() *MetricEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Metrics) DeepCopyInto(out *Metrics) {
+	*out = *in
+	in.ServiceMonitor.DeepCopyInto(&out.ServiceMonitor)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metrics.
+func (in *Metrics) DeepCopy() *Metrics {
+	if in == nil {
+		return nil
+	}
+	out := new(Metrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSet) DeepCopyInto(out *NodeSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSet.
+func (in *NodeSet) DeepCopy() *NodeSet {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetAutoscaling) DeepCopyInto(out *NodeSetAutoscaling) {
+	*out = *in
+	if in.ScaleUpStabilizationWindow != nil {
+		in, out := &in.ScaleUpStabilizationWindow, &out.ScaleUpStabilizationWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ScaleDownStabilizationWindow != nil {
+		in, out := &in.ScaleDownStabilizationWindow, &out.ScaleDownStabilizationWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetAutoscaling.
+func (in *NodeSetAutoscaling) DeepCopy() *NodeSetAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetDrainPolicy) DeepCopyInto(out *NodeSetDrainPolicy) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetDrainPolicy.
+func (in *NodeSetDrainPolicy) DeepCopy() *NodeSetDrainPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetDrainPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetFeatureLabel) DeepCopyInto(out *NodeSetFeatureLabel) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetFeatureLabel.
+func (in *NodeSetFeatureLabel) DeepCopy() *NodeSetFeatureLabel {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetFeatureLabel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetGres) DeepCopyInto(out *NodeSetGres) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetGres.
+func (in *NodeSetGres) DeepCopy() *NodeSetGres {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetGres)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetList) DeepCopyInto(out *NodeSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetList.
+func (in *NodeSetList) DeepCopy() *NodeSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetPartition) DeepCopyInto(out *NodeSetPartition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPartition.
+func (in *NodeSetPartition) DeepCopy() *NodeSetPartition {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetPartition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetPersistentVolumeClaimRetentionPolicy) DeepCopyInto(out *NodeSetPersistentVolumeClaimRetentionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPersistentVolumeClaimRetentionPolicy.
+func (in *NodeSetPersistentVolumeClaimRetentionPolicy) DeepCopy() *NodeSetPersistentVolumeClaimRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetPersistentVolumeClaimRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetPowerSave) DeepCopyInto(out *NodeSetPowerSave) {
+	*out = *in
+	if in.SuspendTime != nil {
+		in, out := &in.SuspendTime, &out.SuspendTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ResumeTimeout != nil {
+		in, out := &in.ResumeTimeout, &out.ResumeTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPowerSave.
+func (in *NodeSetPowerSave) DeepCopy() *NodeSetPowerSave {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetPowerSave)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetResourceClaimTemplate) DeepCopyInto(out *NodeSetResourceClaimTemplate) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetResourceClaimTemplate.
+func (in *NodeSetResourceClaimTemplate) DeepCopy() *NodeSetResourceClaimTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetResourceClaimTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetResourceSpec) DeepCopyInto(out *NodeSetResourceSpec) {
+	*out = *in
+	out.ReservedMemory = in.ReservedMemory.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetResourceSpec.
+func (in *NodeSetResourceSpec) DeepCopy() *NodeSetResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetSpec) DeepCopyInto(out *NodeSetSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Slurmd.DeepCopyInto(&out.Slurmd)
+	in.Ssh.DeepCopyInto(&out.Ssh)
+	in.LogFile.DeepCopyInto(&out.LogFile)
+	in.Template.DeepCopyInto(&out.Template)
+	if in.ConfigFileRefs != nil {
+		in, out := &in.ConfigFileRefs, &out.ConfigFileRefs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrologScriptRefs != nil {
+		in, out := &in.PrologScriptRefs, &out.PrologScriptRefs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.EpilogScriptRefs != nil {
+		in, out := &in.EpilogScriptRefs, &out.EpilogScriptRefs
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.FeatureLabels != nil {
+		in, out := &in.FeatureLabels, &out.FeatureLabels
+		*out = make([]NodeSetFeatureLabel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologyLabels != nil {
+		in, out := &in.TopologyLabels, &out.TopologyLabels
+		*out = make([]NodeSetTopologyLabel, len(*in))
+		copy(*out, *in)
+	}
+	if in.Gres != nil {
+		in, out := &in.Gres, &out.Gres
+		*out = make([]NodeSetGres, len(*in))
+		copy(*out, *in)
+	}
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	out.Partition = in.Partition
+	if in.VolumeClaimTemplates != nil {
+		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
+		*out = make([]corev1.PersistentVolumeClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceClaimTemplates != nil {
+		in, out := &in.ResourceClaimTemplates, &out.ResourceClaimTemplates
+		*out = make([]NodeSetResourceClaimTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
+	out.PersistentVolumeClaimRetentionPolicy = in.PersistentVolumeClaimRetentionPolicy
+	if in.WorkloadDisruptionProtection != nil {
+		in, out := &in.WorkloadDisruptionProtection, &out.WorkloadDisruptionProtection
+		*out = new(bool)
+		**out = **in
+	}
+	in.Autoscaling.DeepCopyInto(&out.Autoscaling)
+	in.PowerSave.DeepCopyInto(&out.PowerSave)
+	in.DrainPolicy.DeepCopyInto(&out.DrainPolicy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSpec.
+func (in *NodeSetSpec) DeepCopy() *NodeSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetSsh) DeepCopyInto(out *NodeSetSsh) {
+	*out = *in
+	in.SssdConfRef.DeepCopyInto(&out.SssdConfRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSsh.
+func (in *NodeSetSsh) DeepCopy() *NodeSetSsh {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetSsh)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetStatus) DeepCopyInto(out *NodeSetStatus) {
+	*out = *in
+	if in.CollisionCount != nil {
+		in, out := &in.CollisionCount, &out.CollisionCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OrdinalToNode != nil {
+		in, out := &in.OrdinalToNode, &out.OrdinalToNode
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetStatus.
+func (in *NodeSetStatus) DeepCopy() *NodeSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetTopologyLabel) DeepCopyInto(out *NodeSetTopologyLabel) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetTopologyLabel.
+func (in *NodeSetTopologyLabel) DeepCopy() *NodeSetTopologyLabel {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetTopologyLabel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetUpdateStrategy) DeepCopyInto(out *NodeSetUpdateStrategy) {
+	*out = *in
+	in.RollingUpdate.DeepCopyInto(&out.RollingUpdate)
+	in.ScheduledUpdate.DeepCopyInto(&out.ScheduledUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetUpdateStrategy.
+func (in *NodeSetUpdateStrategy) DeepCopy() *NodeSetUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Partition) DeepCopyInto(out *Partition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Partition.
+func (in *Partition) DeepCopy() *Partition {
+	if in == nil {
+		return nil
+	}
+	out := new(Partition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Partition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionList) DeepCopyInto(out *PartitionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Partition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionList.
+func (in *PartitionList) DeepCopy() *PartitionList {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PartitionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionSpec) DeepCopyInto(out *PartitionSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.NodeSets != nil {
+		in, out := &in.NodeSets, &out.NodeSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSetSelector != nil {
+		in, out := &in.NodeSetSelector, &out.NodeSetSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PriorityTier != nil {
+		in, out := &in.PriorityTier, &out.PriorityTier
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowAccounts != nil {
+		in, out := &in.AllowAccounts, &out.AllowAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionSpec.
+func (in *PartitionSpec) DeepCopy() *PartitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionStatus) DeepCopyInto(out *PartitionStatus) {
+	*out = *in
+	if in.NodeSets != nil {
+		in, out := &in.NodeSets, &out.NodeSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionStatus.
+func (in *PartitionStatus) DeepCopy() *PartitionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSpecWrapper) DeepCopyInto(out *PodSpecWrapper) {
+	clone := in.DeepCopy()
+	*out = *clone
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.PodSpecWrapper.DeepCopyInto(&out.PodSpecWrapper)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
+func (in *PodTemplate) DeepCopy() *PodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityWeights) DeepCopyInto(out *PriorityWeights) {
+	*out = *in
+	if in.Age != nil {
+		in, out := &in.Age, &out.Age
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Assoc != nil {
+		in, out := &in.Assoc, &out.Assoc
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Fairshare != nil {
+		in, out := &in.Fairshare, &out.Fairshare
+		*out = new(int32)
+		**out = **in
+	}
+	if in.JobSize != nil {
+		in, out := &in.JobSize, &out.JobSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QOS != nil {
+		in, out := &in.QOS, &out.QOS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TRES != nil {
+		in, out := &in.TRES, &out.TRES
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityWeights.
+func (in *PriorityWeights) DeepCopy() *PriorityWeights {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityWeights)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QOS) DeepCopyInto(out *QOS) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QOS.
+func (in *QOS) DeepCopy() *QOS {
+	if in == nil {
+		return nil
+	}
+	out := new(QOS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QOS) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QOSLimits) DeepCopyInto(out *QOSLimits) {
+	*out = *in
+	if in.GrpJobs != nil {
+		in, out := &in.GrpJobs, &out.GrpJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GrpTRES != nil {
+		in, out := &in.GrpTRES, &out.GrpTRES
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxJobsPerAccount != nil {
+		in, out := &in.MaxJobsPerAccount, &out.MaxJobsPerAccount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxJobsPerUser != nil {
+		in, out := &in.MaxJobsPerUser, &out.MaxJobsPerUser
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSubmitJobsPerAccount != nil {
+		in, out := &in.MaxSubmitJobsPerAccount, &out.MaxSubmitJobsPerAccount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSubmitJobsPerUser != nil {
+		in, out := &in.MaxSubmitJobsPerUser, &out.MaxSubmitJobsPerUser
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTRESPerJob != nil {
+		in, out := &in.MaxTRESPerJob, &out.MaxTRESPerJob
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxTRESPerNode != nil {
+		in, out := &in.MaxTRESPerNode, &out.MaxTRESPerNode
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxTRESPerUser != nil {
+		in, out := &in.MaxTRESPerUser, &out.MaxTRESPerUser
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxWallDurationPerJob != nil {
+		in, out := &in.MaxWallDurationPerJob, &out.MaxWallDurationPerJob
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QOSLimits.
+func (in *QOSLimits) DeepCopy() *QOSLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(QOSLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QOSList) DeepCopyInto(out *QOSList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]QOS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QOSList.
+func (in *QOSList) DeepCopy() *QOSList {
+	if in == nil {
+		return nil
+	}
+	out := new(QOSList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QOSList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QOSSpec) DeepCopyInto(out *QOSSpec) {
+	*out = *in
+	in.SlurmdbRef.DeepCopyInto(&out.SlurmdbRef)
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Preempt != nil {
+		in, out := &in.Preempt, &out.Preempt
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Limits.DeepCopyInto(&out.Limits)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QOSSpec.
+func (in *QOSSpec) DeepCopy() *QOSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QOSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QOSStatus) DeepCopyInto(out *QOSStatus) {
+	*out = *in
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	in.Limits.DeepCopyInto(&out.Limits)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QOSStatus.
+func (in *QOSStatus) DeepCopy() *QOSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(QOSStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricEndpoint) DeepCopyInto(out *MetricEndpoint) {
+func (in *Reservation) DeepCopyInto(out *Reservation) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricEndpoint.
-func (in *MetricEndpoint) DeepCopy() *MetricEndpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reservation.
+func (in *Reservation) DeepCopy() *Reservation {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricEndpoint)
+	out := new(Reservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Reservation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Metrics) DeepCopyInto(out *Metrics) {
+func (in *ReservationList) DeepCopyInto(out *ReservationList) {
 	*out = *in
-	in.ServiceMonitor.DeepCopyInto(&out.ServiceMonitor)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Reservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metrics.
-func (in *Metrics) DeepCopy() *Metrics {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationList.
+func (in *ReservationList) DeepCopy() *ReservationList {
 	if in == nil {
 		return nil
 	}
-	out := new(Metrics)
+	out := new(ReservationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReservationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSet) DeepCopyInto(out *NodeSet) {
+func (in *ReservationSpec) DeepCopyInto(out *ReservationSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSets != nil {
+		in, out := &in.NodeSets, &out.NodeSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationSpec.
+func (in *ReservationSpec) DeepCopy() *ReservationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationStatus) DeepCopyInto(out *ReservationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationStatus.
+func (in *ReservationStatus) DeepCopy() *ReservationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestApi) DeepCopyInto(out *RestApi) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -500,18 +1879,18 @@ func (in *NodeSet) DeepCopyInto(out *NodeSet) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSet.
-func (in *NodeSet) DeepCopy() *NodeSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApi.
+func (in *RestApi) DeepCopy() *RestApi {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSet)
+	out := new(RestApi)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodeSet) DeepCopyObject() runtime.Object {
+func (in *RestApi) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -519,31 +1898,31 @@ func (in *NodeSet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetList) DeepCopyInto(out *NodeSetList) {
+func (in *RestApiList) DeepCopyInto(out *RestApiList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NodeSet, len(*in))
+		*out = make([]RestApi, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetList.
-func (in *NodeSetList) DeepCopy() *NodeSetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApiList.
+func (in *RestApiList) DeepCopy() *RestApiList {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetList)
+	out := new(RestApiList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodeSetList) DeepCopyObject() runtime.Object {
+func (in *RestApiList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -551,167 +1930,286 @@ func (in *NodeSetList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetPartition) DeepCopyInto(out *NodeSetPartition) {
+func (in *RestApiSpec) DeepCopyInto(out *RestApiSpec) {
 	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Slurmrestd.DeepCopyInto(&out.Slurmrestd)
+	in.Template.DeepCopyInto(&out.Template)
+	in.Service.DeepCopyInto(&out.Service)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPartition.
-func (in *NodeSetPartition) DeepCopy() *NodeSetPartition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApiSpec.
+func (in *RestApiSpec) DeepCopy() *RestApiSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetPartition)
+	out := new(RestApiSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetPersistentVolumeClaimRetentionPolicy) DeepCopyInto(out *NodeSetPersistentVolumeClaimRetentionPolicy) {
+func (in *RestApiStatus) DeepCopyInto(out *RestApiStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPersistentVolumeClaimRetentionPolicy.
-func (in *NodeSetPersistentVolumeClaimRetentionPolicy) DeepCopy() *NodeSetPersistentVolumeClaimRetentionPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApiStatus.
+func (in *RestApiStatus) DeepCopy() *RestApiStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetPersistentVolumeClaimRetentionPolicy)
+	out := new(RestApiStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetSpec) DeepCopyInto(out *NodeSetSpec) {
+func (in *RollingUpdateNodeSetStrategy) DeepCopyInto(out *RollingUpdateNodeSetStrategy) {
 	*out = *in
-	out.ControllerRef = in.ControllerRef
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
 		**out = **in
 	}
-	in.Slurmd.DeepCopyInto(&out.Slurmd)
-	in.Ssh.DeepCopyInto(&out.Ssh)
-	in.LogFile.DeepCopyInto(&out.LogFile)
-	in.Template.DeepCopyInto(&out.Template)
-	out.Partition = in.Partition
-	if in.VolumeClaimTemplates != nil {
-		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
-		*out = make([]v1.PersistentVolumeClaim, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
 	}
-	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
-	out.PersistentVolumeClaimRetentionPolicy = in.PersistentVolumeClaimRetentionPolicy
-	if in.WorkloadDisruptionProtection != nil {
-		in, out := &in.WorkloadDisruptionProtection, &out.WorkloadDisruptionProtection
-		*out = new(bool)
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSpec.
-func (in *NodeSetSpec) DeepCopy() *NodeSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateNodeSetStrategy.
+func (in *RollingUpdateNodeSetStrategy) DeepCopy() *RollingUpdateNodeSetStrategy {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetSpec)
+	out := new(RollingUpdateNodeSetStrategy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetSsh) DeepCopyInto(out *NodeSetSsh) {
+func (in *ScheduledUpdateNodeSetStrategy) DeepCopyInto(out *ScheduledUpdateNodeSetStrategy) {
 	*out = *in
-	in.SssdConfRef.DeepCopyInto(&out.SssdConfRef)
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	out.Duration = in.Duration
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSsh.
-func (in *NodeSetSsh) DeepCopy() *NodeSetSsh {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledUpdateNodeSetStrategy.
+func (in *ScheduledUpdateNodeSetStrategy) DeepCopy() *ScheduledUpdateNodeSetStrategy {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetSsh)
+	out := new(ScheduledUpdateNodeSetStrategy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetStatus) DeepCopyInto(out *NodeSetStatus) {
+func (in *SchedulingLimits) DeepCopyInto(out *SchedulingLimits) {
 	*out = *in
-	if in.CollisionCount != nil {
-		in, out := &in.CollisionCount, &out.CollisionCount
+	if in.MaxJobCount != nil {
+		in, out := &in.MaxJobCount, &out.MaxJobCount
 		*out = new(int32)
 		**out = **in
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.MaxArraySize != nil {
+		in, out := &in.MaxArraySize, &out.MaxArraySize
+		*out = new(int32)
+		**out = **in
 	}
-	if in.OrdinalToNode != nil {
-		in, out := &in.OrdinalToNode, &out.OrdinalToNode
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.MaxStepCount != nil {
+		in, out := &in.MaxStepCount, &out.MaxStepCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTasksPerNode != nil {
+		in, out := &in.MaxTasksPerNode, &out.MaxTasksPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DefMemPerCPU != nil {
+		in, out := &in.DefMemPerCPU, &out.DefMemPerCPU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefMemPerNode != nil {
+		in, out := &in.DefMemPerNode, &out.DefMemPerNode
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxMemPerCPU != nil {
+		in, out := &in.MaxMemPerCPU, &out.MaxMemPerCPU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxMemPerNode != nil {
+		in, out := &in.MaxMemPerNode, &out.MaxMemPerNode
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingLimits.
+func (in *SchedulingLimits) DeepCopy() *SchedulingLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingPreempt) DeepCopyInto(out *SchedulingPreempt) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingPreempt.
+func (in *SchedulingPreempt) DeepCopy() *SchedulingPreempt {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingPreempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingPriority) DeepCopyInto(out *SchedulingPriority) {
+	*out = *in
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Weights.DeepCopyInto(&out.Weights)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingPriority.
+func (in *SchedulingPriority) DeepCopy() *SchedulingPriority {
+	if in == nil {
+		return nil
 	}
+	out := new(SchedulingPriority)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetStatus.
-func (in *NodeSetStatus) DeepCopy() *NodeSetStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMonitor) DeepCopyInto(out *ServiceMonitor) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	if in.MetricEndpoints != nil {
+		in, out := &in.MetricEndpoints, &out.MetricEndpoints
+		*out = make([]MetricEndpoint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitor.
+func (in *ServiceMonitor) DeepCopy() *ServiceMonitor {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetStatus)
+	out := new(ServiceMonitor)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetUpdateStrategy) DeepCopyInto(out *NodeSetUpdateStrategy) {
+func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 	*out = *in
-	in.RollingUpdate.DeepCopyInto(&out.RollingUpdate)
-	in.ScheduledUpdate.DeepCopyInto(&out.ScheduledUpdate)
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.ServiceSpecWrapper.DeepCopyInto(&out.ServiceSpecWrapper)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetUpdateStrategy.
-func (in *NodeSetUpdateStrategy) DeepCopy() *NodeSetUpdateStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
+func (in *ServiceSpec) DeepCopy() *ServiceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetUpdateStrategy)
+	out := new(ServiceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSpecWrapper) DeepCopyInto(out *PodSpecWrapper) {
+func (in *ServiceSpecWrapper) DeepCopyInto(out *ServiceSpecWrapper) {
 	clone := in.DeepCopy()
 	*out = *clone
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
+func (in *SlurmdbRef) DeepCopyInto(out *SlurmdbRef) {
 	*out = *in
-	in.Metadata.DeepCopyInto(&out.Metadata)
-	in.PodSpecWrapper.DeepCopyInto(&out.PodSpecWrapper)
+	if in.ControllerRef != nil {
+		in, out := &in.ControllerRef, &out.ControllerRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.AccountingRef != nil {
+		in, out := &in.AccountingRef, &out.AccountingRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
-func (in *PodTemplate) DeepCopy() *PodTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmdbRef.
+func (in *SlurmdbRef) DeepCopy() *SlurmdbRef {
 	if in == nil {
 		return nil
 	}
-	out := new(PodTemplate)
+	out := new(SlurmdbRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestApi) DeepCopyInto(out *RestApi) {
+func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
+	*out = *in
+	in.PasswordKeyRef.DeepCopyInto(&out.PasswordKeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageConfig.
+func (in *StorageConfig) DeepCopy() *StorageConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Token) DeepCopyInto(out *Token) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -719,18 +2217,18 @@ func (in *RestApi) DeepCopyInto(out *RestApi) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApi.
-func (in *RestApi) DeepCopy() *RestApi {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Token.
+func (in *Token) DeepCopy() *Token {
 	if in == nil {
 		return nil
 	}
-	out := new(RestApi)
+	out := new(Token)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RestApi) DeepCopyObject() runtime.Object {
+func (in *Token) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -738,31 +2236,31 @@ func (in *RestApi) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestApiList) DeepCopyInto(out *RestApiList) {
+func (in *TokenList) DeepCopyInto(out *TokenList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RestApi, len(*in))
+		*out = make([]Token, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApiList.
-func (in *RestApiList) DeepCopy() *RestApiList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenList.
+func (in *TokenList) DeepCopy() *TokenList {
 	if in == nil {
 		return nil
 	}
-	out := new(RestApiList)
+	out := new(TokenList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RestApiList) DeepCopyObject() runtime.Object {
+func (in *TokenList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -770,155 +2268,173 @@ func (in *RestApiList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestApiSpec) DeepCopyInto(out *RestApiSpec) {
+func (in *TokenSpec) DeepCopyInto(out *TokenSpec) {
 	*out = *in
-	out.ControllerRef = in.ControllerRef
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
+	if in.JwtHs256KeyRef != nil {
+		in, out := &in.JwtHs256KeyRef, &out.JwtHs256KeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JwtKeyRef != nil {
+		in, out := &in.JwtKeyRef, &out.JwtKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lifetime != nil {
+		in, out := &in.Lifetime, &out.Lifetime
+		*out = new(v1.Duration)
 		**out = **in
 	}
-	in.Slurmrestd.DeepCopyInto(&out.Slurmrestd)
-	in.Template.DeepCopyInto(&out.Template)
-	in.Service.DeepCopyInto(&out.Service)
+	if in.Refresh != nil {
+		in, out := &in.Refresh, &out.Refresh
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApiSpec.
-func (in *RestApiSpec) DeepCopy() *RestApiSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenSpec.
+func (in *TokenSpec) DeepCopy() *TokenSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RestApiSpec)
+	out := new(TokenSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestApiStatus) DeepCopyInto(out *RestApiStatus) {
+func (in *TokenStatus) DeepCopyInto(out *TokenStatus) {
 	*out = *in
+	if in.IssuedAt != nil {
+		in, out := &in.IssuedAt, &out.IssuedAt
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestApiStatus.
-func (in *RestApiStatus) DeepCopy() *RestApiStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenStatus.
+func (in *TokenStatus) DeepCopy() *TokenStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RestApiStatus)
+	out := new(TokenStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollingUpdateNodeSetStrategy) DeepCopyInto(out *RollingUpdateNodeSetStrategy) {
+func (in *Topology) DeepCopyInto(out *Topology) {
 	*out = *in
-	if in.MaxUnavailable != nil {
-		in, out := &in.MaxUnavailable, &out.MaxUnavailable
-		*out = new(intstr.IntOrString)
-		**out = **in
+	if in.Tree != nil {
+		in, out := &in.Tree, &out.Tree
+		*out = new(TopologyTree)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Block != nil {
+		in, out := &in.Block, &out.Block
+		*out = new(TopologyBlock)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateNodeSetStrategy.
-func (in *RollingUpdateNodeSetStrategy) DeepCopy() *RollingUpdateNodeSetStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Topology.
+func (in *Topology) DeepCopy() *Topology {
 	if in == nil {
 		return nil
 	}
-	out := new(RollingUpdateNodeSetStrategy)
+	out := new(Topology)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduledUpdateNodeSetStrategy) DeepCopyInto(out *ScheduledUpdateNodeSetStrategy) {
+func (in *TopologyBlock) DeepCopyInto(out *TopologyBlock) {
 	*out = *in
-	in.StartTime.DeepCopyInto(&out.StartTime)
-	out.Duration = in.Duration
-	if in.Flags != nil {
-		in, out := &in.Flags, &out.Flags
-		*out = make([]string, len(*in))
+	if in.BlockSizes != nil {
+		in, out := &in.BlockSizes, &out.BlockSizes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Blocks != nil {
+		in, out := &in.Blocks, &out.Blocks
+		*out = make([]TopologyBlockEntry, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledUpdateNodeSetStrategy.
-func (in *ScheduledUpdateNodeSetStrategy) DeepCopy() *ScheduledUpdateNodeSetStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyBlock.
+func (in *TopologyBlock) DeepCopy() *TopologyBlock {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduledUpdateNodeSetStrategy)
+	out := new(TopologyBlock)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceMonitor) DeepCopyInto(out *ServiceMonitor) {
+func (in *TopologyBlockEntry) DeepCopyInto(out *TopologyBlockEntry) {
 	*out = *in
-	in.Metadata.DeepCopyInto(&out.Metadata)
-	if in.MetricEndpoints != nil {
-		in, out := &in.MetricEndpoints, &out.MetricEndpoints
-		*out = make([]MetricEndpoint, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitor.
-func (in *ServiceMonitor) DeepCopy() *ServiceMonitor {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyBlockEntry.
+func (in *TopologyBlockEntry) DeepCopy() *TopologyBlockEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceMonitor)
+	out := new(TopologyBlockEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
+func (in *TopologySwitch) DeepCopyInto(out *TopologySwitch) {
 	*out = *in
-	in.Metadata.DeepCopyInto(&out.Metadata)
-	in.ServiceSpecWrapper.DeepCopyInto(&out.ServiceSpecWrapper)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
-func (in *ServiceSpec) DeepCopy() *ServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySwitch.
+func (in *TopologySwitch) DeepCopy() *TopologySwitch {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceSpec)
+	out := new(TopologySwitch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceSpecWrapper) DeepCopyInto(out *ServiceSpecWrapper) {
-	clone := in.DeepCopy()
-	*out = *clone
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
+func (in *TopologyTree) DeepCopyInto(out *TopologyTree) {
 	*out = *in
-	in.PasswordKeyRef.DeepCopyInto(&out.PasswordKeyRef)
+	if in.Switches != nil {
+		in, out := &in.Switches, &out.Switches
+		*out = make([]TopologySwitch, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageConfig.
-func (in *StorageConfig) DeepCopy() *StorageConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyTree.
+func (in *TopologyTree) DeepCopy() *TopologyTree {
 	if in == nil {
 		return nil
 	}
-	out := new(StorageConfig)
+	out := new(TopologyTree)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Token) DeepCopyInto(out *Token) {
+func (in *User) DeepCopyInto(out *User) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -926,18 +2442,18 @@ func (in *Token) DeepCopyInto(out *Token) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Token.
-func (in *Token) DeepCopy() *Token {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new User.
+func (in *User) DeepCopy() *User {
 	if in == nil {
 		return nil
 	}
-	out := new(Token)
+	out := new(User)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Token) DeepCopyObject() runtime.Object {
+func (in *User) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -945,31 +2461,68 @@ func (in *Token) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TokenList) DeepCopyInto(out *TokenList) {
+func (in *UserAssociation) DeepCopyInto(out *UserAssociation) {
+	*out = *in
+	if in.QOS != nil {
+		in, out := &in.QOS, &out.QOS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Limits.DeepCopyInto(&out.Limits)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserAssociation.
+func (in *UserAssociation) DeepCopy() *UserAssociation {
+	if in == nil {
+		return nil
+	}
+	out := new(UserAssociation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserAssociationStatus) DeepCopyInto(out *UserAssociationStatus) {
+	*out = *in
+	in.Limits.DeepCopyInto(&out.Limits)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserAssociationStatus.
+func (in *UserAssociationStatus) DeepCopy() *UserAssociationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UserAssociationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserList) DeepCopyInto(out *UserList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Token, len(*in))
+		*out = make([]User, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenList.
-func (in *TokenList) DeepCopy() *TokenList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserList.
+func (in *UserList) DeepCopy() *UserList {
 	if in == nil {
 		return nil
 	}
-	out := new(TokenList)
+	out := new(UserList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TokenList) DeepCopyObject() runtime.Object {
+func (in *UserList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -977,67 +2530,53 @@ func (in *TokenList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TokenSpec) DeepCopyInto(out *TokenSpec) {
+func (in *UserSpec) DeepCopyInto(out *UserSpec) {
 	*out = *in
-	if in.JwtHs256KeyRef != nil {
-		in, out := &in.JwtHs256KeyRef, &out.JwtHs256KeyRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.JwtKeyRef != nil {
-		in, out := &in.JwtKeyRef, &out.JwtKeyRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Lifetime != nil {
-		in, out := &in.Lifetime, &out.Lifetime
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.Refresh != nil {
-		in, out := &in.Refresh, &out.Refresh
-		*out = new(bool)
-		**out = **in
-	}
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	in.SlurmdbRef.DeepCopyInto(&out.SlurmdbRef)
+	if in.Associations != nil {
+		in, out := &in.Associations, &out.Associations
+		*out = make([]UserAssociation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenSpec.
-func (in *TokenSpec) DeepCopy() *TokenSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserSpec.
+func (in *UserSpec) DeepCopy() *UserSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TokenSpec)
+	out := new(UserSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TokenStatus) DeepCopyInto(out *TokenStatus) {
+func (in *UserStatus) DeepCopyInto(out *UserStatus) {
 	*out = *in
-	if in.IssuedAt != nil {
-		in, out := &in.IssuedAt, &out.IssuedAt
-		*out = (*in).DeepCopy()
+	if in.Associations != nil {
+		in, out := &in.Associations, &out.Associations
+		*out = make([]UserAssociationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenStatus.
-func (in *TokenStatus) DeepCopy() *TokenStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserStatus.
+func (in *UserStatus) DeepCopy() *UserStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TokenStatus)
+	out := new(UserStatus)
 	in.DeepCopyInto(out)
 	return out
 }
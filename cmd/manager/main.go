@@ -20,6 +20,7 @@ import (
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
@@ -33,13 +34,19 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/account"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/accounting"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/autoscaler"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/controller"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/loginset"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/partition"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/qos"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/reservation"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/restapi"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmclient"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/token"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/user"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -52,6 +59,7 @@ const defaultProfileAddr = "localhost:6060"
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(mariadbv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(monitoringv1.AddToScheme(scheme))
 
 	utilruntime.Must(slinkyv1beta1.AddToScheme(scheme))
@@ -250,6 +258,30 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "NodeSet")
 		os.Exit(1)
 	}
+	if err := autoscaler.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Autoscaler")
+		os.Exit(1)
+	}
+	if err := partition.NewReconciler(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Partition")
+		os.Exit(1)
+	}
+	if err := reservation.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Reservation")
+		os.Exit(1)
+	}
+	if err := account.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Account")
+		os.Exit(1)
+	}
+	if err := qos.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "QOS")
+		os.Exit(1)
+	}
+	if err := user.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "User")
+		os.Exit(1)
+	}
 	if err := loginset.NewReconciler(mgr.GetClient()).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LoginSet")
 		os.Exit(1)
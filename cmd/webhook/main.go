@@ -193,10 +193,34 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Accounting")
 		os.Exit(1)
 	}
-	if err := (&slinkywebhook.NodeSetWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&slinkywebhook.NodeSetWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "NodeSet")
 		os.Exit(1)
 	}
+	if err := (&slinkywebhook.PartitionWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Partition")
+		os.Exit(1)
+	}
+	if err := (&slinkywebhook.ReservationWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Reservation")
+		os.Exit(1)
+	}
+	if err := (&slinkywebhook.AccountWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Account")
+		os.Exit(1)
+	}
+	if err := (&slinkywebhook.QOSWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "QOS")
+		os.Exit(1)
+	}
+	if err := (&slinkywebhook.UserWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "User")
+		os.Exit(1)
+	}
 	if err = (&slinkywebhook.LoginSetWebhook{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "LoginSet")
 		os.Exit(1)
@@ -211,6 +235,12 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "pods/binding")
 		os.Exit(1)
 	}
+	if err = (&slinkywebhook.PodEvictionWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "pods/eviction")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
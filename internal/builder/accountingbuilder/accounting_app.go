@@ -95,7 +95,7 @@ func (b *AccountingBuilder) accountingPodTemplate(accounting *slinkyv1beta1.Acco
 		Base: corev1.PodSpec{
 			AutomountServiceAccountToken: ptr.To(false),
 			Containers: []corev1.Container{
-				b.slurmdbdContainer(spec.Slurmdbd.Container),
+				b.slurmdbdContainer(accounting),
 			},
 			SecurityContext: &corev1.PodSecurityContext{
 				RunAsNonRoot: ptr.To(true),
@@ -163,10 +163,35 @@ func accountingVolumes(accounting *slinkyv1beta1.Accounting) []corev1.Volume {
 		out[0].Projected.Sources = append(out[0].Projected.Sources, volumeProjection)
 	}
 
+	if isArchiveVolume(accounting) {
+		out = append(out, corev1.Volume{
+			Name: common.SlurmdbdArchiveVolume,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: accounting.Spec.Retention.ArchiveVolume,
+			},
+		})
+	}
+
 	return out
 }
 
-func (b *AccountingBuilder) slurmdbdContainer(merge corev1.Container) corev1.Container {
+// isArchiveVolume returns true if slurmdbd writes archives to a volume.
+func isArchiveVolume(accounting *slinkyv1beta1.Accounting) bool {
+	retention := accounting.Spec.Retention
+	return retention != nil && retention.ArchiveVolume != nil
+}
+
+func (b *AccountingBuilder) slurmdbdContainer(accounting *slinkyv1beta1.Accounting) corev1.Container {
+	volumeMounts := []corev1.VolumeMount{
+		{Name: common.SlurmEtcVolume, MountPath: common.SlurmEtcDir, ReadOnly: true},
+		{Name: common.SlurmPidFileVolume, MountPath: common.SlurmPidFileDir},
+	}
+	if isArchiveVolume(accounting) {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name: common.SlurmdbdArchiveVolume, MountPath: common.SlurmdbdArchiveDir,
+		})
+	}
+
 	opts := common.ContainerOpts{
 		Base: corev1.Container{
 			Name: labels.AccountingApp,
@@ -189,12 +214,9 @@ func (b *AccountingBuilder) slurmdbdContainer(merge corev1.Container) corev1.Con
 				RunAsUser:    ptr.To(common.SlurmUserUid),
 				RunAsGroup:   ptr.To(common.SlurmUserGid),
 			},
-			VolumeMounts: []corev1.VolumeMount{
-				{Name: common.SlurmEtcVolume, MountPath: common.SlurmEtcDir, ReadOnly: true},
-				{Name: common.SlurmPidFileVolume, MountPath: common.SlurmPidFileDir},
-			},
+			VolumeMounts: volumeMounts,
 		},
-		Merge: merge,
+		Merge: accounting.Spec.Slurmdbd.Container,
 	}
 
 	return b.CommonBuilder.BuildContainer(opts)
@@ -71,6 +71,75 @@ func TestBuilder_BuildAccounting(t *testing.T) {
 	}
 }
 
+func TestBuilder_BuildAccounting_archiveVolume(t *testing.T) {
+	archiveVolume := corev1.PersistentVolumeClaimVolumeSource{
+		ClaimName: "archive",
+	}
+	tests := []struct {
+		name      string
+		retention *slinkyv1beta1.AccountingRetention
+		wantMount bool
+	}{
+		{
+			name: "no retention",
+		},
+		{
+			name: "purge only",
+			retention: &slinkyv1beta1.AccountingRetention{
+				PurgeJobAfter: "12months",
+			},
+		},
+		{
+			name: "archive volume",
+			retention: &slinkyv1beta1.AccountingRetention{
+				PurgeJobAfter: "12months",
+				ArchiveJobs:   true,
+				ArchiveVolume: &archiveVolume,
+			},
+			wantMount: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounting := &slinkyv1beta1.Accounting{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "slurm",
+				},
+				Spec: slinkyv1beta1.AccountingSpec{
+					JwtKeyRef: &corev1.SecretKeySelector{},
+					Retention: tt.retention,
+				},
+			}
+			b := New(fake.NewFakeClient())
+			got, err := b.BuildAccounting(accounting)
+			require.NoError(t, err)
+
+			podSpec := got.Spec.Template.Spec
+			var volume *corev1.Volume
+			for i := range podSpec.Volumes {
+				if podSpec.Volumes[i].Name == common.SlurmdbdArchiveVolume {
+					volume = &podSpec.Volumes[i]
+				}
+			}
+			var mount *corev1.VolumeMount
+			for i, m := range podSpec.Containers[0].VolumeMounts {
+				if m.Name == common.SlurmdbdArchiveVolume {
+					mount = &podSpec.Containers[0].VolumeMounts[i]
+				}
+			}
+			if !tt.wantMount {
+				require.Nil(t, volume)
+				require.Nil(t, mount)
+				return
+			}
+			require.NotNil(t, volume)
+			require.Equal(t, &archiveVolume, volume.PersistentVolumeClaim)
+			require.NotNil(t, mount)
+			require.Equal(t, common.SlurmdbdArchiveDir, mount.MountPath)
+		})
+	}
+}
+
 func BenchmarkBuilder_BuildAccounting(b *testing.B) {
 	type fields struct {
 		client client.Client
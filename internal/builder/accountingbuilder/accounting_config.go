@@ -52,10 +52,11 @@ func buildSlurmdbdConf(accounting *slinkyv1beta1.Accounting, storagePass string)
 	}
 
 	dbdHost := accounting.PrimaryName()
-	storageHost := accounting.Spec.StorageConfig.Host
-	storagePort := accounting.Spec.StorageConfig.Port
-	storageLoc := accounting.Spec.StorageConfig.Database
-	storageUser := accounting.Spec.StorageConfig.Username
+	storageConfig := accounting.StorageConfig()
+	storageHost := storageConfig.Host
+	storagePort := storageConfig.Port
+	storageLoc := storageConfig.Database
+	storageUser := storageConfig.Username
 
 	conf := config.NewBuilder()
 
@@ -81,6 +82,48 @@ func buildSlurmdbdConf(accounting *slinkyv1beta1.Accounting, storagePass string)
 	conf.AddProperty(config.NewProperty("StorageLoc", storageLoc))
 	conf.AddProperty(config.NewProperty("StoragePass", storagePass))
 
+	if retention := accounting.Spec.Retention; retention != nil {
+		conf.AddProperty(config.NewPropertyRaw("#"))
+		conf.AddProperty(config.NewPropertyRaw("### ARCHIVE & PURGE ###"))
+		if retention.ArchiveVolume != nil {
+			conf.AddProperty(config.NewProperty("ArchiveDir", common.SlurmdbdArchiveDir))
+		}
+		archives := []struct {
+			key     string
+			enabled bool
+		}{
+			{"ArchiveEvents", retention.ArchiveEvents},
+			{"ArchiveJobs", retention.ArchiveJobs},
+			{"ArchiveResvs", retention.ArchiveReservations},
+			{"ArchiveSteps", retention.ArchiveSteps},
+			{"ArchiveSuspend", retention.ArchiveSuspend},
+			{"ArchiveTXN", retention.ArchiveTXN},
+			{"ArchiveUsage", retention.ArchiveUsage},
+		}
+		for _, archive := range archives {
+			if archive.enabled {
+				conf.AddProperty(config.NewProperty(archive.key, "yes"))
+			}
+		}
+		purges := []struct {
+			key   string
+			after string
+		}{
+			{"PurgeEventAfter", retention.PurgeEventAfter},
+			{"PurgeJobAfter", retention.PurgeJobAfter},
+			{"PurgeResvAfter", retention.PurgeReservationAfter},
+			{"PurgeStepAfter", retention.PurgeStepAfter},
+			{"PurgeSuspendAfter", retention.PurgeSuspendAfter},
+			{"PurgeTXNAfter", retention.PurgeTXNAfter},
+			{"PurgeUsageAfter", retention.PurgeUsageAfter},
+		}
+		for _, purge := range purges {
+			if purge.after != "" {
+				conf.AddProperty(config.NewProperty(purge.key, purge.after))
+			}
+		}
+	}
+
 	conf.AddProperty(config.NewPropertyRaw("#"))
 	conf.AddProperty(config.NewPropertyRaw("### LOGGING ###"))
 	conf.AddProperty(config.NewProperty("LogFile", common.DevNull))
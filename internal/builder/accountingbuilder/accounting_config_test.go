@@ -80,3 +80,70 @@ func TestBuilder_BuildAccountingConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildSlurmdbdConf_retention(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention *slinkyv1beta1.AccountingRetention
+		want      []string
+		notWant   []string
+	}{
+		{
+			name:    "none",
+			notWant: []string{"### ARCHIVE & PURGE ###", "Archive", "Purge"},
+		},
+		{
+			name: "purge",
+			retention: &slinkyv1beta1.AccountingRetention{
+				PurgeJobAfter:         "12months",
+				PurgeReservationAfter: "30days",
+				PurgeTXNAfter:         "720hours",
+			},
+			want: []string{
+				"PurgeJobAfter=12months\n",
+				"PurgeResvAfter=30days\n",
+				"PurgeTXNAfter=720hours\n",
+			},
+			notWant: []string{"ArchiveDir", "ArchiveJobs", "PurgeStepAfter"},
+		},
+		{
+			name: "archive",
+			retention: &slinkyv1beta1.AccountingRetention{
+				PurgeJobAfter:       "12",
+				PurgeStepAfter:      "6",
+				ArchiveJobs:         true,
+				ArchiveReservations: true,
+				ArchiveVolume: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "archive",
+				},
+			},
+			want: []string{
+				"ArchiveDir=" + common.SlurmdbdArchiveDir + "\n",
+				"ArchiveJobs=yes\n",
+				"ArchiveResvs=yes\n",
+				"PurgeJobAfter=12\n",
+				"PurgeStepAfter=6\n",
+			},
+			notWant: []string{"ArchiveSteps"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounting := &slinkyv1beta1.Accounting{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "slurm",
+				},
+				Spec: slinkyv1beta1.AccountingSpec{
+					Retention: tt.retention,
+				},
+			}
+			got := buildSlurmdbdConf(accounting, "password")
+			for _, want := range tt.want {
+				require.Contains(t, got, want)
+			}
+			for _, notWant := range tt.notWant {
+				require.NotContains(t, got, notWant)
+			}
+		})
+	}
+}
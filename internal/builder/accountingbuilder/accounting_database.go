@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package accountingbuilder
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	common "github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/metadata"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/reflectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+)
+
+const (
+	// Ref: https://slurm.schedmd.com/accounting.html#slurm-accounting-configuration-before-build
+	defaultManagedDatabaseMyCnf = `[mariadb]
+bind-address=*
+default_storage_engine=InnoDB
+binlog_format=row
+innodb_autoinc_lock_mode=2
+innodb_buffer_pool_size=1024M
+innodb_lock_wait_timeout=900
+innodb_log_file_size=256M
+max_allowed_packet=256M
+`
+
+	managedDatabaseUserHost = "%"
+)
+
+// BuildAccountingDatabasePassword returns the Secret holding a generated
+// password of the managed database user.
+func (b *AccountingBuilder) BuildAccountingDatabasePassword(accounting *slinkyv1beta1.Accounting) (*corev1.Secret, error) {
+	opts := common.SecretOpts{
+		Key: accounting.MariaDBPasswordKey(),
+		Metadata: slinkyv1beta1.Metadata{
+			Annotations: accounting.Annotations,
+			Labels:      structutils.MergeMaps(accounting.Labels, labels.NewBuilder().WithAccountingLabels(accounting).Build()),
+		},
+		StringData: map[string]string{
+			slinkyv1beta1.MariaDBPasswordSecretKey: rand.Text(),
+		},
+	}
+
+	return b.CommonBuilder.BuildSecret(opts, accounting)
+}
+
+func (b *AccountingBuilder) BuildAccountingMariaDB(accounting *slinkyv1beta1.Accounting) (*mariadbv1alpha1.MariaDB, error) {
+	managed := accounting.Spec.ManagedDatabase
+	if managed == nil {
+		return nil, fmt.Errorf("managed database is not enabled")
+	}
+
+	out := &mariadbv1alpha1.MariaDB{
+		ObjectMeta: b.buildAccountingDatabaseMetadata(accounting),
+		Spec: mariadbv1alpha1.MariaDBSpec{
+			Image: managed.Image,
+			RootPasswordSecretKeyRef: mariadbv1alpha1.GeneratedSecretKeyRef{
+				SecretKeySelector: mariadbv1alpha1.SecretKeySelector{
+					LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+						Name: accounting.MariaDBRootPasswordKey().Name,
+					},
+					Key: slinkyv1beta1.MariaDBPasswordSecretKey,
+				},
+				Generate: true,
+			},
+			MyCnf: ptr.To(reflectutils.UseNonZeroOrDefault(managed.MyCnf, defaultManagedDatabaseMyCnf)),
+			Storage: mariadbv1alpha1.Storage{
+				Size:             managed.Storage,
+				StorageClassName: managed.StorageClassName,
+			},
+			Replicas: 1,
+		},
+	}
+
+	if len(managed.Resources.Limits) > 0 || len(managed.Resources.Requests) > 0 {
+		out.Spec.Resources = &mariadbv1alpha1.ResourceRequirements{
+			Limits:   managed.Resources.Limits,
+			Requests: managed.Resources.Requests,
+		}
+	}
+
+	if err := b.setAccountingDatabaseOwner(accounting, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (b *AccountingBuilder) BuildAccountingDatabase(accounting *slinkyv1beta1.Accounting) (*mariadbv1alpha1.Database, error) {
+	managed := accounting.Spec.ManagedDatabase
+	if managed == nil {
+		return nil, fmt.Errorf("managed database is not enabled")
+	}
+
+	out := &mariadbv1alpha1.Database{
+		ObjectMeta: b.buildAccountingDatabaseMetadata(accounting),
+		Spec: mariadbv1alpha1.DatabaseSpec{
+			SQLTemplate: mariadbv1alpha1.SQLTemplate{
+				// Never drop the accounting records with the object.
+				CleanupPolicy: ptr.To(mariadbv1alpha1.CleanupPolicySkip),
+			},
+			MariaDBRef: buildAccountingMariaDBRef(accounting),
+			Name:       managed.Database,
+		},
+	}
+
+	if err := b.setAccountingDatabaseOwner(accounting, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (b *AccountingBuilder) BuildAccountingDatabaseUser(accounting *slinkyv1beta1.Accounting) (*mariadbv1alpha1.User, error) {
+	managed := accounting.Spec.ManagedDatabase
+	if managed == nil {
+		return nil, fmt.Errorf("managed database is not enabled")
+	}
+
+	out := &mariadbv1alpha1.User{
+		ObjectMeta: b.buildAccountingDatabaseMetadata(accounting),
+		Spec: mariadbv1alpha1.UserSpec{
+			MariaDBRef: buildAccountingMariaDBRef(accounting),
+			PasswordSecretKeyRef: &mariadbv1alpha1.SecretKeySelector{
+				LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+					Name: accounting.MariaDBPasswordKey().Name,
+				},
+				Key: slinkyv1beta1.MariaDBPasswordSecretKey,
+			},
+			Name: managed.Username,
+			Host: managedDatabaseUserHost,
+		},
+	}
+
+	if err := b.setAccountingDatabaseOwner(accounting, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (b *AccountingBuilder) BuildAccountingDatabaseGrant(accounting *slinkyv1beta1.Accounting) (*mariadbv1alpha1.Grant, error) {
+	managed := accounting.Spec.ManagedDatabase
+	if managed == nil {
+		return nil, fmt.Errorf("managed database is not enabled")
+	}
+
+	out := &mariadbv1alpha1.Grant{
+		ObjectMeta: b.buildAccountingDatabaseMetadata(accounting),
+		Spec: mariadbv1alpha1.GrantSpec{
+			MariaDBRef: buildAccountingMariaDBRef(accounting),
+			Privileges: []string{"ALL PRIVILEGES"},
+			Database:   managed.Database,
+			Table:      "*",
+			Username:   managed.Username,
+			Host:       ptr.To(managedDatabaseUserHost),
+		},
+	}
+
+	if err := b.setAccountingDatabaseOwner(accounting, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (b *AccountingBuilder) buildAccountingDatabaseMetadata(accounting *slinkyv1beta1.Accounting) metav1.ObjectMeta {
+	return metadata.NewBuilder(accounting.MariaDBKey()).
+		WithMetadata(slinkyv1beta1.Metadata{
+			Annotations: accounting.Annotations,
+			Labels:      structutils.MergeMaps(accounting.Labels, labels.NewBuilder().WithAccountingLabels(accounting).Build()),
+		}).
+		Build()
+}
+
+func (b *AccountingBuilder) setAccountingDatabaseOwner(accounting *slinkyv1beta1.Accounting, obj client.Object) error {
+	if err := controllerutil.SetControllerReference(accounting, obj, b.client.Scheme()); err != nil {
+		return fmt.Errorf("failed to set owner controller: %w", err)
+	}
+	return nil
+}
+
+func buildAccountingMariaDBRef(accounting *slinkyv1beta1.Accounting) mariadbv1alpha1.MariaDBRef {
+	key := accounting.MariaDBKey()
+	return mariadbv1alpha1.MariaDBRef{
+		ObjectReference: mariadbv1alpha1.ObjectReference{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		WaitForIt: true,
+	}
+}
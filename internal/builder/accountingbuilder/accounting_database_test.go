@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package accountingbuilder
+
+import (
+	"testing"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	common "github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newManagedAccounting(managed *slinkyv1beta1.ManagedDatabase) *slinkyv1beta1.Accounting {
+	accounting := &slinkyv1beta1.Accounting{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+			UID:       "uid",
+		},
+		Spec: slinkyv1beta1.AccountingSpec{
+			ManagedDatabase: managed,
+		},
+	}
+	defaults.SetAccountingDefaults(accounting)
+	return accounting
+}
+
+func TestBuilder_BuildAccountingDatabasePassword(t *testing.T) {
+	accounting := newManagedAccounting(&slinkyv1beta1.ManagedDatabase{})
+	b := New(fake.NewFakeClient())
+
+	got, err := b.BuildAccountingDatabasePassword(accounting)
+	require.NoError(t, err)
+	require.Equal(t, "slurm-accounting-mariadb-password", got.Name)
+	require.True(t, metav1.IsControlledBy(got, accounting))
+	require.NotEmpty(t, got.StringData[slinkyv1beta1.MariaDBPasswordSecretKey])
+
+	other, err := b.BuildAccountingDatabasePassword(accounting)
+	require.NoError(t, err)
+	require.NotEqual(t, got.StringData, other.StringData)
+}
+
+func TestBuilder_BuildAccountingMariaDB(t *testing.T) {
+	size := resource.MustParse("1Gi")
+	tests := []struct {
+		name       string
+		accounting *slinkyv1beta1.Accounting
+		wantErr    bool
+		want       mariadbv1alpha1.MariaDBSpec
+	}{
+		{
+			name: "not managed",
+			accounting: &slinkyv1beta1.Accounting{
+				ObjectMeta: metav1.ObjectMeta{Name: "slurm"},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "default",
+			accounting: newManagedAccounting(&slinkyv1beta1.ManagedDatabase{}),
+			want: mariadbv1alpha1.MariaDBSpec{
+				RootPasswordSecretKeyRef: mariadbv1alpha1.GeneratedSecretKeyRef{
+					SecretKeySelector: mariadbv1alpha1.SecretKeySelector{
+						LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+							Name: "slurm-accounting-mariadb-root",
+						},
+						Key: "password",
+					},
+					Generate: true,
+				},
+				MyCnf: ptr.To(defaultManagedDatabaseMyCnf),
+				Storage: mariadbv1alpha1.Storage{
+					Size: ptr.To(resource.MustParse(defaults.DefaultAccountingManagedDatabaseStorage)),
+				},
+				Replicas: 1,
+			},
+		},
+		{
+			name: "overrides",
+			accounting: newManagedAccounting(&slinkyv1beta1.ManagedDatabase{
+				Image:            "mariadb:11.4",
+				Storage:          &size,
+				StorageClassName: "fast",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+				MyCnf: "[mariadb]\n",
+			}),
+			want: mariadbv1alpha1.MariaDBSpec{
+				ContainerTemplate: mariadbv1alpha1.ContainerTemplate{
+					Resources: &mariadbv1alpha1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("2Gi"),
+						},
+					},
+				},
+				Image: "mariadb:11.4",
+				RootPasswordSecretKeyRef: mariadbv1alpha1.GeneratedSecretKeyRef{
+					SecretKeySelector: mariadbv1alpha1.SecretKeySelector{
+						LocalObjectReference: mariadbv1alpha1.LocalObjectReference{
+							Name: "slurm-accounting-mariadb-root",
+						},
+						Key: "password",
+					},
+					Generate: true,
+				},
+				MyCnf: ptr.To("[mariadb]\n"),
+				Storage: mariadbv1alpha1.Storage{
+					Size:             &size,
+					StorageClassName: "fast",
+				},
+				Replicas: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(fake.NewFakeClient())
+			got, err := b.BuildAccountingMariaDB(tt.accounting)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "slurm-accounting-mariadb", got.Name)
+			require.True(t, metav1.IsControlledBy(got, tt.accounting))
+			require.Equal(t, tt.want, got.Spec)
+		})
+	}
+}
+
+func TestBuilder_BuildAccountingDatabaseObjects(t *testing.T) {
+	accounting := newManagedAccounting(&slinkyv1beta1.ManagedDatabase{
+		Database: "acct",
+		Username: "slurmdbd",
+	})
+	wantRef := mariadbv1alpha1.MariaDBRef{
+		ObjectReference: mariadbv1alpha1.ObjectReference{
+			Name:      "slurm-accounting-mariadb",
+			Namespace: corev1.NamespaceDefault,
+		},
+		WaitForIt: true,
+	}
+	b := New(fake.NewFakeClient())
+
+	database, err := b.BuildAccountingDatabase(accounting)
+	require.NoError(t, err)
+	require.True(t, metav1.IsControlledBy(database, accounting))
+	require.Equal(t, wantRef, database.Spec.MariaDBRef)
+	require.Equal(t, "acct", database.Spec.Name)
+	require.Equal(t, ptr.To(mariadbv1alpha1.CleanupPolicySkip), database.Spec.CleanupPolicy)
+
+	user, err := b.BuildAccountingDatabaseUser(accounting)
+	require.NoError(t, err)
+	require.True(t, metav1.IsControlledBy(user, accounting))
+	require.Equal(t, wantRef, user.Spec.MariaDBRef)
+	require.Equal(t, "slurmdbd", user.Spec.Name)
+	require.Equal(t, "%", user.Spec.Host)
+	require.Equal(t, "slurm-accounting-mariadb-password", user.Spec.PasswordSecretKeyRef.Name)
+
+	grant, err := b.BuildAccountingDatabaseGrant(accounting)
+	require.NoError(t, err)
+	require.True(t, metav1.IsControlledBy(grant, accounting))
+	require.Equal(t, wantRef, grant.Spec.MariaDBRef)
+	require.Equal(t, []string{"ALL PRIVILEGES"}, grant.Spec.Privileges)
+	require.Equal(t, "acct", grant.Spec.Database)
+	require.Equal(t, "*", grant.Spec.Table)
+	require.Equal(t, "slurmdbd", grant.Spec.Username)
+}
+
+func TestBuilder_BuildAccountingConfig_managedDatabase(t *testing.T) {
+	accounting := newManagedAccounting(&slinkyv1beta1.ManagedDatabase{})
+	accounting.Spec.StorageConfig.Host = "ignored"
+	password := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm-accounting-mariadb-password",
+		},
+		Data: map[string][]byte{
+			"password": []byte("generated"),
+		},
+	}
+	b := New(fake.NewClientBuilder().WithObjects(password).Build())
+
+	got, err := b.BuildAccountingConfig(accounting)
+	require.NoError(t, err)
+	conf := got.StringData[common.SlurmdbdConfFile]
+	require.Contains(t, conf, "StorageHost=slurm-accounting-mariadb.default.svc.cluster.local\n")
+	require.Contains(t, conf, "StoragePort=3306\n")
+	require.Contains(t, conf, "StorageLoc=slurm_acct_db\n")
+	require.Contains(t, conf, "StorageUser=slurm\n")
+	require.Contains(t, conf, "StoragePass=generated\n")
+}
@@ -9,6 +9,7 @@ import (
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	"github.com/stretchr/testify/require"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -18,6 +19,7 @@ import (
 
 func init() {
 	utilruntime.Must(slinkyv1beta1.AddToScheme(scheme.Scheme))
+	utilruntime.Must(mariadbv1alpha1.AddToScheme(scheme.Scheme))
 }
 
 func TestNew(t *testing.T) {
@@ -8,16 +8,22 @@ import (
 	"fmt"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
 	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
 	"k8s.io/utils/ptr"
 	"k8s.io/utils/set"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/domainname"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/mathutils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
 )
 
@@ -335,3 +341,379 @@ func GetSlurmNodeSetName(nodeset *slinkyv1beta1.NodeSet) string {
 	}
 	return nodeset.Name
 }
+
+// ReservedSlurmNodeConfKeys are the Slurm node options set by slurm-operator
+// which cannot be overridden by the NodeSet ExtraConf.
+var ReservedSlurmNodeConfKeys = []string{
+	"NodeName",
+	"NodeHostname",
+	"NodeAddr",
+	"Port",
+	"State",
+}
+
+// ResourceSpecSlurmNodeConfKeys are the Slurm node options set by
+// GetSlurmNodeResourceSpec(), when the NodeSet resourceSpec is enabled.
+var ResourceSpecSlurmNodeConfKeys = []string{
+	"Boards",
+	"CPUs",
+	"Procs",
+	"Sockets",
+	"SocketsPerBoard",
+	"CoresPerSocket",
+	"ThreadsPerCore",
+	"RealMemory",
+	"CoreSpecCount",
+	"CpuSpecList",
+	"MemSpecLimit",
+}
+
+// GetSlurmNodeConf returns the Slurm node configuration of the NodeSet as
+// sorted `Key=Value` pairs, which always include the NodeSet feature.
+// Malformed ExtraConf items are ignored, they are rejected by the webhook.
+func GetSlurmNodeConf(nodeset *slinkyv1beta1.NodeSet) []string {
+	extraConf := strings.Fields(nodeset.Spec.ExtraConf)
+
+	name := GetSlurmNodeSetName(nodeset)
+	confMap := map[string]string{
+		"Features": name,
+	}
+	if gres := GetSlurmNodeGres(nodeset); len(gres) > 0 {
+		confMap["Gres"] = strings.Join(gres, ",")
+	}
+	for _, item := range extraConf {
+		key, val, ok := strings.Cut(item, "=")
+		if !ok || key == "" {
+			continue
+		}
+		key = cases.Title(language.English).String(key)
+		if key == "Features" || key == "Feature" {
+			// Slurm treats trailing 's' as optional. We have to
+			// specially handle 'Feature(s)' because we require at
+			// least one feature but the user can request additional.
+			key = "Features"
+		}
+		if ret, ok := confMap[key]; !ok {
+			confMap[key] = val
+		} else {
+			confMap[key] = ret + fmt.Sprintf(",%s", val)
+		}
+	}
+
+	confList := []string{}
+	for key, val := range confMap {
+		confList = append(confList, fmt.Sprintf("%s=%s", key, val))
+	}
+	sort.Strings(confList)
+
+	return confList
+}
+
+// GetSlurmNodeGres returns the sorted Slurm GRES (e.g. `gpu:a100:4`) of the
+// NodeSet. GRES without a count are skipped.
+func GetSlurmNodeGres(nodeset *slinkyv1beta1.NodeSet) []string {
+	gresList := []string{}
+	for _, gres := range nodeset.Spec.Gres {
+		count := GetSlurmNodeGresCount(nodeset, gres)
+		if count <= 0 {
+			continue
+		}
+		item := []string{gres.Name}
+		if gres.Type != "" {
+			item = append(item, gres.Type)
+		}
+		item = append(item, strconv.FormatInt(count, 10))
+		gresList = append(gresList, strings.Join(item, ":"))
+	}
+	sort.Strings(gresList)
+	return gresList
+}
+
+// GetSlurmNodeGresConf returns the gres.conf lines of the GRES of the NodeSet,
+// which are either an `AutoDetect=` line or, when a file is set, an explicit
+// `Name= Type= File=` line. Identical lines are only returned once.
+//
+// https://slurm.schedmd.com/gres.conf.html
+func GetSlurmNodeGresConf(nodeset *slinkyv1beta1.NodeSet) []string {
+	autoDetect := set.New[string]()
+	files := set.New[string]()
+	for _, gres := range nodeset.Spec.Gres {
+		if gres.AutoDetect != "" {
+			autoDetect.Insert(fmt.Sprintf("AutoDetect=%s", gres.AutoDetect))
+		}
+		if gres.File == "" {
+			continue
+		}
+		fileLine := []string{
+			fmt.Sprintf("Name=%s", gres.Name),
+		}
+		if gres.Type != "" {
+			fileLine = append(fileLine, fmt.Sprintf("Type=%s", gres.Type))
+		}
+		fileLine = append(fileLine, fmt.Sprintf("File=%s", gres.File))
+		files.Insert(strings.Join(fileLine, " "))
+	}
+	return slices.Concat(autoDetect.SortedList(), files.SortedList())
+}
+
+// GetSlurmNodeGresCount returns the count of the GRES. For an extended
+// resource, this is the slurmd container limit, falling back to its request.
+// For a resource claim, this is the number of devices of its exact count
+// requests. Returns zero if not set.
+func GetSlurmNodeGresCount(nodeset *slinkyv1beta1.NodeSet, gres slinkyv1beta1.NodeSetGres) int64 {
+	if gres.ResourceClaim != "" {
+		for _, template := range nodeset.Spec.ResourceClaimTemplates {
+			if template.Name == gres.ResourceClaim {
+				return getResourceClaimDeviceCount(&template.Spec)
+			}
+		}
+		return 0
+	}
+	resources := nodeset.Spec.Slurmd.Resources
+	if quantity, ok := resources.Limits[gres.ResourceName]; ok {
+		return quantity.Value()
+	}
+	if quantity, ok := resources.Requests[gres.ResourceName]; ok {
+		return quantity.Value()
+	}
+	return 0
+}
+
+// getResourceClaimDeviceCount returns the number of devices requested by the
+// claim. Requests for all matching devices, or with alternatives, cannot be
+// counted up front and are skipped.
+func getResourceClaimDeviceCount(spec *resourcev1.ResourceClaimSpec) int64 {
+	var count int64
+	for _, request := range spec.Devices.Requests {
+		if request.Exactly == nil {
+			continue
+		}
+		switch request.Exactly.AllocationMode {
+		case resourcev1.DeviceAllocationModeExactCount, "":
+			count += max(request.Exactly.Count, 1)
+		}
+	}
+	return count
+}
+
+// GetSlurmNodeFeatures returns the sorted Slurm node features of the NodeSet,
+// including the features mapped from the Kubernetes node labels.
+func GetSlurmNodeFeatures(nodeset *slinkyv1beta1.NodeSet, nodeLabels map[string]string) []string {
+	features := set.New[string]()
+	for _, item := range GetSlurmNodeConf(nodeset) {
+		key, val, _ := strings.Cut(item, "=")
+		if key != "Features" {
+			continue
+		}
+		features.Insert(strings.Split(val, ",")...)
+	}
+	for _, featureLabel := range nodeset.Spec.FeatureLabels {
+		value, ok := nodeLabels[featureLabel.Key]
+		if !ok {
+			continue
+		}
+		if feature, ok := featureLabel.Values[value]; ok {
+			value = feature
+		}
+		if value == "" {
+			continue
+		}
+		features.Insert(value)
+	}
+	return features.SortedList()
+}
+
+// GetSlurmNodeTopologySpec returns the Slurm node topology spec (e.g.
+// `topo-switch:s0,topo-block:b0`) of the Kubernetes node, derived from the
+// NodeSet topology labels. The topology annotation of the Kubernetes node takes
+// precedence for the topologies it contains.
+func GetSlurmNodeTopologySpec(nodeset *slinkyv1beta1.NodeSet, node *corev1.Node) string {
+	topologySpec := node.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec]
+	if len(nodeset.Spec.TopologyLabels) == 0 {
+		return topologySpec
+	}
+
+	items := []string{}
+	topologies := set.New[string]()
+	if topologySpec != "" {
+		for item := range strings.SplitSeq(topologySpec, ",") {
+			name, _, _ := strings.Cut(item, ":")
+			topologies.Insert(name)
+			items = append(items, item)
+		}
+	}
+	for _, topologyLabel := range nodeset.Spec.TopologyLabels {
+		if topologies.Has(topologyLabel.Topology) {
+			continue
+		}
+		unit := node.Labels[topologyLabel.Key]
+		if unit == "" {
+			continue
+		}
+		topologies.Insert(topologyLabel.Topology)
+		items = append(items, fmt.Sprintf("%s:%s", topologyLabel.Topology, unit))
+	}
+	return strings.Join(items, ",")
+}
+
+// GetSlurmNodeResourceSpec returns the Slurm node resources (e.g.
+// `Sockets=1 CoresPerSocket=4 ThreadsPerCore=2 RealMemory=16384`) of the pod
+// bound to the Kubernetes node. The CPU layout fits the slurmd CPU limit into
+// the hardware of the node, described by its hardware annotation. Returns empty
+// if the pod has no CPU limit.
+func GetSlurmNodeResourceSpec(nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, node *corev1.Node) string {
+	cpus, memory := getSlurmdResourceLimits(pod)
+	return getSlurmNodeResourceSpec(nodeset, cpus, memory, node.Annotations[slinkyv1beta1.AnnotationNodeHardware])
+}
+
+// GetSlurmCloudNodeResourceSpec returns the Slurm node resources of the
+// NodeSet, for Slurm nodes which are defined before their pod is bound to a
+// Kubernetes node (e.g. State=CLOUD). The CPU layout is derived as by
+// GetSlurmNodeResourceSpec(), without the hardware of the node.
+func GetSlurmCloudNodeResourceSpec(nodeset *slinkyv1beta1.NodeSet) string {
+	cpus, memory := GetSlurmNodeResourceLimits(&nodeset.Spec)
+	return getSlurmNodeResourceSpec(nodeset, cpus, memory, "")
+}
+
+func getSlurmNodeResourceSpec(nodeset *slinkyv1beta1.NodeSet, cpus, memory int64, hardware string) string {
+	if cpus <= 0 {
+		return ""
+	}
+
+	sockets, coresPerSocket, threads := int64(1), int64(0), int64(1)
+	for item := range strings.FieldsSeq(hardware) {
+		key, val, _ := strings.Cut(item, "=")
+		count, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || count <= 0 {
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "sockets":
+			sockets = count
+		case "corespersocket":
+			coresPerSocket = count
+		case "threadspercore":
+			threads = count
+		}
+	}
+	// The static CPU manager allocates whole cores when the limit allows.
+	if cpus%threads != 0 {
+		threads = 1
+	}
+	cores := cpus / threads
+	sockets = getSlurmNodeSockets(cores, sockets, coresPerSocket)
+
+	items := []string{
+		fmt.Sprintf("Sockets=%d", sockets),
+		fmt.Sprintf("CoresPerSocket=%d", cores/sockets),
+		fmt.Sprintf("ThreadsPerCore=%d", threads),
+	}
+	if memory > 0 {
+		items = append(items, fmt.Sprintf("RealMemory=%d", memory))
+	}
+	resourceSpec := nodeset.Spec.ResourceSpec
+	if reserved := int64(resourceSpec.ReservedCpus); reserved > 0 && reserved < cpus {
+		cpuSpecList := "0"
+		if reserved > 1 {
+			cpuSpecList = fmt.Sprintf("0-%d", reserved-1)
+		}
+		items = append(items, "CpuSpecList="+cpuSpecList)
+	}
+	if reserved := resourceSpec.ReservedMemory.Value() / 1024 / 1024; reserved > 0 {
+		items = append(items, fmt.Sprintf("MemSpecLimit=%d", reserved))
+	}
+	return strings.Join(items, " ")
+}
+
+// getSlurmNodeSockets returns the number of sockets the cores are allocated
+// from. The static CPU manager fills whole sockets before taking cores from
+// another socket, hence the cores span as few sockets as fit them. The cores
+// must be evenly split over the sockets for Slurm, otherwise they are reported
+// on fewer sockets. Without the cores per socket of the hardware, the cores are
+// assumed to fit into one socket.
+//
+// https://kubernetes.io/docs/tasks/administer-cluster/cpu-management-policies/#static-policy
+func getSlurmNodeSockets(cores, sockets, coresPerSocket int64) int64 {
+	if coresPerSocket <= 0 {
+		return 1
+	}
+	n := min(sockets, (cores+coresPerSocket-1)/coresPerSocket)
+	for cores%n != 0 {
+		n--
+	}
+	return n
+}
+
+// getSlurmdResourceLimits returns the CPU and memory (MiB) limits of the slurmd
+// container, falling back to the pod-level limits.
+func getSlurmdResourceLimits(pod *corev1.Pod) (int64, int64) {
+	var cpus, memory int64
+	if pod.Spec.Resources != nil {
+		cpus = pod.Spec.Resources.Limits.Cpu().Value()
+		memory = pod.Spec.Resources.Limits.Memory().Value()
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name != labels.WorkerApp {
+			continue
+		}
+		if quantity, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
+			cpus = quantity.Value()
+		}
+		if quantity, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+			memory = quantity.Value()
+		}
+	}
+	return cpus, memory / 1024 / 1024
+}
+
+// GetSlurmNodeResourceLimits returns the CPU and memory (MiB) limits of the
+// NodeSet slurmd container, falling back to the pod-level limits. Returns zero
+// if not set.
+func GetSlurmNodeResourceLimits(nodeset *slinkyv1beta1.NodeSetSpec) (int64, int64) {
+	var cpus, memory int64
+	if resources := nodeset.Template.PodSpecWrapper.Resources; resources != nil {
+		cpus = resources.Limits.Cpu().Value()
+		memory = resources.Limits.Memory().Value()
+	}
+	limits := nodeset.Slurmd.Resources.Limits
+	if quantity, ok := limits[corev1.ResourceCPU]; ok && !quantity.IsZero() {
+		cpus = quantity.Value()
+	}
+	if quantity, ok := limits[corev1.ResourceMemory]; ok && !quantity.IsZero() {
+		memory = quantity.Value()
+	}
+	return cpus, memory / 1024 / 1024
+}
+
+// GetSlurmNodeHostlist returns the Slurm hostlist expression of the Slurm
+// node names for the StatefulSet NodeSet ordinals [0, replicas).
+//
+// https://slurm.schedmd.com/slurm.conf.html#OPT_NodeName
+func GetSlurmNodeHostlist(nodeset *slinkyv1beta1.NodeSet, replicas int32) string {
+	if replicas <= 0 {
+		return ""
+	}
+	prefix := nodeset.Spec.Template.PodSpecWrapper.Hostname
+	if prefix == "" {
+		prefix = nodeset.Name + "-"
+	}
+	format := fmt.Sprintf("%%0%vd", nodeset.Spec.OrdinalPadding)
+	first := fmt.Sprintf(format, 0)
+	if replicas == 1 {
+		return prefix + first
+	}
+	last := fmt.Sprintf(format, replicas-1)
+	return fmt.Sprintf("%s[%s-%s]", prefix, first, last)
+}
+
+// GetMaxSurge returns the number of surge pods allowed by the rolling update.
+func GetMaxSurge(nodeset *slinkyv1beta1.NodeSet) int {
+	updateStrategyType := nodeset.Spec.UpdateStrategy.Type
+	if (updateStrategyType != "" && updateStrategyType != slinkyv1beta1.RollingUpdateNodeSetStrategyType) ||
+		nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset ||
+		nodeset.Spec.PowerSave.Enabled {
+		return 0
+	}
+	total := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
+	return mathutils.GetScaledValueFromIntOrPercent(nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge, total, true, 0)
+}
@@ -10,8 +10,15 @@ import (
 
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
 )
 
 func Test_mergeEnvVar(t *testing.T) {
@@ -467,3 +474,696 @@ func Test_parseKVKey(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSlurmNodeConf(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    []string
+	}{
+		{
+			name: "default",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			},
+			want: []string{"Features=foo"},
+		},
+		{
+			name: "extraConf",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					ExtraConf: "weight=5 feature=bar",
+				},
+			},
+			want: []string{"Features=foo,bar", "Weight=5"},
+		},
+		{
+			name: "malformed extraConf",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					ExtraConf: " weight=5  bar =baz ",
+				},
+			},
+			want: []string{"Features=foo", "Weight=5"},
+		},
+		{
+			name: "gres",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					ExtraConf: "gres=shard:8",
+					Gres: []slinkyv1beta1.NodeSetGres{
+						{ResourceName: "nvidia.com/gpu", Name: "gpu", Type: "a100"},
+					},
+					Slurmd: slinkyv1beta1.ContainerWrapper{
+						Container: corev1.Container{
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									"nvidia.com/gpu": resource.MustParse("4"),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []string{"Features=foo", "Gres=gpu:a100:4,shard:8"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetSlurmNodeConf(tt.nodeset))
+		})
+	}
+}
+
+func TestGetSlurmNodeGres(t *testing.T) {
+	newNodeSet := func(gres []slinkyv1beta1.NodeSetGres, resources corev1.ResourceRequirements) *slinkyv1beta1.NodeSet {
+		return &slinkyv1beta1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: slinkyv1beta1.NodeSetSpec{
+				Gres: gres,
+				Slurmd: slinkyv1beta1.ContainerWrapper{
+					Container: corev1.Container{
+						Resources: resources,
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    []string
+	}{
+		{
+			name:    "default",
+			nodeset: newNodeSet(nil, corev1.ResourceRequirements{}),
+			want:    []string{},
+		},
+		{
+			name: "limits",
+			nodeset: newNodeSet([]slinkyv1beta1.NodeSetGres{
+				{ResourceName: "nvidia.com/gpu", Name: "gpu", Type: "h100"},
+				{ResourceName: "example.com/nic", Name: "nic"},
+			}, corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					"nvidia.com/gpu":  resource.MustParse("8"),
+					"example.com/nic": resource.MustParse("2"),
+				},
+			}),
+			want: []string{"gpu:h100:8", "nic:2"},
+		},
+		{
+			name: "requests",
+			nodeset: newNodeSet([]slinkyv1beta1.NodeSetGres{
+				{ResourceName: "nvidia.com/gpu", Name: "gpu"},
+			}, corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					"nvidia.com/gpu": resource.MustParse("2"),
+				},
+			}),
+			want: []string{"gpu:2"},
+		},
+		{
+			name: "missing resource",
+			nodeset: newNodeSet([]slinkyv1beta1.NodeSetGres{
+				{ResourceName: "nvidia.com/gpu", Name: "gpu"},
+			}, corev1.ResourceRequirements{}),
+			want: []string{},
+		},
+		{
+			name: "resource claims",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newNodeSet([]slinkyv1beta1.NodeSetGres{
+					{ResourceClaim: "gpu", Name: "gpu", Type: "h100"},
+					{ResourceClaim: "nic", Name: "nic"},
+					{ResourceClaim: "all", Name: "all"},
+					{ResourceClaim: "missing", Name: "missing"},
+				}, corev1.ResourceRequirements{})
+				newRequest := func(mode resourcev1.DeviceAllocationMode, count int64) resourcev1.DeviceRequest {
+					return resourcev1.DeviceRequest{
+						Name: "req",
+						Exactly: &resourcev1.ExactDeviceRequest{
+							DeviceClassName: "example.com",
+							AllocationMode:  mode,
+							Count:           count,
+						},
+					}
+				}
+				nodeset.Spec.ResourceClaimTemplates = []slinkyv1beta1.NodeSetResourceClaimTemplate{
+					{
+						Name: "gpu",
+						Spec: resourcev1.ResourceClaimSpec{Devices: resourcev1.DeviceClaim{Requests: []resourcev1.DeviceRequest{
+							newRequest(resourcev1.DeviceAllocationModeExactCount, 4),
+							newRequest("", 0),
+						}}},
+					},
+					{
+						Name: "nic",
+						Spec: resourcev1.ResourceClaimSpec{Devices: resourcev1.DeviceClaim{Requests: []resourcev1.DeviceRequest{
+							newRequest("", 0),
+						}}},
+					},
+					{
+						Name: "all",
+						Spec: resourcev1.ResourceClaimSpec{Devices: resourcev1.DeviceClaim{Requests: []resourcev1.DeviceRequest{
+							newRequest(resourcev1.DeviceAllocationModeAll, 0),
+						}}},
+					},
+				}
+				return nodeset
+			}(),
+			want: []string{"gpu:h100:5", "nic:1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetSlurmNodeGres(tt.nodeset))
+		})
+	}
+}
+
+func TestGetSlurmNodeFeatures(t *testing.T) {
+	nodeLabels := map[string]string{
+		"nvidia.com/gpu.product":           "NVIDIA-H100-80GB-HBM3",
+		"node.kubernetes.io/instance-type": "p5.48xlarge",
+		"topology.kubernetes.io/zone":      "us-east-1a",
+		"empty":                            "",
+	}
+	tests := []struct {
+		name       string
+		nodeset    *slinkyv1beta1.NodeSet
+		nodeLabels map[string]string
+		want       []string
+	}{
+		{
+			name: "default",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			},
+			nodeLabels: nodeLabels,
+			want:       []string{"foo"},
+		},
+		{
+			name: "extraConf",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					ExtraConf: "weight=5 features=bar,baz",
+				},
+			},
+			nodeLabels: nodeLabels,
+			want:       []string{"bar", "baz", "foo"},
+		},
+		{
+			name: "featureLabels",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					ExtraConf: "features=bar",
+					FeatureLabels: []slinkyv1beta1.NodeSetFeatureLabel{
+						{
+							Key:    "nvidia.com/gpu.product",
+							Values: map[string]string{"NVIDIA-H100-80GB-HBM3": "h100"},
+						},
+						{Key: "node.kubernetes.io/instance-type"},
+						{Key: "topology.kubernetes.io/zone"},
+						{Key: "empty"},
+						{Key: "missing"},
+					},
+				},
+			},
+			nodeLabels: nodeLabels,
+			want:       []string{"bar", "foo", "h100", "p5.48xlarge", "us-east-1a"},
+		},
+		{
+			name: "featureLabels, no labels",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					FeatureLabels: []slinkyv1beta1.NodeSetFeatureLabel{
+						{Key: "topology.kubernetes.io/zone"},
+					},
+				},
+			},
+			nodeLabels: nil,
+			want:       []string{"foo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetSlurmNodeFeatures(tt.nodeset, tt.nodeLabels))
+		})
+	}
+}
+
+func TestGetSlurmNodeResourceSpec(t *testing.T) {
+	newPod := func(limits corev1.ResourceList) *corev1.Pod {
+		return &corev1.Pod{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "logfile"},
+					{
+						Name:      labels.WorkerApp,
+						Resources: corev1.ResourceRequirements{Limits: limits},
+					},
+				},
+			},
+		}
+	}
+	newNode := func(hardware string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					slinkyv1beta1.AnnotationNodeHardware: hardware,
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+		node    *corev1.Node
+		want    string
+	}{
+		{
+			name:    "no limits",
+			nodeset: &slinkyv1beta1.NodeSet{},
+			pod:     newPod(nil),
+			node:    newNode("Sockets=2 CoresPerSocket=32 ThreadsPerCore=2"),
+			want:    "",
+		},
+		{
+			name:    "no hardware",
+			nodeset: &slinkyv1beta1.NodeSet{},
+			pod: newPod(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("4"),
+			}),
+			node: &corev1.Node{},
+			want: "Sockets=1 CoresPerSocket=4 ThreadsPerCore=1",
+		},
+		{
+			name:    "hardware",
+			nodeset: &slinkyv1beta1.NodeSet{},
+			pod: newPod(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("16"),
+				corev1.ResourceMemory: resource.MustParse("32Gi"),
+			}),
+			node: newNode("Sockets=2 CoresPerSocket=32 ThreadsPerCore=2"),
+			want: "Sockets=1 CoresPerSocket=8 ThreadsPerCore=2 RealMemory=32768",
+		},
+		{
+			name:    "packed into one socket",
+			nodeset: &slinkyv1beta1.NodeSet{},
+			pod: newPod(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("8"),
+			}),
+			node: newNode("Sockets=2 CoresPerSocket=32 ThreadsPerCore=2"),
+			want: "Sockets=1 CoresPerSocket=4 ThreadsPerCore=2",
+		},
+		{
+			name:    "whole socket",
+			nodeset: &slinkyv1beta1.NodeSet{},
+			pod: newPod(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("64"),
+			}),
+			node: newNode("Sockets=2 CoresPerSocket=32 ThreadsPerCore=2"),
+			want: "Sockets=1 CoresPerSocket=32 ThreadsPerCore=2",
+		},
+		{
+			name:    "spans sockets",
+			nodeset: &slinkyv1beta1.NodeSet{},
+			pod: newPod(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("80"),
+			}),
+			node: newNode("Sockets=2 CoresPerSocket=32 ThreadsPerCore=2"),
+			want: "Sockets=2 CoresPerSocket=20 ThreadsPerCore=2",
+		},
+		{
+			name:    "spans sockets unevenly",
+			nodeset: &slinkyv1beta1.NodeSet{},
+			pod: newPod(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("82"),
+			}),
+			node: newNode("Sockets=2 CoresPerSocket=32 ThreadsPerCore=2"),
+			want: "Sockets=1 CoresPerSocket=41 ThreadsPerCore=2",
+		},
+		{
+			name:    "whole node",
+			nodeset: &slinkyv1beta1.NodeSet{},
+			pod: newPod(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("128"),
+			}),
+			node: newNode("Sockets=2 CoresPerSocket=32 ThreadsPerCore=2"),
+			want: "Sockets=2 CoresPerSocket=32 ThreadsPerCore=2",
+		},
+		{
+			name:    "no cores per socket",
+			nodeset: &slinkyv1beta1.NodeSet{},
+			pod: newPod(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("16"),
+			}),
+			node: newNode("Sockets=2 ThreadsPerCore=2"),
+			want: "Sockets=1 CoresPerSocket=8 ThreadsPerCore=2",
+		},
+		{
+			name:    "partial cores",
+			nodeset: &slinkyv1beta1.NodeSet{},
+			pod: newPod(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("3"),
+			}),
+			node: newNode("sockets=2 threadsPerCore=2"),
+			want: "Sockets=1 CoresPerSocket=3 ThreadsPerCore=1",
+		},
+		{
+			name: "pod limits and reserved",
+			nodeset: &slinkyv1beta1.NodeSet{
+				Spec: slinkyv1beta1.NodeSetSpec{
+					ResourceSpec: slinkyv1beta1.NodeSetResourceSpec{
+						Enabled:        true,
+						ReservedCpus:   1,
+						ReservedMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+			pod: func() *corev1.Pod {
+				pod := newPod(nil)
+				pod.Spec.Resources = &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("8"),
+						corev1.ResourceMemory: resource.MustParse("8Gi"),
+					},
+				}
+				return pod
+			}(),
+			node: newNode("Sockets=4 CoresPerSocket=2 ThreadsPerCore=2"),
+			want: "Sockets=2 CoresPerSocket=2 ThreadsPerCore=2 RealMemory=8192 CpuSpecList=0 MemSpecLimit=1024",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetSlurmNodeResourceSpec(tt.nodeset, tt.pod, tt.node))
+		})
+	}
+}
+
+func TestGetSlurmCloudNodeResourceSpec(t *testing.T) {
+	newNodeSet := func(limits corev1.ResourceList, resourceSpec slinkyv1beta1.NodeSetResourceSpec) *slinkyv1beta1.NodeSet {
+		return &slinkyv1beta1.NodeSet{
+			Spec: slinkyv1beta1.NodeSetSpec{
+				Slurmd: slinkyv1beta1.ContainerWrapper{
+					Container: corev1.Container{
+						Resources: corev1.ResourceRequirements{Limits: limits},
+					},
+				},
+				ResourceSpec: resourceSpec,
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    string
+	}{
+		{
+			name:    "no limits",
+			nodeset: newNodeSet(nil, slinkyv1beta1.NodeSetResourceSpec{}),
+			want:    "",
+		},
+		{
+			name: "limits",
+			nodeset: newNodeSet(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}, slinkyv1beta1.NodeSetResourceSpec{
+				ReservedMemory: resource.MustParse("1Gi"),
+			}),
+			want: "Sockets=1 CoresPerSocket=4 ThreadsPerCore=1 RealMemory=8192 MemSpecLimit=1024",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetSlurmCloudNodeResourceSpec(tt.nodeset))
+		})
+	}
+}
+
+func TestGetSlurmNodeTopologySpec(t *testing.T) {
+	topologyLabels := []slinkyv1beta1.NodeSetTopologyLabel{
+		{Topology: "topo-switch", Key: "topology.kubernetes.io/zone"},
+		{Topology: "topo-block", Key: "example.com/rack"},
+	}
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		node    *corev1.Node
+		want    string
+	}{
+		{
+			name:    "annotation",
+			nodeset: &slinkyv1beta1.NodeSet{},
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						slinkyv1beta1.AnnotationNodeTopologySpec: "topo-switch:s0,topo-block:b0",
+					},
+					Labels: map[string]string{
+						"topology.kubernetes.io/zone": "zone-a",
+					},
+				},
+			},
+			want: "topo-switch:s0,topo-block:b0",
+		},
+		{
+			name: "labels",
+			nodeset: &slinkyv1beta1.NodeSet{
+				Spec: slinkyv1beta1.NodeSetSpec{
+					TopologyLabels: topologyLabels,
+				},
+			},
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"topology.kubernetes.io/zone": "zone-a",
+						"example.com/rack":            "rack-1",
+					},
+				},
+			},
+			want: "topo-switch:zone-a,topo-block:rack-1",
+		},
+		{
+			name: "labels, missing label",
+			nodeset: &slinkyv1beta1.NodeSet{
+				Spec: slinkyv1beta1.NodeSetSpec{
+					TopologyLabels: topologyLabels,
+				},
+			},
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"example.com/rack": "rack-1",
+					},
+				},
+			},
+			want: "topo-block:rack-1",
+		},
+		{
+			name: "annotation takes precedence over labels",
+			nodeset: &slinkyv1beta1.NodeSet{
+				Spec: slinkyv1beta1.NodeSetSpec{
+					TopologyLabels: topologyLabels,
+				},
+			},
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						slinkyv1beta1.AnnotationNodeTopologySpec: "topo-block:b0",
+					},
+					Labels: map[string]string{
+						"topology.kubernetes.io/zone": "zone-a",
+						"example.com/rack":            "rack-1",
+					},
+				},
+			},
+			want: "topo-block:b0,topo-switch:zone-a",
+		},
+		{
+			name: "no labels",
+			nodeset: &slinkyv1beta1.NodeSet{
+				Spec: slinkyv1beta1.NodeSetSpec{
+					TopologyLabels: topologyLabels,
+				},
+			},
+			node: &corev1.Node{},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetSlurmNodeTopologySpec(tt.nodeset, tt.node))
+		})
+	}
+}
+
+func TestGetSlurmNodeHostlist(t *testing.T) {
+	tests := []struct {
+		name     string
+		nodeset  *slinkyv1beta1.NodeSet
+		replicas int32
+		want     string
+	}{
+		{
+			name: "no replicas",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			},
+			replicas: 0,
+			want:     "",
+		},
+		{
+			name: "single replica",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			},
+			replicas: 1,
+			want:     "foo-0",
+		},
+		{
+			name: "pod name",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			},
+			replicas: 4,
+			want:     "foo-[0-3]",
+		},
+		{
+			name: "hostname with padding",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					OrdinalPadding: 2,
+					Template: slinkyv1beta1.PodTemplate{
+						PodSpecWrapper: slinkyv1beta1.PodSpecWrapper{
+							PodSpec: corev1.PodSpec{
+								Hostname: "gpu",
+							},
+						},
+					},
+				},
+			},
+			replicas: 16,
+			want:     "gpu[00-15]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetSlurmNodeHostlist(tt.nodeset, tt.replicas))
+		})
+	}
+}
+
+func TestGetSlurmNodeGresConf(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    []string
+	}{
+		{
+			name:    "empty",
+			nodeset: &slinkyv1beta1.NodeSet{},
+			want:    nil,
+		},
+		{
+			name: "autodetect and files",
+			nodeset: &slinkyv1beta1.NodeSet{
+				Spec: slinkyv1beta1.NodeSetSpec{
+					Gres: []slinkyv1beta1.NodeSetGres{
+						{ResourceName: "nvidia.com/gpu", Name: "gpu", Type: "h100", AutoDetect: "nvml"},
+						{ResourceName: "nvidia.com/gpu", Name: "gpu", Type: "a100", AutoDetect: "nvml"},
+						{ResourceName: "example.com/nic", Name: "nic", File: "/dev/infiniband/uverbs[0-1]"},
+						{ResourceName: "example.com/mps", Name: "mps"},
+					},
+				},
+			},
+			want: []string{
+				"AutoDetect=nvml",
+				"Name=nic File=/dev/infiniband/uverbs[0-1]",
+			},
+		},
+		{
+			name: "typed files",
+			nodeset: &slinkyv1beta1.NodeSet{
+				Spec: slinkyv1beta1.NodeSetSpec{
+					Gres: []slinkyv1beta1.NodeSetGres{
+						{ResourceName: "nvidia.com/gpu", Name: "gpu", Type: "h100", File: "/dev/nvidia[4-7]"},
+						{ResourceName: "nvidia.com/gpu", Name: "gpu", Type: "a100", File: "/dev/nvidia[0-3]"},
+					},
+				},
+			},
+			want: []string{
+				"Name=gpu Type=a100 File=/dev/nvidia[0-3]",
+				"Name=gpu Type=h100 File=/dev/nvidia[4-7]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetSlurmNodeGresConf(tt.nodeset))
+		})
+	}
+}
+
+func TestGetMaxSurge(t *testing.T) {
+	newNodeSet := func(fn func(nodeset *slinkyv1beta1.NodeSet)) *slinkyv1beta1.NodeSet {
+		nodeset := &slinkyv1beta1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: slinkyv1beta1.NodeSetSpec{
+				Replicas: ptr.To[int32](4),
+			},
+		}
+		fn(nodeset)
+		return nodeset
+	}
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    int
+	}{
+		{
+			name:    "Unset",
+			nodeset: newNodeSet(func(*slinkyv1beta1.NodeSet) {}),
+			want:    0,
+		},
+		{
+			name: "Percent",
+			nodeset: newNodeSet(func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromString("10%"))
+			}),
+			want: 1,
+		},
+		{
+			name: "DaemonSet",
+			nodeset: newNodeSet(func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+				nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(2))
+			}),
+			want: 0,
+		},
+		{
+			name: "OnDelete",
+			nodeset: newNodeSet(func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.OnDeleteNodeSetStrategyType
+				nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(2))
+			}),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetMaxSurge(tt.nodeset))
+		})
+	}
+}
@@ -55,6 +55,16 @@ const (
 	SlurmdLogFilePath = SlurmLogFileDir + "/" + SlurmdLogFile
 
 	SlurmdSpoolDir = "/var/spool/slurmd"
+
+	// NodeSet prolog and epilog scripts, run by the dispatcher of the Controller.
+	NodeSetPrologVolume = "nodeset-prolog"
+	NodeSetPrologDir    = "/etc/slurm-nodeset/prolog.d"
+	NodeSetEpilogVolume = "nodeset-epilog"
+	NodeSetEpilogDir    = "/etc/slurm-nodeset/epilog.d"
+
+	// NodeSet node-scoped config files, included by the config files of the Controller.
+	NodeSetConfigVolume = "nodeset-config"
+	NodeSetConfigDir    = "/etc/slurm-nodeset/conf"
 )
 
 // Controller
@@ -70,6 +80,13 @@ const (
 	SlurmctldStateSaveVolume = "statesave"
 
 	SlurmctldSpoolDir = "/var/spool/slurmctld"
+
+	SlurmctldBackupVolume = "backup"
+	SlurmctldBackupDir    = "/var/backup/slurmctld"
+
+	// PowerSaveProgram is the SuspendProgram and ResumeProgram of slurmctld.
+	// The operator observes the Slurm node power states instead.
+	PowerSaveProgram = "/bin/true"
 )
 
 // Accounting
@@ -77,6 +94,19 @@ const (
 	SlurmdbdPort = 6819
 
 	SlurmdbdConfFile = "slurmdbd.conf"
+
+	SlurmdbdArchiveVolume = "archive"
+	SlurmdbdArchiveDir    = "/var/archive/slurmdbd"
+)
+
+const (
+	AnnotationSlurmConfigHash = slinkyv1beta1.SlinkyPrefix + "slurm-config-hash"
+)
+
+const (
+	// AnnotationBackupScheduledTime is the time a backup of the slurmctld
+	// save-state was scheduled at, in RFC 3339 format.
+	AnnotationBackupScheduledTime = slinkyv1beta1.SlinkyPrefix + "backup-scheduled-time"
 )
 
 const (
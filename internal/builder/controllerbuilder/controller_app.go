@@ -51,7 +51,7 @@ func (b *ControllerBuilder) BuildController(controller *slinkyv1beta1.Controller
 		ObjectMeta: objectMeta,
 		Spec: appsv1.StatefulSetSpec{
 			PodManagementPolicy:  appsv1.ParallelPodManagement,
-			Replicas:             ptr.To[int32](1),
+			Replicas:             ptr.To(ptr.Deref(controller.Spec.Replicas, defaults.DefaultControllerReplicas)),
 			RevisionHistoryLimit: ptr.To[int32](0),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: selectorLabels,
@@ -73,13 +73,26 @@ func (b *ControllerBuilder) BuildController(controller *slinkyv1beta1.Controller
 			},
 		}
 		out.Spec.Template.Spec.Volumes = append(out.Spec.Template.Spec.Volumes, volume)
+	case isPersistenceEnabled && len(controller.SlurmctldNames()) > 1:
+		volume := corev1.Volume{
+			Name: common.SlurmctldStateSaveVolume,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: controller.StateSaveKey().Name,
+				},
+			},
+		}
+		out.Spec.Template.Spec.Volumes = append(out.Spec.Template.Spec.Volumes, volume)
 	case isPersistenceEnabled:
 		volumeClaimTemplate := corev1.PersistentVolumeClaim{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      common.SlurmctldStateSaveVolume,
 				Namespace: key.Namespace,
 			},
-			Spec: persistence.PersistentVolumeClaimSpec,
+			Spec: *persistence.PersistentVolumeClaimSpec.DeepCopy(),
+		}
+		if dataSource := stateSaveDataSource(controller); dataSource != nil {
+			volumeClaimTemplate.Spec.DataSource = dataSource
 		}
 		out.Spec.VolumeClaimTemplates = append(out.Spec.VolumeClaimTemplates, volumeClaimTemplate)
 	default:
@@ -99,6 +112,38 @@ func (b *ControllerBuilder) BuildController(controller *slinkyv1beta1.Controller
 	return out, nil
 }
 
+// BuildControllerStateSave creates the ReadWriteMany claim shared by all
+// slurmctld instances for StateSaveLocation, when there are backups and no
+// existing claim. Like the volumeClaimTemplates of a single slurmctld, it
+// outlives the Controller.
+// Ref: https://slurm.schedmd.com/quickstart_admin.html#HA
+func (b *ControllerBuilder) BuildControllerStateSave(controller *slinkyv1beta1.Controller) (*corev1.PersistentVolumeClaim, error) {
+	persistence := controller.Spec.Persistence
+	isPersistenceEnabled := ptr.Deref(persistence.Enabled, defaults.DefaultControllerPersistenceEnabled)
+	if !isPersistenceEnabled || persistence.ExistingClaim != "" || len(controller.SlurmctldNames()) <= 1 {
+		return nil, nil
+	}
+
+	objectMeta := metadata.NewBuilder(controller.StateSaveKey()).
+		WithAnnotations(controller.Annotations).
+		WithLabels(controller.Labels).
+		WithLabels(labels.NewBuilder().WithControllerLabels(controller).Build()).
+		Build()
+
+	out := &corev1.PersistentVolumeClaim{
+		ObjectMeta: objectMeta,
+		Spec:       *persistence.PersistentVolumeClaimSpec.DeepCopy(),
+	}
+	if len(out.Spec.AccessModes) == 0 {
+		out.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+	}
+	if dataSource := stateSaveDataSource(controller); dataSource != nil {
+		out.Spec.DataSource = dataSource
+	}
+
+	return out, nil
+}
+
 func (b *ControllerBuilder) controllerPodTemplate(controller *slinkyv1beta1.Controller) (corev1.PodTemplateSpec, error) {
 	ctx := context.TODO()
 	key := controller.Key()
@@ -163,6 +208,9 @@ func (b *ControllerBuilder) controllerPodTemplate(controller *slinkyv1beta1.Cont
 			},
 			InitContainers: func() []corev1.Container {
 				var initContainers []corev1.Container
+				if restore := controller.Spec.RestoreFrom; restore != nil && restore.PersistentVolumeClaim != nil {
+					initContainers = append(initContainers, b.restoreContainer(controller))
+				}
 				if controller.Spec.InplaceReconfigure {
 					initContainers = append(initContainers, b.reconfigureContainer(spec.Reconfigure))
 				}
@@ -180,6 +228,22 @@ func (b *ControllerBuilder) controllerPodTemplate(controller *slinkyv1beta1.Cont
 		Merge: template.PodSpec,
 	}
 
+	// Keep backups off the node of the primary, unless the template says otherwise.
+	if len(controller.SlurmctldNames()) > 1 && template.Affinity == nil {
+		opts.Base.Affinity = &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+					{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: labels.NewBuilder().WithControllerSelectorLabels(controller).Build(),
+						},
+						TopologyKey: corev1.LabelHostname,
+					},
+				},
+			},
+		}
+	}
+
 	return b.CommonBuilder.BuildPodTemplate(opts), nil
 }
 
@@ -230,6 +294,7 @@ func controllerVolumes(controller *slinkyv1beta1.Controller, extra []string) []c
 				EmptyDir: &corev1.EmptyDirVolumeSource{},
 			},
 		},
+		nodesetConfigVolume(controller),
 	}
 	slices.Sort(extra)
 	for _, name := range extra {
@@ -250,6 +315,19 @@ func controllerVolumes(controller *slinkyv1beta1.Controller, extra []string) []c
 		out[0].Projected.Sources = append(out[0].Projected.Sources, volumeProjection)
 	}
 
+	if restore := controller.Spec.RestoreFrom; restore != nil && restore.PersistentVolumeClaim != nil {
+		volume := corev1.Volume{
+			Name: common.SlurmctldBackupVolume,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: restore.PersistentVolumeClaim.ClaimName,
+					ReadOnly:  true,
+				},
+			},
+		}
+		out = append(out, volume)
+	}
+
 	return out
 }
 
@@ -307,6 +385,7 @@ func (b *ControllerBuilder) slurmctldContainer(merge corev1.Container, clusterNa
 				{Name: common.SlurmctldStateSaveVolume, MountPath: clusterSpoolDir(clusterName)},
 				{Name: common.SlurmAuthSocketVolume, MountPath: common.SlurmctldAuthSocketDir},
 				{Name: common.SlurmLogFileVolume, MountPath: common.SlurmLogFileDir},
+				{Name: common.NodeSetConfigVolume, MountPath: common.NodeSetConfigDir, ReadOnly: true},
 			},
 		},
 		Merge: merge,
@@ -315,6 +394,29 @@ func (b *ControllerBuilder) slurmctldContainer(merge corev1.Container, clusterNa
 	return b.CommonBuilder.BuildContainer(opts)
 }
 
+// nodesetConfigVolume returns the volume of the node-scoped config files of
+// NodeSets included by the config files, which are empty for slurmctld. The
+// stand-in file only exists while a NodeSet sets a config file.
+func nodesetConfigVolume(controller *slinkyv1beta1.Controller) corev1.Volume {
+	files := slices.Concat(NodeSetPropertyConfigFiles, NodeSetLineConfigFiles)
+	items := make([]corev1.KeyToPath, 0, len(files))
+	for _, file := range files {
+		items = append(items, corev1.KeyToPath{Key: NodeSetConfigFile, Path: file})
+	}
+	return corev1.Volume{
+		Name: common.NodeSetConfigVolume,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: controller.ConfigKey().Name,
+				},
+				Items:    items,
+				Optional: ptr.To(true),
+			},
+		},
+	}
+}
+
 //go:embed scripts/reconfigure.sh
 var reconfigureScript string
 
@@ -342,10 +444,6 @@ func (b *ControllerBuilder) reconfigureContainer(container slinkyv1beta1.Contain
 	return b.CommonBuilder.BuildContainer(opts)
 }
 
-const (
-	annotationSlurmConfigHash = slinkyv1beta1.SlinkyPrefix + "slurm-config-hash"
-)
-
 func (b *ControllerBuilder) getHashes(ctx context.Context, controller *slinkyv1beta1.Controller) (map[string]string, error) {
 	hashMap, err := b.getAuthHashes(ctx, controller)
 	if err != nil {
@@ -362,7 +460,7 @@ func (b *ControllerBuilder) getHashes(ctx context.Context, controller *slinkyv1b
 	slurmConfigHash := crypto.CheckSumFromMap(config.Data)
 
 	hashMap = structutils.MergeMaps(hashMap, map[string]string{
-		annotationSlurmConfigHash: slurmConfigHash,
+		common.AnnotationSlurmConfigHash: slurmConfigHash,
 	})
 
 	return hashMap, nil
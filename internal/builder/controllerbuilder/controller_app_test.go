@@ -87,6 +87,26 @@ func TestBuilder_BuildController(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with backups",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "slurm",
+					},
+					Spec: slinkyv1beta1.ControllerSpec{
+						Replicas: ptr.To[int32](2),
+						Persistence: slinkyv1beta1.ControllerPersistence{
+							Enabled: ptr.To(true),
+						},
+						JwtKeyRef: &corev1.SecretKeySelector{},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -110,6 +130,162 @@ func TestBuilder_BuildController(t *testing.T) {
 	}
 }
 
+func TestBuilder_BuildController_backups(t *testing.T) {
+	newController := func(affinity *corev1.Affinity) *slinkyv1beta1.Controller {
+		return &slinkyv1beta1.Controller{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "slurm",
+			},
+			Spec: slinkyv1beta1.ControllerSpec{
+				Replicas: ptr.To[int32](3),
+				Persistence: slinkyv1beta1.ControllerPersistence{
+					Enabled: ptr.To(true),
+				},
+				JwtKeyRef: &corev1.SecretKeySelector{},
+				Template: slinkyv1beta1.PodTemplate{
+					PodSpecWrapper: slinkyv1beta1.PodSpecWrapper{
+						PodSpec: corev1.PodSpec{
+							Affinity: affinity,
+						},
+					},
+				},
+			},
+		}
+	}
+	nodeAffinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: "foo", Operator: corev1.NodeSelectorOpExists},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		controller   *slinkyv1beta1.Controller
+		wantAffinity *corev1.Affinity
+	}{
+		{
+			name:       "default affinity",
+			controller: newController(nil),
+			wantAffinity: &corev1.Affinity{
+				PodAntiAffinity: &corev1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+						{
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"app.kubernetes.io/instance": "slurm",
+									"app.kubernetes.io/name":     "slurmctld",
+								},
+							},
+							TopologyKey: corev1.LabelHostname,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:         "template affinity",
+			controller:   newController(nodeAffinity),
+			wantAffinity: nodeAffinity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(fake.NewFakeClient())
+			got, err := b.BuildController(tt.controller)
+			require.NoError(t, err)
+			require.Equal(t, int32(3), ptr.Deref(got.Spec.Replicas, 0))
+			require.Empty(t, got.Spec.VolumeClaimTemplates)
+			require.Equal(t, tt.wantAffinity, got.Spec.Template.Spec.Affinity)
+
+			var claimName string
+			for _, volume := range got.Spec.Template.Spec.Volumes {
+				if volume.Name == common.SlurmctldStateSaveVolume && volume.PersistentVolumeClaim != nil {
+					claimName = volume.PersistentVolumeClaim.ClaimName
+				}
+			}
+			require.Equal(t, "slurm-controller-statesave", claimName)
+		})
+	}
+}
+
+func TestBuilder_BuildControllerStateSave(t *testing.T) {
+	newController := func(replicas int32, persistence slinkyv1beta1.ControllerPersistence) *slinkyv1beta1.Controller {
+		return &slinkyv1beta1.Controller{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: corev1.NamespaceDefault,
+				Name:      "slurm",
+			},
+			Spec: slinkyv1beta1.ControllerSpec{
+				Replicas:    ptr.To(replicas),
+				Persistence: persistence,
+				JwtKeyRef:   &corev1.SecretKeySelector{},
+			},
+		}
+	}
+	tests := []struct {
+		name            string
+		controller      *slinkyv1beta1.Controller
+		wantNil         bool
+		wantAccessModes []corev1.PersistentVolumeAccessMode
+	}{
+		{
+			name:       "single",
+			controller: newController(1, slinkyv1beta1.ControllerPersistence{Enabled: ptr.To(true)}),
+			wantNil:    true,
+		},
+		{
+			name:       "persistence disabled",
+			controller: newController(2, slinkyv1beta1.ControllerPersistence{Enabled: ptr.To(false)}),
+			wantNil:    true,
+		},
+		{
+			name: "existing claim",
+			controller: newController(2, slinkyv1beta1.ControllerPersistence{
+				Enabled:       ptr.To(true),
+				ExistingClaim: "pvc",
+			}),
+			wantNil: true,
+		},
+		{
+			name:            "backups",
+			controller:      newController(2, slinkyv1beta1.ControllerPersistence{Enabled: ptr.To(true)}),
+			wantAccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+		},
+		{
+			name: "backups with access modes",
+			controller: newController(2, slinkyv1beta1.ControllerPersistence{
+				Enabled: ptr.To(true),
+				PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany, corev1.ReadWriteOnce},
+				},
+			}),
+			wantAccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany, corev1.ReadWriteOnce},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(fake.NewFakeClient())
+			got, err := b.BuildControllerStateSave(tt.controller)
+			require.NoError(t, err)
+			if tt.wantNil {
+				require.Nil(t, got)
+				return
+			}
+			require.Equal(t, tt.controller.StateSaveKey().Name, got.Name)
+			require.Equal(t, tt.controller.Namespace, got.Namespace)
+			require.Empty(t, got.OwnerReferences)
+			require.Equal(t, tt.wantAccessModes, got.Spec.AccessModes)
+		})
+	}
+}
+
 func BenchmarkBuilder_BuildController(b *testing.B) {
 	type fields struct {
 		client client.Client
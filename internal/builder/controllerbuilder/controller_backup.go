@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllerbuilder
+
+import (
+	_ "embed"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/metadata"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+)
+
+// VolumeSnapshotGVK is the kind of the backups taken as snapshots. Its API is
+// provided by the CSI external-snapshotter, if installed.
+// Ref: https://kubernetes.io/docs/concepts/storage/volume-snapshots/
+var VolumeSnapshotGVK = schema.GroupVersionKind{
+	Group:   "snapshot.storage.k8s.io",
+	Version: "v1",
+	Kind:    "VolumeSnapshot",
+}
+
+// BuildControllerBackupSnapshot creates the VolumeSnapshot of the save-state
+// claim for the backup scheduled at the given time. Like the claim, it
+// outlives the Controller.
+func (b *ControllerBuilder) BuildControllerBackupSnapshot(controller *slinkyv1beta1.Controller, scheduled time.Time) (*unstructured.Unstructured, error) {
+	backup := controller.Spec.Backup
+	if backup == nil || backup.VolumeSnapshot == nil {
+		return nil, fmt.Errorf("backup.volumeSnapshot is not set")
+	}
+
+	objectMeta := backupObjectMeta(controller, scheduled)
+
+	spec := map[string]any{
+		"source": map[string]any{
+			"persistentVolumeClaimName": stateSaveClaimName(controller),
+		},
+	}
+	if className := backup.VolumeSnapshot.VolumeSnapshotClassName; className != "" {
+		spec["volumeSnapshotClassName"] = className
+	}
+
+	out := &unstructured.Unstructured{
+		Object: map[string]any{
+			"spec": spec,
+		},
+	}
+	out.SetGroupVersionKind(VolumeSnapshotGVK)
+	out.SetName(objectMeta.Name)
+	out.SetNamespace(objectMeta.Namespace)
+	out.SetAnnotations(objectMeta.Annotations)
+	out.SetLabels(objectMeta.Labels)
+
+	return out, nil
+}
+
+//go:embed scripts/backup.sh
+var backupScript string
+
+// BuildControllerBackupJob creates the Job which archives the save-state into
+// the backup claim, for the backup scheduled at the given time.
+func (b *ControllerBuilder) BuildControllerBackupJob(controller *slinkyv1beta1.Controller, scheduled time.Time) (*batchv1.Job, error) {
+	backup := controller.Spec.Backup
+	if backup == nil || backup.PersistentVolumeClaim == nil {
+		return nil, fmt.Errorf("backup.persistentVolumeClaim is not set")
+	}
+
+	objectMeta := backupObjectMeta(controller, scheduled)
+	retention := ptr.Deref(backup.Retention, defaults.DefaultControllerBackupRetention)
+	template := controller.Spec.Template.PodSpecWrapper
+	slurmctld := controller.Spec.Slurmctld
+
+	podSpec := corev1.PodSpec{
+		AutomountServiceAccountToken: ptr.To(false),
+		RestartPolicy:                corev1.RestartPolicyNever,
+		ImagePullSecrets:             template.ImagePullSecrets,
+		NodeSelector:                 template.NodeSelector,
+		Tolerations:                  template.Tolerations,
+		PriorityClassName:            template.PriorityClassName,
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot: ptr.To(true),
+			RunAsUser:    ptr.To(common.SlurmUserUid),
+			RunAsGroup:   ptr.To(common.SlurmUserGid),
+			FSGroup:      ptr.To(common.SlurmUserGid),
+		},
+		Containers: []corev1.Container{
+			{
+				Name:            "backup",
+				Image:           slurmctld.Image,
+				ImagePullPolicy: slurmctld.ImagePullPolicy,
+				Command: []string{
+					"bash",
+					"-c",
+					backupScript,
+				},
+				Env: []corev1.EnvVar{
+					{Name: "STATESAVE_DIR", Value: common.SlurmctldSpoolDir},
+					{Name: "BACKUP_DIR", Value: path.Join(common.SlurmctldBackupDir, controller.Name)},
+					{Name: "BACKUP_NAME", Value: objectMeta.Name},
+					{Name: "BACKUP_RETENTION", Value: strconv.Itoa(int(retention))},
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: common.SlurmctldStateSaveVolume, MountPath: common.SlurmctldSpoolDir, ReadOnly: true},
+					{Name: common.SlurmctldBackupVolume, MountPath: common.SlurmctldBackupDir},
+				},
+			},
+		},
+		Volumes: []corev1.Volume{
+			{
+				Name: common.SlurmctldStateSaveVolume,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: stateSaveClaimName(controller),
+						ReadOnly:  true,
+					},
+				},
+			},
+			{
+				Name: common.SlurmctldBackupVolume,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: backup.PersistentVolumeClaim.ClaimName,
+					},
+				},
+			},
+		},
+	}
+
+	// The save-state claim of a single slurmctld may only be attached to its node.
+	if len(controller.SlurmctldNames()) == 1 {
+		podSpec.Affinity = &corev1.Affinity{
+			PodAffinity: &corev1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+					{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: labels.NewBuilder().
+								WithControllerSelectorLabels(controller).
+								WithLabels(map[string]string{appsv1.StatefulSetPodNameLabel: controller.PrimaryName()}).
+								Build(),
+						},
+						TopologyKey: corev1.LabelHostname,
+					},
+				},
+			},
+		}
+	}
+
+	out := &batchv1.Job{
+		ObjectMeta: objectMeta,
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](2),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: objectMeta.Labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(controller, out, b.client.Scheme()); err != nil {
+		return nil, fmt.Errorf("failed to set owner controller: %w", err)
+	}
+
+	return out, nil
+}
+
+func backupObjectMeta(controller *slinkyv1beta1.Controller, scheduled time.Time) metav1.ObjectMeta {
+	return metadata.NewBuilder(controller.BackupKey(scheduled)).
+		WithAnnotations(controller.Annotations).
+		WithLabels(controller.Labels).
+		WithLabels(labels.NewBuilder().WithControllerBackupLabels(controller).Build()).
+		WithAnnotations(map[string]string{
+			common.AnnotationBackupScheduledTime: scheduled.UTC().Format(time.RFC3339),
+		}).
+		Build()
+}
+
+// stateSaveClaimName returns the name of the claim holding the save-state.
+func stateSaveClaimName(controller *slinkyv1beta1.Controller) string {
+	switch {
+	case controller.Spec.Persistence.ExistingClaim != "":
+		return controller.Spec.Persistence.ExistingClaim
+	case len(controller.SlurmctldNames()) > 1:
+		return controller.StateSaveKey().Name
+	default:
+		// As named by the StatefulSet from its volumeClaimTemplates.
+		return fmt.Sprintf("%s-%s", common.SlurmctldStateSaveVolume, controller.PrimaryName())
+	}
+}
+
+// stateSaveDataSource returns the data source of a created save-state claim,
+// when restoring from a VolumeSnapshot.
+func stateSaveDataSource(controller *slinkyv1beta1.Controller) *corev1.TypedLocalObjectReference {
+	restore := controller.Spec.RestoreFrom
+	if restore == nil || restore.VolumeSnapshotName == "" {
+		return nil
+	}
+	return &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To(VolumeSnapshotGVK.Group),
+		Kind:     VolumeSnapshotGVK.Kind,
+		Name:     restore.VolumeSnapshotName,
+	}
+}
+
+//go:embed scripts/restore.sh
+var restoreScript string
+
+// restoreContainer extracts an archive into an empty save-state before
+// slurmctld starts.
+func (b *ControllerBuilder) restoreContainer(controller *slinkyv1beta1.Controller) corev1.Container {
+	restore := controller.Spec.RestoreFrom.PersistentVolumeClaim
+	restorePath := restore.Path
+	if restorePath == "" {
+		restorePath = controller.Name
+	}
+	slurmctld := controller.Spec.Slurmctld
+
+	return corev1.Container{
+		Name:            "restore",
+		Image:           slurmctld.Image,
+		ImagePullPolicy: slurmctld.ImagePullPolicy,
+		Command: []string{
+			"bash",
+			"-c",
+			restoreScript,
+		},
+		Env: []corev1.EnvVar{
+			{Name: "STATESAVE_DIR", Value: clusterSpoolDir(controller.ClusterName())},
+			{Name: "RESTORE_PATH", Value: path.Join(common.SlurmctldBackupDir, restorePath)},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsNonRoot: ptr.To(true),
+			RunAsUser:    ptr.To(common.SlurmUserUid),
+			RunAsGroup:   ptr.To(common.SlurmUserGid),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: common.SlurmctldStateSaveVolume, MountPath: clusterSpoolDir(controller.ClusterName())},
+			{Name: common.SlurmctldBackupVolume, MountPath: common.SlurmctldBackupDir, ReadOnly: true},
+		},
+	}
+}
@@ -0,0 +1,245 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package controllerbuilder
+
+import (
+	"testing"
+	"time"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newBackupController(replicas int32, backup *slinkyv1beta1.ControllerBackup, restore *slinkyv1beta1.ControllerRestore) *slinkyv1beta1.Controller {
+	return &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+			UID:       "uid",
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			Replicas: ptr.To(replicas),
+			Persistence: slinkyv1beta1.ControllerPersistence{
+				Enabled: ptr.To(true),
+			},
+			JwtKeyRef:   &corev1.SecretKeySelector{},
+			Backup:      backup,
+			RestoreFrom: restore,
+		},
+	}
+}
+
+func TestBuilder_BuildControllerBackupSnapshot(t *testing.T) {
+	scheduled := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		wantErr    bool
+		wantSpec   map[string]any
+	}{
+		{
+			name: "not a snapshot",
+			controller: newBackupController(1, &slinkyv1beta1.ControllerBackup{
+				PersistentVolumeClaim: &slinkyv1beta1.ControllerBackupVolume{ClaimName: "backup"},
+			}, nil),
+			wantErr: true,
+		},
+		{
+			name: "single",
+			controller: newBackupController(1, &slinkyv1beta1.ControllerBackup{
+				VolumeSnapshot: &slinkyv1beta1.ControllerBackupVolumeSnapshot{},
+			}, nil),
+			wantSpec: map[string]any{
+				"source": map[string]any{
+					"persistentVolumeClaimName": "statesave-slurm-controller-0",
+				},
+			},
+		},
+		{
+			name: "backups with class",
+			controller: newBackupController(2, &slinkyv1beta1.ControllerBackup{
+				VolumeSnapshot: &slinkyv1beta1.ControllerBackupVolumeSnapshot{
+					VolumeSnapshotClassName: "csi",
+				},
+			}, nil),
+			wantSpec: map[string]any{
+				"source": map[string]any{
+					"persistentVolumeClaimName": "slurm-controller-statesave",
+				},
+				"volumeSnapshotClassName": "csi",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(fake.NewFakeClient())
+			got, err := b.BuildControllerBackupSnapshot(tt.controller, scheduled)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, VolumeSnapshotGVK, got.GroupVersionKind())
+			require.Equal(t, "slurm-controller-backup-20260102030405", got.GetName())
+			require.Equal(t, "2026-01-02T03:04:05Z", got.GetAnnotations()[common.AnnotationBackupScheduledTime])
+			require.Empty(t, got.GetOwnerReferences())
+			spec, _, _ := unstructured.NestedMap(got.Object, "spec")
+			require.Equal(t, tt.wantSpec, spec)
+		})
+	}
+}
+
+func TestBuilder_BuildControllerBackupJob(t *testing.T) {
+	scheduled := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	claimBackup := &slinkyv1beta1.ControllerBackup{
+		PersistentVolumeClaim: &slinkyv1beta1.ControllerBackupVolume{ClaimName: "backup"},
+	}
+	tests := []struct {
+		name         string
+		controller   *slinkyv1beta1.Controller
+		wantErr      bool
+		wantClaim    string
+		wantAffinity bool
+	}{
+		{
+			name: "not a claim",
+			controller: newBackupController(1, &slinkyv1beta1.ControllerBackup{
+				VolumeSnapshot: &slinkyv1beta1.ControllerBackupVolumeSnapshot{},
+			}, nil),
+			wantErr: true,
+		},
+		{
+			name:         "single",
+			controller:   newBackupController(1, claimBackup, nil),
+			wantClaim:    "statesave-slurm-controller-0",
+			wantAffinity: true,
+		},
+		{
+			name:       "backups",
+			controller: newBackupController(2, claimBackup, nil),
+			wantClaim:  "slurm-controller-statesave",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(fake.NewFakeClient())
+			got, err := b.BuildControllerBackupJob(tt.controller, scheduled)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "slurm-controller-backup-20260102030405", got.Name)
+			require.True(t, metav1.IsControlledBy(got, tt.controller))
+
+			podSpec := got.Spec.Template.Spec
+			require.Equal(t, corev1.RestartPolicyNever, podSpec.RestartPolicy)
+			require.Equal(t, tt.wantAffinity, podSpec.Affinity != nil)
+
+			claims := map[string]string{}
+			for _, volume := range podSpec.Volumes {
+				claims[volume.Name] = volume.PersistentVolumeClaim.ClaimName
+			}
+			require.Equal(t, tt.wantClaim, claims[common.SlurmctldStateSaveVolume])
+			require.Equal(t, "backup", claims[common.SlurmctldBackupVolume])
+
+			env := map[string]string{}
+			for _, e := range podSpec.Containers[0].Env {
+				env[e.Name] = e.Value
+			}
+			require.Equal(t, "/var/backup/slurmctld/slurm", env["BACKUP_DIR"])
+			require.Equal(t, "7", env["BACKUP_RETENTION"])
+		})
+	}
+}
+
+func TestBuilder_BuildController_restore(t *testing.T) {
+	tests := []struct {
+		name             string
+		controller       *slinkyv1beta1.Controller
+		wantDataSource   *corev1.TypedLocalObjectReference
+		wantInitRestore  bool
+		wantRestorePath  string
+		wantBackupVolume bool
+	}{
+		{
+			name:       "none",
+			controller: newBackupController(1, nil, nil),
+		},
+		{
+			name: "snapshot",
+			controller: newBackupController(1, nil, &slinkyv1beta1.ControllerRestore{
+				VolumeSnapshotName: "snapshot",
+			}),
+			wantDataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: ptr.To("snapshot.storage.k8s.io"),
+				Kind:     "VolumeSnapshot",
+				Name:     "snapshot",
+			},
+		},
+		{
+			name: "claim",
+			controller: newBackupController(1, nil, &slinkyv1beta1.ControllerRestore{
+				PersistentVolumeClaim: &slinkyv1beta1.ControllerRestoreVolume{ClaimName: "backup"},
+			}),
+			wantInitRestore:  true,
+			wantRestorePath:  "/var/backup/slurmctld/slurm",
+			wantBackupVolume: true,
+		},
+		{
+			name: "claim with path",
+			controller: newBackupController(1, nil, &slinkyv1beta1.ControllerRestore{
+				PersistentVolumeClaim: &slinkyv1beta1.ControllerRestoreVolume{
+					ClaimName: "backup",
+					Path:      "old/slurm-controller-backup-20260102030405.tar.gz",
+				},
+			}),
+			wantInitRestore:  true,
+			wantRestorePath:  "/var/backup/slurmctld/old/slurm-controller-backup-20260102030405.tar.gz",
+			wantBackupVolume: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(fake.NewFakeClient())
+			got, err := b.BuildController(tt.controller)
+			require.NoError(t, err)
+
+			require.Len(t, got.Spec.VolumeClaimTemplates, 1)
+			require.Equal(t, tt.wantDataSource, got.Spec.VolumeClaimTemplates[0].Spec.DataSource)
+
+			podSpec := got.Spec.Template.Spec
+			var restore *corev1.Container
+			for i := range podSpec.InitContainers {
+				if podSpec.InitContainers[i].Name == "restore" {
+					restore = &podSpec.InitContainers[i]
+				}
+			}
+			require.Equal(t, tt.wantInitRestore, restore != nil)
+			if restore != nil {
+				require.Equal(t, "restore", podSpec.InitContainers[0].Name)
+				env := map[string]string{}
+				for _, e := range restore.Env {
+					env[e.Name] = e.Value
+				}
+				require.Equal(t, tt.wantRestorePath, env["RESTORE_PATH"])
+			}
+
+			hasBackupVolume := false
+			for _, volume := range podSpec.Volumes {
+				if volume.Name == common.SlurmctldBackupVolume {
+					hasBackupVolume = true
+					require.True(t, volume.PersistentVolumeClaim.ReadOnly)
+				}
+			}
+			require.Equal(t, tt.wantBackupVolume, hasBackupVolume)
+		})
+	}
+}
@@ -5,26 +5,76 @@ package controllerbuilder
 
 import (
 	"context"
+	_ "embed"
 	"fmt"
+	"maps"
 	"path"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/yaml"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
 )
 
 const (
-	SlurmConfFile  = "slurm.conf"
-	CgroupConfFile = "cgroup.conf"
+	SlurmConfFile        = "slurm.conf"
+	CgroupConfFile       = "cgroup.conf"
+	TopologyYamlConfFile = "topology.yaml"
+	GresConfFile         = "gres.conf"
+	AcctGatherConfFile   = "acct_gather.conf"
+	HelpersConfFile      = "helpers.conf"
+	OciConfFile          = "oci.conf"
+
+	// NodeSetScriptsFile is the prolog and epilog dispatcher of NodeSet scripts.
+	NodeSetScriptsFile = "nodeset-scripts.sh"
+	// NodeSetConfigFile is the empty stand-in for the node-scoped config
+	// files of NodeSets, which slurmctld includes.
+	NodeSetConfigFile = "nodeset-config.conf"
+)
+
+var (
+	// NodeSetPropertyConfigFiles are the node-scoped config files of NodeSets
+	// that contain `key=value` properties, included from the pods of the NodeSet.
+	NodeSetPropertyConfigFiles = []string{AcctGatherConfFile, CgroupConfFile, OciConfFile}
+	// NodeSetLineConfigFiles are the node-scoped config files of NodeSets
+	// that contain lines, included from the pods of the NodeSet. The gres.conf
+	// also contains the GRES of the NodeSet.
+	NodeSetLineConfigFiles = []string{GresConfFile, HelpersConfFile}
+
+	// ReservedSlurmConfKeys are the slurm.conf options set by buildSlurmConf()
+	// which cannot be overridden by ExtraConf.
+	ReservedSlurmConfKeys = []string{
+		"ClusterName",
+		"SlurmUser",
+		"SlurmctldHost",
+		"SlurmctldPort",
+		"StateSaveLocation",
+		"SlurmdUser",
+		"SlurmdPort",
+		"SlurmdSpoolDir",
+		"SlurmctldLogFile",
+		"SlurmdLogFile",
+		"AuthType",
+		"CredType",
+		"AuthAltTypes",
+		"AccountingStorageType",
+		"AccountingStorageHost",
+		"AccountingStoragePort",
+	}
 )
 
 func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Controller) (*corev1.ConfigMap, error) {
@@ -46,6 +96,11 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 		return nil, err
 	}
 
+	partitionList, err := b.refResolver.GetPartitionsForController(ctx, controller)
+	if err != nil {
+		return nil, err
+	}
+
 	configFilesList := &corev1.ConfigMapList{
 		Items: make([]corev1.ConfigMap, 0, len(controller.Spec.ConfigFileRefs)),
 	}
@@ -60,12 +115,14 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 		}
 		configFilesList.Items = append(configFilesList.Items, *cm)
 	}
-	hasCgroupConfFile := false
+	controllerConfigFiles := set.New[string]()
 	for _, configMap := range configFilesList.Items {
-		if _, ok := configMap.Data[CgroupConfFile]; ok {
-			hasCgroupConfFile = true
-			break
-		}
+		controllerConfigFiles.Insert(structutils.Keys(configMap.Data)...)
+	}
+
+	nodesetConfigFiles, err := b.getNodeSetConfigFiles(ctx, nodesetList)
+	if err != nil {
+		return nil, err
 	}
 
 	prologScripts := []string{}
@@ -128,6 +185,16 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 		epilogSlurmctldScripts = append(epilogSlurmctldScripts, filenames...)
 	}
 
+	// NodeSet scripts are only mounted into the pods of their NodeSet,
+	// where the dispatcher runs them.
+	hasNodeSetPrologScripts, hasNodeSetEpilogScripts := hasNodeSetScripts(nodesetList)
+	if hasNodeSetPrologScripts {
+		prologScripts = append(prologScripts, NodeSetScriptsFile)
+	}
+	if hasNodeSetEpilogScripts {
+		epilogScripts = append(epilogScripts, NodeSetScriptsFile)
+	}
+
 	opts := common.ConfigMapOpts{
 		Key: controller.ConfigKey(),
 		Metadata: slinkyv1beta1.Metadata{
@@ -136,32 +203,86 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 		},
 		Data: map[string]string{
 			SlurmConfFile: buildSlurmConf(
-				controller, accounting, nodesetList,
+				controller, accounting, nodesetList, partitionList,
 				prologScripts, epilogScripts,
 				prologSlurmctldScripts, epilogSlurmctldScripts,
 			),
 		},
 	}
-	if !hasCgroupConfFile {
-		opts.Data[CgroupConfFile] = buildCgroupConf()
+	if hasNodeSetPrologScripts || hasNodeSetEpilogScripts {
+		opts.Data[NodeSetScriptsFile] = nodesetScripts
+	}
+	if !controllerConfigFiles.Has(CgroupConfFile) {
+		opts.Data[CgroupConfFile] = buildCgroupConf(nodesetConfigFiles[CgroupConfFile])
+	}
+	// Config files of NodeSets are included from the node-scoped config
+	// directory, where only their pods mount them. slurmctld includes an empty file.
+	hasNodeSetConfig := false
+	for _, file := range []string{AcctGatherConfFile, GresConfFile, HelpersConfFile, OciConfFile} {
+		if controllerConfigFiles.Has(file) {
+			continue
+		}
+		if len(nodesetConfigFiles[file]) > 0 || (file == GresConfFile && hasGresConf(nodesetList)) {
+			conf := config.NewBuilder()
+			conf.AddProperty(config.NewPropertyRaw(nodesetConfigInclude(file)))
+			opts.Data[file] = conf.Build()
+			hasNodeSetConfig = true
+		}
+	}
+	if len(nodesetConfigFiles[CgroupConfFile]) > 0 && !controllerConfigFiles.Has(CgroupConfFile) {
+		hasNodeSetConfig = true
+	}
+	if hasNodeSetConfig {
+		opts.Data[NodeSetConfigFile] = ""
+	}
+	if len(controller.Spec.Topology.Topologies) > 0 {
+		topologyYaml, err := buildTopologyYaml(controller.Spec.Topology.Topologies)
+		if err != nil {
+			return nil, err
+		}
+		opts.Data[TopologyYamlConfFile] = topologyYaml
 	}
 
 	return b.CommonBuilder.BuildConfigMap(opts, controller)
 }
 
+// slurmctldHosts returns the `SlurmctldHost` values, with the primary first.
+// A single slurmctld is addressed through the controller Service, whereas each
+// of several slurmctld is addressed through its own Service, because the
+// controller Service follows the one in control.
+// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SlurmctldHost
+func slurmctldHosts(controller *slinkyv1beta1.Controller) []string {
+	names := controller.SlurmctldNames()
+	if len(names) == 1 {
+		return []string{fmt.Sprintf("%s(%s)", names[0], controller.ServiceFQDNShort())}
+	}
+	hosts := make([]string, 0, len(names))
+	for _, name := range names {
+		hosts = append(hosts, fmt.Sprintf("%s(%s)", name, controller.SlurmctldServiceFQDNShort(name)))
+	}
+	return hosts
+}
+
 // https://slurm.schedmd.com/slurm.conf.html
 func buildSlurmConf(
 	controller *slinkyv1beta1.Controller,
 	accounting *slinkyv1beta1.Accounting,
 	nodesetList *slinkyv1beta1.NodeSetList,
+	partitionList *slinkyv1beta1.PartitionList,
 	prologScripts, epilogScripts []string,
 	prologSlurmctldScripts, epilogSlurmctldScripts []string,
 ) string {
 	mergeConfig := map[string][]string{
-		"SlurmctldParameters": {
-			"enable_configless",
-			"reconfig_on_restart",
-		},
+		"SlurmctldParameters": func() []string {
+			params := []string{
+				"enable_configless",
+				"reconfig_on_restart",
+			}
+			if isPowerSaveEnabled(nodesetList) {
+				params = append(params, "cloud_reg_addrs")
+			}
+			return params
+		}(),
 		"AuthInfo": {
 			common.AuthInfo,
 		},
@@ -173,8 +294,25 @@ func buildSlurmConf(
 			return params
 		}(),
 	}
-
-	controllerHost := fmt.Sprintf("%s(%s)", controller.PrimaryName(), controller.ServiceFQDNShort())
+	if params := controller.Spec.Topology.Params; len(params) > 0 {
+		mergeConfig["TopologyParam"] = params
+	}
+	if gresTypes := getGresTypes(nodesetList); len(gresTypes) > 0 {
+		mergeConfig["GresTypes"] = gresTypes
+	}
+	scheduling := controller.Spec.Scheduling
+	if params := scheduling.SchedulerParameters; len(params) > 0 {
+		mergeConfig["SchedulerParameters"] = params
+	}
+	if params := scheduling.SelectTypeParameters; len(params) > 0 {
+		mergeConfig["SelectTypeParameters"] = params
+	}
+	if flags := scheduling.Priority.Flags; len(flags) > 0 {
+		mergeConfig["PriorityFlags"] = flags
+	}
+	if modes := scheduling.Preempt.Mode; len(modes) > 0 {
+		mergeConfig["PreemptMode"] = modes
+	}
 
 	conf := config.NewBuilder()
 
@@ -182,7 +320,9 @@ func buildSlurmConf(
 	conf.AddProperty(config.NewPropertyRaw("### GENERAL ###"))
 	conf.AddProperty(config.NewProperty("ClusterName", controller.ClusterName()))
 	conf.AddProperty(config.NewProperty("SlurmUser", common.SlurmUser))
-	conf.AddProperty(config.NewProperty("SlurmctldHost", controllerHost))
+	for _, host := range slurmctldHosts(controller) {
+		conf.AddProperty(config.NewProperty("SlurmctldHost", host))
+	}
 	conf.AddProperty(config.NewProperty("SlurmctldPort", common.SlurmctldPort))
 	conf.AddProperty(config.NewProperty("StateSaveLocation", clusterSpoolDir(controller.ClusterName())))
 	conf.AddProperty(config.NewProperty("SlurmdUser", common.SlurmdUser))
@@ -204,6 +344,12 @@ func buildSlurmConf(
 	conf.AddProperty(config.NewProperty("AuthAltParameters", strings.Join(mergeConfig["AuthAltParameters"], ",")))
 	conf.AddProperty(config.NewProperty("AuthInfo", strings.Join(mergeConfig["AuthInfo"], ",")))
 	conf.AddProperty(config.NewProperty("SlurmctldParameters", strings.Join(mergeConfig["SlurmctldParameters"], ",")))
+	if params, ok := mergeConfig["TopologyParam"]; ok {
+		conf.AddProperty(config.NewProperty("TopologyParam", strings.Join(params, ",")))
+	}
+	if gresTypes, ok := mergeConfig["GresTypes"]; ok {
+		conf.AddProperty(config.NewProperty("GresTypes", strings.Join(gresTypes, ",")))
+	}
 
 	metricsEnabled := controller.Spec.Metrics.Enabled
 	if metricsEnabled {
@@ -220,6 +366,12 @@ func buildSlurmConf(
 		conf.AddProperty(config.NewProperty("AccountingStorageType", "accounting_storage/none"))
 	}
 
+	if snippet := buildSchedulingConf(scheduling, mergeConfig); snippet != "" {
+		conf.AddProperty(config.NewPropertyRaw("#"))
+		conf.AddProperty(config.NewPropertyRaw("### SCHEDULING ###"))
+		conf.AddProperty(config.NewPropertyRaw(snippet))
+	}
+
 	if snippet := buildPrologEpilogSlurmctldConf(prologSlurmctldScripts, epilogSlurmctldScripts); snippet != "" {
 		conf.AddProperty(config.NewPropertyRaw("#"))
 		conf.AddProperty(config.NewPropertyRaw("### SLURMCTLD PROLOG & EPILOG ###"))
@@ -232,12 +384,25 @@ func buildSlurmConf(
 		conf.AddProperty(config.NewPropertyRaw(snippet))
 	}
 
+	if isPowerSaveEnabled(nodesetList) {
+		conf.AddProperty(config.NewPropertyRaw("#"))
+		conf.AddProperty(config.NewPropertyRaw("### POWER SAVING ###"))
+		conf.AddProperty(config.NewProperty("SuspendProgram", common.PowerSaveProgram))
+		conf.AddProperty(config.NewProperty("ResumeProgram", common.PowerSaveProgram))
+	}
+
 	if snippet := buildNodeSetConf(nodesetList); snippet != "" {
 		conf.AddProperty(config.NewPropertyRaw("#"))
 		conf.AddProperty(config.NewPropertyRaw("### NODESET & PARTITION ###"))
 		conf.AddProperty(config.NewPropertyRaw(snippet))
 	}
 
+	if snippet := buildPartitionConf(partitionList, nodesetList); snippet != "" {
+		conf.AddProperty(config.NewPropertyRaw("#"))
+		conf.AddProperty(config.NewPropertyRaw("### PARTITION ###"))
+		conf.AddProperty(config.NewPropertyRaw(snippet))
+	}
+
 	extraConf := controller.Spec.ExtraConf
 	if extraConf != "" {
 		conf.AddProperty(config.NewPropertyRaw("#"))
@@ -254,6 +419,76 @@ func buildSlurmConf(
 	return conf.Build()
 }
 
+// buildSchedulingConf() returns a slurm.conf snippet containing scheduling and resource selection config.
+// List options are taken from the mergeConfig, so they are merged with ExtraConf.
+//
+// https://slurm.schedmd.com/slurm.conf.html#SECTION_SCHEDULING
+func buildSchedulingConf(scheduling slinkyv1beta1.ControllerScheduling, mergeConfig map[string][]string) string {
+	conf := config.NewBuilder().WithFinalNewline(false)
+
+	addString := func(key, val string) {
+		if val != "" {
+			conf.AddProperty(config.NewProperty(key, val))
+		}
+	}
+	addList := func(key string) {
+		if vals, ok := mergeConfig[key]; ok {
+			conf.AddProperty(config.NewProperty(key, strings.Join(vals, ",")))
+		}
+	}
+	addInt32 := func(key string, val *int32) {
+		if val != nil {
+			conf.AddProperty(config.NewProperty(key, *val))
+		}
+	}
+	addInt64 := func(key string, val *int64) {
+		if val != nil {
+			conf.AddProperty(config.NewProperty(key, *val))
+		}
+	}
+
+	addString("SchedulerType", scheduling.SchedulerType)
+	addList("SchedulerParameters")
+	addString("SelectType", scheduling.SelectType)
+	addList("SelectTypeParameters")
+
+	priority := scheduling.Priority
+	addString("PriorityType", priority.Type)
+	addList("PriorityFlags")
+	addString("PriorityDecayHalfLife", priority.DecayHalfLife)
+	addString("PriorityMaxAge", priority.MaxAge)
+	addInt32("PriorityWeightAge", priority.Weights.Age)
+	addInt32("PriorityWeightAssoc", priority.Weights.Assoc)
+	addInt32("PriorityWeightFairshare", priority.Weights.Fairshare)
+	addInt32("PriorityWeightJobSize", priority.Weights.JobSize)
+	addInt32("PriorityWeightPartition", priority.Weights.Partition)
+	addInt32("PriorityWeightQOS", priority.Weights.QOS)
+	if tres := priority.Weights.TRES; len(tres) > 0 {
+		weights := make([]string, 0, len(tres))
+		for _, key := range slices.Sorted(maps.Keys(tres)) {
+			weights = append(weights, fmt.Sprintf("%s=%d", key, tres[key]))
+		}
+		addString("PriorityWeightTRES", strings.Join(weights, ","))
+	}
+
+	preempt := scheduling.Preempt
+	addString("PreemptType", preempt.Type)
+	addList("PreemptMode")
+	addString("PreemptExemptTime", preempt.ExemptTime)
+
+	limits := scheduling.Limits
+	addInt32("MaxJobCount", limits.MaxJobCount)
+	addInt32("MaxArraySize", limits.MaxArraySize)
+	addInt32("MaxStepCount", limits.MaxStepCount)
+	addInt32("MaxTasksPerNode", limits.MaxTasksPerNode)
+	addInt64("DefMemPerCPU", limits.DefMemPerCPU)
+	addInt64("DefMemPerNode", limits.DefMemPerNode)
+	addInt64("MaxMemPerCPU", limits.MaxMemPerCPU)
+	addInt64("MaxMemPerNode", limits.MaxMemPerNode)
+
+	return conf.Build()
+}
+
 // buildPrologEpilogConf() returns a slurm.conf snippet containing PrologSlurmctld and EpilogSlurmctld config.
 //
 // https://slurm.schedmd.com/slurm.conf.html#OPT_PrologSlurmctld
@@ -296,6 +531,23 @@ func buildPrologEpilogConf(prologScripts, epilogScripts []string) string {
 	return conf.WithFinalNewline(false).Build()
 }
 
+//go:embed scripts/nodeset-scripts.sh
+var nodesetScripts string
+
+// hasNodeSetScripts returns whether any NodeSet has prolog or epilog scripts.
+func hasNodeSetScripts(nodesetList *slinkyv1beta1.NodeSetList) (bool, bool) {
+	hasPrologScripts, hasEpilogScripts := false, false
+	for _, nodeset := range nodesetList.Items {
+		if len(nodeset.Spec.PrologScriptRefs) > 0 {
+			hasPrologScripts = true
+		}
+		if len(nodeset.Spec.EpilogScriptRefs) > 0 {
+			hasEpilogScripts = true
+		}
+	}
+	return hasPrologScripts, hasEpilogScripts
+}
+
 // buildNodeSetConf() returns a slurm.conf snippet containing NodeSets and their Partitions.
 //
 // https://slurm.schedmd.com/slurm.conf.html#SECTION_NODESET-CONFIGURATION
@@ -308,6 +560,19 @@ func buildNodeSetConf(nodesetList *slinkyv1beta1.NodeSetList) string {
 	})
 	for _, nodeset := range nodesetList.Items {
 		name := common.GetSlurmNodeSetName(&nodeset)
+		powerSave := nodeset.Spec.PowerSave
+		if powerSave.Enabled {
+			replicas := ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas)
+			if hostlist := common.GetSlurmNodeHostlist(&nodeset, replicas); hostlist != "" {
+				nodeLine := []string{
+					fmt.Sprintf("NodeName=%v", hostlist),
+					"State=CLOUD",
+				}
+				nodeLine = append(nodeLine, getCloudNodeConf(&nodeset)...)
+				nodeLineRendered := strings.Join(nodeLine, " ")
+				conf.AddProperty(config.NewPropertyRaw(nodeLineRendered))
+			}
+		}
 		nodesetLine := []string{
 			fmt.Sprintf("NodeSet=%v", name),
 			fmt.Sprintf("Feature=%v", name),
@@ -321,7 +586,97 @@ func buildNodeSetConf(nodesetList *slinkyv1beta1.NodeSetList) string {
 		partitionLine := []string{
 			fmt.Sprintf("PartitionName=%v", name),
 			fmt.Sprintf("Nodes=%v", name),
-			partition.Config,
+		}
+		if powerSave.Enabled {
+			suspendTime := ptr.Deref(powerSave.SuspendTime, defaults.DefaultNodeSetPowerSaveSuspendTime)
+			resumeTimeout := ptr.Deref(powerSave.ResumeTimeout, defaults.DefaultNodeSetPowerSaveResumeTimeout)
+			partitionLine = append(partitionLine,
+				fmt.Sprintf("SuspendTime=%d", int64(suspendTime.Seconds())),
+				fmt.Sprintf("ResumeTimeout=%d", int64(resumeTimeout.Seconds())),
+			)
+		}
+		partitionLine = append(partitionLine, partition.Config)
+		partitionLineRendered := strings.Join(partitionLine, " ")
+		conf.AddProperty(config.NewPropertyRaw(partitionLineRendered))
+	}
+
+	return conf.WithFinalNewline(false).Build()
+}
+
+// getCloudNodeConf returns the Slurm node configuration of the power saving
+// NodeSet. Slurmd of CLOUD nodes does not register its resources with --conf,
+// hence the CPU layout and RealMemory are derived from the resource limits as
+// for the other NodeSets, unless they are set by the ExtraConf.
+func getCloudNodeConf(nodeset *slinkyv1beta1.NodeSet) []string {
+	confList := []string{}
+	keys := set.New[string]()
+	for _, item := range common.GetSlurmNodeConf(nodeset) {
+		key, val, _ := strings.Cut(item, "=")
+		key = config.CanonicalSlurmNodeKey(key)
+		keys.Insert(strings.ToLower(key))
+		confList = append(confList, fmt.Sprintf("%s=%s", key, val))
+	}
+	sort.Strings(confList)
+
+	layoutKeys := []string{"boards", "cpus", "corespersocket", "procs", "sockets", "socketsperboard", "threadspercore"}
+	for item := range strings.FieldsSeq(common.GetSlurmCloudNodeResourceSpec(nodeset)) {
+		key, _, _ := strings.Cut(item, "=")
+		key = strings.ToLower(key)
+		if keys.Has(key) || (slices.Contains(layoutKeys, key) && keys.HasAny(layoutKeys...)) {
+			continue
+		}
+		confList = append(confList, item)
+	}
+
+	return confList
+}
+
+// buildPartitionConf() returns a slurm.conf snippet containing Partitions and their member NodeSets.
+//
+// https://slurm.schedmd.com/slurm.conf.html#SECTION_PARTITION-CONFIGURATION
+func buildPartitionConf(partitionList *slinkyv1beta1.PartitionList, nodesetList *slinkyv1beta1.NodeSetList) string {
+	conf := config.NewBuilder()
+
+	sort.Slice(partitionList.Items, func(i, j int) bool {
+		return partitionList.Items[i].Name < partitionList.Items[j].Name
+	})
+	for _, partition := range partitionList.Items {
+		nodesets := []string{}
+		for _, nodeset := range nodesetList.Items {
+			// An invalid selector is rejected by the webhook, treat it as no match.
+			if ok, _ := refresolver.IsPartitionMember(&partition, &nodeset); ok {
+				nodesets = append(nodesets, common.GetSlurmNodeSetName(&nodeset))
+			}
+		}
+		sort.Strings(nodesets)
+
+		spec := partition.Spec
+		partitionLine := []string{
+			fmt.Sprintf("PartitionName=%v", partition.Name),
+		}
+		if len(nodesets) > 0 {
+			partitionLine = append(partitionLine, fmt.Sprintf("Nodes=%v", strings.Join(nodesets, ",")))
+		}
+		if spec.Default {
+			partitionLine = append(partitionLine, "Default=YES")
+		}
+		if spec.MaxTime != "" {
+			partitionLine = append(partitionLine, fmt.Sprintf("MaxTime=%v", spec.MaxTime))
+		}
+		if spec.PriorityTier != nil {
+			partitionLine = append(partitionLine, fmt.Sprintf("PriorityTier=%d", *spec.PriorityTier))
+		}
+		if spec.OverSubscribe != "" {
+			partitionLine = append(partitionLine, fmt.Sprintf("OverSubscribe=%v", spec.OverSubscribe))
+		}
+		if len(spec.AllowAccounts) > 0 {
+			partitionLine = append(partitionLine, fmt.Sprintf("AllowAccounts=%v", strings.Join(spec.AllowAccounts, ",")))
+		}
+		if spec.State != "" {
+			partitionLine = append(partitionLine, fmt.Sprintf("State=%v", spec.State))
+		}
+		if spec.ExtraConf != "" {
+			partitionLine = append(partitionLine, spec.ExtraConf)
 		}
 		partitionLineRendered := strings.Join(partitionLine, " ")
 		conf.AddProperty(config.NewPropertyRaw(partitionLineRendered))
@@ -330,16 +685,185 @@ func buildNodeSetConf(nodesetList *slinkyv1beta1.NodeSetList) string {
 	return conf.WithFinalNewline(false).Build()
 }
 
+// getGresTypes returns the sorted GRES names of the NodeSets.
+//
+// https://slurm.schedmd.com/slurm.conf.html#OPT_GresTypes
+func getGresTypes(nodesetList *slinkyv1beta1.NodeSetList) []string {
+	gresTypes := set.New[string]()
+	for _, nodeset := range nodesetList.Items {
+		for _, gres := range nodeset.Spec.Gres {
+			gresTypes.Insert(gres.Name)
+		}
+	}
+	return gresTypes.SortedList()
+}
+
+// hasGresConf returns true if any NodeSet has GRES that need a gres.conf line.
+func hasGresConf(nodesetList *slinkyv1beta1.NodeSetList) bool {
+	for _, nodeset := range nodesetList.Items {
+		if len(common.GetSlurmNodeGresConf(&nodeset)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isPowerSaveEnabled returns true if any NodeSet uses Slurm power saving.
+func isPowerSaveEnabled(nodesetList *slinkyv1beta1.NodeSetList) bool {
+	for _, nodeset := range nodesetList.Items {
+		if nodeset.Spec.PowerSave.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// buildCgroupConf() returns a cgroup.conf, which includes the cgroup.conf of
+// the NodeSet on its nodes. Defaults apply unless the NodeSet sets them.
+//
 // https://slurm.schedmd.com/cgroup.conf.html
-func buildCgroupConf() string {
+func buildCgroupConf(nodesetFiles []nodesetConfigFile) string {
 	conf := config.NewBuilder()
 
 	conf.AddProperty(config.NewProperty("CgroupPlugin", "cgroup/v2"))
 	conf.AddProperty(config.NewProperty("IgnoreSystemd", "yes"))
 
+	if len(nodesetFiles) > 0 {
+		conf.AddProperty(config.NewPropertyRaw(nodesetConfigInclude(CgroupConfFile)))
+	}
+
 	return conf.Build()
 }
 
+// nodesetConfigInclude returns the `Include` of the node-scoped config file
+// of the NodeSet. Each NodeSet pod mounts the file of its NodeSet, which is
+// empty if the NodeSet does not set it, as Slurm fails on a missing include.
+//
+// https://slurm.schedmd.com/slurm.conf.html#OPT_Include
+func nodesetConfigInclude(file string) string {
+	return fmt.Sprintf("Include %s", path.Join(common.NodeSetConfigDir, file))
+}
+
+// nodesetConfigFile is a node-scoped config file of a NodeSet.
+type nodesetConfigFile struct {
+	NodeSet string
+}
+
+// getNodeSetConfigFiles returns the node-scoped config files of the NodeSets
+// by filename, ordered by NodeSet name.
+func (b *ControllerBuilder) getNodeSetConfigFiles(ctx context.Context, nodesetList *slinkyv1beta1.NodeSetList) (map[string][]nodesetConfigFile, error) {
+	nodesets := slices.Clone(nodesetList.Items)
+	slices.SortFunc(nodesets, func(a, b slinkyv1beta1.NodeSet) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	out := make(map[string][]nodesetConfigFile)
+	for _, nodeset := range nodesets {
+		for _, ref := range nodeset.Spec.ConfigFileRefs {
+			cm := &corev1.ConfigMap{}
+			key := types.NamespacedName{
+				Namespace: nodeset.Namespace,
+				Name:      ref.Name,
+			}
+			if err := b.client.Get(ctx, key, cm); err != nil {
+				return nil, err
+			}
+			filenames := structutils.Keys(cm.Data)
+			sort.Strings(filenames)
+			for _, file := range filenames {
+				if !IsNodeSetConfigFile(file) {
+					continue
+				}
+				out[file] = append(out[file], nodesetConfigFile{
+					NodeSet: nodeset.Name,
+				})
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// IsNodeSetConfigFile returns true if the file is a node-scoped config file
+// that a NodeSet may provide.
+func IsNodeSetConfigFile(file string) bool {
+	return slices.Contains(NodeSetPropertyConfigFiles, file) || slices.Contains(NodeSetLineConfigFiles, file)
+}
+
+// topologyYamlEntry is a topology of `topology.yaml`.
+type topologyYamlEntry struct {
+	Topology       string             `json:"topology"`
+	ClusterDefault bool               `json:"cluster_default"`
+	Tree           *topologyYamlTree  `json:"tree,omitempty"`
+	Block          *topologyYamlBlock `json:"block,omitempty"`
+	Flat           bool               `json:"flat,omitempty"`
+}
+
+type topologyYamlTree struct {
+	Switches []topologyYamlSwitch `json:"switches"`
+}
+
+type topologyYamlSwitch struct {
+	Switch   string `json:"switch"`
+	Children string `json:"children,omitempty"`
+	Nodes    string `json:"nodes,omitempty"`
+}
+
+type topologyYamlBlock struct {
+	BlockSizes []int32            `json:"block_sizes,omitempty"`
+	Blocks     []topologyYamlItem `json:"blocks"`
+}
+
+type topologyYamlItem struct {
+	Block string `json:"block"`
+	Nodes string `json:"nodes,omitempty"`
+}
+
+// buildTopologyYaml() returns the `topology.yaml` for the topologies.
+//
+// https://slurm.schedmd.com/topology.yaml.html
+func buildTopologyYaml(topologies []slinkyv1beta1.Topology) (string, error) {
+	entries := make([]topologyYamlEntry, 0, len(topologies))
+	for _, topology := range topologies {
+		entry := topologyYamlEntry{
+			Topology:       topology.Name,
+			ClusterDefault: topology.ClusterDefault,
+			Flat:           topology.Flat,
+		}
+		if tree := topology.Tree; tree != nil {
+			entry.Tree = &topologyYamlTree{
+				Switches: make([]topologyYamlSwitch, 0, len(tree.Switches)),
+			}
+			for _, sw := range tree.Switches {
+				entry.Tree.Switches = append(entry.Tree.Switches, topologyYamlSwitch{
+					Switch:   sw.Name,
+					Children: sw.Children,
+					Nodes:    sw.Nodes,
+				})
+			}
+		}
+		if block := topology.Block; block != nil {
+			entry.Block = &topologyYamlBlock{
+				BlockSizes: block.BlockSizes,
+				Blocks:     make([]topologyYamlItem, 0, len(block.Blocks)),
+			}
+			for _, b := range block.Blocks {
+				entry.Block.Blocks = append(entry.Block.Blocks, topologyYamlItem{
+					Block: b.Name,
+					Nodes: b.Nodes,
+				})
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	out, err := yaml.Marshal(entries)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s: %w", TopologyYamlConfFile, err)
+	}
+	return "---\n" + string(out), nil
+}
+
 func isCgroupEnabled(cgroupConf string) bool {
 	r := regexp.MustCompile(`(?im)^CgroupPlugin=disabled`)
 	found := r.FindStringSubmatch(cgroupConf)
@@ -7,11 +7,14 @@ import (
 	"math/rand/v2"
 	"strings"
 	"testing"
+	"time"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -24,11 +27,13 @@ func TestBuilder_BuildControllerConfig(t *testing.T) {
 		controller *slinkyv1beta1.Controller
 	}
 	tests := []struct {
-		name        string
-		fields      fields
-		args        args
-		wantErr     bool
-		wantScripts []string
+		name         string
+		fields       fields
+		args         args
+		wantErr      bool
+		wantScripts  []string
+		wantTopology bool
+		wantFiles    map[string]string
 	}{
 		{
 			name: "default",
@@ -221,6 +226,134 @@ func TestBuilder_BuildControllerConfig(t *testing.T) {
 			},
 			wantScripts: []string{"00-cleanup.sh", "90-finalize.sh"},
 		},
+		{
+			name: "with topology",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{Name: "slurm"},
+					Spec: slinkyv1beta1.ControllerSpec{
+						Topology: slinkyv1beta1.ControllerTopology{
+							Topologies: []slinkyv1beta1.Topology{
+								{Name: "topo-flat", ClusterDefault: true, Flat: true},
+							},
+							Params: []string{"SwitchAsNodeRank"},
+						},
+					},
+				},
+			},
+			wantTopology: true,
+		},
+		{
+			name: "with nodeset scripts",
+			fields: fields{
+				client: fake.NewClientBuilder().
+					WithObjects(&slinkyv1beta1.NodeSet{
+						ObjectMeta: metav1.ObjectMeta{Name: "slurm-gpu"},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							ControllerRef: corev1.LocalObjectReference{Name: "slurm"},
+							PrologScriptRefs: []corev1.LocalObjectReference{
+								{Name: "gpu-prolog"},
+							},
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{Name: "slurm"},
+				},
+			},
+			wantScripts: []string{"Prolog=" + NodeSetScriptsFile},
+			wantFiles: map[string]string{
+				NodeSetScriptsFile: nodesetScripts,
+			},
+		},
+		{
+			name: "with nodeset config files",
+			fields: fields{
+				client: fake.NewClientBuilder().
+					WithObjects(&slinkyv1beta1.NodeSet{
+						ObjectMeta: metav1.ObjectMeta{Name: "slurm-gpu"},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							ControllerRef: corev1.LocalObjectReference{Name: "slurm"},
+							ConfigFileRefs: []corev1.LocalObjectReference{
+								{Name: "slurm-gpu-config"},
+							},
+						},
+					}).
+					WithObjects(&slinkyv1beta1.NodeSet{
+						ObjectMeta: metav1.ObjectMeta{Name: "slurm-cpu"},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							ControllerRef: corev1.LocalObjectReference{Name: "slurm"},
+							ConfigFileRefs: []corev1.LocalObjectReference{
+								{Name: "slurm-cpu-config"},
+							},
+						},
+					}).
+					WithObjects(&corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{Name: "slurm-gpu-config"},
+						Data: map[string]string{
+							CgroupConfFile: "ConstrainDevices=yes\nConstrainRAMSpace=yes",
+							GresConfFile:   "NodeName=gpu-[0-3] AutoDetect=nvml",
+							OciConfFile:    "RunTimeQuery=runc state %n.%u.%j.%s.%t",
+							"foo.conf":     "Foo=bar",
+						},
+					}).
+					WithObjects(&corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{Name: "slurm-cpu-config"},
+						Data: map[string]string{
+							CgroupConfFile:  "ConstrainRAMSpace=yes\nAllowedRAMSpace=95",
+							HelpersConfFile: "Feature=avx512 Helper=/usr/local/bin/avx512",
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{Name: "slurm"},
+				},
+			},
+			wantFiles: map[string]string{
+				CgroupConfFile: strings.Join([]string{
+					"CgroupPlugin=cgroup/v2",
+					"IgnoreSystemd=yes",
+					"Include /etc/slurm-nodeset/conf/cgroup.conf",
+					"",
+				}, "\n"),
+				GresConfFile:      "Include /etc/slurm-nodeset/conf/gres.conf\n",
+				HelpersConfFile:   "Include /etc/slurm-nodeset/conf/helpers.conf\n",
+				OciConfFile:       "Include /etc/slurm-nodeset/conf/oci.conf\n",
+				NodeSetConfigFile: "",
+			},
+		},
+		{
+			name: "with nodeset gres",
+			fields: fields{
+				client: fake.NewClientBuilder().
+					WithObjects(&slinkyv1beta1.NodeSet{
+						ObjectMeta: metav1.ObjectMeta{Name: "slurm-gpu"},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							ControllerRef: corev1.LocalObjectReference{Name: "slurm"},
+							Gres: []slinkyv1beta1.NodeSetGres{
+								{ResourceName: "nvidia.com/gpu", Name: "gpu", AutoDetect: "nvml"},
+							},
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{Name: "slurm"},
+				},
+			},
+			wantFiles: map[string]string{
+				GresConfFile:      "Include /etc/slurm-nodeset/conf/gres.conf\n",
+				NodeSetConfigFile: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -239,6 +372,20 @@ func TestBuilder_BuildControllerConfig(t *testing.T) {
 			for _, script := range tt.wantScripts {
 				require.Contains(t, got.Data[SlurmConfFile], script)
 			}
+
+			_, gotTopology := got.Data[TopologyYamlConfFile]
+			require.Equal(t, tt.wantTopology, gotTopology)
+			if tt.wantTopology {
+				// Slurm takes the topology plugins from topology.yaml.
+				require.NotContains(t, got.Data[SlurmConfFile], "TopologyPlugin=")
+				require.Contains(t, got.Data[SlurmConfFile], "TopologyParam=SwitchAsNodeRank")
+			}
+
+			for file, want := range tt.wantFiles {
+				require.Contains(t, got.Data, file)
+				require.Equal(t, want, got.Data[file], file)
+			}
+			require.NotContains(t, got.Data, "foo.conf")
 		})
 	}
 }
@@ -425,6 +572,42 @@ func TestBuilder_BuildControllerConfigExternal(t *testing.T) {
 	}
 }
 
+func Test_slurmctldHosts(t *testing.T) {
+	tests := []struct {
+		name     string
+		replicas *int32
+		want     []string
+	}{
+		{
+			name: "default",
+			want: []string{"slurm-controller-0(slurm-controller.default)"},
+		},
+		{
+			name:     "backups",
+			replicas: ptr.To[int32](3),
+			want: []string{
+				"slurm-controller-0(slurm-controller-0.default)",
+				"slurm-controller-1(slurm-controller-1.default)",
+				"slurm-controller-2(slurm-controller-2.default)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: corev1.NamespaceDefault,
+					Name:      "slurm",
+				},
+				Spec: slinkyv1beta1.ControllerSpec{
+					Replicas: tt.replicas,
+				},
+			}
+			require.Equal(t, tt.want, slurmctldHosts(controller))
+		})
+	}
+}
+
 func Test_buildNodeSetConf(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -486,6 +669,104 @@ PartitionName=nodeset-1 Nodes=nodeset-1 MaxTime=UNLIMITED OverSubscribe=EXCLUSIV
 NodeSet=nodeset-2 Feature=nodeset-2
 PartitionName=nodeset-2 Nodes=nodeset-2 MaxTime=UNLIMITED PreemptMode=REQUEUE`,
 		},
+		{
+			name: "power save",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "nodeset-0",
+						},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							Replicas:  ptr.To[int32](4),
+							ExtraConf: "cpus=8 realmemory=16000",
+							Partition: slinkyv1beta1.NodeSetPartition{
+								Enabled: true,
+								Config:  "MaxTime=UNLIMITED",
+							},
+							PowerSave: slinkyv1beta1.NodeSetPowerSave{
+								Enabled:       true,
+								SuspendTime:   &metav1.Duration{Duration: 10 * time.Minute},
+								ResumeTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "nodeset-1",
+						},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							Replicas: ptr.To[int32](0),
+							Partition: slinkyv1beta1.NodeSetPartition{
+								Enabled: true,
+							},
+							PowerSave: slinkyv1beta1.NodeSetPowerSave{
+								Enabled: true,
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "nodeset-2",
+						},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							Replicas: ptr.To[int32](2),
+							Slurmd: slinkyv1beta1.ContainerWrapper{
+								Container: corev1.Container{
+									Resources: corev1.ResourceRequirements{
+										Limits: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("4"),
+											corev1.ResourceMemory: resource.MustParse("8Gi"),
+										},
+									},
+								},
+							},
+							PowerSave: slinkyv1beta1.NodeSetPowerSave{
+								Enabled: true,
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "nodeset-3",
+						},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							Replicas:  ptr.To[int32](1),
+							ExtraConf: "realmemory=4000",
+							Slurmd: slinkyv1beta1.ContainerWrapper{
+								Container: corev1.Container{
+									Resources: corev1.ResourceRequirements{
+										Limits: corev1.ResourceList{
+											corev1.ResourceCPU:    resource.MustParse("8"),
+											corev1.ResourceMemory: resource.MustParse("8Gi"),
+										},
+									},
+								},
+							},
+							ResourceSpec: slinkyv1beta1.NodeSetResourceSpec{
+								ReservedCpus: 2,
+							},
+							PowerSave: slinkyv1beta1.NodeSetPowerSave{
+								Enabled: true,
+							},
+						},
+					},
+				},
+			},
+			want: `NodeName=nodeset-0-[0-3] State=CLOUD CPUs=8 Features=nodeset-0 RealMemory=16000
+NodeSet=nodeset-0 Feature=nodeset-0
+PartitionName=nodeset-0 Nodes=nodeset-0 SuspendTime=600 ResumeTimeout=300 MaxTime=UNLIMITED
+NodeSet=nodeset-1 Feature=nodeset-1
+PartitionName=nodeset-1 Nodes=nodeset-1 SuspendTime=300 ResumeTimeout=600 
+NodeName=nodeset-2-[0-1] State=CLOUD Features=nodeset-2 Sockets=1 CoresPerSocket=4 ThreadsPerCore=1 RealMemory=8192
+NodeSet=nodeset-2 Feature=nodeset-2
+NodeName=nodeset-3-0 State=CLOUD Features=nodeset-3 RealMemory=4000 Sockets=1 CoresPerSocket=8 ThreadsPerCore=1 CpuSpecList=0-1
+NodeSet=nodeset-3 Feature=nodeset-3`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -504,6 +785,186 @@ PartitionName=nodeset-2 Nodes=nodeset-2 MaxTime=UNLIMITED PreemptMode=REQUEUE`,
 	}
 }
 
+func Test_buildPartitionConf(t *testing.T) {
+	newNodeSet := func(name, hostname string, labels map[string]string) slinkyv1beta1.NodeSet {
+		return slinkyv1beta1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Labels:    labels,
+			},
+			Spec: slinkyv1beta1.NodeSetSpec{
+				ControllerRef: corev1.LocalObjectReference{
+					Name: "slurm",
+				},
+				Template: slinkyv1beta1.PodTemplate{
+					PodSpecWrapper: slinkyv1beta1.PodSpecWrapper{
+						PodSpec: corev1.PodSpec{
+							Hostname: hostname,
+						},
+					},
+				},
+			},
+		}
+	}
+	nodesetList := &slinkyv1beta1.NodeSetList{
+		Items: []slinkyv1beta1.NodeSet{
+			newNodeSet("slurm-cpu", "cpu-", nil),
+			newNodeSet("slurm-gpu", "gpu-", map[string]string{"tier": "gpu"}),
+			newNodeSet("slurm-gpu-big", "", map[string]string{"tier": "gpu"}),
+		},
+	}
+	tests := []struct {
+		name          string
+		partitionList *slinkyv1beta1.PartitionList
+		want          string
+	}{
+		{
+			name: "empty",
+			partitionList: &slinkyv1beta1.PartitionList{
+				Items: []slinkyv1beta1.Partition{},
+			},
+			want: "",
+		},
+		{
+			name: "non-empty",
+			partitionList: &slinkyv1beta1.PartitionList{
+				Items: []slinkyv1beta1.Partition{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "gpu",
+						},
+						Spec: slinkyv1beta1.PartitionSpec{
+							ControllerRef: corev1.LocalObjectReference{
+								Name: "slurm",
+							},
+							NodeSetSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"tier": "gpu"},
+							},
+							MaxTime:       "1-00:00:00",
+							PriorityTier:  ptr.To[int32](10),
+							OverSubscribe: "EXCLUSIVE",
+							AllowAccounts: []string{"physics", "chemistry"},
+							State:         slinkyv1beta1.PartitionStateDrain,
+							ExtraConf:     "PreemptMode=REQUEUE",
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "all",
+						},
+						Spec: slinkyv1beta1.PartitionSpec{
+							ControllerRef: corev1.LocalObjectReference{
+								Name: "slurm",
+							},
+							NodeSets: []string{"slurm-cpu", "slurm-gpu"},
+							Default:  true,
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "empty",
+						},
+						Spec: slinkyv1beta1.PartitionSpec{
+							ControllerRef: corev1.LocalObjectReference{
+								Name: "slurm",
+							},
+							State: slinkyv1beta1.PartitionStateInactive,
+						},
+					},
+				},
+			},
+			want: `PartitionName=all Nodes=cpu,gpu Default=YES
+PartitionName=empty State=INACTIVE
+PartitionName=gpu Nodes=gpu,slurm-gpu-big MaxTime=1-00:00:00 PriorityTier=10 OverSubscribe=EXCLUSIVE AllowAccounts=physics,chemistry State=DRAIN PreemptMode=REQUEUE`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, buildPartitionConf(tt.partitionList, nodesetList))
+		})
+	}
+}
+
+func Test_buildSchedulingConf(t *testing.T) {
+	tests := []struct {
+		name        string
+		scheduling  slinkyv1beta1.ControllerScheduling
+		mergeConfig map[string][]string
+		want        string
+	}{
+		{
+			name:        "empty",
+			scheduling:  slinkyv1beta1.ControllerScheduling{},
+			mergeConfig: map[string][]string{},
+			want:        "",
+		},
+		{
+			name: "full",
+			scheduling: slinkyv1beta1.ControllerScheduling{
+				SchedulerType: "sched/backfill",
+				SelectType:    "select/cons_tres",
+				Priority: slinkyv1beta1.SchedulingPriority{
+					Type:          "priority/multifactor",
+					DecayHalfLife: "7-0",
+					MaxAge:        "14-0",
+					Weights: slinkyv1beta1.PriorityWeights{
+						Age:       ptr.To[int32](1000),
+						Fairshare: ptr.To[int32](10000),
+						QOS:       ptr.To[int32](0),
+						TRES: map[string]int32{
+							"GRES/gpu": 3000,
+							"CPU":      1000,
+						},
+					},
+				},
+				Preempt: slinkyv1beta1.SchedulingPreempt{
+					Type:       "preempt/qos",
+					ExemptTime: "00:10:00",
+				},
+				Limits: slinkyv1beta1.SchedulingLimits{
+					MaxJobCount:  ptr.To[int32](50000),
+					MaxArraySize: ptr.To[int32](10001),
+					DefMemPerCPU: ptr.To[int64](2048),
+				},
+			},
+			mergeConfig: map[string][]string{
+				"SchedulerParameters":  {"bf_continue", "bf_max_job_test=1000"},
+				"SelectTypeParameters": {"CR_Core_Memory"},
+				"PriorityFlags":        {"FAIR_TREE"},
+				"PreemptMode":          {"REQUEUE"},
+				"GresTypes":            {"gpu"},
+			},
+			want: `SchedulerType=sched/backfill
+SchedulerParameters=bf_continue,bf_max_job_test=1000
+SelectType=select/cons_tres
+SelectTypeParameters=CR_Core_Memory
+PriorityType=priority/multifactor
+PriorityFlags=FAIR_TREE
+PriorityDecayHalfLife=7-0
+PriorityMaxAge=14-0
+PriorityWeightAge=1000
+PriorityWeightFairshare=10000
+PriorityWeightQOS=0
+PriorityWeightTRES=CPU=1000,GRES/gpu=3000
+PreemptType=preempt/qos
+PreemptMode=REQUEUE
+PreemptExemptTime=00:10:00
+MaxJobCount=50000
+MaxArraySize=10001
+DefMemPerCPU=2048`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, buildSchedulingConf(tt.scheduling, tt.mergeConfig))
+		})
+	}
+}
+
 func Test_buildPrologEpilogConf(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -629,3 +1090,163 @@ EpilogSlurmctld=/etc/slurm/epilog-slurmctld-2.sh`,
 		})
 	}
 }
+
+func Test_buildTopologyYaml(t *testing.T) {
+	tests := []struct {
+		name       string
+		topologies []slinkyv1beta1.Topology
+		want       string
+	}{
+		{
+			name: "tree, block, flat",
+			topologies: []slinkyv1beta1.Topology{
+				{
+					Name:           "topo-switch",
+					ClusterDefault: true,
+					Tree: &slinkyv1beta1.TopologyTree{
+						Switches: []slinkyv1beta1.TopologySwitch{
+							{Name: "sw_root", Children: "s[1-2]"},
+							{Name: "s1", Nodes: "node[1-2]"},
+							{Name: "s2", Nodes: "node[3-4]"},
+						},
+					},
+				},
+				{
+					Name: "topo-block",
+					Block: &slinkyv1beta1.TopologyBlock{
+						BlockSizes: []int32{2, 4},
+						Blocks: []slinkyv1beta1.TopologyBlockEntry{
+							{Name: "b1", Nodes: "node[1-2]"},
+							{Name: "b2", Nodes: "node[3-4]"},
+						},
+					},
+				},
+				{
+					Name: "topo-flat",
+					Flat: true,
+				},
+			},
+			want: `---
+- cluster_default: true
+  topology: topo-switch
+  tree:
+    switches:
+    - children: s[1-2]
+      switch: sw_root
+    - nodes: node[1-2]
+      switch: s1
+    - nodes: node[3-4]
+      switch: s2
+- block:
+    block_sizes:
+    - 2
+    - 4
+    blocks:
+    - block: b1
+      nodes: node[1-2]
+    - block: b2
+      nodes: node[3-4]
+  cluster_default: false
+  topology: topo-block
+- cluster_default: false
+  flat: true
+  topology: topo-flat
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildTopologyYaml(tt.topologies)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_hasGresConf(t *testing.T) {
+	newNodeSet := func(name string, gres ...slinkyv1beta1.NodeSetGres) slinkyv1beta1.NodeSet {
+		return slinkyv1beta1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: slinkyv1beta1.NodeSetSpec{
+				Replicas: ptr.To[int32](2),
+				Gres:     gres,
+			},
+		}
+	}
+	tests := []struct {
+		name          string
+		nodesetList   *slinkyv1beta1.NodeSetList
+		want          bool
+		wantGresTypes []string
+	}{
+		{
+			name:          "empty",
+			nodesetList:   &slinkyv1beta1.NodeSetList{},
+			want:          false,
+			wantGresTypes: []string{},
+		},
+		{
+			name: "autodetect and files",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					newNodeSet("h100",
+						slinkyv1beta1.NodeSetGres{ResourceName: "nvidia.com/gpu", Name: "gpu", Type: "h100", AutoDetect: "nvml"},
+						slinkyv1beta1.NodeSetGres{ResourceName: "example.com/nic", Name: "nic", File: "/dev/infiniband/uverbs[0-1]"},
+					),
+					newNodeSet("cpu"),
+				},
+			},
+			want:          true,
+			wantGresTypes: []string{"gpu", "nic"},
+		},
+		{
+			name: "count only",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					newNodeSet("mps",
+						slinkyv1beta1.NodeSetGres{ResourceName: "example.com/mps", Name: "mps"},
+					),
+				},
+			},
+			want:          false,
+			wantGresTypes: []string{"mps"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, hasGresConf(tt.nodesetList))
+			require.Equal(t, tt.wantGresTypes, getGresTypes(tt.nodesetList))
+		})
+	}
+}
+
+func Test_buildCgroupConf(t *testing.T) {
+	tests := []struct {
+		name         string
+		nodesetFiles []nodesetConfigFile
+		want         string
+	}{
+		{
+			name: "default",
+			want: "CgroupPlugin=cgroup/v2\nIgnoreSystemd=yes\n",
+		},
+		{
+			name: "included",
+			nodesetFiles: []nodesetConfigFile{
+				{NodeSet: "cpu"},
+				{NodeSet: "gpu"},
+			},
+			want: strings.Join([]string{
+				"CgroupPlugin=cgroup/v2",
+				"IgnoreSystemd=yes",
+				"Include /etc/slurm-nodeset/conf/cgroup.conf",
+				"",
+			}, "\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, buildCgroupConf(tt.nodesetFiles))
+		})
+	}
+}
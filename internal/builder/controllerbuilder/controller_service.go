@@ -4,6 +4,9 @@
 package controllerbuilder
 
 import (
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -27,6 +30,11 @@ func (b *ControllerBuilder) BuildControllerService(controller *slinkyv1beta1.Con
 			Build(),
 	}
 
+	// With backups, only the slurmctld in control may receive traffic.
+	if names := controller.SlurmctldNames(); len(names) > 1 {
+		opts.Selector[appsv1.StatefulSetPodNameLabel] = activeSlurmctld(controller)
+	}
+
 	opts.Metadata.Labels = structutils.MergeMaps(opts.Metadata.Labels, labels.NewBuilder().WithControllerLabels(controller).Build())
 
 	port := corev1.ServicePort{
@@ -40,3 +48,51 @@ func (b *ControllerBuilder) BuildControllerService(controller *slinkyv1beta1.Con
 
 	return b.CommonBuilder.BuildService(opts, controller)
 }
+
+// BuildControllerSlurmctldServices creates a Service for each slurmctld
+// instance when there are backups, which serve as their `SlurmctldHost`
+// address. A single slurmctld is addressed through the controller Service.
+func (b *ControllerBuilder) BuildControllerSlurmctldServices(controller *slinkyv1beta1.Controller) ([]*corev1.Service, error) {
+	names := controller.SlurmctldNames()
+	if len(names) <= 1 {
+		return nil, nil
+	}
+
+	out := make([]*corev1.Service, 0, len(names))
+	for _, name := range names {
+		opts := common.ServiceOpts{
+			Key: controller.SlurmctldServiceKey(name),
+			Metadata: slinkyv1beta1.Metadata{
+				Annotations: controller.Annotations,
+				Labels:      structutils.MergeMaps(controller.Labels, labels.NewBuilder().WithControllerLabels(controller).Build()),
+			},
+			Selector: structutils.MergeMaps(
+				labels.NewBuilder().WithControllerSelectorLabels(controller).Build(),
+				map[string]string{appsv1.StatefulSetPodNameLabel: name},
+			),
+		}
+		opts.Ports = append(opts.Ports, corev1.ServicePort{
+			Name:       labels.ControllerApp,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       common.SlurmctldPort,
+			TargetPort: intstr.FromString(labels.ControllerApp),
+		})
+		service, err := b.CommonBuilder.BuildService(opts, controller)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, service)
+	}
+
+	return out, nil
+}
+
+// activeSlurmctld returns the host name of the slurmctld in control, as last
+// observed, falling back to the primary.
+func activeSlurmctld(controller *slinkyv1beta1.Controller) string {
+	names := controller.SlurmctldNames()
+	if active := controller.Status.ActiveController; slices.Contains(names, active) {
+		return active
+	}
+	return names[0]
+}
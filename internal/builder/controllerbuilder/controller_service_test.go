@@ -8,9 +8,11 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"k8s.io/utils/set"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -148,3 +150,90 @@ func TestBuilder_BuildControllerService(t *testing.T) {
 		})
 	}
 }
+
+func TestBuilder_BuildControllerService_backups(t *testing.T) {
+	newController := func(active string) *slinkyv1beta1.Controller {
+		return &slinkyv1beta1.Controller{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "slurm",
+			},
+			Spec: slinkyv1beta1.ControllerSpec{
+				Replicas:  ptr.To[int32](2),
+				JwtKeyRef: &corev1.SecretKeySelector{},
+			},
+			Status: slinkyv1beta1.ControllerStatus{
+				ActiveController: active,
+			},
+		}
+	}
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		wantActive string
+	}{
+		{
+			name:       "unobserved",
+			controller: newController(""),
+			wantActive: "slurm-controller-0",
+		},
+		{
+			name:       "backup in control",
+			controller: newController("slurm-controller-1"),
+			wantActive: "slurm-controller-1",
+		},
+		{
+			name:       "unknown host",
+			controller: newController("foo"),
+			wantActive: "slurm-controller-0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(fake.NewFakeClient())
+			got, err := b.BuildControllerService(tt.controller)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantActive, got.Spec.Selector[appsv1.StatefulSetPodNameLabel])
+		})
+	}
+}
+
+func TestBuilder_BuildControllerSlurmctldServices(t *testing.T) {
+	tests := []struct {
+		name      string
+		replicas  int32
+		wantNames []string
+	}{
+		{
+			name:     "single",
+			replicas: 1,
+		},
+		{
+			name:      "backups",
+			replicas:  2,
+			wantNames: []string{"slurm-controller-0", "slurm-controller-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "slurm",
+				},
+				Spec: slinkyv1beta1.ControllerSpec{
+					Replicas:  ptr.To(tt.replicas),
+					JwtKeyRef: &corev1.SecretKeySelector{},
+				},
+			}
+			b := New(fake.NewFakeClient())
+			got, err := b.BuildControllerSlurmctldServices(controller)
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.wantNames))
+			for i, service := range got {
+				require.Equal(t, controller.SlurmctldServiceKey(tt.wantNames[i]).Name, service.Name)
+				require.Equal(t, tt.wantNames[i], service.Spec.Selector[appsv1.StatefulSetPodNameLabel])
+				require.Equal(t, int32(6817), service.Spec.Ports[0].Port)
+				require.True(t, metav1.IsControlledBy(service, controller))
+			}
+		})
+	}
+}
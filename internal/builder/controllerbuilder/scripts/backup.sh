@@ -0,0 +1,44 @@
+#!/usr/bin/env bash
+# SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+# SPDX-License-Identifier: Apache-2.0
+
+set -euo pipefail
+
+: "${STATESAVE_DIR:?}"
+: "${BACKUP_DIR:?}"
+: "${BACKUP_NAME:?}"
+: "${BACKUP_RETENTION:?}"
+
+function archive() {
+	local archive="$BACKUP_DIR/$BACKUP_NAME.tar.gz"
+	local rc=0
+
+	# slurmctld replaces a state file by writing "<file>.new", then renaming the
+	# current one to "<file>.old" and "<file>.new" to "<file>". Skipping the
+	# files being written yields a state slurmctld can recover from.
+	echo "[$(date)] Archiving '$STATESAVE_DIR' into '$archive'"
+	mkdir -p "$BACKUP_DIR"
+	tar --create --gzip --file="$archive.tmp" --exclude='*.new' --exclude='./lost+found' \
+		--warning=no-file-changed --warning=no-file-removed \
+		--directory="$STATESAVE_DIR" . || rc="$?"
+	# Exit code 1 means files changed while being read, which were skipped.
+	if [ "$rc" -gt 1 ]; then
+		rm -f "$archive.tmp"
+		return "$rc"
+	fi
+	mv "$archive.tmp" "$archive"
+}
+
+function prune() {
+	# Archive names sort by the time they were scheduled at.
+	echo "[$(date)] Keeping the last $BACKUP_RETENTION archives"
+	find "$BACKUP_DIR" -maxdepth 1 -type f -name '*.tar.gz' | sort -r |
+		tail -n "+$((BACKUP_RETENTION + 1))" | xargs -r rm -f --
+}
+
+function main() {
+	archive
+	prune
+	echo "[$(date)] SUCCESS"
+}
+main
@@ -0,0 +1,30 @@
+#!/bin/sh
+# SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+# SPDX-License-Identifier: Apache-2.0
+
+# Runs the prolog or epilog scripts of the NodeSet of this Slurm node. They are
+# only mounted into the pods of that NodeSet, other Slurm nodes run nothing.
+
+set -eu
+
+case "${SLURM_SCRIPT_CONTEXT:-}" in
+prolog_slurmd)
+	SCRIPT_DIR="/etc/slurm-nodeset/prolog.d"
+	;;
+epilog_slurmd)
+	SCRIPT_DIR="/etc/slurm-nodeset/epilog.d"
+	;;
+*)
+	exit 0
+	;;
+esac
+
+if [ ! -d "$SCRIPT_DIR" ]; then
+	exit 0
+fi
+
+for script in "$SCRIPT_DIR"/*; do
+	if [ -f "$script" ]; then
+		"$script"
+	fi
+done
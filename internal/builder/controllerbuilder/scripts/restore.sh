@@ -0,0 +1,34 @@
+#!/usr/bin/env bash
+# SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+# SPDX-License-Identifier: Apache-2.0
+
+set -euo pipefail
+
+: "${STATESAVE_DIR:?}"
+: "${RESTORE_PATH:?}"
+
+function isEmpty() {
+	[ -z "$(find "$STATESAVE_DIR" -mindepth 1 -maxdepth 1 ! -name 'lost+found' -print -quit)" ]
+}
+
+function main() {
+	local archive="$RESTORE_PATH"
+
+	# Never overwrite an existing save-state.
+	if ! isEmpty; then
+		echo "[$(date)] '$STATESAVE_DIR' is not empty, skipping restore"
+		return
+	fi
+	if [ -d "$archive" ]; then
+		archive="$(find "$archive" -maxdepth 1 -type f -name '*.tar.gz' | sort | tail -n 1)"
+	fi
+	if [ ! -f "$archive" ]; then
+		echo "[$(date)] No archive found at '$RESTORE_PATH'"
+		return 1
+	fi
+
+	echo "[$(date)] Restoring '$archive' into '$STATESAVE_DIR'"
+	tar --extract --gzip --file="$archive" --directory="$STATESAVE_DIR"
+	echo "[$(date)] SUCCESS"
+}
+main
@@ -71,6 +71,9 @@ const (
 	ControllerApp  = "slurmctld"
 	ControllerComp = "controller"
 
+	ControllerBackupApp  = "slurmctld-backup"
+	ControllerBackupComp = "backup"
+
 	RestapiApp  = "slurmrestd"
 	RestapiComp = "restapi"
 
@@ -96,6 +99,18 @@ func (b *Builder) WithControllerLabels(obj *slinkyv1beta1.Controller) *Builder {
 		WithComponent(ControllerComp)
 }
 
+func (b *Builder) WithControllerBackupSelectorLabels(obj *slinkyv1beta1.Controller) *Builder {
+	return b.
+		WithApp(ControllerBackupApp).
+		WithInstance(obj.Name)
+}
+
+func (b *Builder) WithControllerBackupLabels(obj *slinkyv1beta1.Controller) *Builder {
+	return b.
+		WithControllerBackupSelectorLabels(obj).
+		WithComponent(ControllerBackupComp)
+}
+
 func (b *Builder) WithRestapiSelectorLabels(obj *slinkyv1beta1.RestApi) *Builder {
 	return b.
 		WithApp(RestapiApp).
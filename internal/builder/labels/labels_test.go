@@ -145,6 +145,41 @@ func TestNewBuilder(t *testing.T) {
 				componentLabel: ControllerComp,
 			},
 		},
+		{
+			name: "WithControllerBackupSelectorLabels",
+			args: args{
+				builder: NewBuilder().
+					WithControllerBackupSelectorLabels(
+						&slinkyv1beta1.Controller{
+							ObjectMeta: v1.ObjectMeta{
+								Name: "test",
+							},
+						},
+					),
+			},
+			want: map[string]string{
+				instanceLabel: "test",
+				AppLabel:      ControllerBackupApp,
+			},
+		},
+		{
+			name: "WithControllerBackupLabels",
+			args: args{
+				builder: NewBuilder().
+					WithControllerBackupLabels(
+						&slinkyv1beta1.Controller{
+							ObjectMeta: v1.ObjectMeta{
+								Name: "test",
+							},
+						},
+					),
+			},
+			want: map[string]string{
+				instanceLabel:  "test",
+				AppLabel:       ControllerBackupApp,
+				componentLabel: ControllerBackupComp,
+			},
+		},
 		{
 			name: "WithRestapiSelectorLabels",
 			args: args{
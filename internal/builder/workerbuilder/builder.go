@@ -11,6 +11,9 @@ import (
 
 const (
 	annotationDefaultContainer = "kubectl.kubernetes.io/default-container"
+
+	// resourceSpecEnv holds the Slurm node resources derived at pod binding.
+	resourceSpecEnv = "POD_RESOURCE_SPEC"
 )
 
 type WorkerBuilder struct {
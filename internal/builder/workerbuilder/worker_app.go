@@ -7,12 +7,9 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
-	"sort"
 	"strconv"
 	"strings"
 
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -107,6 +104,16 @@ func nodesetVolumes(nodeset *slinkyv1beta1.NodeSet, controller *slinkyv1beta1.Co
 			},
 		},
 		common.LogFileVolume(),
+		{
+			Name: common.NodeSetConfigVolume,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: nodeset.NodeConfigKey().Name,
+					},
+				},
+			},
+		},
 	}
 
 	// Add SSH host keys volume if SSH is enabled
@@ -155,9 +162,38 @@ func nodesetVolumes(nodeset *slinkyv1beta1.NodeSet, controller *slinkyv1beta1.Co
 		})
 	}
 
+	// Add NodeSet prolog and epilog script volumes
+	if len(nodeset.Spec.PrologScriptRefs) > 0 {
+		out = append(out, scriptsVolume(common.NodeSetPrologVolume, nodeset.Spec.PrologScriptRefs))
+	}
+	if len(nodeset.Spec.EpilogScriptRefs) > 0 {
+		out = append(out, scriptsVolume(common.NodeSetEpilogVolume, nodeset.Spec.EpilogScriptRefs))
+	}
+
 	return out
 }
 
+// scriptsVolume returns a volume of the executable scripts of the ConfigMaps.
+func scriptsVolume(name string, refs []corev1.LocalObjectReference) corev1.Volume {
+	sources := make([]corev1.VolumeProjection, 0, len(refs))
+	for _, ref := range refs {
+		sources = append(sources, corev1.VolumeProjection{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: ref,
+			},
+		})
+	}
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				DefaultMode: ptr.To[int32](0o755),
+				Sources:     sources,
+			},
+		},
+	}
+}
+
 func (b *WorkerBuilder) slurmdContainer(nodeset *slinkyv1beta1.NodeSet, controller *slinkyv1beta1.Controller) corev1.Container {
 	merge := nodeset.Spec.Slurmd.Container
 
@@ -183,6 +219,7 @@ func (b *WorkerBuilder) slurmdContainer(nodeset *slinkyv1beta1.NodeSet, controll
 	volumeMounts := []corev1.VolumeMount{
 		{Name: common.SlurmEtcVolume, MountPath: common.SlurmEtcDir, ReadOnly: true},
 		{Name: common.SlurmLogFileVolume, MountPath: common.SlurmLogFileDir},
+		{Name: common.NodeSetConfigVolume, MountPath: common.NodeSetConfigDir, ReadOnly: true},
 	}
 
 	// Add SSH host key mounts if enabled
@@ -193,31 +230,63 @@ func (b *WorkerBuilder) slurmdContainer(nodeset *slinkyv1beta1.NodeSet, controll
 		})
 	}
 
-	cpus, memory := b.getResourceLimits(&nodeset.Spec)
+	// Add NodeSet prolog and epilog script mounts
+	if len(nodeset.Spec.PrologScriptRefs) > 0 {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: common.NodeSetPrologVolume, MountPath: common.NodeSetPrologDir, ReadOnly: true})
+	}
+	if len(nodeset.Spec.EpilogScriptRefs) > 0 {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: common.NodeSetEpilogVolume, MountPath: common.NodeSetEpilogDir, ReadOnly: true})
+	}
 
-	opts := common.ContainerOpts{
-		Base: corev1.Container{
-			Name: labels.WorkerApp,
-			Args: slurmdArgs(nodeset, controller),
-			Env: []corev1.EnvVar{
-				{
-					Name: "POD_TOPOLOGY",
-					ValueFrom: &corev1.EnvVarSource{
-						FieldRef: &corev1.ObjectFieldSelector{
-							FieldPath: fmt.Sprintf("metadata.annotations['%s']", slinkyv1beta1.AnnotationNodeTopologySpec),
-						},
-					},
-				},
-				{
-					Name:  "POD_CPUS",
-					Value: strconv.FormatInt(cpus, 10),
+	// Give slurmd access to the devices of all resource claims
+	resourceClaims := make([]corev1.ResourceClaim, 0, len(nodeset.Spec.ResourceClaimTemplates))
+	for _, template := range nodeset.Spec.ResourceClaimTemplates {
+		resourceClaims = append(resourceClaims, corev1.ResourceClaim{Name: template.Name})
+	}
+
+	env := []corev1.EnvVar{
+		{
+			Name: "POD_TOPOLOGY",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: fmt.Sprintf("metadata.annotations['%s']", slinkyv1beta1.AnnotationNodeTopologySpec),
 				},
-				{
-					Name:  "POD_MEMORY",
-					Value: strconv.FormatInt(memory, 10),
+			},
+		},
+	}
+	if nodeset.Spec.ResourceSpec.Enabled {
+		// The resource spec replaces the CPUs and RealMemory of the limits.
+		env = append(env, corev1.EnvVar{
+			Name: resourceSpecEnv,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: fmt.Sprintf("metadata.annotations['%s']", slinkyv1beta1.AnnotationPodResourceSpec),
 				},
 			},
+		})
+	} else {
+		cpus, memory := b.getResourceLimits(&nodeset.Spec)
+		env = append(env,
+			corev1.EnvVar{
+				Name:  "POD_CPUS",
+				Value: strconv.FormatInt(cpus, 10),
+			},
+			corev1.EnvVar{
+				Name:  "POD_MEMORY",
+				Value: strconv.FormatInt(memory, 10),
+			},
+		)
+	}
+
+	opts := common.ContainerOpts{
+		Base: corev1.Container{
+			Name:  labels.WorkerApp,
+			Args:  slurmdArgs(nodeset, controller),
+			Env:   env,
 			Ports: ports,
+			Resources: corev1.ResourceRequirements{
+				Claims: resourceClaims,
+			},
 			StartupProbe: &corev1.Probe{
 				ProbeHandler: corev1.ProbeHandler{
 					HTTPGet: &corev1.HTTPGetAction{
@@ -257,17 +326,7 @@ func (b *WorkerBuilder) slurmdContainer(nodeset *slinkyv1beta1.NodeSet, controll
 					},
 				},
 			},
-			Lifecycle: &corev1.Lifecycle{
-				PreStop: &corev1.LifecycleHandler{
-					Exec: &corev1.ExecAction{
-						Command: []string{
-							"/usr/bin/sh",
-							"-c",
-							"scontrol update nodename=$(hostname) state=down reason='slurm-operator: Pod is terminating';",
-						},
-					},
-				},
-			},
+			Lifecycle:    slurmdLifecycle(nodeset),
 			VolumeMounts: volumeMounts,
 		},
 		Merge: merge,
@@ -276,7 +335,33 @@ func (b *WorkerBuilder) slurmdContainer(nodeset *slinkyv1beta1.NodeSet, controll
 	return b.CommonBuilder.BuildContainer(opts)
 }
 
+// slurmdLifecycle returns the slurmd container lifecycle hooks.
+//
+// Power saving NodeSet pods are deleted after Slurm has suspended their node,
+// which must not be set DOWN or Slurm would not resume it again.
+func slurmdLifecycle(nodeset *slinkyv1beta1.NodeSet) *corev1.Lifecycle {
+	if nodeset.Spec.PowerSave.Enabled {
+		return nil
+	}
+	return &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{
+					"/usr/bin/sh",
+					"-c",
+					"scontrol update nodename=$(hostname) state=down reason='slurm-operator: Pod is terminating';",
+				},
+			},
+		},
+	}
+}
+
 func slurmdArgs(nodeset *slinkyv1beta1.NodeSet, controller *slinkyv1beta1.Controller) []string {
+	if nodeset.Spec.PowerSave.Enabled {
+		// Power saving nodes are defined in slurm.conf (State=CLOUD), hence
+		// slurmd must register with its hostname instead of dynamically.
+		return common.ConfiglessArgs(controller)
+	}
 	args := []string{"-Z"}
 	args = append(args, common.ConfiglessArgs(controller)...)
 	args = append(args, slurmdConfArgs(nodeset)...)
@@ -284,41 +369,12 @@ func slurmdArgs(nodeset *slinkyv1beta1.NodeSet, controller *slinkyv1beta1.Contro
 }
 
 func slurmdConfArgs(nodeset *slinkyv1beta1.NodeSet) []string {
-	extraConf := []string{}
-	if nodeset.Spec.ExtraConf != "" {
-		extraConf = strings.Split(nodeset.Spec.ExtraConf, " ")
-	}
-
-	name := common.GetSlurmNodeSetName(nodeset)
-	confMap := map[string]string{
-		"Features": name,
-	}
-	for _, item := range extraConf {
-		pair := strings.SplitN(item, "=", 2)
-		key := cases.Title(language.English).String(pair[0])
-		if len(pair) != 2 {
-			panic(fmt.Sprintf("malformed --conf item: %v", item))
-		}
-		val := pair[1]
-		if key == "Features" || key == "Feature" {
-			// Slurm treats trailing 's' as optional. We have to
-			// specially handle 'Feature(s)' because we require at
-			// least one feature but the user can request additional.
-			key = "Features"
-		}
-		if ret, ok := confMap[key]; !ok {
-			confMap[key] = val
-		} else {
-			confMap[key] = ret + fmt.Sprintf(",%s", val)
-		}
+	confList := common.GetSlurmNodeConf(nodeset)
+	if nodeset.Spec.ResourceSpec.Enabled {
+		// Expanded by the kubelet from the container environment.
+		confList = append(confList, fmt.Sprintf("$(%s)", resourceSpecEnv))
 	}
 
-	confList := []string{}
-	for key, val := range confMap {
-		confList = append(confList, fmt.Sprintf("%s=%s", key, val))
-	}
-	sort.Strings(confList)
-
 	args := []string{
 		"--conf",
 		fmt.Sprintf("'%s'", strings.Join(confList, " ")),
@@ -354,22 +410,5 @@ func (b *WorkerBuilder) getWorkerHashes(ctx context.Context, nodeset *slinkyv1be
 }
 
 func (b *WorkerBuilder) getResourceLimits(nodeset *slinkyv1beta1.NodeSetSpec) (int64, int64) {
-	// Check NodeSet pod-level resource limits. Returns zero if not set.
-	cpus, memory := b.CommonBuilder.GetPodResourceLimits(nodeset.Template.PodSpecWrapper.PodSpec)
-
-	// Check slurmd container resource limits. Returns zero if mpt set/
-	containerCpus, containerMemory := b.CommonBuilder.GetContainerResourceLimits(nodeset.Slurmd.Container)
-
-	// Only override pod limits with container limits if the latter is non-zero.
-	if containerCpus != 0 {
-		cpus = containerCpus
-	}
-	if containerMemory != 0 {
-		memory = containerMemory
-	}
-
-	// Convert memory resource limits to MiB
-	memory = int64(memory / 1024 / 1024)
-
-	return cpus, memory
+	return common.GetSlurmNodeResourceLimits(nodeset)
 }
@@ -30,9 +30,10 @@ func TestBuilder_BuildWorkerPodTemplate(t *testing.T) {
 		controller *slinkyv1beta1.Controller
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
+		name       string
+		fields     fields
+		args       args
+		wantMounts map[string]string
 	}{
 		{
 			name: "default",
@@ -71,11 +72,65 @@ func TestBuilder_BuildWorkerPodTemplate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with prolog and epilog scripts",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				nodeset: &slinkyv1beta1.NodeSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "slurm-gpu",
+					},
+					Spec: slinkyv1beta1.NodeSetSpec{
+						ControllerRef: corev1.LocalObjectReference{
+							Name: "slurm",
+						},
+						PrologScriptRefs: []corev1.LocalObjectReference{
+							{Name: "gpu-prolog"},
+						},
+						EpilogScriptRefs: []corev1.LocalObjectReference{
+							{Name: "gpu-epilog"},
+							{Name: "dcgm-epilog"},
+						},
+					},
+					Status: slinkyv1beta1.NodeSetStatus{
+						Selector: k8slabels.SelectorFromSet(k8slabels.Set(labels.NewBuilder().WithWorkerSelectorLabels(&slinkyv1beta1.NodeSet{ObjectMeta: metav1.ObjectMeta{Name: "slurm"}}).Build())).String(),
+					},
+				},
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "slurm",
+					},
+				},
+			},
+			wantMounts: map[string]string{
+				common.NodeSetPrologVolume: common.NodeSetPrologDir,
+				common.NodeSetEpilogVolume: common.NodeSetEpilogDir,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			b := New(tt.fields.client)
 			got := b.BuildWorkerPodTemplate(tt.args.nodeset, tt.args.controller)
+
+			gotMounts := make(map[string]string)
+			for _, volumeMount := range got.Spec.Containers[0].VolumeMounts {
+				gotMounts[volumeMount.Name] = volumeMount.MountPath
+			}
+			gotVolumes := make(map[string]corev1.Volume)
+			for _, volume := range got.Spec.Volumes {
+				gotVolumes[volume.Name] = volume
+			}
+			for name, mountPath := range tt.wantMounts {
+				require.Equal(t, mountPath, gotMounts[name])
+				require.Contains(t, gotVolumes, name)
+				require.Equal(t, int32(0o755), *gotVolumes[name].Projected.DefaultMode)
+			}
+			require.Equal(t, common.NodeSetConfigDir, gotMounts[common.NodeSetConfigVolume])
+			require.Equal(t, tt.args.nodeset.NodeConfigKey().Name, gotVolumes[common.NodeSetConfigVolume].ConfigMap.Name)
+
 			selector, err := k8slabels.ConvertSelectorToLabelsMap(tt.args.nodeset.Status.Selector)
 
 			require.NoError(t, err)
@@ -349,3 +404,55 @@ func TestWorkerBuilder_getResourceLimits(t *testing.T) {
 		})
 	}
 }
+
+func Test_slurmdArgs(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "slurm",
+			Namespace: corev1.NamespaceDefault,
+		},
+	}
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    []string
+	}{
+		{
+			name: "dynamic",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			},
+			want: append(append([]string{"-Z"}, common.ConfiglessArgs(controller)...), "--conf", "'Features=foo'"),
+		},
+		{
+			name: "resource spec",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					ResourceSpec: slinkyv1beta1.NodeSetResourceSpec{
+						Enabled: true,
+					},
+				},
+			},
+			want: append(append([]string{"-Z"}, common.ConfiglessArgs(controller)...), "--conf", "'Features=foo $(POD_RESOURCE_SPEC)'"),
+		},
+		{
+			name: "power save",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					PowerSave: slinkyv1beta1.NodeSetPowerSave{
+						Enabled: true,
+					},
+				},
+			},
+			want: common.ConfiglessArgs(controller),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, slurmdArgs(tt.nodeset, controller))
+			require.Equal(t, !tt.nodeset.Spec.PowerSave.Enabled, slurmdLifecycle(tt.nodeset) != nil)
+		})
+	}
+}
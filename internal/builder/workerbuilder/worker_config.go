@@ -4,10 +4,15 @@
 package workerbuilder
 
 import (
+	"context"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/set"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/controllerbuilder"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
 	loginbuilder "github.com/SlinkyProject/slurm-operator/internal/builder/loginbuilder"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
@@ -29,6 +34,87 @@ func (b *WorkerBuilder) BuildWorkerSshConfig(nodeset *slinkyv1beta1.NodeSet) (*c
 	return b.CommonBuilder.BuildConfigMap(opts, nodeset)
 }
 
+// BuildWorkerNodeConfig returns the node-scoped config files of the NodeSet,
+// which the config files of the Controller include on its pods. Every file is
+// present, as Slurm fails on a missing include.
+func (b *WorkerBuilder) BuildWorkerNodeConfig(nodeset *slinkyv1beta1.NodeSet) (*corev1.ConfigMap, error) {
+	ctx := context.TODO()
+
+	data := make(map[string]string, len(controllerbuilder.NodeSetPropertyConfigFiles)+len(controllerbuilder.NodeSetLineConfigFiles))
+	for _, file := range controllerbuilder.NodeSetPropertyConfigFiles {
+		data[file] = ""
+	}
+	lines := map[string][]string{
+		controllerbuilder.GresConfFile: common.GetSlurmNodeGresConf(nodeset),
+	}
+	for _, ref := range nodeset.Spec.ConfigFileRefs {
+		cm := &corev1.ConfigMap{}
+		key := types.NamespacedName{
+			Namespace: nodeset.Namespace,
+			Name:      ref.Name,
+		}
+		if err := b.client.Get(ctx, key, cm); err != nil {
+			return nil, err
+		}
+		for _, file := range controllerbuilder.NodeSetPropertyConfigFiles {
+			if val, ok := cm.Data[file]; ok {
+				data[file] = buildWorkerNodeConf(val)
+			}
+		}
+		for _, file := range controllerbuilder.NodeSetLineConfigFiles {
+			if val, ok := cm.Data[file]; ok {
+				lines[file] = append(lines[file], config.ParseLines(val)...)
+			}
+		}
+	}
+	for _, file := range controllerbuilder.NodeSetLineConfigFiles {
+		data[file] = buildWorkerNodeLines(lines[file])
+	}
+
+	opts := common.ConfigMapOpts{
+		Key: nodeset.NodeConfigKey(),
+		Metadata: slinkyv1beta1.Metadata{
+			Annotations: nodeset.Annotations,
+			Labels:      structutils.MergeMaps(nodeset.Labels, labels.NewBuilder().WithWorkerLabels(nodeset).Build()),
+		},
+		Data: data,
+	}
+
+	return b.CommonBuilder.BuildConfigMap(opts, nodeset)
+}
+
+// buildWorkerNodeConf returns the `key=value` properties of the node-scoped
+// property file, other lines are ignored.
+func buildWorkerNodeConf(data string) string {
+	conf := config.NewBuilder()
+
+	for _, prop := range config.ParseProperties(data) {
+		if prop.IsRaw() {
+			continue
+		}
+		conf.AddProperty(config.NewProperty(prop.Key(), prop.Value()))
+	}
+
+	return conf.Build()
+}
+
+// buildWorkerNodeLines returns the lines of the node-scoped line file.
+// Identical lines are only rendered once.
+func buildWorkerNodeLines(lines []string) string {
+	conf := config.NewBuilder()
+
+	seen := set.New[string]()
+	for _, line := range lines {
+		if seen.Has(line) {
+			continue
+		}
+		seen.Insert(line)
+		conf.AddProperty(config.NewPropertyRaw(line))
+	}
+
+	return conf.Build()
+}
+
 // Ref: https://slurm.schedmd.com/pam_slurm_adopt.html#ssh_config
 func buildWorkerSshdConfig(extraConf string) string {
 	conf := config.NewBuilder().WithSeparator(" ")
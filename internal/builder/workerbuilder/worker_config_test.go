@@ -7,8 +7,10 @@ import (
 	"testing"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/controllerbuilder"
 	loginbuilder "github.com/SlinkyProject/slurm-operator/internal/builder/loginbuilder"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -61,3 +63,110 @@ func TestBuilder_BuildWorkerSshConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestBuilder_BuildWorkerNodeConfig(t *testing.T) {
+	type fields struct {
+		client client.Client
+	}
+	type args struct {
+		nodeset *slinkyv1beta1.NodeSet
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "default",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				nodeset: &slinkyv1beta1.NodeSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "slurm",
+					},
+				},
+			},
+			want: map[string]string{
+				controllerbuilder.AcctGatherConfFile: "",
+				controllerbuilder.CgroupConfFile:     "",
+				controllerbuilder.GresConfFile:       "",
+				controllerbuilder.HelpersConfFile:    "",
+				controllerbuilder.OciConfFile:        "",
+			},
+		},
+		{
+			name: "with config files",
+			fields: fields{
+				client: fake.NewFakeClient(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "gpu-config"},
+					Data: map[string]string{
+						controllerbuilder.CgroupConfFile:  "# gpu\nConstrainDevices=yes\nAllowedRAMSpace=90",
+						controllerbuilder.GresConfFile:    "# gpu\nAutoDetect=nvml\nName=mps Count=100",
+						controllerbuilder.HelpersConfFile: "Feature=avx512 Helper=/usr/local/bin/avx512",
+					},
+				}),
+			},
+			args: args{
+				nodeset: &slinkyv1beta1.NodeSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "slurm-gpu",
+					},
+					Spec: slinkyv1beta1.NodeSetSpec{
+						ConfigFileRefs: []corev1.LocalObjectReference{
+							{Name: "gpu-config"},
+						},
+						Gres: []slinkyv1beta1.NodeSetGres{
+							{ResourceName: "nvidia.com/gpu", Name: "gpu", AutoDetect: "nvml"},
+							{ResourceName: "example.com/nic", Name: "nic", File: "/dev/infiniband/uverbs[0-1]"},
+						},
+					},
+				},
+			},
+			want: map[string]string{
+				controllerbuilder.AcctGatherConfFile: "",
+				controllerbuilder.CgroupConfFile:     "ConstrainDevices=yes\nAllowedRAMSpace=90\n",
+				controllerbuilder.GresConfFile:       "AutoDetect=nvml\nName=nic File=/dev/infiniband/uverbs[0-1]\nName=mps Count=100\n",
+				controllerbuilder.HelpersConfFile:    "Feature=avx512 Helper=/usr/local/bin/avx512\n",
+				controllerbuilder.OciConfFile:        "",
+			},
+		},
+		{
+			name: "missing configMap",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				nodeset: &slinkyv1beta1.NodeSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "slurm-gpu",
+					},
+					Spec: slinkyv1beta1.NodeSetSpec{
+						ConfigFileRefs: []corev1.LocalObjectReference{
+							{Name: "gpu-config"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(tt.fields.client)
+			got, err := b.BuildWorkerNodeConfig(tt.args.nodeset)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.args.nodeset.NodeConfigKey().Name, got.Name)
+			require.Equal(t, tt.want, got.Data)
+		})
+	}
+}
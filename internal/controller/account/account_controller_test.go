@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package account
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slurmfake "github.com/SlinkyProject/slurm-client/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	testutils "github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("Account Controller", func() {
+	Context("When reconciling an Account", func() {
+		var name = testutils.GenerateResourceName(5)
+		var account *slinkyv1beta1.Account
+		var controller *slinkyv1beta1.Controller
+
+		BeforeEach(func() {
+			controller = testutils.NewController(name, corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account = testutils.NewAccount(name, controller)
+			account.Spec.Limits.MaxJobs = ptr.To[int32](10)
+			slurmClient := slurmfake.NewFakeClient()
+			clientMap.Add(types.NamespacedName{Namespace: corev1.NamespaceDefault, Name: controller.Name}, slurmClient)
+			Expect(k8sClient.Create(ctx, controller.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, account.DeepCopy())).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, account)
+			_ = k8sClient.Delete(ctx, controller)
+		})
+
+		It("Should create the Slurm account", func(ctx SpecContext) {
+			By("Expecting Account status limits")
+			accountKey := client.ObjectKeyFromObject(account)
+			Eventually(func(g Gomega) {
+				checkAccount := &slinkyv1beta1.Account{}
+				g.Expect(k8sClient.Get(ctx, accountKey, checkAccount)).To(Succeed())
+				g.Expect(checkAccount.Finalizers).To(ContainElement(slinkyv1beta1.FinalizerAccount))
+				g.Expect(checkAccount.Status.Limits.MaxJobs).To(Equal(ptr.To[int32](10)))
+			}, testutils.Timeout, testutils.Interval).Should(Succeed())
+		}, SpecTimeout(testutils.Timeout))
+	})
+})
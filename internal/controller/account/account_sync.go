@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package account
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/slurmdbsync"
+)
+
+// Sync implements control logic for synchronizing an Account.
+func (r *AccountReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	account := &slinkyv1beta1.Account{}
+	if err := r.Get(ctx, req.NamespacedName, account); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Account has been deleted", "request", req)
+			return nil
+		}
+		return err
+	}
+	account = account.DeepCopy()
+
+	syncer := &slurmdbsync.Syncer[*slinkyv1beta1.Account]{
+		Client:        r.Client,
+		RefResolver:   r.refResolver,
+		EventRecorder: r.eventRecorder,
+		DurationStore: durationStore,
+		SyncPeriod:    syncPeriod,
+		Kind:          "Account",
+		Finalizer:     slinkyv1beta1.FinalizerAccount,
+		SlurmdbRefFn: func(account *slinkyv1beta1.Account) slinkyv1beta1.SlurmdbRef {
+			return account.Spec.SlurmdbRef
+		},
+		SyncFn:   r.slurmControl.SyncAccount,
+		DeleteFn: r.slurmControl.DeleteAccount,
+		StatusFn: r.syncStatus,
+	}
+	return syncer.Sync(ctx, account)
+}
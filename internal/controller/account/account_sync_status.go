@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package account
+
+import (
+	"context"
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// syncStatus handles determining and updating the status.
+func (r *AccountReconciler) syncStatus(
+	ctx context.Context,
+	account *slinkyv1beta1.Account,
+	controller *slinkyv1beta1.Controller,
+	errors ...error,
+) error {
+	logger := log.FromContext(ctx)
+
+	var assoc *slurmtypes.V0044Assoc
+	if controller != nil {
+		var err error
+		assoc, err = r.slurmControl.GetAccountAssociation(ctx, controller, account)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	newStatus := calculateStatus(account, assoc, utilerrors.NewAggregate(errors))
+
+	if apiequality.Semantic.DeepEqual(account.Status, newStatus) {
+		logger.V(2).Info("Account Status has not changed, skipping status update",
+			"account", klog.KObj(account), "status", account.Status)
+		return utilerrors.NewAggregate(errors)
+	}
+
+	if err := r.updateStatus(ctx, account, &newStatus); err != nil {
+		errors = append(errors, fmt.Errorf("error updating Account(%s) status: %w",
+			klog.KObj(account), err))
+	}
+
+	return utilerrors.NewAggregate(errors)
+}
+
+// calculateStatus returns the Account status given the Slurm account
+// association, which is nil when it does not exist.
+func calculateStatus(
+	account *slinkyv1beta1.Account,
+	assoc *slurmtypes.V0044Assoc,
+	syncErr error,
+) slinkyv1beta1.AccountStatus {
+	newStatus := slinkyv1beta1.AccountStatus{
+		ObservedGeneration: account.Generation,
+		Conditions:         []metav1.Condition{},
+	}
+	newStatus.Conditions = append(newStatus.Conditions, account.Status.Conditions...)
+
+	condition := metav1.Condition{
+		Type:               slurmconditions.SlurmdbConditionSynced,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: account.Generation,
+	}
+
+	if assoc != nil {
+		newStatus.Limits = slurmdbutils.GetAssociationLimits(assoc.V0044Assoc)
+	}
+
+	switch {
+	case syncErr != nil:
+		condition.Reason = "SyncFailed"
+		condition.Message = syncErr.Error()
+	case assoc != nil:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Synced"
+	default:
+		condition.Reason = "NotFound"
+		condition.Message = "The Slurm account does not exist."
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, condition)
+
+	return newStatus
+}
+
+func (r *AccountReconciler) updateStatus(
+	ctx context.Context,
+	account *slinkyv1beta1.Account,
+	newStatus *slinkyv1beta1.AccountStatus,
+) error {
+	logger := log.FromContext(ctx)
+
+	namespacedName := types.NamespacedName{
+		Namespace: account.GetNamespace(),
+		Name:      account.GetName(),
+	}
+
+	logger.V(1).Info("Pending Account Status update",
+		"account", klog.KObj(account), "newStatus", newStatus)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.Account{}
+		if err := r.Get(ctx, namespacedName, toUpdate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		toUpdate.Status = *newStatus
+		return r.Status().Update(ctx, toUpdate)
+	})
+}
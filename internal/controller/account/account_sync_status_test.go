@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package account
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func Test_calculateStatus(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	limits := slinkyv1beta1.AssociationLimits{
+		MaxJobs: ptr.To[int32](10),
+		GrpTRES: map[string]int64{"cpu": 64},
+	}
+	assoc := &slurmtypes.V0044Assoc{
+		V0044Assoc: slurmapi.V0044Assoc{
+			Account: ptr.To("physics"),
+			Cluster: ptr.To("slurm"),
+		},
+	}
+	slurmdbutils.SetAssociationLimits(&assoc.V0044Assoc, limits, slinkyv1beta1.AssociationLimits{})
+	type args struct {
+		account *slinkyv1beta1.Account
+		assoc   *slurmtypes.V0044Assoc
+		syncErr error
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantLimits slinkyv1beta1.AssociationLimits
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name: "synced",
+			args: args{
+				account: testutils.NewAccount("physics", controller),
+				assoc:   assoc,
+			},
+			wantLimits: limits,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "Synced",
+		},
+		{
+			name: "not found",
+			args: args{
+				account: testutils.NewAccount("physics", controller),
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "NotFound",
+		},
+		{
+			name: "sync failed",
+			args: args{
+				account: testutils.NewAccount("physics", controller),
+				assoc:   assoc,
+				syncErr: errors.New("failed"),
+			},
+			wantLimits: limits,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "SyncFailed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateStatus(tt.args.account, tt.args.assoc, tt.args.syncErr)
+			require.Equal(t, tt.wantLimits, got.Limits)
+			require.Len(t, got.Conditions, 1)
+			require.Equal(t, slurmconditions.SlurmdbConditionSynced, got.Conditions[0].Type)
+			require.Equal(t, tt.wantStatus, got.Conditions[0].Status)
+			require.Equal(t, tt.wantReason, got.Conditions[0].Reason)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package account
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmfake "github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newClientMap(controllerName string, client slurmclient.Client) *clientmap.ClientMap {
+	cm := clientmap.NewClientMap()
+	key := types.NamespacedName{
+		Namespace: corev1.NamespaceDefault,
+		Name:      controllerName,
+	}
+	cm.Add(key, client)
+	return cm
+}
+
+func TestAccountReconciler_Sync(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	account := testutils.NewAccount("physics", controller)
+	account.Spec.Limits.MaxJobs = ptr.To[int32](10)
+	accountNoController := testutils.NewAccount("physics", testutils.NewController("other", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil))
+	deletedAccount := account.DeepCopy()
+	deletedAccount.Finalizers = []string{slinkyv1beta1.FinalizerAccount}
+	deletedAccount.DeletionTimestamp = ptr.To(metav1.Now())
+	tests := []struct {
+		name        string
+		client      client.Client
+		slurmClient slurmclient.Client
+		wantFound   bool
+		wantAssoc   bool
+		wantReason  string
+		wantErr     bool
+	}{
+		{
+			name: "create",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(controller.DeepCopy(), account.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.Account{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(),
+			wantFound:   true,
+			wantAssoc:   true,
+			wantReason:  "Synced",
+		},
+		{
+			name: "controller not found",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(accountNoController.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.Account{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(),
+			wantFound:   false,
+			wantReason:  "SyncFailed",
+			wantErr:     true,
+		},
+		{
+			name: "delete",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(controller.DeepCopy(), deletedAccount.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.Account{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(
+				&slurmtypes.V0044Account{
+					V0044Account: slurmapi.V0044Account{
+						Name: account.Name,
+					},
+				},
+				&slurmtypes.V0044Assoc{
+					V0044Assoc: slurmapi.V0044Assoc{
+						Account: ptr.To(account.Name),
+						Cluster: ptr.To(controller.ClusterName()),
+					},
+				},
+			),
+			// The Slurm account is left, only its association is deleted.
+			wantFound: true,
+		},
+		{
+			name: "not found",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(),
+			wantFound:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r := NewReconciler(tt.client, newClientMap(controller.Name, tt.slurmClient))
+			key := client.ObjectKeyFromObject(account)
+			err := r.Sync(ctx, reconcile.Request{NamespacedName: key})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			slurmAccount := &slurmtypes.V0044Account{}
+			err = tt.slurmClient.Get(ctx, slurmobject.ObjectKey(account.Name), slurmAccount)
+			require.Equal(t, tt.wantFound, err == nil)
+			assocList := &slurmtypes.V0044AssocList{}
+			require.NoError(t, tt.slurmClient.List(ctx, assocList))
+			gotAssoc := slices.ContainsFunc(assocList.Items, func(assoc slurmtypes.V0044Assoc) bool {
+				return ptr.Deref(assoc.Account, "") == account.Name
+			})
+			require.Equal(t, tt.wantAssoc, gotAssoc)
+
+			checkAccount := &slinkyv1beta1.Account{}
+			if err := tt.client.Get(ctx, key, checkAccount); err != nil {
+				return
+			}
+			if checkAccount.DeletionTimestamp.IsZero() {
+				require.Contains(t, checkAccount.Finalizers, slinkyv1beta1.FinalizerAccount)
+			}
+			if tt.wantReason != "" {
+				require.Len(t, checkAccount.Status.Conditions, 1)
+				require.Equal(t, tt.wantReason, checkAccount.Status.Conditions[0].Reason)
+			}
+			if tt.wantFound {
+				require.Equal(t, account.Spec.Limits.MaxJobs, checkAccount.Status.Limits.MaxJobs)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+)
+
+const (
+	rootAccount = "root"
+)
+
+type SlurmControlInterface interface {
+	// GetAccountAssociation returns the Slurm account association on the cluster
+	// of the Controller, or nil if it does not exist.
+	GetAccountAssociation(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.Account) (*slurmtypes.V0044Assoc, error)
+	// SyncAccount creates and updates the Slurm account and its association on the cluster of the Controller.
+	SyncAccount(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.Account) error
+	// DeleteAccount deletes the Slurm account association on the cluster of the Controller.
+	// The Slurm account is left in slurmdbd, it may be associated with other clusters.
+	DeleteAccount(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.Account) error
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+}
+
+// GetAccountAssociation implements SlurmControlInterface.
+func (r *realSlurmControl) GetAccountAssociation(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.Account) (*slurmtypes.V0044Assoc, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for account, cannot do GetAccountAssociation()")
+		return nil, nil
+	}
+
+	return getAccountAssociation(ctx, slurmClient, controller.ClusterName(), account.Name)
+}
+
+// SyncAccount implements SlurmControlInterface.
+func (r *realSlurmControl) SyncAccount(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.Account) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for account, cannot do SyncAccount()")
+		return nil
+	}
+
+	newAccount := formatAccount(account)
+	oldAccount := new(slurmtypes.V0044Account)
+	key := slurmobject.ObjectKey(account.Name)
+	if err := slurmClient.Get(ctx, key, oldAccount); !tolerateError(err) {
+		return err
+	}
+
+	switch {
+	case oldAccount.Name == "":
+		if err := slurmClient.Create(ctx, &newAccount, newAccount.V0044Account); !tolerateError(err) {
+			return fmt.Errorf("SyncAccount() failed to Create Account=%s with error=%w", account.Name, err)
+		}
+	case oldAccount.Description != newAccount.Description ||
+		oldAccount.Organization != newAccount.Organization:
+		if err := slurmClient.Update(ctx, &newAccount, newAccount.V0044Account); !tolerateError(err) {
+			return fmt.Errorf("SyncAccount() failed to Update Account=%s with error=%w", account.Name, err)
+		}
+	}
+
+	clusterName := controller.ClusterName()
+	oldAssoc, err := getAccountAssociation(ctx, slurmClient, clusterName, account.Name)
+	if err != nil {
+		return err
+	}
+
+	if oldAssoc == nil {
+		newAssoc := formatAccountAssociation(account, clusterName, slinkyv1beta1.AssociationLimits{})
+		if err := slurmClient.Create(ctx, &newAssoc, newAssoc.V0044Assoc); !tolerateError(err) {
+			return fmt.Errorf("SyncAccount() failed to Create Association for Account=%s with error=%w", account.Name, err)
+		}
+		return nil
+	}
+
+	if isAccountAssociationMatch(*oldAssoc, account) {
+		return nil
+	}
+
+	observedLimits := slurmdbutils.GetAssociationLimits(oldAssoc.V0044Assoc)
+	newAssoc := formatAccountAssociation(account, clusterName, observedLimits)
+	if err := slurmClient.Update(ctx, &newAssoc, newAssoc.V0044Assoc); !tolerateError(err) {
+		return fmt.Errorf("SyncAccount() failed to Update Association for Account=%s with error=%w", account.Name, err)
+	}
+
+	return nil
+}
+
+// DeleteAccount implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteAccount(ctx context.Context, controller *slinkyv1beta1.Controller, account *slinkyv1beta1.Account) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for account, cannot do DeleteAccount()")
+		return nil
+	}
+
+	assoc, err := getAccountAssociation(ctx, slurmClient, controller.ClusterName(), account.Name)
+	if err != nil {
+		return err
+	}
+
+	if assoc == nil {
+		return nil
+	}
+
+	if err := slurmClient.Delete(ctx, assoc); !tolerateError(err) {
+		return fmt.Errorf("DeleteAccount() failed to Delete Association for Account=%s with error=%w", account.Name, err)
+	}
+
+	return nil
+}
+
+// getAccountAssociation returns the association of the account, which has no
+// user nor partition, or nil if it does not exist.
+func getAccountAssociation(ctx context.Context, slurmClient slurmclient.Client, clusterName, accountName string) (*slurmtypes.V0044Assoc, error) {
+	assocList := &slurmtypes.V0044AssocList{}
+	if err := slurmClient.List(ctx, assocList); err != nil {
+		if tolerateError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, assoc := range assocList.Items {
+		if ptr.Deref(assoc.Cluster, "") != clusterName ||
+			ptr.Deref(assoc.Account, "") != accountName ||
+			assoc.User != "" ||
+			ptr.Deref(assoc.Partition, "") != "" {
+			continue
+		}
+		return &assoc, nil
+	}
+
+	return nil, nil
+}
+
+// isAccountAssociationMatch returns true when the Slurm association matches the Account.
+func isAccountAssociationMatch(assoc slurmtypes.V0044Assoc, account *slinkyv1beta1.Account) bool {
+	if ptr.Deref(assoc.ParentAccount, "") != getParentAccount(account) {
+		return false
+	}
+	if account.Spec.DefaultQOS != "" {
+		if assoc.Default == nil || ptr.Deref(assoc.Default.Qos, "") != account.Spec.DefaultQOS {
+			return false
+		}
+	}
+	if len(account.Spec.QOS) > 0 {
+		if !slurmdbutils.IsQosListMatch(ptr.Deref(assoc.Qos, nil), account.Spec.QOS) {
+			return false
+		}
+	}
+	return slurmdbutils.IsAssociationLimitsMatch(assoc.V0044Assoc, account.Spec.Limits)
+}
+
+func formatAccount(account *slinkyv1beta1.Account) slurmtypes.V0044Account {
+	description := account.Spec.Description
+	if description == "" {
+		description = account.Name
+	}
+	// Match the sacctmgr default organization.
+	organization := account.Spec.Organization
+	if organization == "" {
+		organization = getParentAccount(account)
+		if organization == rootAccount {
+			organization = account.Name
+		}
+	}
+	return slurmtypes.V0044Account{
+		V0044Account: slurmapi.V0044Account{
+			Name:         account.Name,
+			Description:  description,
+			Organization: organization,
+		},
+	}
+}
+
+func formatAccountAssociation(account *slinkyv1beta1.Account, clusterName string, observedLimits slinkyv1beta1.AssociationLimits) slurmtypes.V0044Assoc {
+	assoc := slurmtypes.V0044Assoc{
+		V0044Assoc: slurmapi.V0044Assoc{
+			Account:       ptr.To(account.Name),
+			Cluster:       ptr.To(clusterName),
+			ParentAccount: ptr.To(getParentAccount(account)),
+		},
+	}
+	if account.Spec.DefaultQOS != "" {
+		assoc.Default = &slurmapi.V0044AssocDefault{
+			Qos: ptr.To(account.Spec.DefaultQOS),
+		}
+	}
+	if len(account.Spec.QOS) > 0 {
+		assoc.Qos = ptr.To(slurmapi.V0044QosStringIdList(account.Spec.QOS))
+	}
+	slurmdbutils.SetAssociationLimits(&assoc.V0044Assoc, account.Spec.Limits, observedLimits)
+	return assoc
+}
+
+func getParentAccount(account *slinkyv1beta1.Account) string {
+	if account.Spec.ParentAccount == "" {
+		return rootAccount
+	}
+	return account.Spec.ParentAccount
+}
+
+func (r *realSlurmControl) lookupClient(controller *slinkyv1beta1.Controller) slurmclient.Client {
+	if controller == nil {
+		return nil
+	}
+	return r.clientMap.Get(objectutils.NamespacedName(controller))
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clientMap *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clientMap,
+	}
+}
+
+func tolerateError(err error) bool {
+	if err == nil {
+		return true
+	}
+	errText := err.Error()
+	if errText == http.StatusText(http.StatusNoContent) ||
+		errText == http.StatusText(http.StatusNotFound) {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,412 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
+	"github.com/SlinkyProject/slurm-client/pkg/types"
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+// slurmUpdateFn mimics slurmdbd by applying the request onto the object.
+func slurmUpdateFn(_ context.Context, obj object.Object, req any, opts ...client.UpdateOption) error {
+	switch o := obj.(type) {
+	case *types.V0044Account:
+		r, ok := req.(api.V0044Account)
+		if !ok {
+			return errors.New("failed to cast request object")
+		}
+		o.V0044Account = r
+	case *types.V0044Assoc:
+		r, ok := req.(api.V0044Assoc)
+		if !ok {
+			return errors.New("failed to cast request object")
+		}
+		o.V0044Assoc = r
+	default:
+		return errors.New("failed to cast slurm object")
+	}
+	return nil
+}
+
+func newController(name string) *slinkyv1beta1.Controller {
+	return &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      name,
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			ClusterName: "slurm",
+		},
+	}
+}
+
+func newAccount(name string, limits slinkyv1beta1.AssociationLimits) *slinkyv1beta1.Account {
+	return &slinkyv1beta1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      name,
+		},
+		Spec: slinkyv1beta1.AccountSpec{
+			SlurmdbRef: slinkyv1beta1.SlurmdbRef{
+				ControllerRef: &corev1.LocalObjectReference{
+					Name: "slurm",
+				},
+			},
+			Limits: limits,
+		},
+	}
+}
+
+func newSlurmAccount(name string) *types.V0044Account {
+	return &types.V0044Account{
+		V0044Account: api.V0044Account{
+			Name:         name,
+			Description:  name,
+			Organization: name,
+		},
+	}
+}
+
+func newSlurmAssoc(account string, limits slinkyv1beta1.AssociationLimits) *types.V0044Assoc {
+	assoc := &types.V0044Assoc{
+		V0044Assoc: api.V0044Assoc{
+			Account:       ptr.To(account),
+			Cluster:       ptr.To("slurm"),
+			ParentAccount: ptr.To(rootAccount),
+		},
+	}
+	slurmdbutils.SetAssociationLimits(&assoc.V0044Assoc, limits, slinkyv1beta1.AssociationLimits{})
+	return assoc
+}
+
+func newSlurmClientMap(controllerName string, client client.Client) *clientmap.ClientMap {
+	cm := clientmap.NewClientMap()
+	key := k8stypes.NamespacedName{
+		Namespace: corev1.NamespaceDefault,
+		Name:      controllerName,
+	}
+	cm.Add(key, client)
+	return cm
+}
+
+func Test_realSlurmControl_GetAccountAssociation(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     client.Client
+		controller *slinkyv1beta1.Controller
+		wantFound  bool
+		wantErr    bool
+	}{
+		{
+			name:       "No client",
+			client:     fake.NewFakeClient(newSlurmAssoc("foo", slinkyv1beta1.AssociationLimits{})),
+			controller: newController("other"),
+			wantFound:  false,
+		},
+		{
+			name:       "Not found",
+			client:     fake.NewFakeClient(newSlurmAssoc("bar", slinkyv1beta1.AssociationLimits{})),
+			controller: newController("slurm"),
+			wantFound:  false,
+		},
+		{
+			name:       "Found",
+			client:     fake.NewFakeClient(newSlurmAssoc("foo", slinkyv1beta1.AssociationLimits{})),
+			controller: newController("slurm"),
+			wantFound:  true,
+		},
+		{
+			name: "List error",
+			client: fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, list object.ObjectList, opts ...client.ListOption) error {
+						return errors.New("failed to list")
+					},
+				}).
+				Build(),
+			controller: newController("slurm"),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newAccount("foo", slinkyv1beta1.AssociationLimits{})
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			got, err := r.GetAccountAssociation(context.Background(), tt.controller, account)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetAccountAssociation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got != nil) != tt.wantFound {
+				t.Errorf("GetAccountAssociation() = %v, wantFound %v", got, tt.wantFound)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_SyncAccount(t *testing.T) {
+	limits := slinkyv1beta1.AssociationLimits{
+		MaxJobs: ptr.To[int32](10),
+		GrpTRES: map[string]int64{"cpu": 64},
+	}
+	tests := []struct {
+		name       string
+		client     client.Client
+		account    *slinkyv1beta1.Account
+		wantLimits slinkyv1beta1.AssociationLimits
+		wantErr    bool
+	}{
+		{
+			name:       "Create",
+			client:     fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).Build(),
+			account:    newAccount("foo", limits),
+			wantLimits: limits,
+		},
+		{
+			name: "Correct drift",
+			client: fake.NewClientBuilder().
+				WithUpdateFn(slurmUpdateFn).
+				WithObjects(newSlurmAccount("foo")).
+				WithObjects(newSlurmAssoc("foo", slinkyv1beta1.AssociationLimits{
+					MaxJobs:       ptr.To[int32](5),
+					MaxSubmitJobs: ptr.To[int32](5),
+				})).
+				Build(),
+			account:    newAccount("foo", limits),
+			wantLimits: limits,
+		},
+		{
+			name: "No change",
+			client: fake.NewClientBuilder().
+				WithObjects(newSlurmAccount("foo")).
+				WithObjects(newSlurmAssoc("foo", limits)).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Update: func(ctx context.Context, obj object.Object, req any, opts ...client.UpdateOption) error {
+						return errors.New("unexpected update")
+					},
+				}).
+				Build(),
+			account:    newAccount("foo", limits),
+			wantLimits: limits,
+		},
+		{
+			name: "Create error",
+			client: fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, obj object.Object, req any, opts ...client.CreateOption) error {
+						return errors.New("failed to create")
+					},
+				}).
+				Build(),
+			account: newAccount("foo", limits),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := newController("slurm")
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			if err := r.SyncAccount(context.Background(), controller, tt.account); (err != nil) != tt.wantErr {
+				t.Fatalf("SyncAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := r.GetAccountAssociation(context.Background(), controller, tt.account)
+			if err != nil {
+				t.Fatalf("GetAccountAssociation() error = %v", err)
+			}
+			if got == nil {
+				t.Fatalf("SyncAccount() did not create association")
+			}
+			if limits := slurmdbutils.GetAssociationLimits(got.V0044Assoc); !apiequality.Semantic.DeepEqual(limits, tt.wantLimits) {
+				t.Errorf("SyncAccount() limits = %v, want %v", limits, tt.wantLimits)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_DeleteAccount(t *testing.T) {
+	otherAssoc := newSlurmAssoc("foo", slinkyv1beta1.AssociationLimits{})
+	otherAssoc.Cluster = ptr.To("other")
+	tests := []struct {
+		name             string
+		client           client.Client
+		wantAccount      bool
+		wantOtherCluster bool
+		wantErr          bool
+	}{
+		{
+			name:   "Not found",
+			client: fake.NewFakeClient(),
+		},
+		{
+			name: "Found",
+			client: fake.NewFakeClient(
+				newSlurmAccount("foo"),
+				newSlurmAssoc("foo", slinkyv1beta1.AssociationLimits{}),
+				otherAssoc.DeepCopyObject(),
+			),
+			wantAccount:      true,
+			wantOtherCluster: true,
+		},
+		{
+			name: "Delete error",
+			client: fake.NewClientBuilder().
+				WithObjects(newSlurmAccount("foo"), newSlurmAssoc("foo", slinkyv1beta1.AssociationLimits{})).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, obj object.Object, opts ...client.DeleteOption) error {
+						return errors.New("failed to delete")
+					},
+				}).
+				Build(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			account := newAccount("foo", slinkyv1beta1.AssociationLimits{})
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			if err := r.DeleteAccount(ctx, newController("slurm"), account); (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			assoc, err := getAccountAssociation(ctx, tt.client, "slurm", "foo")
+			if err != nil {
+				t.Fatalf("getAccountAssociation() error = %v", err)
+			}
+			if assoc != nil {
+				t.Errorf("DeleteAccount() did not delete association = %v", assoc)
+			}
+			// The account and its associations on other clusters are left to slurmdbd.
+			gotAccount := tt.client.Get(ctx, object.ObjectKey("foo"), &types.V0044Account{}) == nil
+			if gotAccount != tt.wantAccount {
+				t.Errorf("DeleteAccount() account found = %v, want %v", gotAccount, tt.wantAccount)
+			}
+			otherAssoc, err := getAccountAssociation(ctx, tt.client, "other", "foo")
+			if err != nil {
+				t.Fatalf("getAccountAssociation() error = %v", err)
+			}
+			if gotOther := otherAssoc != nil; gotOther != tt.wantOtherCluster {
+				t.Errorf("DeleteAccount() other cluster association found = %v, want %v", gotOther, tt.wantOtherCluster)
+			}
+		})
+	}
+}
+
+func Test_formatAccount(t *testing.T) {
+	tests := []struct {
+		name    string
+		account *slinkyv1beta1.Account
+		want    api.V0044Account
+	}{
+		{
+			name:    "Defaults",
+			account: newAccount("foo", slinkyv1beta1.AssociationLimits{}),
+			want: api.V0044Account{
+				Name:         "foo",
+				Description:  "foo",
+				Organization: "foo",
+			},
+		},
+		{
+			name: "Organization from parent",
+			account: func() *slinkyv1beta1.Account {
+				account := newAccount("foo", slinkyv1beta1.AssociationLimits{})
+				account.Spec.ParentAccount = "physics"
+				account.Spec.Description = "Foo group"
+				return account
+			}(),
+			want: api.V0044Account{
+				Name:         "foo",
+				Description:  "Foo group",
+				Organization: "physics",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatAccount(tt.account); !apiequality.Semantic.DeepEqual(got.V0044Account, tt.want) {
+				t.Errorf("formatAccount() = %v, want %v", got.V0044Account, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isAccountAssociationMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		assoc   *types.V0044Assoc
+		account *slinkyv1beta1.Account
+		want    bool
+	}{
+		{
+			name:    "Match",
+			assoc:   newSlurmAssoc("foo", slinkyv1beta1.AssociationLimits{}),
+			account: newAccount("foo", slinkyv1beta1.AssociationLimits{}),
+			want:    true,
+		},
+		{
+			name:  "Parent changed",
+			assoc: newSlurmAssoc("foo", slinkyv1beta1.AssociationLimits{}),
+			account: func() *slinkyv1beta1.Account {
+				account := newAccount("foo", slinkyv1beta1.AssociationLimits{})
+				account.Spec.ParentAccount = "physics"
+				return account
+			}(),
+			want: false,
+		},
+		{
+			name:  "QOS changed",
+			assoc: newSlurmAssoc("foo", slinkyv1beta1.AssociationLimits{}),
+			account: func() *slinkyv1beta1.Account {
+				account := newAccount("foo", slinkyv1beta1.AssociationLimits{})
+				account.Spec.QOS = []string{"normal"}
+				return account
+			}(),
+			want: false,
+		},
+		{
+			name:  "Default QOS changed",
+			assoc: newSlurmAssoc("foo", slinkyv1beta1.AssociationLimits{}),
+			account: func() *slinkyv1beta1.Account {
+				account := newAccount("foo", slinkyv1beta1.AssociationLimits{})
+				account.Spec.DefaultQOS = "normal"
+				return account
+			}(),
+			want: false,
+		},
+		{
+			name:    "Limits changed",
+			assoc:   newSlurmAssoc("foo", slinkyv1beta1.AssociationLimits{}),
+			account: newAccount("foo", slinkyv1beta1.AssociationLimits{MaxJobs: ptr.To[int32](1)}),
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAccountAssociationMatch(*tt.assoc, tt.account); got != tt.want {
+				t.Errorf("isAccountAssociationMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	builder "github.com/SlinkyProject/slurm-operator/internal/builder/accountingbuilder"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/accounting/eventhandler"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/dbcheck"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
 )
@@ -31,6 +32,9 @@ import (
 const (
 	ControllerName = "accounting-controller"
 
+	// PreflightFailedReason is the event reason when the database check fails.
+	PreflightFailedReason = "PreflightFailed"
+
 	// BackoffGCInterval is the time that has to pass before next iteration of backoff GC is run
 	BackoffGCInterval = 1 * time.Minute
 )
@@ -57,6 +61,7 @@ type AccountingReconciler struct {
 	builder       *builder.AccountingBuilder
 	refResolver   *refresolver.RefResolver
 	eventRecorder events.EventRecorder
+	dbChecker     dbcheck.Checker
 }
 
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=accountings,verbs=get;list;watch;create;update;patch;delete
@@ -67,6 +72,7 @@ type AccountingReconciler struct {
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=k8s.mariadb.com,resources=mariadbs;databases;users;grants,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -106,6 +112,9 @@ func (r *AccountingReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.builder = builder.New(r.Client)
 	r.refResolver = refresolver.New(r.Client)
 	r.eventRecorder = mgr.GetEventRecorder(ControllerName)
+	if r.dbChecker == nil {
+		r.dbChecker = dbcheck.NewChecker()
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(ControllerName).
 		For(&slinkyv1beta1.Accounting{}).
@@ -130,5 +139,6 @@ func NewReconciler(c client.Client) *AccountingReconciler {
 		builder:       builder.New(c),
 		refResolver:   refresolver.New(c),
 		eventRecorder: events.NewFakeRecorder(100),
+		dbChecker:     dbcheck.NewChecker(),
 	}
 }
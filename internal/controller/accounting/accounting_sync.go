@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
@@ -39,6 +40,7 @@ func (r *AccountingReconciler) Sync(ctx context.Context, req reconcile.Request)
 		return nil
 	}
 
+	var preflight *preflight
 	steps := []syncsteps.Step[*slinkyv1beta1.Accounting]{
 		{
 			Name: "Service",
@@ -56,6 +58,23 @@ func (r *AccountingReconciler) Sync(ctx context.Context, req reconcile.Request)
 				return nil
 			},
 		},
+		{
+			Name: "Database",
+			SyncFn: func(ctx context.Context, accounting *slinkyv1beta1.Accounting) error {
+				return r.syncDatabase(ctx, accounting)
+			},
+		},
+		{
+			Name: "Preflight",
+			SyncFn: func(ctx context.Context, accounting *slinkyv1beta1.Accounting) error {
+				preflight = r.syncPreflight(ctx, accounting)
+				if preflight != nil && preflight.err != nil {
+					r.eventRecorder.Eventf(accounting, nil, corev1.EventTypeWarning, PreflightFailedReason, "Preflight",
+						"Holding back changes to the slurmdbd configuration, failed database check: %v", preflight.err)
+				}
+				return nil
+			},
+		},
 		{
 			Name: "Config",
 			SyncFn: func(ctx context.Context, accounting *slinkyv1beta1.Accounting) error {
@@ -66,7 +85,10 @@ func (r *AccountingReconciler) Sync(ctx context.Context, req reconcile.Request)
 				if err != nil {
 					return fmt.Errorf("failed to build: %w", err)
 				}
-				if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, accounting, object, true); err != nil {
+				// The running slurmdbd keeps the last good configuration while
+				// the database check fails, the first one is always created.
+				shouldUpdate := preflight == nil || preflight.err == nil
+				if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, accounting, object, shouldUpdate); err != nil {
 					return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
 				}
 				return nil
@@ -92,12 +114,12 @@ func (r *AccountingReconciler) Sync(ctx context.Context, req reconcile.Request)
 
 	if err := syncsteps.Sync(ctx, r.eventRecorder, accounting, steps); err != nil {
 		errs := []error{err}
-		if err := r.syncStatus(ctx, accounting); err != nil {
+		if err := r.syncStatus(ctx, accounting, preflight); err != nil {
 			e := fmt.Errorf("failed status syncFn: %w", err)
 			errs = append(errs, e)
 		}
 		return utilerrors.NewAggregate(errs)
 	}
 
-	return r.syncStatus(ctx, accounting)
+	return r.syncStatus(ctx, accounting, preflight)
 }
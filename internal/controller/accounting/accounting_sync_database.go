@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package accounting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+const (
+	// databaseSyncPeriod is how often the managed database objects are checked
+	// until they are ready, as they are not watched.
+	databaseSyncPeriod = 30 * time.Second
+)
+
+var errMariaDBUnavailable = errors.New("the MariaDB API is not available, is the mariadb-operator installed?")
+
+func isManagedDatabase(accounting *slinkyv1beta1.Accounting) bool {
+	return !accounting.Spec.External && accounting.Spec.ManagedDatabase != nil
+}
+
+// syncDatabase provisions the managed database of the Accounting with the
+// mariadb-operator.
+func (r *AccountingReconciler) syncDatabase(
+	ctx context.Context,
+	accounting *slinkyv1beta1.Accounting,
+) error {
+	if !isManagedDatabase(accounting) {
+		return nil
+	}
+
+	mariadb, err := r.builder.BuildAccountingMariaDB(accounting)
+	if err != nil {
+		return fmt.Errorf("failed to build: %w", err)
+	}
+	if err := r.checkMariaDBAvailable(mariadb); err != nil {
+		return err
+	}
+
+	password, err := r.builder.BuildAccountingDatabasePassword(accounting)
+	if err != nil {
+		return fmt.Errorf("failed to build: %w", err)
+	}
+	database, err := r.builder.BuildAccountingDatabase(accounting)
+	if err != nil {
+		return fmt.Errorf("failed to build: %w", err)
+	}
+	user, err := r.builder.BuildAccountingDatabaseUser(accounting)
+	if err != nil {
+		return fmt.Errorf("failed to build: %w", err)
+	}
+	grant, err := r.builder.BuildAccountingDatabaseGrant(accounting)
+	if err != nil {
+		return fmt.Errorf("failed to build: %w", err)
+	}
+
+	// The generated password must never be rotated, and the SQL objects are
+	// immutable.
+	objects := []struct {
+		object       client.Object
+		shouldUpdate bool
+	}{
+		{object: password, shouldUpdate: false},
+		{object: mariadb, shouldUpdate: true},
+		{object: database, shouldUpdate: false},
+		{object: user, shouldUpdate: false},
+		{object: grant, shouldUpdate: false},
+	}
+	for _, o := range objects {
+		if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, accounting, o.object, o.shouldUpdate); err != nil {
+			return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(o.object), err)
+		}
+	}
+
+	return nil
+}
+
+// checkMariaDBAvailable returns an error when the mariadb-operator CRDs are not
+// installed.
+func (r *AccountingReconciler) checkMariaDBAvailable(mariadb *mariadbv1alpha1.MariaDB) error {
+	gvk, err := r.GroupVersionKindFor(mariadb)
+	if err != nil {
+		if isMariaDBUnavailable(err) {
+			return errMariaDBUnavailable
+		}
+		return err
+	}
+	if _, err := r.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if isMariaDBUnavailable(err) {
+			return errMariaDBUnavailable
+		}
+		return err
+	}
+	return nil
+}
+
+func isMariaDBUnavailable(err error) bool {
+	if meta.IsNoMatchError(err) {
+		return true
+	}
+	for err != nil {
+		if runtime.IsNotRegisteredError(err) {
+			return true
+		}
+		err = errors.Unwrap(err)
+	}
+	return false
+}
+
+// calculateDatabaseCondition returns the DatabaseReady condition of the
+// managed database, from the readiness of its mariadb-operator objects.
+func (r *AccountingReconciler) calculateDatabaseCondition(
+	ctx context.Context,
+	accounting *slinkyv1beta1.Accounting,
+) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               slurmconditions.AccountingConditionDatabaseReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: accounting.Generation,
+		Reason:             "NotReady",
+	}
+
+	type readyObject interface {
+		client.Object
+		IsReady() bool
+	}
+	objects := []struct {
+		kind   string
+		object readyObject
+	}{
+		{kind: "MariaDB", object: &mariadbv1alpha1.MariaDB{}},
+		{kind: "Database", object: &mariadbv1alpha1.Database{}},
+		{kind: "User", object: &mariadbv1alpha1.User{}},
+		{kind: "Grant", object: &mariadbv1alpha1.Grant{}},
+	}
+	key := accounting.MariaDBKey()
+	var notReady []string
+	for _, o := range objects {
+		if err := r.Get(ctx, key, o.object); err != nil {
+			if apierrors.IsNotFound(err) {
+				notReady = append(notReady, o.kind)
+				continue
+			}
+			if isMariaDBUnavailable(err) {
+				cond.Reason = "Unavailable"
+				cond.Message = errMariaDBUnavailable.Error()
+				return cond
+			}
+			cond.Status = metav1.ConditionUnknown
+			cond.Reason = "Unknown"
+			cond.Message = fmt.Sprintf("Failed to get %s: %v", o.kind, err)
+			return cond
+		}
+		if !o.object.IsReady() {
+			notReady = append(notReady, o.kind)
+		}
+	}
+
+	if len(notReady) == 0 {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "Ready"
+		cond.Message = "The managed database is ready."
+	} else {
+		cond.Message = fmt.Sprintf("Waiting for %s to be ready.", strings.Join(notReady, ", "))
+	}
+	return cond
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package accounting
+
+import (
+	"context"
+	"testing"
+
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func newManagedAccounting() *slinkyv1beta1.Accounting {
+	accounting := testutils.NewAccounting("slurm",
+		testutils.NewSlurmKeyRef("slurmkey"),
+		testutils.NewJwtKeyRef("jwtkey"),
+		testutils.NewPasswordRef("password"))
+	accounting.UID = "uid"
+	accounting.Spec.ManagedDatabase = &slinkyv1beta1.ManagedDatabase{}
+	defaults.SetAccountingDefaults(accounting)
+	return accounting
+}
+
+func TestAccountingReconciler_syncDatabase(t *testing.T) {
+	noMariaDBScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(noMariaDBScheme))
+	utilruntime.Must(slinkyv1beta1.AddToScheme(noMariaDBScheme))
+
+	external := newManagedAccounting()
+	external.Spec.External = true
+
+	tests := []struct {
+		name        string
+		client      client.Client
+		accounting  *slinkyv1beta1.Accounting
+		wantErr     error
+		wantCreated bool
+	}{
+		{
+			name:       "not managed",
+			client:     fake.NewFakeClient(),
+			accounting: testutils.NewAccounting("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, corev1.SecretKeySelector{}),
+		},
+		{
+			name:       "external",
+			client:     fake.NewFakeClient(),
+			accounting: external,
+		},
+		{
+			name:       "mariadb-operator not installed",
+			client:     fake.NewClientBuilder().WithScheme(noMariaDBScheme).Build(),
+			accounting: newManagedAccounting(),
+			wantErr:    errMariaDBUnavailable,
+		},
+		{
+			name:        "managed",
+			client:      fake.NewClientBuilder().WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(clientgoscheme.Scheme)).Build(),
+			accounting:  newManagedAccounting(),
+			wantCreated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r := newAccountingController(tt.client)
+			err := r.syncDatabase(ctx, tt.accounting)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			key := tt.accounting.MariaDBKey()
+			objects := []client.Object{
+				&mariadbv1alpha1.MariaDB{},
+				&mariadbv1alpha1.Database{},
+				&mariadbv1alpha1.User{},
+				&mariadbv1alpha1.Grant{},
+			}
+			for _, object := range objects {
+				err := tt.client.Get(ctx, key, object)
+				if !tt.wantCreated {
+					require.Error(t, err)
+					continue
+				}
+				require.NoError(t, err)
+				require.True(t, metav1.IsControlledBy(object, tt.accounting))
+			}
+			if !tt.wantCreated {
+				return
+			}
+
+			// The generated password is kept across syncs.
+			password := &corev1.Secret{}
+			require.NoError(t, tt.client.Get(ctx, tt.accounting.MariaDBPasswordKey(), password))
+			require.NoError(t, r.syncDatabase(ctx, tt.accounting))
+			got := &corev1.Secret{}
+			require.NoError(t, tt.client.Get(ctx, tt.accounting.MariaDBPasswordKey(), got))
+			require.Equal(t, password.StringData, got.StringData)
+		})
+	}
+}
+
+func TestAccountingReconciler_calculateDatabaseCondition(t *testing.T) {
+	accounting := newManagedAccounting()
+	key := accounting.MariaDBKey()
+	meta := metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}
+	ready := []metav1.Condition{{Type: mariadbv1alpha1.ConditionTypeReady, Status: metav1.ConditionTrue}}
+	notReady := []metav1.Condition{{Type: mariadbv1alpha1.ConditionTypeReady, Status: metav1.ConditionFalse}}
+
+	tests := []struct {
+		name        string
+		objects     []client.Object
+		wantStatus  metav1.ConditionStatus
+		wantMessage string
+	}{
+		{
+			name:        "not created",
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: "Waiting for MariaDB, Database, User, Grant to be ready.",
+		},
+		{
+			name: "user not ready",
+			objects: []client.Object{
+				&mariadbv1alpha1.MariaDB{ObjectMeta: meta, Status: mariadbv1alpha1.MariaDBStatus{Conditions: ready}},
+				&mariadbv1alpha1.Database{ObjectMeta: meta, Status: mariadbv1alpha1.DatabaseStatus{Conditions: ready}},
+				&mariadbv1alpha1.User{ObjectMeta: meta, Status: mariadbv1alpha1.UserStatus{Conditions: notReady}},
+				&mariadbv1alpha1.Grant{ObjectMeta: meta, Status: mariadbv1alpha1.GrantStatus{Conditions: ready}},
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: "Waiting for User to be ready.",
+		},
+		{
+			name: "ready",
+			objects: []client.Object{
+				&mariadbv1alpha1.MariaDB{ObjectMeta: meta, Status: mariadbv1alpha1.MariaDBStatus{Conditions: ready}},
+				&mariadbv1alpha1.Database{ObjectMeta: meta, Status: mariadbv1alpha1.DatabaseStatus{Conditions: ready}},
+				&mariadbv1alpha1.User{ObjectMeta: meta, Status: mariadbv1alpha1.UserStatus{Conditions: ready}},
+				&mariadbv1alpha1.Grant{ObjectMeta: meta, Status: mariadbv1alpha1.GrantStatus{Conditions: ready}},
+			},
+			wantStatus:  metav1.ConditionTrue,
+			wantMessage: "The managed database is ready.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(tt.objects...).Build()
+			r := newAccountingController(c)
+			got := r.calculateDatabaseCondition(context.TODO(), accounting)
+			require.Equal(t, slurmconditions.AccountingConditionDatabaseReady, got.Type)
+			require.Equal(t, tt.wantStatus, got.Status)
+			require.Equal(t, tt.wantMessage, got.Message)
+		})
+	}
+}
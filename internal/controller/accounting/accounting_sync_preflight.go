@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package accounting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/dbcheck"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/domainname"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+const (
+	// preflightRetryPeriod is how often the database is checked while it
+	// cannot be used by slurmdbd.
+	preflightRetryPeriod = 30 * time.Second
+	// preflightSyncPeriod is how often the database is checked to refresh the
+	// schema version and registered clusters.
+	preflightSyncPeriod = 5 * time.Minute
+)
+
+// preflight is the outcome of the database check of a sync.
+type preflight struct {
+	result *dbcheck.Result
+	err    error
+}
+
+// syncPreflight checks that slurmdbd can use its database with the current
+// storage configuration, before it is rolled out. This catches unreachable
+// databases and bad credentials (e.g. a rotated password which was not applied
+// to the database) while the running slurmdbd still uses the last good
+// configuration.
+func (r *AccountingReconciler) syncPreflight(
+	ctx context.Context,
+	accounting *slinkyv1beta1.Accounting,
+) *preflight {
+	if accounting.Spec.External || !ptr.Deref(accounting.Spec.Preflight.Enabled, true) {
+		return nil
+	}
+
+	storageConfig := accounting.StorageConfig()
+	password, err := r.refResolver.GetSecretKeyRef(ctx, accounting.AuthStorageRef(), accounting.Namespace)
+	if err != nil {
+		return &preflight{err: fmt.Errorf("%w: failed to get password: %w", dbcheck.ErrAccessDenied, err)}
+	}
+
+	config := dbcheck.Config{
+		Host:     preflightHost(storageConfig.Host, accounting.Namespace),
+		Port:     storageConfig.Port,
+		Database: storageConfig.Database,
+		Username: storageConfig.Username,
+		Password: string(password),
+	}
+	result, err := r.dbChecker.Check(ctx, config)
+	return &preflight{result: result, err: err}
+}
+
+// preflightHost returns the database host as resolved from slurmdbd, which
+// runs in the namespace of the Accounting. A short Service name is qualified
+// with that namespace, as the operator runs in another one.
+func preflightHost(host, namespace string) string {
+	if strings.Contains(host, ".") || net.ParseIP(host) != nil {
+		return host
+	}
+	return domainname.FqdnShort(host, namespace)
+}
+
+// calculatePreflightConditions returns the DatabaseReachable and SchemaVersion
+// conditions from the database check.
+func calculatePreflightConditions(preflight *preflight) []metav1.Condition {
+	reachable := metav1.Condition{
+		Type:   slurmconditions.AccountingConditionDatabaseReachable,
+		Status: metav1.ConditionTrue,
+		Reason: "Reachable",
+	}
+	schema := metav1.Condition{
+		Type: slurmconditions.AccountingConditionSchemaVersion,
+	}
+
+	switch err := preflight.err; {
+	case err == nil && preflight.result != nil && len(preflight.result.Warnings) > 0:
+		// The check was not conclusive, hence the rollout is not blocked.
+		reachable.Reason = "PrivilegesUnverified"
+		reachable.Message = strings.Join(preflight.result.Warnings, "; ")
+	case err == nil:
+		reachable.Message = "The database is reachable with sufficient privileges."
+	case errors.Is(err, dbcheck.ErrAccessDenied):
+		reachable.Status = metav1.ConditionFalse
+		reachable.Reason = "AccessDenied"
+		reachable.Message = err.Error()
+	case errors.Is(err, dbcheck.ErrInsufficientPrivileges):
+		reachable.Status = metav1.ConditionFalse
+		reachable.Reason = "InsufficientPrivileges"
+		reachable.Message = err.Error()
+	default:
+		reachable.Status = metav1.ConditionFalse
+		reachable.Reason = "Unreachable"
+		reachable.Message = err.Error()
+	}
+
+	switch {
+	case preflight.err != nil:
+		schema.Status = metav1.ConditionUnknown
+		schema.Reason = "Unknown"
+		schema.Message = "The database is not reachable."
+	case preflight.result == nil || preflight.result.SchemaVersion == nil:
+		schema.Status = metav1.ConditionFalse
+		schema.Reason = "NotInitialized"
+		schema.Message = "slurmdbd has not created its schema yet."
+	default:
+		schema.Status = metav1.ConditionTrue
+		schema.Reason = "Initialized"
+		schema.Message = fmt.Sprintf("Schema version is %d.", *preflight.result.SchemaVersion)
+	}
+
+	return []metav1.Condition{reachable, schema}
+}
+
+// calculateSlurmdbdCondition returns the SlurmdbdReady condition from the
+// slurmdbd StatefulSet.
+func (r *AccountingReconciler) calculateSlurmdbdCondition(
+	ctx context.Context,
+	accounting *slinkyv1beta1.Accounting,
+) metav1.Condition {
+	cond := metav1.Condition{
+		Type:   slurmconditions.AccountingConditionSlurmdbdReady,
+		Status: metav1.ConditionFalse,
+		Reason: "NotReady",
+	}
+
+	statefulset := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, accounting.Key(), statefulset); err != nil {
+		if apierrors.IsNotFound(err) {
+			cond.Message = "slurmdbd has not been deployed."
+		} else {
+			cond.Reason = "Unknown"
+			cond.Status = metav1.ConditionUnknown
+			cond.Message = err.Error()
+		}
+		return cond
+	}
+
+	replicas := ptr.Deref(statefulset.Spec.Replicas, 1)
+	status := statefulset.Status
+	if status.ObservedGeneration >= statefulset.Generation &&
+		status.UpdatedReplicas == replicas &&
+		status.ReadyReplicas == replicas {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "Ready"
+		cond.Message = "slurmdbd is running the current configuration."
+	} else {
+		cond.Message = fmt.Sprintf("%d of %d slurmdbd replicas are updated and ready.",
+			min(status.UpdatedReplicas, status.ReadyReplicas), replicas)
+	}
+	return cond
+}
@@ -0,0 +1,382 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/dbcheck"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func newPreflightAccounting() *slinkyv1beta1.Accounting {
+	accounting := testutils.NewAccounting("slurm",
+		testutils.NewSlurmKeyRef("slurmkey"),
+		testutils.NewJwtKeyRef("jwtkey"),
+		testutils.NewPasswordRef("password"))
+	accounting.Spec.StorageConfig.Host = "mariadb"
+	accounting.Spec.StorageConfig.Username = "slurm"
+	defaults.SetAccountingDefaults(accounting)
+	return accounting
+}
+
+func TestAccountingReconciler_syncPreflight(t *testing.T) {
+	passwordSecret := testutils.NewPasswordSecret(testutils.NewPasswordRef("password"))
+	external := newPreflightAccounting()
+	external.Spec.External = true
+	disabled := newPreflightAccounting()
+	disabled.Spec.Preflight.Enabled = ptr.To(false)
+
+	tests := []struct {
+		name        string
+		client      client.Client
+		accounting  *slinkyv1beta1.Accounting
+		checker     *dbcheck.FakeChecker
+		wantNil     bool
+		wantErr     error
+		wantConfigs []dbcheck.Config
+	}{
+		{
+			name:       "external",
+			client:     fake.NewFakeClient(passwordSecret.DeepCopy()),
+			accounting: external,
+			checker:    dbcheck.NewFakeChecker(&dbcheck.Result{}, nil),
+			wantNil:    true,
+		},
+		{
+			name:       "disabled",
+			client:     fake.NewFakeClient(passwordSecret.DeepCopy()),
+			accounting: disabled,
+			checker:    dbcheck.NewFakeChecker(&dbcheck.Result{}, nil),
+			wantNil:    true,
+		},
+		{
+			name:       "missing password",
+			client:     fake.NewFakeClient(),
+			accounting: newPreflightAccounting(),
+			checker:    dbcheck.NewFakeChecker(&dbcheck.Result{}, nil),
+			wantErr:    dbcheck.ErrAccessDenied,
+		},
+		{
+			name:       "access denied",
+			client:     fake.NewFakeClient(passwordSecret.DeepCopy()),
+			accounting: newPreflightAccounting(),
+			checker:    dbcheck.NewFakeChecker(nil, fmt.Errorf("%w: bad password", dbcheck.ErrAccessDenied)),
+			wantErr:    dbcheck.ErrAccessDenied,
+			wantConfigs: []dbcheck.Config{
+				{Host: "mariadb.default", Port: 3306, Database: "slurm_acct_db", Username: "slurm", Password: "password"},
+			},
+		},
+		{
+			name:       "success",
+			client:     fake.NewFakeClient(passwordSecret.DeepCopy()),
+			accounting: newPreflightAccounting(),
+			checker:    dbcheck.NewFakeChecker(&dbcheck.Result{Clusters: []string{"slurm"}}, nil),
+			wantConfigs: []dbcheck.Config{
+				{Host: "mariadb.default", Port: 3306, Database: "slurm_acct_db", Username: "slurm", Password: "password"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newAccountingController(tt.client)
+			r.dbChecker = tt.checker
+			got := r.syncPreflight(context.TODO(), tt.accounting)
+			if tt.wantNil {
+				require.Nil(t, got)
+				require.Empty(t, tt.checker.Configs)
+				return
+			}
+			require.NotNil(t, got)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, got.err, tt.wantErr)
+			} else {
+				require.NoError(t, got.err)
+				require.Equal(t, tt.checker.Result, got.result)
+			}
+			require.Equal(t, tt.wantConfigs, tt.checker.Configs)
+		})
+	}
+}
+
+func Test_preflightHost(t *testing.T) {
+	tests := []struct {
+		name string
+		host string
+		want string
+	}{
+		{name: "service", host: "mariadb", want: "mariadb.slurm"},
+		{name: "qualified", host: "mariadb.db", want: "mariadb.db"},
+		{name: "fqdn", host: "db.example.com", want: "db.example.com"},
+		{name: "ipv4", host: "10.0.0.1", want: "10.0.0.1"},
+		{name: "ipv6", host: "fd00::1", want: "fd00::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, preflightHost(tt.host, "slurm"))
+		})
+	}
+}
+
+func Test_calculatePreflightConditions(t *testing.T) {
+	tests := []struct {
+		name             string
+		preflight        *preflight
+		wantReachable    metav1.ConditionStatus
+		wantReason       string
+		wantMessage      string
+		wantSchema       metav1.ConditionStatus
+		wantSchemaMsg    string
+		wantSchemaReason string
+	}{
+		{
+			name:             "unreachable",
+			preflight:        &preflight{err: fmt.Errorf("%w: no such host", dbcheck.ErrUnreachable)},
+			wantReachable:    metav1.ConditionFalse,
+			wantReason:       "Unreachable",
+			wantSchema:       metav1.ConditionUnknown,
+			wantSchemaReason: "Unknown",
+			wantSchemaMsg:    "The database is not reachable.",
+		},
+		{
+			name:             "access denied",
+			preflight:        &preflight{err: fmt.Errorf("%w: bad password", dbcheck.ErrAccessDenied)},
+			wantReachable:    metav1.ConditionFalse,
+			wantReason:       "AccessDenied",
+			wantSchema:       metav1.ConditionUnknown,
+			wantSchemaReason: "Unknown",
+			wantSchemaMsg:    "The database is not reachable.",
+		},
+		{
+			name:             "insufficient privileges",
+			preflight:        &preflight{err: fmt.Errorf("%w: missing ALTER", dbcheck.ErrInsufficientPrivileges)},
+			wantReachable:    metav1.ConditionFalse,
+			wantReason:       "InsufficientPrivileges",
+			wantSchema:       metav1.ConditionUnknown,
+			wantSchemaReason: "Unknown",
+			wantSchemaMsg:    "The database is not reachable.",
+		},
+		{
+			name: "privileges unverified",
+			preflight: &preflight{result: &dbcheck.Result{
+				SchemaVersion: ptr.To(15),
+				Warnings:      []string{`user "slurm" is missing ALTER on database "slurm_acct_db", unless granted by a role`},
+			}},
+			wantReachable:    metav1.ConditionTrue,
+			wantReason:       "PrivilegesUnverified",
+			wantMessage:      `user "slurm" is missing ALTER on database "slurm_acct_db", unless granted by a role`,
+			wantSchema:       metav1.ConditionTrue,
+			wantSchemaReason: "Initialized",
+			wantSchemaMsg:    "Schema version is 15.",
+		},
+		{
+			name:             "not initialized",
+			preflight:        &preflight{result: &dbcheck.Result{}},
+			wantReachable:    metav1.ConditionTrue,
+			wantReason:       "Reachable",
+			wantSchema:       metav1.ConditionFalse,
+			wantSchemaReason: "NotInitialized",
+			wantSchemaMsg:    "slurmdbd has not created its schema yet.",
+		},
+		{
+			name:             "initialized",
+			preflight:        &preflight{result: &dbcheck.Result{SchemaVersion: ptr.To(15)}},
+			wantReachable:    metav1.ConditionTrue,
+			wantReason:       "Reachable",
+			wantSchema:       metav1.ConditionTrue,
+			wantSchemaReason: "Initialized",
+			wantSchemaMsg:    "Schema version is 15.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculatePreflightConditions(tt.preflight)
+			reachable := meta.FindStatusCondition(got, slurmconditions.AccountingConditionDatabaseReachable)
+			require.NotNil(t, reachable)
+			require.Equal(t, tt.wantReachable, reachable.Status)
+			require.Equal(t, tt.wantReason, reachable.Reason)
+			if tt.preflight.err != nil {
+				require.Equal(t, tt.preflight.err.Error(), reachable.Message)
+			}
+			if tt.wantMessage != "" {
+				require.Equal(t, tt.wantMessage, reachable.Message)
+			}
+			schema := meta.FindStatusCondition(got, slurmconditions.AccountingConditionSchemaVersion)
+			require.NotNil(t, schema)
+			require.Equal(t, tt.wantSchema, schema.Status)
+			require.Equal(t, tt.wantSchemaReason, schema.Reason)
+			require.Equal(t, tt.wantSchemaMsg, schema.Message)
+		})
+	}
+}
+
+func TestAccountingReconciler_calculateSlurmdbdCondition(t *testing.T) {
+	accounting := newPreflightAccounting()
+	key := accounting.Key()
+	newStatefulSet := func(status appsv1.StatefulSetStatus) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](1)},
+			Status:     status,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		objects     []client.Object
+		wantStatus  metav1.ConditionStatus
+		wantMessage string
+	}{
+		{
+			name:        "not deployed",
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: "slurmdbd has not been deployed.",
+		},
+		{
+			name: "not ready",
+			objects: []client.Object{
+				newStatefulSet(appsv1.StatefulSetStatus{UpdatedReplicas: 1}),
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: "0 of 1 slurmdbd replicas are updated and ready.",
+		},
+		{
+			name: "ready",
+			objects: []client.Object{
+				newStatefulSet(appsv1.StatefulSetStatus{UpdatedReplicas: 1, ReadyReplicas: 1}),
+			},
+			wantStatus:  metav1.ConditionTrue,
+			wantMessage: "slurmdbd is running the current configuration.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(tt.objects...).Build()
+			r := newAccountingController(c)
+			got := r.calculateSlurmdbdCondition(context.TODO(), accounting)
+			require.Equal(t, slurmconditions.AccountingConditionSlurmdbdReady, got.Type)
+			require.Equal(t, tt.wantStatus, got.Status)
+			require.Equal(t, tt.wantMessage, got.Message)
+		})
+	}
+}
+
+func TestAccountingReconciler_Sync_preflight(t *testing.T) {
+	slurmKeyRef := testutils.NewSlurmKeyRef("slurmkey")
+	jwtKeyRef := testutils.NewJwtKeyRef("jwtkey")
+
+	tests := []struct {
+		name           string
+		warnings       []string
+		err            error
+		existingConfig bool
+		disabled       bool
+		wantUpdated    bool
+		wantReachable  *metav1.ConditionStatus
+		wantClusters   []string
+	}{
+		{
+			name:          "reachable",
+			wantUpdated:   true,
+			wantReachable: ptr.To(metav1.ConditionTrue),
+			wantClusters:  []string{"slurm"},
+		},
+		{
+			name:          "privileges unverified",
+			warnings:      []string{`user "slurm" is missing ALTER on database "slurm_acct_db", unless granted by a role`},
+			wantUpdated:   true,
+			wantReachable: ptr.To(metav1.ConditionTrue),
+			wantClusters:  []string{"slurm"},
+		},
+		{
+			name:          "bad password, first rollout",
+			err:           fmt.Errorf("%w: bad password", dbcheck.ErrAccessDenied),
+			wantUpdated:   true,
+			wantReachable: ptr.To(metav1.ConditionFalse),
+		},
+		{
+			name:           "bad password, existing config",
+			err:            fmt.Errorf("%w: bad password", dbcheck.ErrAccessDenied),
+			existingConfig: true,
+			wantReachable:  ptr.To(metav1.ConditionFalse),
+		},
+		{
+			name:           "disabled",
+			err:            fmt.Errorf("%w: bad password", dbcheck.ErrAccessDenied),
+			existingConfig: true,
+			disabled:       true,
+			wantUpdated:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			accounting := newPreflightAccounting()
+			if tt.disabled {
+				accounting.Spec.Preflight.Enabled = ptr.To(false)
+			}
+			objects := []client.Object{
+				accounting.DeepCopy(),
+				testutils.NewSlurmKeySecret(slurmKeyRef),
+				testutils.NewJwtKeySecret(jwtKeyRef),
+				testutils.NewPasswordSecret(testutils.NewPasswordRef("password")),
+			}
+			if tt.existingConfig {
+				objects = append(objects, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      accounting.ConfigKey().Name,
+						Namespace: accounting.ConfigKey().Namespace,
+					},
+					Data: map[string][]byte{"slurmdbd.conf": []byte("# last good")},
+				})
+			}
+			c := fake.NewClientBuilder().WithObjects(objects...).
+				WithStatusSubresource(&slinkyv1beta1.Accounting{}).Build()
+			r := newAccountingController(c)
+			r.dbChecker = dbcheck.NewFakeChecker(&dbcheck.Result{Clusters: []string{"slurm"}, Warnings: tt.warnings}, tt.err)
+
+			err := r.Sync(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(accounting)})
+			require.NoError(t, err)
+
+			// slurmdbd is deployed regardless of the database check, which
+			// only holds back changes to an existing configuration.
+			statefulset := &appsv1.StatefulSet{}
+			require.NoError(t, c.Get(ctx, accounting.Key(), statefulset))
+			config := &corev1.Secret{}
+			require.NoError(t, c.Get(ctx, accounting.ConfigKey(), config))
+			slurmdbdConf := string(config.Data["slurmdbd.conf"])
+			if conf, ok := config.StringData["slurmdbd.conf"]; ok {
+				slurmdbdConf = conf
+			}
+			require.Equal(t, tt.wantUpdated, slurmdbdConf != "# last good", slurmdbdConf)
+
+			got := &slinkyv1beta1.Accounting{}
+			require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(accounting), got))
+			reachable := meta.FindStatusCondition(got.Status.Conditions, slurmconditions.AccountingConditionDatabaseReachable)
+			if tt.wantReachable == nil {
+				require.Nil(t, reachable)
+			} else {
+				require.NotNil(t, reachable)
+				require.Equal(t, *tt.wantReachable, reachable.Status)
+			}
+			require.NotNil(t, meta.FindStatusCondition(got.Status.Conditions, slurmconditions.AccountingConditionSlurmdbdReady))
+			require.Equal(t, tt.wantClusters, got.Status.Clusters)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
@@ -16,20 +17,67 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
 
 // syncStatus handles determining and updating the status.
 func (r *AccountingReconciler) syncStatus(
 	ctx context.Context,
 	accounting *slinkyv1beta1.Accounting,
+	preflight *preflight,
 ) error {
 	logger := log.FromContext(ctx)
 
 	newStatus := slinkyv1beta1.AccountingStatus{
 		Conditions: []metav1.Condition{},
+		Clusters:   accounting.Status.Clusters,
 	}
 	newStatus.Conditions = append(newStatus.Conditions, accounting.Status.Conditions...)
 
+	databasePending := false
+	if !isManagedDatabase(accounting) {
+		meta.RemoveStatusCondition(&newStatus.Conditions, slurmconditions.AccountingConditionDatabaseReady)
+	} else {
+		cond := r.calculateDatabaseCondition(ctx, accounting)
+		meta.SetStatusCondition(&newStatus.Conditions, cond)
+		if cond.Status != metav1.ConditionTrue {
+			databasePending = true
+			durationStore.Push(objectutils.KeyFunc(accounting), databaseSyncPeriod)
+		}
+	}
+
+	if accounting.Spec.External {
+		meta.RemoveStatusCondition(&newStatus.Conditions, slurmconditions.AccountingConditionDatabaseReachable)
+		meta.RemoveStatusCondition(&newStatus.Conditions, slurmconditions.AccountingConditionSchemaVersion)
+		meta.RemoveStatusCondition(&newStatus.Conditions, slurmconditions.AccountingConditionSlurmdbdReady)
+		newStatus.Clusters = nil
+	} else {
+		if preflight == nil {
+			// The database check is disabled.
+			meta.RemoveStatusCondition(&newStatus.Conditions, slurmconditions.AccountingConditionDatabaseReachable)
+			meta.RemoveStatusCondition(&newStatus.Conditions, slurmconditions.AccountingConditionSchemaVersion)
+			newStatus.Clusters = nil
+		} else {
+			for _, cond := range calculatePreflightConditions(preflight) {
+				meta.SetStatusCondition(&newStatus.Conditions, cond)
+			}
+			// The duration store keeps the greatest duration, so the periodic
+			// refresh must not delay the recheck of a pending managed database.
+			if preflight.err != nil {
+				durationStore.Push(objectutils.KeyFunc(accounting), preflightRetryPeriod)
+			} else {
+				if preflight.result != nil {
+					newStatus.Clusters = preflight.result.Clusters
+				}
+				if !databasePending {
+					durationStore.Push(objectutils.KeyFunc(accounting), preflightSyncPeriod)
+				}
+			}
+		}
+		meta.SetStatusCondition(&newStatus.Conditions, r.calculateSlurmdbdCondition(ctx, accounting))
+	}
+
 	if apiequality.Semantic.DeepEqual(accounting.Status, newStatus) {
 		logger.V(2).Info("Accounting Status has not changed, skipping status update",
 			"accounting", klog.KObj(accounting), "status", accounting.Status)
@@ -14,6 +14,7 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	builder "github.com/SlinkyProject/slurm-operator/internal/builder/accountingbuilder"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/dbcheck"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 	"k8s.io/client-go/tools/events"
@@ -28,6 +29,7 @@ func newAccountingController(client client.Client) *AccountingReconciler {
 		builder:       builder.New(client),
 		refResolver:   refresolver.New(client),
 		eventRecorder: events.NewFakeRecorder(10),
+		dbChecker:     dbcheck.NewFakeChecker(&dbcheck.Result{}, nil),
 	}
 
 	return r
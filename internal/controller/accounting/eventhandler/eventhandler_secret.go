@@ -84,8 +84,10 @@ func (e *SecretEventHandler) enqueueRequest(
 	for _, accounting := range accountingList.Items {
 		slurmKeyKey := accounting.AuthSlurmKey()
 		jwtKeyKey := accounting.AuthJwtKey()
+		storageKey := accounting.AuthStorageKey()
 		if !refresolver.IsKeyMatch(secretKey, slurmKeyKey) &&
-			!refresolver.IsKeyMatch(secretKey, jwtKeyKey) {
+			!refresolver.IsKeyMatch(secretKey, jwtKeyKey) &&
+			!refresolver.IsKeyMatch(secretKey, storageKey) {
 			continue
 		}
 		objectutils.EnqueueRequest(q, &accounting)
@@ -80,6 +80,26 @@ func Test_SecretEventHandler_Create(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "storage password",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					slurmKeySecret,
+					jwtKeySecret,
+					controller,
+					passwordSecret,
+					accounting,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: passwordSecret,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -155,6 +175,26 @@ func Test_SecretEventHandler_Delete(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "storage password",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					slurmKeySecret,
+					jwtKeySecret,
+					controller,
+					passwordSecret,
+					accounting,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.DeleteEvent{
+					Object: passwordSecret,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -271,6 +311,27 @@ func Test_SecretEventHandler_Update(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "storage password",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					slurmKeySecret,
+					jwtKeySecret,
+					controller,
+					passwordSecret,
+					accounting,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: passwordSecret,
+					ObjectNew: passwordSecret,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -11,6 +11,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -22,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/dbcheck"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 	//+kubebuilder:scaffold:imports
 )
@@ -38,6 +40,7 @@ var cancel context.CancelFunc
 func init() {
 	utilruntime.Must(scheme.AddToScheme(scheme.Scheme))
 	utilruntime.Must(slinkyv1beta1.AddToScheme(scheme.Scheme))
+	utilruntime.Must(mariadbv1alpha1.AddToScheme(scheme.Scheme))
 }
 
 func TestHandlers(t *testing.T) {
@@ -80,7 +83,9 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).ToNot(HaveOccurred())
 
-	err = NewReconciler(k8sManager.GetClient()).SetupWithManager(k8sManager)
+	reconciler := NewReconciler(k8sManager.GetClient())
+	reconciler.dbChecker = dbcheck.NewFakeChecker(&dbcheck.Result{}, nil)
+	err = reconciler.SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	go func() {
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package autoscaler
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
+)
+
+const (
+	ControllerName = "autoscaler-controller"
+)
+
+// Reasons for Autoscaler events
+const (
+	// SuccessfulRescaleReason is added to an event when the autoscaler changes the NodeSet replicas.
+	SuccessfulRescaleReason = "SuccessfulRescale"
+	// FailedGetDemandReason is added to an event when the Slurm demand of the NodeSet cannot be computed.
+	FailedGetDemandReason = "FailedGetDemand"
+	// FailedRescaleReason is added to an event when the NodeSet replicas cannot be updated.
+	FailedRescaleReason = "FailedRescale"
+)
+
+func init() {
+	flag.IntVar(&maxConcurrentReconciles, "autoscaler-workers", maxConcurrentReconciles, "Max concurrent workers for Autoscaler controller.")
+	flag.DurationVar(&syncPeriod, "autoscaler-sync-period", syncPeriod, "The period between autoscaling evaluations of a NodeSet.")
+}
+
+var (
+	maxConcurrentReconciles = 1
+
+	// syncPeriod is how often the Slurm demand of an autoscaled NodeSet is evaluated.
+	syncPeriod = 30 * time.Second
+
+	// this is a short cut for any sub-functions to notify the reconcile how long to wait to requeue
+	durationStore = durationstore.NewDurationStore(durationstore.Less)
+)
+
+// AutoscalerReconciler scales NodeSet replicas based on Slurm workload demand.
+type AutoscalerReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	ClientMap *clientmap.ClientMap
+
+	slurmControl    slurmcontrol.SlurmControlInterface
+	eventRecorder   events.EventRecorder
+	recommendations *recommendationStore
+}
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *AutoscalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, retErr error) {
+	logger := log.FromContext(ctx)
+
+	logger.V(1).Info("Started autoscaling NodeSet", "request", req)
+
+	startTime := time.Now()
+	defer func() {
+		if retErr == nil {
+			if res.RequeueAfter > 0 {
+				logger.V(1).Info("Finished autoscaling NodeSet", "duration", time.Since(startTime), "result", res)
+			} else {
+				logger.V(1).Info("Finished autoscaling NodeSet", "duration", time.Since(startTime))
+			}
+		} else {
+			logger.Info("Finished autoscaling NodeSet", "duration", time.Since(startTime), "error", retErr)
+		}
+		// clean the duration store
+		_ = durationStore.Pop(req.String())
+	}()
+
+	retErr = r.Sync(ctx, req)
+	res = reconcile.Result{
+		RequeueAfter: durationStore.Pop(req.String()),
+	}
+	return res, retErr
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AutoscalerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.eventRecorder = mgr.GetEventRecorder(ControllerName)
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(ControllerName).
+		For(&slinkyv1beta1.NodeSet{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+		}).
+		Complete(r)
+}
+
+func NewReconciler(c client.Client, cm *clientmap.ClientMap) *AutoscalerReconciler {
+	s := c.Scheme()
+	if cm == nil {
+		panic("ClientMap cannot be nil")
+	}
+	return &AutoscalerReconciler{
+		Client: c,
+		Scheme: s,
+
+		ClientMap: cm,
+
+		slurmControl:    slurmcontrol.NewSlurmControl(cm),
+		eventRecorder:   events.NewFakeRecorder(100),
+		recommendations: newRecommendationStore(),
+	}
+}
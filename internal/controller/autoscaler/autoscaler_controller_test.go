@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package autoscaler
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("Autoscaler Controller", func() {
+	Context("When reconciling an autoscaled NodeSet", func() {
+		var name = testutils.GenerateResourceName(5)
+		var nodeset *slinkyv1beta1.NodeSet
+		var controller *slinkyv1beta1.Controller
+
+		BeforeEach(func() {
+			controller = testutils.NewController(name, corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset = testutils.NewNodeset(name, controller, 0)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MinReplicas = 1
+			nodeset.Spec.Autoscaling.MaxReplicas = 2
+			Expect(k8sClient.Create(ctx, nodeset.DeepCopy())).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, nodeset)
+		})
+
+		It("Should scale replicas to minReplicas", func(ctx SpecContext) {
+			By("Reconciling the NodeSet")
+			r := NewReconciler(k8sClient, clientmap.NewClientMap())
+			nodesetKey := client.ObjectKeyFromObject(nodeset)
+			res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: nodesetKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(Equal(syncPeriod))
+
+			By("Checking NodeSet replicas")
+			Eventually(func(g Gomega) {
+				checkNodeSet := &slinkyv1beta1.NodeSet{}
+				g.Expect(k8sClient.Get(ctx, nodesetKey, checkNodeSet)).To(Succeed())
+				g.Expect(ptr.Deref(checkNodeSet.Spec.Replicas, 0)).To(Equal(int32(1)))
+			}).Should(Succeed())
+		}, SpecTimeout(testutils.Timeout))
+	})
+})
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package autoscaler
+
+import (
+	"sync"
+	"time"
+)
+
+// timestampedRecommendation is a desired replica count at a point in time.
+type timestampedRecommendation struct {
+	recommendation int32
+	timestamp      time.Time
+}
+
+// recommendationStore tracks the recent replica recommendations of each
+// NodeSet, which are used to stabilize scaling decisions.
+type recommendationStore struct {
+	mu              sync.Mutex
+	recommendations map[string][]timestampedRecommendation
+}
+
+// Stabilize records the desired replicas for the key and returns the stabilized
+// replica count, similar to the HorizontalPodAutoscaler behavior.
+//
+// When scaling out, the lowest recommendation within upWindow is used. When
+// scaling in, the highest recommendation within downWindow is used.
+func (s *recommendationStore) Stabilize(key string, current, desired int32, upWindow, downWindow time.Duration, now time.Time) int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upRecommendation := desired
+	downRecommendation := desired
+	upCutoff := now.Add(-upWindow)
+	downCutoff := now.Add(-downWindow)
+	maxCutoff := now.Add(-max(upWindow, downWindow))
+
+	records := []timestampedRecommendation{}
+	for _, rec := range s.recommendations[key] {
+		if rec.timestamp.After(upCutoff) {
+			upRecommendation = min(upRecommendation, rec.recommendation)
+		}
+		if rec.timestamp.After(downCutoff) {
+			downRecommendation = max(downRecommendation, rec.recommendation)
+		}
+		if rec.timestamp.After(maxCutoff) {
+			records = append(records, rec)
+		}
+	}
+	records = append(records, timestampedRecommendation{
+		recommendation: desired,
+		timestamp:      now,
+	})
+	s.recommendations[key] = records
+
+	stabilized := current
+	if stabilized < upRecommendation {
+		stabilized = upRecommendation
+	}
+	if stabilized > downRecommendation {
+		stabilized = downRecommendation
+	}
+	return stabilized
+}
+
+// Delete forgets all recommendations for the key.
+func (s *recommendationStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.recommendations, key)
+}
+
+func newRecommendationStore() *recommendationStore {
+	return &recommendationStore{
+		recommendations: make(map[string][]timestampedRecommendation),
+	}
+}
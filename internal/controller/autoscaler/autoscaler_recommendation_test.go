@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package autoscaler
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_recommendationStore_Stabilize(t *testing.T) {
+	now := time.Now()
+	type record struct {
+		desired int32
+		age     time.Duration
+	}
+	type args struct {
+		current    int32
+		desired    int32
+		upWindow   time.Duration
+		downWindow time.Duration
+	}
+	tests := []struct {
+		name    string
+		history []record
+		args    args
+		want    int32
+	}{
+		{
+			name: "No history",
+			args: args{
+				current: 1,
+				desired: 3,
+			},
+			want: 3,
+		},
+		{
+			name: "Scale out without window",
+			history: []record{
+				{desired: 1, age: time.Minute},
+			},
+			args: args{
+				current:    1,
+				desired:    3,
+				downWindow: 5 * time.Minute,
+			},
+			want: 3,
+		},
+		{
+			name: "Scale out within window",
+			history: []record{
+				{desired: 1, age: time.Minute},
+				{desired: 2, age: 30 * time.Second},
+			},
+			args: args{
+				current:  1,
+				desired:  3,
+				upWindow: 5 * time.Minute,
+			},
+			want: 1,
+		},
+		{
+			name: "Scale in within window",
+			history: []record{
+				{desired: 4, age: time.Minute},
+				{desired: 3, age: 30 * time.Second},
+			},
+			args: args{
+				current:    4,
+				desired:    1,
+				downWindow: 5 * time.Minute,
+			},
+			want: 4,
+		},
+		{
+			name: "Scale in after window",
+			history: []record{
+				{desired: 4, age: 10 * time.Minute},
+				{desired: 2, age: 30 * time.Second},
+			},
+			args: args{
+				current:    4,
+				desired:    1,
+				downWindow: 5 * time.Minute,
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newRecommendationStore()
+			for _, rec := range tt.history {
+				s.recommendations["foo"] = append(s.recommendations["foo"], timestampedRecommendation{
+					recommendation: rec.desired,
+					timestamp:      now.Add(-rec.age),
+				})
+			}
+			got := s.Stabilize("foo", tt.args.current, tt.args.desired, tt.args.upWindow, tt.args.downWindow, now)
+			if got != tt.want {
+				t.Errorf("Stabilize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_recommendationStore_Delete(t *testing.T) {
+	s := newRecommendationStore()
+	now := time.Now()
+	_ = s.Stabilize("foo", 1, 4, 0, time.Hour, now)
+	s.Delete("foo")
+	if got := s.Stabilize("foo", 4, 1, 0, time.Hour, now); got != 1 {
+		t.Errorf("Stabilize() = %v, want %v", got, 1)
+	}
+}
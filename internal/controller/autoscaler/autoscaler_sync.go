@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package autoscaler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/mathutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+)
+
+// Sync implements control logic for autoscaling a NodeSet.
+func (r *AutoscalerReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	nodeset := &slinkyv1beta1.NodeSet{}
+	if err := r.Get(ctx, req.NamespacedName, nodeset); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("NodeSet has been deleted", "request", req)
+			r.recommendations.Delete(req.String())
+			return nil
+		}
+		return err
+	}
+	original := nodeset.DeepCopy()
+	nodeset = nodeset.DeepCopy()
+	defaults.SetNodeSetDefaults(nodeset)
+
+	key := objectutils.KeyFunc(nodeset)
+	if !nodeset.DeletionTimestamp.IsZero() {
+		logger.V(1).Info("NodeSet is being deleted, skipping autoscaling", "request", req)
+		r.recommendations.Delete(key)
+		return nil
+	}
+	if !isAutoscalingEnabled(nodeset) {
+		r.recommendations.Delete(key)
+		return nil
+	}
+	durationStore.Push(key, syncPeriod)
+
+	pods, err := r.getNodeSetPods(ctx, nodeset)
+	if err != nil {
+		return err
+	}
+
+	demand, err := r.slurmControl.GetNodeSetDemand(ctx, nodeset, pods)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to get Slurm demand: %v", err)
+		r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeWarning, FailedGetDemandReason, "Autoscale", msg)
+		return err
+	}
+
+	now := time.Now()
+	current := ptr.Deref(nodeset.Spec.Replicas, 0)
+	desired := computeDesiredReplicas(nodeset, demand, now)
+	autoscaling := nodeset.Spec.Autoscaling
+	replicas := r.recommendations.Stabilize(key, current, desired,
+		autoscaling.ScaleUpStabilizationWindow.Duration,
+		autoscaling.ScaleDownStabilizationWindow.Duration,
+		now)
+	logger.V(1).Info("Computed NodeSet replicas",
+		"current", current, "desired", desired, "replicas", replicas,
+		"pendingNodes", demand.PendingNodes, "registered", demand.Registered, "idle", demand.Idle)
+	if replicas == current {
+		return nil
+	}
+
+	if err := r.scaleNodeSet(ctx, original, replicas); err != nil {
+		msg := fmt.Sprintf("Failed to rescale NodeSet from %d to %d: %v", current, replicas, err)
+		r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeWarning, FailedRescaleReason, "Autoscale", msg)
+		return err
+	}
+	msg := fmt.Sprintf("New size: %d; pending nodes: %d, idle nodes: %d", replicas, demand.PendingNodes, demand.Idle)
+	r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, SuccessfulRescaleReason, "Autoscale", msg)
+
+	return nil
+}
+
+// isAutoscalingEnabled returns true if the NodeSet replicas are managed by the autoscaler.
+func isAutoscalingEnabled(nodeset *slinkyv1beta1.NodeSet) bool {
+	return nodeset.Spec.Autoscaling.Enabled &&
+		nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeStatefulset
+}
+
+// computeDesiredReplicas returns the replica count which satisfies the Slurm
+// demand of the NodeSet partition, bounded by minReplicas and maxReplicas.
+//
+// Pending jobs which cannot be satisfied by idle or not yet registered nodes
+// cause a scale-out. When no jobs are pending, the replicas are reduced by the
+// number of nodes which have been idle for at least the idleTimeout. Idle nodes
+// whose last busy time is unknown are not counted, as they may have just
+// registered. Which pods are deleted is left to the NodeSet controller, which
+// prefers pods whose Slurm nodes have no running jobs.
+func computeDesiredReplicas(nodeset *slinkyv1beta1.NodeSet, demand slurmcontrol.SlurmNodeSetDemand, now time.Time) int32 {
+	autoscaling := nodeset.Spec.Autoscaling
+	replicas := ptr.Deref(nodeset.Spec.Replicas, 0)
+
+	desired := replicas
+	available := demand.Idle + max(replicas-demand.Registered, 0)
+	switch {
+	case demand.PendingNodes > available:
+		desired = replicas + (demand.PendingNodes - available)
+	case demand.PendingNodes == 0:
+		idleTimeout := ptr.Deref(autoscaling.IdleTimeout, defaults.DefaultNodeSetAutoscalingIdleTimeout).Duration
+		var expired int32
+		for _, idleSince := range demand.IdleSince {
+			if !idleSince.IsZero() && now.Sub(idleSince) >= idleTimeout {
+				expired++
+			}
+		}
+		desired = replicas - expired
+	}
+
+	return mathutils.Clamp(desired, autoscaling.MinReplicas, max(autoscaling.MinReplicas, autoscaling.MaxReplicas))
+}
+
+// scaleNodeSet patches the NodeSet replicas.
+func (r *AutoscalerReconciler) scaleNodeSet(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, replicas int32) error {
+	logger := log.FromContext(ctx)
+
+	logger.Info("Autoscaling NodeSet", "replicas", ptr.Deref(nodeset.Spec.Replicas, 0), "newReplicas", replicas)
+
+	mutateFn := func(nodeset *slinkyv1beta1.NodeSet) error {
+		nodeset.Spec.Replicas = ptr.To(replicas)
+		return nil
+	}
+
+	return objectutils.PatchObject(r.Client, ctx, nodeset, mutateFn)
+}
+
+// getNodeSetPods returns the active pods which are owned by the NodeSet.
+func (r *AutoscalerReconciler) getNodeSetPods(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) ([]*corev1.Pod, error) {
+	selectorLabels := labels.NewBuilder().WithWorkerSelectorLabels(nodeset).Build()
+	opts := &client.ListOptions{
+		Namespace:     nodeset.GetNamespace(),
+		LabelSelector: k8slabels.SelectorFromSet(k8slabels.Set(selectorLabels)),
+	}
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, opts); err != nil {
+		return nil, err
+	}
+
+	pods := make([]*corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if podutils.IsTerminating(pod) || !nodesetutils.IsPodFromNodeSet(nodeset, pod) {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
@@ -0,0 +1,287 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package autoscaler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmfake "github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newAutoscalerController(client client.Client, clientMap *clientmap.ClientMap) *AutoscalerReconciler {
+	return &AutoscalerReconciler{
+		Client:          client,
+		Scheme:          client.Scheme(),
+		ClientMap:       clientMap,
+		slurmControl:    slurmcontrol.NewSlurmControl(clientMap),
+		eventRecorder:   events.NewFakeRecorder(10),
+		recommendations: newRecommendationStore(),
+	}
+}
+
+func newClientMap(controllerName string, client slurmclient.Client) *clientmap.ClientMap {
+	cm := clientmap.NewClientMap()
+	key := types.NamespacedName{
+		Namespace: corev1.NamespaceDefault,
+		Name:      controllerName,
+	}
+	cm.Add(key, client)
+	return cm
+}
+
+func newAutoscaledNodeSet(name string, controller *slinkyv1beta1.Controller, replicas, minReplicas, maxReplicas int32) *slinkyv1beta1.NodeSet {
+	nodeset := testutils.NewNodeset(name, controller, replicas)
+	nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeStatefulset
+	nodeset.Spec.Partition.Enabled = true
+	nodeset.Spec.Autoscaling = slinkyv1beta1.NodeSetAutoscaling{
+		Enabled:                      true,
+		MinReplicas:                  minReplicas,
+		MaxReplicas:                  maxReplicas,
+		ScaleUpStabilizationWindow:   &metav1.Duration{},
+		ScaleDownStabilizationWindow: &metav1.Duration{},
+		IdleTimeout:                  &metav1.Duration{Duration: 5 * time.Minute},
+	}
+	return nodeset
+}
+
+func Test_computeDesiredReplicas(t *testing.T) {
+	now := time.Now()
+	type args struct {
+		nodeset *slinkyv1beta1.NodeSet
+		demand  slurmcontrol.SlurmNodeSetDemand
+	}
+	tests := []struct {
+		name string
+		args args
+		want int32
+	}{
+		{
+			name: "No demand",
+			args: args{
+				nodeset: newAutoscaledNodeSet("foo", nil, 0, 0, 4),
+			},
+			want: 0,
+		},
+		{
+			name: "Below minReplicas",
+			args: args{
+				nodeset: newAutoscaledNodeSet("foo", nil, 0, 2, 4),
+			},
+			want: 2,
+		},
+		{
+			name: "Pending jobs scale out",
+			args: args{
+				nodeset: newAutoscaledNodeSet("foo", nil, 1, 0, 4),
+				demand: slurmcontrol.SlurmNodeSetDemand{
+					PendingNodes: 3,
+					Registered:   1,
+				},
+			},
+			want: 4,
+		},
+		{
+			name: "Pending jobs limited by maxReplicas",
+			args: args{
+				nodeset: newAutoscaledNodeSet("foo", nil, 1, 0, 4),
+				demand: slurmcontrol.SlurmNodeSetDemand{
+					PendingNodes: 10,
+					Registered:   1,
+				},
+			},
+			want: 4,
+		},
+		{
+			name: "Pending jobs satisfied by idle and starting nodes",
+			args: args{
+				nodeset: newAutoscaledNodeSet("foo", nil, 3, 0, 4),
+				demand: slurmcontrol.SlurmNodeSetDemand{
+					PendingNodes: 2,
+					Registered:   2,
+					Idle:         1,
+					IdleSince: map[string]time.Time{
+						"foo-0": now.Add(-time.Hour),
+					},
+				},
+			},
+			want: 3,
+		},
+		{
+			name: "Idle nodes past idleTimeout scale in",
+			args: args{
+				nodeset: newAutoscaledNodeSet("foo", nil, 3, 0, 4),
+				demand: slurmcontrol.SlurmNodeSetDemand{
+					Registered: 3,
+					Idle:       2,
+					IdleSince: map[string]time.Time{
+						"foo-0": now.Add(-time.Hour),
+						"foo-1": now.Add(-time.Minute),
+					},
+				},
+			},
+			want: 2,
+		},
+		{
+			name: "Idle nodes with unknown last busy time do not scale in",
+			args: args{
+				nodeset: newAutoscaledNodeSet("foo", nil, 3, 0, 4),
+				demand: slurmcontrol.SlurmNodeSetDemand{
+					Registered: 3,
+					Idle:       2,
+					IdleSince: map[string]time.Time{
+						"foo-0": now.Add(-time.Hour),
+						"foo-1": {},
+					},
+				},
+			},
+			want: 2,
+		},
+		{
+			name: "Idle nodes limited by minReplicas",
+			args: args{
+				nodeset: newAutoscaledNodeSet("foo", nil, 2, 2, 4),
+				demand: slurmcontrol.SlurmNodeSetDemand{
+					Registered: 2,
+					Idle:       2,
+					IdleSince: map[string]time.Time{
+						"foo-0": now.Add(-time.Hour),
+						"foo-1": now.Add(-time.Hour),
+					},
+				},
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computeDesiredReplicas(tt.args.nodeset, tt.args.demand, now); got != tt.want {
+				t.Errorf("computeDesiredReplicas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutoscalerReconciler_Sync(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	type fields struct {
+		nodeset     *slinkyv1beta1.NodeSet
+		slurmClient slurmclient.Client
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		wantReplicas int32
+		wantErr      bool
+	}{
+		{
+			name: "Autoscaling disabled",
+			fields: func() fields {
+				nodeset := newAutoscaledNodeSet("foo", controller, 0, 1, 4)
+				nodeset.Spec.Autoscaling.Enabled = false
+				return fields{
+					nodeset:     nodeset,
+					slurmClient: slurmfake.NewFakeClient(),
+				}
+			}(),
+			wantReplicas: 0,
+		},
+		{
+			name: "Scale out for pending jobs",
+			fields: func() fields {
+				nodeset := newAutoscaledNodeSet("foo", controller, 0, 0, 4)
+				jobList := &slurmtypes.V0044JobInfoList{
+					Items: []slurmtypes.V0044JobInfo{
+						{
+							V0044JobInfo: slurmapi.V0044JobInfo{
+								JobId:       ptr.To[int32](1),
+								JobState:    ptr.To([]slurmapi.V0044JobInfoJobState{slurmapi.V0044JobInfoJobStatePENDING}),
+								StateReason: ptr.To("Resources"),
+								Partition:   ptr.To("foo"),
+								NodeCount:   ptr.To(slurmapi.V0044Uint32NoValStruct{Number: ptr.To[int32](2)}),
+							},
+						},
+					},
+				}
+				return fields{
+					nodeset:     nodeset,
+					slurmClient: slurmfake.NewClientBuilder().WithLists(jobList, &slurmtypes.V0044NodeList{}).Build(),
+				}
+			}(),
+			wantReplicas: 2,
+		},
+		{
+			name: "Scale in idle nodes",
+			fields: func() fields {
+				nodeset := newAutoscaledNodeSet("foo", controller, 1, 0, 4)
+				pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
+				nodeList := &slurmtypes.V0044NodeList{
+					Items: []slurmtypes.V0044Node{
+						{
+							V0044Node: slurmapi.V0044Node{
+								Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+								State: ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE}),
+								LastBusy: ptr.To(slurmapi.V0044Uint64NoValStruct{
+									Number: ptr.To(time.Now().Add(-time.Hour).Unix()),
+								}),
+							},
+						},
+					},
+				}
+				return fields{
+					nodeset:     nodeset,
+					slurmClient: slurmfake.NewClientBuilder().WithLists(&slurmtypes.V0044JobInfoList{}, nodeList).Build(),
+				}
+			}(),
+			wantReplicas: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			objs := []client.Object{tt.fields.nodeset}
+			if tt.wantReplicas < ptr.Deref(tt.fields.nodeset.Spec.Replicas, 0) {
+				nodeset := tt.fields.nodeset.DeepCopy()
+				defaults.SetNodeSetDefaults(nodeset)
+				pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
+				objs = append(objs, pod)
+			}
+			c := fake.NewClientBuilder().WithObjects(objs...).Build()
+			r := newAutoscalerController(c, newClientMap(controller.Name, tt.fields.slurmClient))
+			req := reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(tt.fields.nodeset),
+			}
+			if err := r.Sync(ctx, req); (err != nil) != tt.wantErr {
+				t.Errorf("AutoscalerReconciler.Sync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			checkNodeSet := &slinkyv1beta1.NodeSet{}
+			if err := c.Get(ctx, req.NamespacedName, checkNodeSet); err != nil {
+				t.Fatalf("failed to get NodeSet: %v", err)
+			}
+			if got := ptr.Deref(checkNodeSet.Spec.Replicas, 0); got != tt.wantReplicas {
+				t.Errorf("replicas = %v, want %v", got, tt.wantReplicas)
+			}
+		})
+	}
+}
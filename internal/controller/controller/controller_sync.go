@@ -11,13 +11,17 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
 	"github.com/SlinkyProject/slurm-operator/internal/defaults"
 	"github.com/SlinkyProject/slurm-operator/internal/syncsteps"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
@@ -54,12 +58,30 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 				if err != nil {
 					return fmt.Errorf("failed to build: %w", err)
 				}
-				if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, controller, object, false); err != nil {
+				if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, controller, object, true); err != nil {
 					return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
 				}
 				return nil
 			},
 		},
+		{
+			Name: "SlurmctldServices",
+			SyncFn: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
+				if controller.Spec.External {
+					return nil
+				}
+				objects, err := r.builder.BuildControllerSlurmctldServices(controller)
+				if err != nil {
+					return fmt.Errorf("failed to build: %w", err)
+				}
+				for _, object := range objects {
+					if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, controller, object, true); err != nil {
+						return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+					}
+				}
+				return r.pruneSlurmctldServices(ctx, controller, objects)
+			},
+		},
 		{
 			Name: "Config",
 			SyncFn: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
@@ -79,6 +101,25 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 				return nil
 			},
 		},
+		{
+			Name: "StateSave",
+			SyncFn: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
+				if controller.Spec.External {
+					return nil
+				}
+				object, err := r.builder.BuildControllerStateSave(controller)
+				if err != nil {
+					return fmt.Errorf("failed to build: %w", err)
+				}
+				if object == nil {
+					return nil
+				}
+				if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, controller, object, true); err != nil {
+					return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+				}
+				return nil
+			},
+		},
 		{
 			Name: "StatefulSet",
 			SyncFn: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
@@ -95,6 +136,12 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 				return nil
 			},
 		},
+		{
+			Name: "Backup",
+			SyncFn: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
+				return r.syncBackup(ctx, controller)
+			},
+		},
 		{
 			Name: "ServiceMonitor",
 			SyncFn: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
@@ -155,6 +202,39 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 	return r.syncStatus(ctx, controller)
 }
 
+// pruneSlurmctldServices deletes the slurmctld Services of the Controller that
+// are no longer wanted, after the replicas were scaled down.
+func (r *ControllerReconciler) pruneSlurmctldServices(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	wanted []*corev1.Service,
+) error {
+	serviceList := &corev1.ServiceList{}
+	opts := []client.ListOption{
+		client.InNamespace(controller.Namespace),
+		client.MatchingLabels(labels.NewBuilder().WithControllerLabels(controller).Build()),
+	}
+	if err := r.List(ctx, serviceList, opts...); err != nil {
+		return err
+	}
+
+	keep := set.New(controller.ServiceKey().Name)
+	for _, service := range wanted {
+		keep.Insert(service.Name)
+	}
+	for i := range serviceList.Items {
+		service := &serviceList.Items[i]
+		if keep.Has(service.Name) || !metav1.IsControlledBy(service, controller) {
+			continue
+		}
+		if err := objectutils.DeleteObject(r.Client, ctx, r.eventRecorder, controller, service); err != nil {
+			return fmt.Errorf("failed to delete object (%s): %w", klog.KObj(service), err)
+		}
+	}
+
+	return nil
+}
+
 func isServiceMonitorUnavailable(err error) bool {
 	if meta.IsNoMatchError(err) {
 		return true
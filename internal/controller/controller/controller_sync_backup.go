@@ -0,0 +1,330 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	builder "github.com/SlinkyProject/slurm-operator/internal/builder/controllerbuilder"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+const (
+	// backupSyncPeriod is how often a VolumeSnapshot is checked until it is
+	// ready, as it is not watched.
+	backupSyncPeriod = 30 * time.Second
+)
+
+// backup is a backup of the slurmctld save-state, which is either a
+// VolumeSnapshot or a Job archiving the save-state.
+type backup struct {
+	// Object is the VolumeSnapshot or Job.
+	Object client.Object
+	// Name is the name of the VolumeSnapshot, or the path of the archive
+	// within the backup claim.
+	Name          string
+	ScheduledTime time.Time
+	// Finished is true once the backup has either succeeded or failed.
+	Finished       bool
+	Succeeded      bool
+	CompletionTime time.Time
+	Message        string
+}
+
+// syncBackup takes the scheduled backups of the slurmctld save-state, one at a
+// time, and deletes the ones beyond retention.
+func (r *ControllerReconciler) syncBackup(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+) error {
+	logger := log.FromContext(ctx)
+
+	spec := controller.Spec.Backup
+	if spec == nil || controller.Spec.External {
+		return nil
+	}
+
+	schedule, err := cron.ParseStandard(spec.Schedule)
+	if err != nil {
+		return fmt.Errorf("failed to parse backup schedule (%s): %w", spec.Schedule, err)
+	}
+
+	backups, err := r.listBackups(ctx, controller)
+	if err != nil {
+		return err
+	}
+
+	retention := ptr.Deref(spec.Retention, defaults.DefaultControllerBackupRetention)
+	for _, b := range backupsToPrune(backups, int(retention)) {
+		if err := objectutils.DeleteObject(r.Client, ctx, r.eventRecorder, controller, b.Object); err != nil {
+			return fmt.Errorf("failed to delete object (%s): %w", klog.KObj(b.Object), err)
+		}
+	}
+
+	now := time.Now()
+	last := controller.CreationTimestamp.Time
+	inProgress := false
+	for _, b := range backups {
+		if b.ScheduledTime.After(last) {
+			last = b.ScheduledTime
+		}
+		if !b.Finished {
+			inProgress = true
+		}
+	}
+	if inProgress && spec.VolumeSnapshot != nil {
+		durationStore.Push(objectutils.KeyFunc(controller), backupSyncPeriod)
+	}
+
+	scheduled, next := nextBackupTime(schedule, last, now)
+	// A schedule that never fires again (e.g. February 30th) has no next time.
+	if !next.IsZero() {
+		durationStore.Push(objectutils.KeyFunc(controller), next.Sub(now))
+	}
+	switch {
+	case scheduled.IsZero():
+		return nil
+	case inProgress:
+		// The missed schedule is taken once the backup in progress finishes.
+		logger.V(1).Info("Backup in progress, delaying the next one",
+			"controller", klog.KObj(controller), "scheduled", scheduled)
+		return nil
+	}
+
+	var object client.Object
+	if spec.VolumeSnapshot != nil {
+		object, err = r.builder.BuildControllerBackupSnapshot(controller, scheduled)
+	} else {
+		object, err = r.builder.BuildControllerBackupJob(controller, scheduled)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to build: %w", err)
+	}
+	if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, controller, object, false); err != nil {
+		return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
+	}
+	if spec.VolumeSnapshot != nil {
+		durationStore.Push(objectutils.KeyFunc(controller), backupSyncPeriod)
+	}
+
+	return nil
+}
+
+// nextBackupTime returns the latest schedule time after the last backup that
+// is due, which is zero if none is, and the next schedule time, which is zero
+// if the schedule never fires again.
+func nextBackupTime(schedule cron.Schedule, last, now time.Time) (time.Time, time.Time) {
+	var scheduled time.Time
+	next := schedule.Next(last)
+	for !next.IsZero() && !next.After(now) {
+		scheduled = next
+		next = schedule.Next(next)
+	}
+	return scheduled, next
+}
+
+// backupsToPrune returns the successful backups beyond retention, and the
+// failed backups older than the latest finished one.
+func backupsToPrune(backups []backup, retention int) []backup {
+	var out []backup
+	succeeded := 0
+	finished := 0
+	for _, b := range slices.Backward(backups) {
+		if !b.Finished {
+			continue
+		}
+		finished++
+		switch {
+		case b.Succeeded:
+			succeeded++
+			if succeeded > retention {
+				out = append(out, b)
+			}
+		case finished > 1:
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// listBackups returns the backups of the Controller in the backup destination,
+// ordered by schedule time.
+func (r *ControllerReconciler) listBackups(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+) ([]backup, error) {
+	spec := controller.Spec.Backup
+	if spec == nil {
+		return nil, nil
+	}
+
+	opts := []client.ListOption{
+		client.InNamespace(controller.Namespace),
+		client.MatchingLabels(labels.NewBuilder().WithControllerBackupLabels(controller).Build()),
+	}
+
+	var backups []backup
+	if spec.VolumeSnapshot != nil {
+		snapshotList := &unstructured.UnstructuredList{}
+		snapshotList.SetGroupVersionKind(builder.VolumeSnapshotGVK.GroupVersion().WithKind(builder.VolumeSnapshotGVK.Kind + "List"))
+		if err := r.List(ctx, snapshotList, opts...); err != nil {
+			if meta.IsNoMatchError(err) {
+				return nil, fmt.Errorf("the VolumeSnapshot API is not available, is the CSI snapshot controller installed? %w", err)
+			}
+			return nil, err
+		}
+		for i := range snapshotList.Items {
+			backups = append(backups, backupFromSnapshot(&snapshotList.Items[i]))
+		}
+	} else {
+		jobList := &batchv1.JobList{}
+		if err := r.List(ctx, jobList, opts...); err != nil {
+			return nil, err
+		}
+		for i := range jobList.Items {
+			job := &jobList.Items[i]
+			if !metav1.IsControlledBy(job, controller) {
+				continue
+			}
+			backups = append(backups, backupFromJob(controller, job))
+		}
+	}
+
+	slices.SortFunc(backups, func(a, b backup) int {
+		return a.ScheduledTime.Compare(b.ScheduledTime)
+	})
+	return backups, nil
+}
+
+func backupFromSnapshot(snapshot *unstructured.Unstructured) backup {
+	out := backup{
+		Object:        snapshot,
+		Name:          snapshot.GetName(),
+		ScheduledTime: backupScheduledTime(snapshot),
+	}
+
+	readyToUse, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	errorMessage, hasError, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message")
+	switch {
+	case readyToUse:
+		out.Finished = true
+		out.Succeeded = true
+		out.CompletionTime = snapshot.GetCreationTimestamp().Time
+		if creationTime, ok, _ := unstructured.NestedString(snapshot.Object, "status", "creationTime"); ok {
+			if t, err := time.Parse(time.RFC3339, creationTime); err == nil {
+				out.CompletionTime = t
+			}
+		}
+	case hasError:
+		out.Finished = true
+		out.Message = errorMessage
+	}
+	return out
+}
+
+func backupFromJob(controller *slinkyv1beta1.Controller, job *batchv1.Job) backup {
+	out := backup{
+		Object:        job,
+		Name:          path.Join(controller.Name, job.Name+".tar.gz"),
+		ScheduledTime: backupScheduledTime(job),
+	}
+
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			out.Finished = true
+			out.Succeeded = true
+			out.CompletionTime = cond.LastTransitionTime.Time
+			if job.Status.CompletionTime != nil {
+				out.CompletionTime = job.Status.CompletionTime.Time
+			}
+		case batchv1.JobFailed:
+			out.Finished = true
+			out.Message = cond.Message
+		}
+	}
+	return out
+}
+
+func backupScheduledTime(obj client.Object) time.Time {
+	if value, ok := obj.GetAnnotations()[common.AnnotationBackupScheduledTime]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t
+		}
+	}
+	return obj.GetCreationTimestamp().Time
+}
+
+// calculateBackupStatus returns the backup status and condition given the
+// backups, ordered by schedule time.
+func calculateBackupStatus(
+	controller *slinkyv1beta1.Controller,
+	backups []backup,
+) (*slinkyv1beta1.ControllerBackupStatus, metav1.Condition) {
+	status := &slinkyv1beta1.ControllerBackupStatus{}
+	cond := metav1.Condition{
+		Type:               slurmconditions.ControllerConditionBackupSucceeded,
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: controller.Generation,
+		Reason:             "NoBackup",
+		Message:            "No backup has finished yet.",
+	}
+	if old := controller.Status.Backup; old != nil {
+		// Keep the last backups across retention.
+		status.LastScheduleTime = old.LastScheduleTime
+		status.LastSuccessfulTime = old.LastSuccessfulTime
+		status.LastSuccessfulBackup = old.LastSuccessfulBackup
+	}
+
+	var latestFinished *backup
+	for i := range backups {
+		b := &backups[i]
+		status.LastScheduleTime = ptr.To(metav1.NewTime(b.ScheduledTime))
+		if !b.Finished {
+			continue
+		}
+		latestFinished = b
+		if b.Succeeded && (status.LastSuccessfulTime == nil || !b.CompletionTime.Before(status.LastSuccessfulTime.Time)) {
+			status.LastSuccessfulTime = ptr.To(metav1.NewTime(b.CompletionTime))
+			status.LastSuccessfulBackup = b.Name
+		}
+	}
+
+	switch {
+	case latestFinished == nil:
+	case latestFinished.Succeeded:
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "Succeeded"
+		cond.Message = fmt.Sprintf("The latest backup (%s) succeeded.", latestFinished.Name)
+	default:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "Failed"
+		cond.Message = fmt.Sprintf("The latest backup (%s) failed: %s", latestFinished.Name, latestFinished.Message)
+	}
+
+	return status, cond
+}
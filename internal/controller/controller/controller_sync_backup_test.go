@@ -0,0 +1,329 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	builder "github.com/SlinkyProject/slurm-operator/internal/builder/controllerbuilder"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func Test_nextBackupTime(t *testing.T) {
+	schedule, err := cron.ParseStandard("0 * * * *")
+	require.NoError(t, err)
+	never, err := cron.ParseStandard("0 0 30 2 *")
+	require.NoError(t, err)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 1, 2, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name          string
+		schedule      cron.Schedule
+		last          time.Time
+		now           time.Time
+		wantScheduled time.Time
+		wantNext      time.Time
+	}{
+		{
+			name:     "not due",
+			schedule: schedule,
+			last:     at(3, 0),
+			now:      at(3, 30),
+			wantNext: at(4, 0),
+		},
+		{
+			name:          "due",
+			schedule:      schedule,
+			last:          at(3, 0),
+			now:           at(4, 0),
+			wantScheduled: at(4, 0),
+			wantNext:      at(5, 0),
+		},
+		{
+			name:          "missed",
+			schedule:      schedule,
+			last:          at(1, 0),
+			now:           at(4, 30),
+			wantScheduled: at(4, 0),
+			wantNext:      at(5, 0),
+		},
+		{
+			name:     "never",
+			schedule: never,
+			last:     at(3, 0),
+			now:      at(4, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotScheduled, gotNext := nextBackupTime(tt.schedule, tt.last, tt.now)
+			require.Equal(t, tt.wantScheduled, gotScheduled)
+			require.Equal(t, tt.wantNext, gotNext)
+		})
+	}
+}
+
+func Test_backupsToPrune(t *testing.T) {
+	succeeded := func(name string) backup {
+		return backup{Name: name, Finished: true, Succeeded: true}
+	}
+	failed := func(name string) backup {
+		return backup{Name: name, Finished: true}
+	}
+	running := func(name string) backup {
+		return backup{Name: name}
+	}
+
+	tests := []struct {
+		name      string
+		backups   []backup
+		retention int
+		want      []string
+	}{
+		{
+			name:      "empty",
+			retention: 2,
+		},
+		{
+			name:      "within retention",
+			backups:   []backup{succeeded("a"), succeeded("b"), running("c")},
+			retention: 2,
+		},
+		{
+			name:      "beyond retention",
+			backups:   []backup{succeeded("a"), succeeded("b"), succeeded("c"), running("d")},
+			retention: 2,
+			want:      []string{"a"},
+		},
+		{
+			name:      "failed",
+			backups:   []backup{failed("a"), succeeded("b"), failed("c"), failed("d")},
+			retention: 2,
+			want:      []string{"c", "a"},
+		},
+		{
+			name:      "failed does not count",
+			backups:   []backup{succeeded("a"), succeeded("b"), failed("c")},
+			retention: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, b := range backupsToPrune(tt.backups, tt.retention) {
+				got = append(got, b.Name)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_calculateBackupStatus(t *testing.T) {
+	scheduled := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	completed := scheduled.Add(time.Minute)
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "slurm",
+			Generation: 1,
+		},
+	}
+	withStatus := func(status *slinkyv1beta1.ControllerBackupStatus) *slinkyv1beta1.Controller {
+		out := controller.DeepCopy()
+		out.Status.Backup = status
+		return out
+	}
+
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		backups    []backup
+		want       *slinkyv1beta1.ControllerBackupStatus
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "no backup",
+			controller: controller,
+			want:       &slinkyv1beta1.ControllerBackupStatus{},
+			wantStatus: metav1.ConditionUnknown,
+			wantReason: "NoBackup",
+		},
+		{
+			name:       "in progress",
+			controller: controller,
+			backups: []backup{
+				{Name: "a", ScheduledTime: scheduled},
+			},
+			want: &slinkyv1beta1.ControllerBackupStatus{
+				LastScheduleTime: ptr.To(metav1.NewTime(scheduled)),
+			},
+			wantStatus: metav1.ConditionUnknown,
+			wantReason: "NoBackup",
+		},
+		{
+			name:       "succeeded",
+			controller: controller,
+			backups: []backup{
+				{Name: "a", ScheduledTime: scheduled, Finished: true, Succeeded: true, CompletionTime: completed},
+			},
+			want: &slinkyv1beta1.ControllerBackupStatus{
+				LastScheduleTime:     ptr.To(metav1.NewTime(scheduled)),
+				LastSuccessfulTime:   ptr.To(metav1.NewTime(completed)),
+				LastSuccessfulBackup: "a",
+			},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "Succeeded",
+		},
+		{
+			name: "failed after pruned success",
+			controller: withStatus(&slinkyv1beta1.ControllerBackupStatus{
+				LastSuccessfulTime:   ptr.To(metav1.NewTime(completed)),
+				LastSuccessfulBackup: "a",
+			}),
+			backups: []backup{
+				{Name: "b", ScheduledTime: scheduled.Add(time.Hour), Finished: true, Message: "error"},
+			},
+			want: &slinkyv1beta1.ControllerBackupStatus{
+				LastScheduleTime:     ptr.To(metav1.NewTime(scheduled.Add(time.Hour))),
+				LastSuccessfulTime:   ptr.To(metav1.NewTime(completed)),
+				LastSuccessfulBackup: "a",
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "Failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotCond := calculateBackupStatus(tt.controller, tt.backups)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, slurmconditions.ControllerConditionBackupSucceeded, gotCond.Type)
+			require.Equal(t, tt.wantStatus, gotCond.Status)
+			require.Equal(t, tt.wantReason, gotCond.Reason)
+		})
+	}
+}
+
+func TestControllerReconciler_syncBackup(t *testing.T) {
+	created := metav1.NewTime(time.Now().Add(-90 * time.Minute))
+	newController := func(backup *slinkyv1beta1.ControllerBackup) *slinkyv1beta1.Controller {
+		return &slinkyv1beta1.Controller{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: slinkyv1beta1.GroupVersion.String(),
+				Kind:       slinkyv1beta1.ControllerKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         corev1.NamespaceDefault,
+				Name:              "slurm",
+				UID:               "uid",
+				CreationTimestamp: created,
+			},
+			Spec: slinkyv1beta1.ControllerSpec{
+				Backup: backup,
+			},
+		}
+	}
+	snapshotBackup := &slinkyv1beta1.ControllerBackup{
+		Schedule:       "0 * * * *",
+		Retention:      ptr.To[int32](1),
+		VolumeSnapshot: &slinkyv1beta1.ControllerBackupVolumeSnapshot{},
+	}
+	claimBackup := &slinkyv1beta1.ControllerBackup{
+		Schedule:  "0 * * * *",
+		Retention: ptr.To[int32](1),
+		PersistentVolumeClaim: &slinkyv1beta1.ControllerBackupVolume{
+			ClaimName: "backup",
+		},
+	}
+	newJob := func(controller *slinkyv1beta1.Controller, scheduled time.Time, cond batchv1.JobConditionType) *batchv1.Job {
+		job, err := builder.New(fake.NewFakeClient()).BuildControllerBackupJob(controller, scheduled)
+		require.NoError(t, err)
+		if cond != "" {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: cond, Status: corev1.ConditionTrue}}
+		}
+		return job
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		objects    func(controller *slinkyv1beta1.Controller) []client.Object
+		wantJobs   int
+		wantSnaps  int
+	}{
+		{
+			name:       "no backup",
+			controller: newController(nil),
+		},
+		{
+			name:       "snapshot due",
+			controller: newController(snapshotBackup),
+			wantSnaps:  1,
+		},
+		{
+			name:       "job due",
+			controller: newController(claimBackup),
+			wantJobs:   1,
+		},
+		{
+			name:       "job in progress",
+			controller: newController(claimBackup),
+			objects: func(controller *slinkyv1beta1.Controller) []client.Object {
+				return []client.Object{
+					newJob(controller, created.Add(time.Minute), ""),
+				}
+			},
+			wantJobs: 1,
+		},
+		{
+			name:       "job beyond retention",
+			controller: newController(claimBackup),
+			objects: func(controller *slinkyv1beta1.Controller) []client.Object {
+				return []client.Object{
+					newJob(controller, now.Add(-3*time.Hour), batchv1.JobComplete),
+					newJob(controller, now.Add(-2*time.Hour), batchv1.JobComplete),
+				}
+			},
+			wantJobs: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objects []client.Object
+			if tt.objects != nil {
+				objects = tt.objects(tt.controller)
+			}
+			c := fake.NewClientBuilder().WithObjects(objects...).Build()
+			r := newControllerController(c, nil)
+			err := r.syncBackup(context.TODO(), tt.controller)
+			require.NoError(t, err)
+			_ = durationStore.Pop(objectutils.KeyFunc(tt.controller))
+
+			jobList := &batchv1.JobList{}
+			require.NoError(t, c.List(context.TODO(), jobList))
+			require.Len(t, jobList.Items, tt.wantJobs)
+
+			snapshotList := &unstructured.UnstructuredList{}
+			snapshotList.SetGroupVersionKind(builder.VolumeSnapshotGVK.GroupVersion().WithKind("VolumeSnapshotList"))
+			require.NoError(t, c.List(context.TODO(), snapshotList))
+			require.Len(t, snapshotList.Items, tt.wantSnaps)
+		})
+	}
+}
@@ -6,18 +6,51 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+const (
+	// statusSyncPeriod is how often the status is refreshed until the
+	// Controller is Ready.
+	statusSyncPeriod = 10 * time.Second
 )
 
+// slurmctldInfo is what slurmctld reports through ping and diag.
+type slurmctldInfo struct {
+	// Responding is true when a slurmctld responds to ping.
+	Responding bool
+	// Active is the host of the slurmctld in control, which is the primary
+	// when it is up, otherwise the first backup that is up.
+	Active string
+	// StartTime is when slurmctld started collecting statistics. It is reset
+	// whenever slurmctld (re)starts, which includes a reconfigure.
+	StartTime *metav1.Time
+	// Reason and Message describe why slurmctld is not responding.
+	Reason  string
+	Message string
+}
+
 // syncStatus handles determining and updating the status.
 func (r *ControllerReconciler) syncStatus(
 	ctx context.Context,
@@ -25,10 +58,40 @@ func (r *ControllerReconciler) syncStatus(
 ) error {
 	logger := log.FromContext(ctx)
 
-	newStatus := slinkyv1beta1.ControllerStatus{
-		Conditions: []metav1.Condition{},
+	configHash, err := r.getConfigHash(ctx, controller)
+	if err != nil {
+		return err
+	}
+
+	var statefulset *appsv1.StatefulSet
+	if !controller.Spec.External {
+		statefulset = &appsv1.StatefulSet{}
+		if err := r.Get(ctx, controller.Key(), statefulset); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			statefulset = nil
+		}
+	}
+
+	info := r.getSlurmctldInfo(ctx, controller)
+
+	newStatus := calculateStatus(controller, configHash, statefulset, info, metav1.Now())
+
+	if controller.Spec.Backup == nil || controller.Spec.External {
+		newStatus.Backup = nil
+		meta.RemoveStatusCondition(&newStatus.Conditions, slurmconditions.ControllerConditionBackupSucceeded)
+	} else if backups, err := r.listBackups(ctx, controller); err != nil {
+		logger.Error(err, "failed to list backups", "controller", klog.KObj(controller))
+	} else {
+		backupStatus, backupCond := calculateBackupStatus(controller, backups)
+		newStatus.Backup = backupStatus
+		meta.SetStatusCondition(&newStatus.Conditions, backupCond)
+	}
+
+	if !meta.IsStatusConditionTrue(newStatus.Conditions, slurmconditions.ControllerConditionReady) {
+		durationStore.Push(objectutils.KeyFunc(controller), statusSyncPeriod)
 	}
-	newStatus.Conditions = append(newStatus.Conditions, controller.Status.Conditions...)
 
 	if apiequality.Semantic.DeepEqual(controller.Status, newStatus) {
 		logger.V(2).Info("Controller Status has not changed, skipping status update",
@@ -44,6 +107,235 @@ func (r *ControllerReconciler) syncStatus(
 	return nil
 }
 
+// getConfigHash returns the hash of the rendered Slurm configuration, which is
+// empty when it has not been rendered yet.
+func (r *ControllerReconciler) getConfigHash(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+) (string, error) {
+	config := &corev1.ConfigMap{}
+	if err := r.Get(ctx, controller.ConfigKey(), config); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return crypto.CheckSumFromMap(config.Data), nil
+}
+
+// getSlurmctldInfo pings slurmctld and queries its diagnostics through the
+// slurm client of the Controller.
+func (r *ControllerReconciler) getSlurmctldInfo(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+) slurmctldInfo {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.ClientMap.Get(objectutils.NamespacedName(controller))
+	if slurmClient == nil {
+		return slurmctldInfo{
+			Reason:  "ClientUnavailable",
+			Message: "There is no Slurm client for this Controller, is a RestApi deployed?",
+		}
+	}
+
+	pingList := &slurmtypes.V0044ControllerPingList{}
+	if err := slurmClient.List(ctx, pingList); err != nil {
+		return slurmctldInfo{
+			Reason:  "PingFailed",
+			Message: err.Error(),
+		}
+	}
+	info := slurmctldInfo{
+		Reason:  "NotResponding",
+		Message: "No slurmctld responds to ping.",
+	}
+	for _, ping := range pingList.Items {
+		if !isPingUp(ping) {
+			continue
+		}
+		if !info.Responding || isPingPrimary(ping) {
+			info = slurmctldInfo{
+				Responding: true,
+				Active:     ptr.Deref(ping.Hostname, ""),
+			}
+		}
+	}
+	if !info.Responding {
+		return info
+	}
+
+	statsList := &slurmtypes.V0044StatsList{}
+	if err := slurmClient.List(ctx, statsList); err != nil {
+		logger.Error(err, "failed to get slurmctld diagnostics",
+			"controller", klog.KObj(controller))
+		return info
+	}
+	for _, stats := range statsList.Items {
+		if start := ptr.Deref(stats.ReqTimeStart, 0); start > 0 {
+			info.StartTime = ptr.To(metav1.NewTime(time.Unix(start, 0)))
+			break
+		}
+	}
+
+	return info
+}
+
+// isPingUp returns true if the slurmctld responds to ping.
+func isPingUp(ping slurmtypes.V0044ControllerPing) bool {
+	return ping.Responding || strings.EqualFold(ptr.Deref(ping.Pinged, ""), "UP")
+}
+
+// isPingPrimary returns true if the slurmctld is the primary, which takes
+// control whenever it is up.
+func isPingPrimary(ping slurmtypes.V0044ControllerPing) bool {
+	return ping.Primary || ptr.Deref(ping.Mode, "") == "primary"
+}
+
+// calculateStatus returns the Controller status given the hash of the rendered
+// Slurm configuration, the slurmctld StatefulSet (nil when external or not
+// found), and what slurmctld reports.
+func calculateStatus(
+	controller *slinkyv1beta1.Controller,
+	configHash string,
+	statefulset *appsv1.StatefulSet,
+	info slurmctldInfo,
+	now metav1.Time,
+) slinkyv1beta1.ControllerStatus {
+	oldStatus := controller.Status
+	newStatus := slinkyv1beta1.ControllerStatus{
+		ActiveController:    info.Active,
+		ConfigHash:          configHash,
+		AppliedConfigHash:   oldStatus.AppliedConfigHash,
+		LastReconfigureTime: oldStatus.LastReconfigureTime,
+		Backup:              oldStatus.Backup,
+		Conditions:          []metav1.Condition{},
+	}
+	newStatus.Conditions = append(newStatus.Conditions, oldStatus.Conditions...)
+
+	reachable := metav1.Condition{
+		Type:               slurmconditions.ControllerConditionSlurmctldReachable,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: controller.Generation,
+		Reason:             info.Reason,
+		Message:            info.Message,
+	}
+	if info.Responding {
+		reachable.Status = metav1.ConditionTrue
+		reachable.Reason = "Responding"
+		reachable.Message = "slurmctld responds to ping."
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, reachable)
+
+	applied := metav1.Condition{
+		Type:               slurmconditions.ControllerConditionConfigApplied,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: controller.Generation,
+	}
+	rolledOut := isRolledOut(statefulset)
+	switch {
+	case controller.Spec.External:
+		newStatus.AppliedConfigHash = ""
+		newStatus.LastReconfigureTime = nil
+		applied.Status = metav1.ConditionUnknown
+		applied.Reason = "External"
+		applied.Message = "The configuration of an external slurmctld is not managed."
+	case configHash == "":
+		applied.Reason = "NotRendered"
+		applied.Message = "The Slurm configuration has not been rendered yet."
+	default:
+		// A new configuration restarts the pending period, so that a
+		// slurmctld (re)start before it is not mistaken for its reconfigure.
+		if oldStatus.ConfigHash != configHash {
+			meta.RemoveStatusCondition(&newStatus.Conditions, applied.Type)
+		}
+		pendingSince := now
+		if cond := meta.FindStatusCondition(newStatus.Conditions, applied.Type); cond != nil && cond.Status == metav1.ConditionFalse {
+			pendingSince = cond.LastTransitionTime
+		}
+		if newStatus.AppliedConfigHash != configHash && info.Responding && rolledOut &&
+			isConfigLoaded(controller, configHash, statefulset, info, pendingSince) {
+			newStatus.AppliedConfigHash = configHash
+			newStatus.LastReconfigureTime = ptr.To(now)
+			if info.StartTime != nil {
+				newStatus.LastReconfigureTime = info.StartTime
+			}
+		}
+		if newStatus.AppliedConfigHash == configHash {
+			applied.Status = metav1.ConditionTrue
+			applied.Reason = "Applied"
+			applied.Message = "slurmctld has loaded the rendered configuration."
+		} else {
+			applied.Reason = "Pending"
+			applied.Message = fmt.Sprintf("Waiting for slurmctld to load the rendered configuration (%s).", configHash)
+		}
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, applied)
+
+	ready := metav1.Condition{
+		Type:               slurmconditions.ControllerConditionReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: controller.Generation,
+		Reason:             "NotReady",
+	}
+	var notReady []string
+	if !controller.Spec.External && !rolledOut {
+		notReady = append(notReady, "slurmctld is rolled out")
+	}
+	if reachable.Status != metav1.ConditionTrue {
+		notReady = append(notReady, "slurmctld is reachable")
+	}
+	if !controller.Spec.External && applied.Status != metav1.ConditionTrue {
+		notReady = append(notReady, "the configuration is applied")
+	}
+	if len(notReady) == 0 {
+		ready.Status = metav1.ConditionTrue
+		ready.Reason = "Ready"
+		ready.Message = "slurmctld is running the rendered configuration."
+	} else {
+		ready.Message = fmt.Sprintf("Waiting until %s.", strings.Join(notReady, ", "))
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, ready)
+
+	return newStatus
+}
+
+// isConfigLoaded returns true when slurmctld has loaded the configuration with
+// the given hash. Without in-place reconfigure, the slurmctld pod is recreated
+// on configuration change and its template carries the configuration hash.
+// Otherwise the reconfigure sidecar restarts slurmctld, which is observed as
+// slurmctld having started after the configuration became pending. The first
+// observed configuration is the one the pods were started with.
+func isConfigLoaded(
+	controller *slinkyv1beta1.Controller,
+	configHash string,
+	statefulset *appsv1.StatefulSet,
+	info slurmctldInfo,
+	pendingSince metav1.Time,
+) bool {
+	if !controller.Spec.InplaceReconfigure {
+		return statefulset.Spec.Template.Annotations[common.AnnotationSlurmConfigHash] == configHash
+	}
+	if controller.Status.ConfigHash == "" {
+		return true
+	}
+	return info.StartTime != nil && !info.StartTime.Time.Before(pendingSince.Truncate(time.Second))
+}
+
+// isRolledOut returns true when all slurmctld pods run the current template and
+// are ready.
+func isRolledOut(statefulset *appsv1.StatefulSet) bool {
+	if statefulset == nil {
+		return false
+	}
+	replicas := ptr.Deref(statefulset.Spec.Replicas, 1)
+	status := statefulset.Status
+	return status.ObservedGeneration >= statefulset.Generation &&
+		status.UpdateRevision == status.CurrentRevision &&
+		status.UpdatedReplicas == replicas &&
+		status.ReadyReplicas == replicas
+}
+
 func (r *ControllerReconciler) updateStatus(
 	ctx context.Context,
 	controller *slinkyv1beta1.Controller,
@@ -0,0 +1,343 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	clientfake "github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func Test_calculateStatus(t *testing.T) {
+	now := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	before := metav1.NewTime(now.Add(-time.Hour))
+	after := metav1.NewTime(now.Add(time.Minute))
+
+	newController := func(inplace, external bool, status slinkyv1beta1.ControllerStatus) *slinkyv1beta1.Controller {
+		return &slinkyv1beta1.Controller{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  corev1.NamespaceDefault,
+				Name:       "slurm",
+				Generation: 1,
+			},
+			Spec: slinkyv1beta1.ControllerSpec{
+				InplaceReconfigure: inplace,
+				External:           external,
+			},
+			Status: status,
+		}
+	}
+	newStatefulSet := func(configHash string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Generation: 1,
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To[int32](1),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							common.AnnotationSlurmConfigHash: configHash,
+						},
+					},
+				},
+			},
+			Status: appsv1.StatefulSetStatus{
+				ObservedGeneration: 1,
+				CurrentRevision:    "a",
+				UpdateRevision:     "a",
+				UpdatedReplicas:    1,
+				ReadyReplicas:      1,
+			},
+		}
+	}
+	pending := func(hash string, since metav1.Time) slinkyv1beta1.ControllerStatus {
+		return slinkyv1beta1.ControllerStatus{
+			ConfigHash:        "new",
+			AppliedConfigHash: hash,
+			Conditions: []metav1.Condition{
+				{
+					Type:               slurmconditions.ControllerConditionConfigApplied,
+					Status:             metav1.ConditionFalse,
+					Reason:             "Pending",
+					LastTransitionTime: since,
+				},
+			},
+		}
+	}
+	responding := slurmctldInfo{Responding: true, StartTime: &after}
+
+	type args struct {
+		controller  *slinkyv1beta1.Controller
+		configHash  string
+		statefulset *appsv1.StatefulSet
+		info        slurmctldInfo
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		wantAppliedConfigHash   string
+		wantLastReconfigureTime *metav1.Time
+		wantReachable           metav1.ConditionStatus
+		wantApplied             metav1.ConditionStatus
+		wantAppliedReason       string
+		wantReady               metav1.ConditionStatus
+	}{
+		{
+			name: "not rendered",
+			args: args{
+				controller: newController(false, false, slinkyv1beta1.ControllerStatus{}),
+				info:       slurmctldInfo{Reason: "ClientUnavailable"},
+			},
+			wantReachable:     metav1.ConditionFalse,
+			wantApplied:       metav1.ConditionFalse,
+			wantAppliedReason: "NotRendered",
+			wantReady:         metav1.ConditionFalse,
+		},
+		{
+			name: "rolled out",
+			args: args{
+				controller:  newController(false, false, slinkyv1beta1.ControllerStatus{}),
+				configHash:  "new",
+				statefulset: newStatefulSet("new"),
+				info:        responding,
+			},
+			wantAppliedConfigHash:   "new",
+			wantLastReconfigureTime: &after,
+			wantReachable:           metav1.ConditionTrue,
+			wantApplied:             metav1.ConditionTrue,
+			wantAppliedReason:       "Applied",
+			wantReady:               metav1.ConditionTrue,
+		},
+		{
+			name: "rolling out",
+			args: args{
+				controller:  newController(false, false, slinkyv1beta1.ControllerStatus{ConfigHash: "old", AppliedConfigHash: "old"}),
+				configHash:  "new",
+				statefulset: newStatefulSet("old"),
+				info:        responding,
+			},
+			wantAppliedConfigHash: "old",
+			wantReachable:         metav1.ConditionTrue,
+			wantApplied:           metav1.ConditionFalse,
+			wantAppliedReason:     "Pending",
+			wantReady:             metav1.ConditionFalse,
+		},
+		{
+			name: "not reachable",
+			args: args{
+				controller:  newController(false, false, slinkyv1beta1.ControllerStatus{}),
+				configHash:  "new",
+				statefulset: newStatefulSet("new"),
+				info:        slurmctldInfo{Reason: "NotResponding"},
+			},
+			wantReachable:     metav1.ConditionFalse,
+			wantApplied:       metav1.ConditionFalse,
+			wantAppliedReason: "Pending",
+			wantReady:         metav1.ConditionFalse,
+		},
+		{
+			name: "inplace, first observation",
+			args: args{
+				controller:  newController(true, false, slinkyv1beta1.ControllerStatus{}),
+				configHash:  "new",
+				statefulset: newStatefulSet(""),
+				info:        slurmctldInfo{Responding: true, StartTime: &before},
+			},
+			wantAppliedConfigHash:   "new",
+			wantLastReconfigureTime: &before,
+			wantReachable:           metav1.ConditionTrue,
+			wantApplied:             metav1.ConditionTrue,
+			wantAppliedReason:       "Applied",
+			wantReady:               metav1.ConditionTrue,
+		},
+		{
+			name: "inplace, config changed",
+			args: args{
+				controller:  newController(true, false, slinkyv1beta1.ControllerStatus{ConfigHash: "old", AppliedConfigHash: "old"}),
+				configHash:  "new",
+				statefulset: newStatefulSet(""),
+				info:        slurmctldInfo{Responding: true, StartTime: &before},
+			},
+			wantAppliedConfigHash: "old",
+			wantReachable:         metav1.ConditionTrue,
+			wantApplied:           metav1.ConditionFalse,
+			wantAppliedReason:     "Pending",
+			wantReady:             metav1.ConditionFalse,
+		},
+		{
+			name: "inplace, not reconfigured",
+			args: args{
+				controller:  newController(true, false, pending("old", now)),
+				configHash:  "new",
+				statefulset: newStatefulSet(""),
+				info:        slurmctldInfo{Responding: true, StartTime: &before},
+			},
+			wantAppliedConfigHash: "old",
+			wantReachable:         metav1.ConditionTrue,
+			wantApplied:           metav1.ConditionFalse,
+			wantAppliedReason:     "Pending",
+			wantReady:             metav1.ConditionFalse,
+		},
+		{
+			name: "inplace, reconfigured",
+			args: args{
+				controller:  newController(true, false, pending("old", now)),
+				configHash:  "new",
+				statefulset: newStatefulSet(""),
+				info:        responding,
+			},
+			wantAppliedConfigHash:   "new",
+			wantLastReconfigureTime: &after,
+			wantReachable:           metav1.ConditionTrue,
+			wantApplied:             metav1.ConditionTrue,
+			wantAppliedReason:       "Applied",
+			wantReady:               metav1.ConditionTrue,
+		},
+		{
+			name: "external",
+			args: args{
+				controller: newController(false, true, slinkyv1beta1.ControllerStatus{}),
+				configHash: "new",
+				info:       responding,
+			},
+			wantReachable:     metav1.ConditionTrue,
+			wantApplied:       metav1.ConditionUnknown,
+			wantAppliedReason: "External",
+			wantReady:         metav1.ConditionTrue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateStatus(tt.args.controller, tt.args.configHash, tt.args.statefulset, tt.args.info, now)
+			require.Equal(t, tt.args.configHash, got.ConfigHash)
+			require.Equal(t, tt.wantAppliedConfigHash, got.AppliedConfigHash)
+			require.Equal(t, tt.wantLastReconfigureTime, got.LastReconfigureTime)
+
+			reachable := meta.FindStatusCondition(got.Conditions, slurmconditions.ControllerConditionSlurmctldReachable)
+			require.NotNil(t, reachable)
+			require.Equal(t, tt.wantReachable, reachable.Status)
+
+			applied := meta.FindStatusCondition(got.Conditions, slurmconditions.ControllerConditionConfigApplied)
+			require.NotNil(t, applied)
+			require.Equal(t, tt.wantApplied, applied.Status)
+			require.Equal(t, tt.wantAppliedReason, applied.Reason)
+
+			ready := meta.FindStatusCondition(got.Conditions, slurmconditions.ControllerConditionReady)
+			require.NotNil(t, ready)
+			require.Equal(t, tt.wantReady, ready.Status)
+		})
+	}
+}
+
+func TestControllerReconciler_getSlurmctldInfo(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		clientMap *clientmap.ClientMap
+		want      slurmctldInfo
+	}{
+		{
+			name:      "no client",
+			clientMap: clientmap.NewClientMap(),
+			want: slurmctldInfo{
+				Reason:  "ClientUnavailable",
+				Message: "There is no Slurm client for this Controller, is a RestApi deployed?",
+			},
+		},
+		{
+			name: "not responding",
+			clientMap: newClientMap(controller.Name, clientfake.NewClientBuilder().
+				WithLists(&slurmtypes.V0044ControllerPingList{
+					Items: []slurmtypes.V0044ControllerPing{
+						{V0044ControllerPing: slurmapi.V0044ControllerPing{Hostname: ptr.To("slurm-controller-0")}},
+					},
+				}).
+				Build()),
+			want: slurmctldInfo{
+				Reason:  "NotResponding",
+				Message: "No slurmctld responds to ping.",
+			},
+		},
+		{
+			name: "responding",
+			clientMap: newClientMap(controller.Name, clientfake.NewClientBuilder().
+				WithLists(&slurmtypes.V0044ControllerPingList{
+					Items: []slurmtypes.V0044ControllerPing{
+						{V0044ControllerPing: slurmapi.V0044ControllerPing{Hostname: ptr.To("slurm-controller-0"), Responding: true}},
+					},
+				}).
+				WithObjects(&slurmtypes.V0044Stats{
+					V0044StatsMsg: slurmapi.V0044StatsMsg{ReqTimeStart: ptr.To(start.Unix())},
+				}).
+				Build()),
+			want: slurmctldInfo{
+				Responding: true,
+				Active:     "slurm-controller-0",
+				StartTime:  ptr.To(metav1.NewTime(start.Local())),
+			},
+		},
+		{
+			name: "backup responding first",
+			clientMap: newClientMap(controller.Name, clientfake.NewClientBuilder().
+				WithLists(&slurmtypes.V0044ControllerPingList{
+					Items: []slurmtypes.V0044ControllerPing{
+						{V0044ControllerPing: slurmapi.V0044ControllerPing{Hostname: ptr.To("slurm-controller-1"), Mode: ptr.To("backup"), Pinged: ptr.To("UP"), Responding: true}},
+						{V0044ControllerPing: slurmapi.V0044ControllerPing{Hostname: ptr.To("slurm-controller-0"), Mode: ptr.To("primary"), Pinged: ptr.To("UP"), Primary: true, Responding: true}},
+					},
+				}).
+				Build()),
+			want: slurmctldInfo{
+				Responding: true,
+				Active:     "slurm-controller-0",
+			},
+		},
+		{
+			name: "primary down",
+			clientMap: newClientMap(controller.Name, clientfake.NewClientBuilder().
+				WithLists(&slurmtypes.V0044ControllerPingList{
+					Items: []slurmtypes.V0044ControllerPing{
+						{V0044ControllerPing: slurmapi.V0044ControllerPing{Hostname: ptr.To("slurm-controller-0"), Mode: ptr.To("primary"), Pinged: ptr.To("DOWN"), Primary: true}},
+						{V0044ControllerPing: slurmapi.V0044ControllerPing{Hostname: ptr.To("slurm-controller-1"), Mode: ptr.To("backup"), Pinged: ptr.To("UP"), Responding: true}},
+					},
+				}).
+				Build()),
+			want: slurmctldInfo{
+				Responding: true,
+				Active:     "slurm-controller-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newControllerController(fake.NewFakeClient(), tt.clientMap)
+			got := r.getSlurmctldInfo(context.TODO(), controller)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
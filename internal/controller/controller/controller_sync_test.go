@@ -73,7 +73,10 @@ func TestControllerReconciler_sync(t *testing.T) {
 		{
 			name: "default",
 			fields: fields{
-				Client: fake.NewFakeClient(controller.DeepCopy()),
+				Client: fake.NewClientBuilder().
+					WithObjects(controller.DeepCopy()).
+					WithStatusSubresource(&slinkyv1beta1.Controller{}).
+					Build(),
 				ClientMap: func() *clientmap.ClientMap {
 					sclient := clientfake.NewClientBuilder().WithInterceptorFuncs(sinterceptor.Funcs{}).Build()
 					return newClientMap(controller.Name, sclient)
@@ -38,6 +38,9 @@ const (
 
 	// BackoffGCInterval is the time that has to pass before next iteration of backoff GC is run
 	BackoffGCInterval = 1 * time.Minute
+
+	// powerSaveSyncPeriod is the time between polling Slurm node power states, when power saving is enabled.
+	powerSaveSyncPeriod = 10 * time.Second
 )
 
 // Reasons for NodeSet events
@@ -64,6 +67,8 @@ const (
 	DefunctSlurmNodePrunedReason = "DefunctSlurmNodePruned"
 	// RollingUpdateReason is added to an event when pods are being replaced during a rolling update.
 	RollingUpdateReason = "RollingUpdate"
+	// DrainTimeoutReason is added to an event when a pod pending termination did not drain in time.
+	DrainTimeoutReason = "DrainTimeout"
 	// ControllerRefFailedReason is added to an event when the referenced Controller CR cannot be fetched.
 	ControllerRefFailedReason = "ControllerRefFailed"
 )
@@ -113,6 +118,7 @@ type NodeSetReconciler struct {
 // +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=resource.k8s.io,resources=resourceclaims,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -49,6 +49,9 @@ func newFakeClientList(interceptorFuncs interceptor.Funcs, initObjLists ...objec
 			o.Comment = r.Comment
 			o.Reason = r.Reason
 			o.Topology = r.TopologyStr
+			if r.Features != nil {
+				o.Features = r.Features
+			}
 		default:
 			return errors.New("failed to cast slurm object")
 		}
@@ -11,6 +11,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/kubernetes/pkg/controller/history"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
@@ -121,7 +122,7 @@ func (r *NodeSetReconciler) getNodeSetRevisions(
 
 	// attempt to find the revision that corresponds to the current revision
 	for i := range revisions {
-		if revisions[i].Name == nodeset.Status.NodeSetHash {
+		if revisions[i].Name == nodeset.Status.CurrentRevision {
 			currentRevision = revisions[i]
 			break
 		}
@@ -217,3 +218,22 @@ func getPatch(nodeset *slinkyv1beta1.NodeSet) ([]byte, error) {
 	patch, err := json.Marshal(objCopy)
 	return patch, err
 }
+
+// applyRevision returns a new NodeSet constructed by restoring the state in revision to nodeset. If the returned error
+// is nil, the returned NodeSet is valid.
+func applyRevision(nodeset *slinkyv1beta1.NodeSet, revision *appsv1.ControllerRevision) (*slinkyv1beta1.NodeSet, error) {
+	clone := nodeset.DeepCopy()
+	original, err := json.Marshal(clone)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, revision.Data.Raw, clone)
+	if err != nil {
+		return nil, err
+	}
+	restoredNodeSet := &slinkyv1beta1.NodeSet{}
+	if err := json.Unmarshal(patched, restoredNodeSet); err != nil {
+		return nil, err
+	}
+	return restoredNodeSet, nil
+}
@@ -229,7 +229,7 @@ func TestNodeSetReconciler_getNodeSetRevisions(t *testing.T) {
 					}(),
 				},
 			}
-			nodeset.Status.NodeSetHash = revisionList.Items[1].Name
+			nodeset.Status.CurrentRevision = revisionList.Items[1].Name
 
 			return testCaseFields{
 				name: "nodeset hash does match",
@@ -13,6 +13,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
@@ -31,7 +32,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
 	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
 	"github.com/SlinkyProject/slurm-operator/internal/defaults"
 	"github.com/SlinkyProject/slurm-operator/internal/syncsteps"
@@ -372,6 +375,12 @@ func (r *NodeSetReconciler) sync(
 				return r.syncSshConfig(ctx, nodeset)
 			},
 		},
+		{
+			Name: "NodeConfig",
+			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+				return r.syncNodeConfig(ctx, nodeset)
+			},
+		},
 		{
 			Name: "RefreshNodeCache",
 			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
@@ -417,6 +426,12 @@ func (r *NodeSetReconciler) sync(
 				return r.syncSlurmTopology(ctx, nodeset, pods)
 			},
 		},
+		{
+			Name: "SlurmFeatures",
+			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+				return r.syncSlurmFeatures(ctx, nodeset, pods)
+			},
+		},
 		{
 			Name: "SlurmReservation",
 			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
@@ -761,7 +776,7 @@ func (r *NodeSetReconciler) syncSlurmTopology(
 			return err
 		}
 
-		topologySpec := node.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec]
+		topologySpec := common.GetSlurmNodeTopologySpec(nodeset, node)
 		mutateFn := func(pod *corev1.Pod) error {
 			pod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec] = topologySpec
 			return nil
@@ -785,6 +800,102 @@ func (r *NodeSetReconciler) syncSlurmTopology(
 	return nil
 }
 
+// syncSlurmFeatures handles the Slurm Node's features mapped from Kubernetes node labels
+// and allocated resource claims.
+func (r *NodeSetReconciler) syncSlurmFeatures(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	hasClaimFeatures := slices.ContainsFunc(nodeset.Spec.ResourceClaimTemplates, func(template slinkyv1beta1.NodeSetResourceClaimTemplate) bool {
+		return len(template.Features) > 0
+	})
+	if len(nodeset.Spec.FeatureLabels) == 0 && !hasClaimFeatures {
+		return nil
+	}
+
+	nodeFeatures, err := r.slurmControl.GetNodeFeatures(ctx, nodeset, pods)
+	if err != nil {
+		return fmt.Errorf("failed to get Slurm node features: %w", err)
+	}
+
+	syncSlurmFeaturesFn := func(i int) error {
+		pod := pods[i]
+
+		if pod.Spec.NodeName == "" {
+			// Skip if Pod has not been allocated to a Node.
+			return nil
+		}
+		currentFeatures, ok := nodeFeatures[nodesetutils.GetSlurmNodeName(pod)]
+		if !ok {
+			// Skip if the Slurm node has not registered yet.
+			return nil
+		}
+
+		node := &corev1.Node{}
+		nodeKey := types.NamespacedName{Name: pod.Spec.NodeName}
+		if err := r.Get(ctx, nodeKey, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		features := common.GetSlurmNodeFeatures(nodeset, node.Labels)
+		if hasClaimFeatures {
+			claimFeatures, err := r.getResourceClaimFeatures(ctx, nodeset, pod)
+			if err != nil {
+				return err
+			}
+			features = set.New(features...).Insert(claimFeatures...).SortedList()
+		}
+		if set.New(currentFeatures...).Equal(set.New(features...)) {
+			return nil
+		}
+		if err := r.slurmControl.UpdateNodeFeatures(ctx, nodeset, pod, features); err != nil {
+			return fmt.Errorf("failed to update Slurm node features: %w", err)
+		}
+
+		return nil
+	}
+	if _, err := utils.SlowStartBatch(len(pods), utils.SlowStartInitialBatchSize, syncSlurmFeaturesFn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getResourceClaimFeatures returns the features of the pod's allocated resource claims.
+func (r *NodeSetReconciler) getResourceClaimFeatures(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pod *corev1.Pod,
+) ([]string, error) {
+	claims := nodesetutils.GetResourceClaims(nodeset, pod)
+	features := []string{}
+	for _, template := range nodeset.Spec.ResourceClaimTemplates {
+		if len(template.Features) == 0 {
+			continue
+		}
+		claim := &resourcev1.ResourceClaim{}
+		claimKey := types.NamespacedName{
+			Namespace: nodeset.Namespace,
+			Name:      claims[template.Name].Name,
+		}
+		if err := r.Get(ctx, claimKey, claim); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if claim.Status.Allocation == nil {
+			continue
+		}
+		features = append(features, template.Features...)
+	}
+	return features, nil
+}
+
 // EnqueueNodeSetAfter schedules a reconcile of the NodeSet after the given delay.
 // It uses the shared durationStore so that the next Reconcile result will have RequeueAfter set.
 func (r *NodeSetReconciler) EnqueueNodeSetAfter(nodeset *slinkyv1beta1.NodeSet, after time.Duration) {
@@ -996,13 +1107,28 @@ func (r *NodeSetReconciler) syncNodeSetPods(
 			}
 			return r.doPodScale(ctx, nodeset, podsNewScaling, podsToDelete, podsToCreate)
 		}
+	} else if nodeset.Spec.PowerSave.Enabled {
+		logger.V(2).Info("Processing NodeSet pods in StatefulSet mode with power saving")
+		if scaled, err := r.syncPowerSavePods(ctx, nodeset, podsNewScaling, hash); scaled || err != nil {
+			return err
+		}
 	} else {
 		logger.V(2).Info("Processing NodeSet pods in StatefulSet mode")
 
 		// Handle replica scaling by comparing the known pods to the target number of replicas.
 		// Create or delete pods as needed to reach the target number.
+		// Surge pods are above the replicas, and are managed by the rolling update.
+		// They are left over when surge is no longer allowed (e.g. maxSurge was
+		// lowered to 0 during the update).
+		surgePods, replicaPods := splitSurgePods(podsNewScaling)
+		if len(surgePods) > 0 && common.GetMaxSurge(nodeset) == 0 {
+			logger.V(2).Info("Surge pods are not allowed", "deleting", len(surgePods))
+			r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, ScalingDownReason, "ScaleDown",
+				"Deleting %d surge Pod(s)", len(surgePods))
+			return r.doPodScale(ctx, nodeset, replicaPods, surgePods, nil)
+		}
 		replicaCount := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
-		diff := len(podsNewScaling) - replicaCount
+		diff := len(replicaPods) - replicaCount
 		if diff < 0 {
 			diff = -diff
 
@@ -1032,7 +1158,7 @@ func (r *NodeSetReconciler) syncNodeSetPods(
 			logger.V(2).Info("Too many NodeSet pods", "need", replicaCount, "deleting", diff)
 			r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, ScalingDownReason, "ScaleDown",
 				"Deleting %d Pod(s) to stabilize at %d replicas", diff, replicaCount)
-			podsToDelete, podsToKeep := nodesetutils.SplitActivePods(podsNewScaling, diff)
+			podsToDelete, podsToKeep := nodesetutils.SplitActivePods(replicaPods, diff)
 			return r.doPodScale(ctx, nodeset, podsToKeep, podsToDelete, nil)
 		}
 	}
@@ -1041,6 +1167,115 @@ func (r *NodeSetReconciler) syncNodeSetPods(
 	return r.doPodProcessing(ctx, nodeset, podsNewScaling, podsOldScaling, hash)
 }
 
+// syncPowerSavePods creates and deletes NodeSet pods as Slurm resumes and
+// suspends their Slurm nodes. Pods beyond the replicas are drained and deleted,
+// as their Slurm nodes are no longer defined. Returns true if pods were scaled.
+func (r *NodeSetReconciler) syncPowerSavePods(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	hash string,
+) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	// Slurm does not notify us of power state changes, so we poll for them.
+	durationStore.Push(objectutils.KeyFunc(nodeset), powerSaveSyncPeriod)
+
+	powerStates, err := r.slurmControl.GetNodePowerStates(ctx, nodeset)
+	if err != nil {
+		return false, err
+	}
+
+	replicaCount := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
+	usedOrdinals := set.New[int]()
+	var podsToKeep, podsToDelete, podsToSuspend []*corev1.Pod
+	for _, pod := range pods {
+		ordinal := nodesetutils.GetOrdinal(pod)
+		usedOrdinals.Insert(ordinal)
+		switch {
+		case ordinal >= replicaCount:
+			podsToDelete = append(podsToDelete, pod)
+		case powerStates[nodesetutils.GetSlurmNodeName(pod)] == slurmcontrol.SlurmNodePowerStateSuspended:
+			podsToSuspend = append(podsToSuspend, pod)
+		default:
+			podsToKeep = append(podsToKeep, pod)
+		}
+	}
+
+	podsToCreate := []*corev1.Pod{}
+	for ordinal := range replicaCount {
+		if usedOrdinals.Has(ordinal) {
+			continue
+		}
+		nodeName := nodesetutils.GetOrdinalSlurmNodeName(nodeset, ordinal)
+		if powerStates[nodeName] != slurmcontrol.SlurmNodePowerStateResumed {
+			continue
+		}
+		pod, err := r.newNodeSetPodOrdinal(r.Client, ctx, nodeset, ordinal, hash)
+		if err != nil {
+			return false, err
+		}
+		podsToCreate = append(podsToCreate, pod)
+	}
+
+	if len(podsToCreate) > 0 || len(podsToDelete) > 0 {
+		if len(podsToCreate) > 0 {
+			logger.V(2).Info("Slurm nodes are resuming", "creating", len(podsToCreate))
+			r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, ScalingUpReason, "ScaleUp",
+				"Creating %d Pod(s) for Slurm nodes being resumed", len(podsToCreate))
+		}
+		if len(podsToDelete) > 0 {
+			logger.V(2).Info("Too many NodeSet pods", "need", replicaCount, "deleting", len(podsToDelete))
+			r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, ScalingDownReason, "ScaleDown",
+				"Deleting %d Pod(s) to stabilize at %d replicas", len(podsToDelete), replicaCount)
+		}
+		return true, r.doPodScale(ctx, nodeset, podsToKeep, podsToDelete, podsToCreate)
+	}
+
+	if len(podsToSuspend) > 0 {
+		logger.V(2).Info("Slurm nodes are suspending", "deleting", len(podsToSuspend))
+		r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, ScalingDownReason, "ScaleDown",
+			"Deleting %d Pod(s) for Slurm nodes being suspended", len(podsToSuspend))
+		return true, r.doPodSuspend(ctx, nodeset, podsToSuspend)
+	}
+
+	return false, nil
+}
+
+// doPodSuspend deletes NodeSet pods whose Slurm nodes were suspended.
+// Slurm only suspends idle nodes, hence the pods are deleted without draining
+// and their PersistentVolumeClaims are retained for when Slurm resumes them.
+func (r *NodeSetReconciler) doPodSuspend(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	podsToSuspend []*corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+	key := objectutils.KeyFunc(nodeset)
+
+	numDelete := mathutils.Clamp(len(podsToSuspend), 0, burstReplicas)
+	if err := r.expectations.ExpectDeletions(logger, key, getPodKeys(podsToSuspend[:numDelete])); err != nil {
+		return err
+	}
+
+	deletePodFn := func(index int) error {
+		pod := podsToSuspend[index]
+		podKey := kubecontroller.PodKey(pod)
+		if err := r.podControl.DeleteNodeSetPod(ctx, nodeset, pod); err != nil {
+			// Decrement the expected number of deletes because the informer won't observe this deletion
+			r.expectations.DeletionObserved(logger, key, podKey)
+			if !apierrors.IsNotFound(err) {
+				logger.V(2).Info("Failed to delete pod, decremented expectations",
+					"pod", podKey, "kind", slinkyv1beta1.NodeSetGVK)
+				return err
+			}
+		}
+		return nil
+	}
+	_, err := utils.SlowStartBatch(numDelete, utils.SlowStartInitialBatchSize, deletePodFn)
+	return err
+}
+
 // doPodScale manages NodeSet pod creation and deletion
 // podsToKeep - should be uncordoned and undrained.
 // podsToDelete - should be cordoned and drained, then deleted.
@@ -1233,11 +1468,53 @@ func (r *NodeSetReconciler) newNodeSetPodOrdinal(
 		return nil, err
 	}
 
+	// Pods below the partition are not updated, hence they are recreated at
+	// the current revision instead of the update revision.
+	partition := int(ptr.Deref(nodeset.Spec.UpdateStrategy.RollingUpdate.Partition, 0))
+	if nodeset.Spec.UpdateStrategy.Type == slinkyv1beta1.RollingUpdateNodeSetStrategyType && ordinal < partition {
+		currentNodeSet, currentHash, err := r.getCurrentNodeSet(ctx, nodeset)
+		if err != nil {
+			return nil, err
+		}
+		if currentNodeSet != nil {
+			nodeset, revisionHash = currentNodeSet, currentHash
+		}
+	}
+
 	pod := nodesetutils.NewNodeSetStatefulSetPod(client, nodeset, controller, ordinal, revisionHash)
 
 	return pod, nil
 }
 
+// getCurrentNodeSet returns the NodeSet restored from its current revision, and
+// the revision hash. Returns nil if the current revision is not known.
+func (r *NodeSetReconciler) getCurrentNodeSet(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+) (*slinkyv1beta1.NodeSet, string, error) {
+	if nodeset.Status.CurrentRevision == "" {
+		return nil, "", nil
+	}
+
+	revision := &appsv1.ControllerRevision{}
+	key := types.NamespacedName{
+		Namespace: nodeset.Namespace,
+		Name:      nodeset.Status.CurrentRevision,
+	}
+	if err := r.Get(ctx, key, revision); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+
+	currentNodeSet, err := applyRevision(nodeset, revision)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to apply revision (%s): %w", revision.Name, err)
+	}
+	return currentNodeSet, historycontrol.GetRevision(revision.GetLabels()), nil
+}
+
 func getPodKeys(pods []*corev1.Pod) []string {
 	podKeys := make([]string, 0, len(pods))
 	for _, pod := range pods {
@@ -1282,7 +1559,10 @@ func (r *NodeSetReconciler) processCondemned(
 		durationStore.Push(nodesetKey, 30*time.Second)
 		r.expectations.DeletionObserved(logger, nodesetKey, kubecontroller.PodKey(pod))
 		reason := fmt.Sprintf("Pod (%s) is pending termination for scale-in", klog.KObj(pod))
-		return r.makePodCordonAndDrain(ctx, nodeset, pod, reason, true)
+		if err := r.makePodCordonAndDrain(ctx, nodeset, pod, reason, true); err != nil {
+			return err
+		}
+		return r.syncDrainTimeout(ctx, nodeset, pod)
 	}
 
 	logger.V(2).Info("NodeSet Pod is terminating for scale-in",
@@ -1295,6 +1575,84 @@ func (r *NodeSetReconciler) processCondemned(
 	return nil
 }
 
+// syncDrainTimeout enforces the drain policy of a pod pending termination. Once
+// its Slurm node has been draining for longer than the timeout, the timeout
+// action is taken on the running jobs of the Slurm node or the pod.
+func (r *NodeSetReconciler) syncDrainTimeout(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pod *corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+
+	timeout := nodeset.Spec.DrainPolicy.Timeout
+	if timeout == nil {
+		return nil
+	}
+
+	drainStartTime, _ := structutils.GetTimeFromAnnotations(pod.Annotations, slinkyv1beta1.AnnotationPodDrainStartTime)
+	if drainStartTime.IsZero() {
+		mutateFn := func(pod *corev1.Pod) error {
+			if pod.Annotations == nil {
+				pod.Annotations = make(map[string]string)
+			}
+			pod.Annotations[slinkyv1beta1.AnnotationPodDrainStartTime] = time.Now().Format(time.RFC3339)
+			return nil
+		}
+		return objectutils.PatchObject(r.Client, ctx, pod, mutateFn)
+	}
+
+	nodesetKey := objectutils.KeyFunc(nodeset)
+	drainExpiry := drainStartTime.Add(timeout.Duration)
+	if remaining := time.Until(drainExpiry); remaining > 0 {
+		durationStore.Push(nodesetKey, remaining)
+		return nil
+	}
+
+	// The deadline is only set while the Slurm node has running jobs.
+	deadline, _ := structutils.GetTimeFromAnnotations(pod.Annotations, slinkyv1beta1.AnnotationPodDeadline)
+	action := nodeset.Spec.DrainPolicy.TimeoutAction
+	if deadline.IsZero() && action != slinkyv1beta1.DrainTimeoutActionForceDelete {
+		logger.V(2).Info("NodeSet Pod drain timed out, but has no running jobs",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+
+	logger.Info("NodeSet Pod drain timed out",
+		"pod", klog.KObj(pod), "timeout", timeout.Duration, "deadline", deadline, "action", action)
+	r.eventRecorder.Eventf(nodeset, pod, corev1.EventTypeWarning, DrainTimeoutReason, "DrainTimeout",
+		"Pod (%s) did not drain within %s, running jobs have a deadline of %s: taking action %s",
+		klog.KObj(pod), timeout.Duration, formatDeadline(deadline), action)
+
+	switch action {
+	case slinkyv1beta1.DrainTimeoutActionForceDelete:
+		if err := r.podControl.ForceDeleteNodeSetPod(ctx, nodeset, pod); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+	case slinkyv1beta1.DrainTimeoutActionCancel:
+		if err := r.slurmControl.CancelNodeJobs(ctx, nodeset, pod); err != nil {
+			return err
+		}
+	default:
+		reason := fmt.Sprintf("Pod (%s) did not drain within %s", klog.KObj(pod), timeout.Duration)
+		if err := r.slurmControl.RequeueNodeJobs(ctx, nodeset, pod, reason); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatDeadline formats the deadline of running jobs for humans.
+func formatDeadline(deadline time.Time) string {
+	if deadline.IsZero() {
+		return "none"
+	}
+	return deadline.Format(time.RFC3339)
+}
+
 // doPodProcessing handles batch processing of NodeSet pods.
 func (r *NodeSetReconciler) doPodProcessing(
 	ctx context.Context,
@@ -1453,6 +1811,7 @@ func (r *NodeSetReconciler) makePodUncordon(ctx context.Context, pod *corev1.Pod
 	logger.Info("Uncordon Pod", "Pod", klog.KObj(pod))
 	mutateFn := func(pod *corev1.Pod) error {
 		delete(pod.Annotations, slinkyv1beta1.AnnotationPodCordon)
+		delete(pod.Annotations, slinkyv1beta1.AnnotationPodDrainStartTime)
 		return nil
 	}
 	if err := objectutils.PatchObject(r.Client, ctx, pod, mutateFn); err != nil {
@@ -1525,9 +1884,18 @@ func (r *NodeSetReconciler) syncRollingUpdate(
 ) error {
 	logger := log.FromContext(ctx)
 
-	_, oldPods := findUpdatedPods(pods, hash)
+	if maxSurge := common.GetMaxSurge(nodeset); maxSurge > 0 {
+		if err := r.syncSurgePods(ctx, nodeset, pods, hash, maxSurge); err != nil {
+			return err
+		}
+	}
 
-	unhealthyPods, healthyPods := nodesetutils.SplitUnhealthyPods(oldPods)
+	_, replicaPods := splitSurgePods(pods)
+	partition := int(ptr.Deref(nodeset.Spec.UpdateStrategy.RollingUpdate.Partition, 0))
+	_, partitionedPods := nodesetutils.SplitPartitionedPods(replicaPods, partition)
+	_, oldPods := findUpdatedPods(partitionedPods, hash)
+
+	unhealthyPods, _ := nodesetutils.SplitUnhealthyPods(oldPods)
 	if len(unhealthyPods) > 0 {
 		logger.Info("Delete unhealthy pods for Rolling Update",
 			"unhealthyPods", len(unhealthyPods))
@@ -1538,7 +1906,8 @@ func (r *NodeSetReconciler) syncRollingUpdate(
 		}
 	}
 
-	podsToDelete, _ := r.splitUpdatePods(ctx, nodeset, healthyPods, hash)
+	remainingPods := nodesetutils.ExcludePods(pods, unhealthyPods)
+	podsToDelete, _ := r.splitUpdatePods(ctx, nodeset, remainingPods, hash)
 	if len(podsToDelete) > 0 {
 		logger.Info("Scale-in pods for Rolling Update",
 			"delete", len(podsToDelete))
@@ -1565,7 +1934,14 @@ func (r *NodeSetReconciler) splitUpdatePods(
 	default:
 		fallthrough
 	case slinkyv1beta1.RollingUpdateNodeSetStrategyType:
-		newPods, oldPods := findUpdatedPods(pods, hash)
+		// Terminating pods are already being replaced, hence they do not count
+		// against the partition.
+		_, replicaPods := splitSurgePods(kubecontroller.FilterActivePods(logger, pods))
+		partition := int(ptr.Deref(nodeset.Spec.UpdateStrategy.RollingUpdate.Partition, 0))
+		protectedPods, partitionedPods := nodesetutils.SplitPartitionedPods(replicaPods, partition)
+		newPods, _ := findUpdatedPods(pods, hash)
+		_, oldPods := findUpdatedPods(partitionedPods, hash)
+		_, protectedOldPods := findUpdatedPods(protectedPods, hash)
 
 		var numUnavailable int
 		now := metav1.Now()
@@ -1581,14 +1957,21 @@ func (r *NodeSetReconciler) splitUpdatePods(
 		}
 		maxUnavailable := mathutils.GetScaledValueFromIntOrPercent(nodeset.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable, total, true, 1)
 		remainingUnavailable := mathutils.Clamp((maxUnavailable - numUnavailable), 0, maxUnavailable)
+		if common.GetMaxSurge(nodeset) > 0 && len(oldPods) > 0 {
+			// Old pods are only replaced as the new pods become available in Slurm.
+			surgeBudget := r.getSurgeBudget(ctx, nodeset, newPods, slices.Concat(oldPods, protectedOldPods))
+			remainingUnavailable = min(remainingUnavailable, surgeBudget)
+		}
 		podsToDelete, remainingOldPods := nodesetutils.SplitActivePods(oldPods, remainingUnavailable)
 
 		remainingPods := make([]*corev1.Pod, len(newPods))
 		copy(remainingPods, newPods)
 		remainingPods = append(remainingPods, remainingOldPods...)
+		remainingPods = append(remainingPods, protectedOldPods...)
 
 		logger.V(1).Info("calculated pod lists for update",
 			"maxUnavailable", maxUnavailable,
+			"partition", partition,
 			"updatePods", len(podsToDelete),
 			"remainingPods", len(remainingPods))
 		return podsToDelete, remainingPods
@@ -1608,6 +1991,137 @@ func (r *NodeSetReconciler) splitUpdatePods(
 	}
 }
 
+// syncSurgePods creates surge pods on the update revision while old pods remain
+// to be updated, and deletes them once the updated pods are available in Slurm.
+func (r *NodeSetReconciler) syncSurgePods(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	hash string,
+	maxSurge int,
+) error {
+	logger := log.FromContext(ctx)
+
+	surgePods, replicaPods := splitSurgePods(pods)
+	newSurgePods, oldSurgePods := findUpdatedPods(surgePods, hash)
+	partition := int(ptr.Deref(nodeset.Spec.UpdateStrategy.RollingUpdate.Partition, 0))
+	_, partitionedPods := nodesetutils.SplitPartitionedPods(replicaPods, partition)
+	_, oldPods := findUpdatedPods(partitionedPods, hash)
+	replicaCount := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
+
+	// Surge pods of an outdated revision are replaced.
+	podsToDelete := oldSurgePods
+	podsToCreate := []*corev1.Pod{}
+	if len(oldPods) > 0 {
+		numSurge := min(maxSurge, len(oldPods))
+		usedOrdinals := set.New[int]()
+		for _, pod := range pods {
+			usedOrdinals.Insert(nodesetutils.GetOrdinal(pod))
+		}
+		// Surge pods take temporary ordinals above the replicas.
+		ordinal := replicaCount
+		for range numSurge - len(newSurgePods) {
+			for usedOrdinals.Has(ordinal) {
+				ordinal++
+			}
+			pod, err := r.newNodeSetPodOrdinal(r.Client, ctx, nodeset, ordinal, hash)
+			if err != nil {
+				return err
+			}
+			pod.Labels[slinkyv1beta1.LabelNodeSetPodSurge] = "true"
+			usedOrdinals.Insert(ordinal)
+			podsToCreate = append(podsToCreate, pod)
+		}
+	} else if len(newSurgePods) > 0 {
+		// The update is complete, the surge pods are no longer needed once the
+		// replicas are available in Slurm.
+		activeReplicaPods := slices.DeleteFunc(slices.Clone(replicaPods), podutils.IsTerminating)
+		numAvailable, err := r.countAvailablePods(ctx, nodeset, activeReplicaPods)
+		if err != nil {
+			return err
+		}
+		if numAvailable >= replicaCount {
+			podsToDelete = append(podsToDelete, newSurgePods...)
+		}
+	}
+
+	if len(podsToCreate) == 0 && len(podsToDelete) == 0 {
+		return nil
+	}
+	if len(podsToCreate) > 0 {
+		logger.Info("Scale-out surge pods for Rolling Update",
+			"create", len(podsToCreate))
+		r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, RollingUpdateReason, "RollingUpdate",
+			"Rolling update: creating %d surge pod(s) with updated revision", len(podsToCreate))
+	}
+	if len(podsToDelete) > 0 {
+		logger.Info("Scale-in surge pods for Rolling Update",
+			"delete", len(podsToDelete))
+		r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, RollingUpdateReason, "RollingUpdate",
+			"Rolling update: deleting %d surge pod(s)", len(podsToDelete))
+	}
+	return r.doPodScale(ctx, nodeset, nil, podsToDelete, podsToCreate)
+}
+
+// getSurgeBudget returns the number of old pods that can be out of service,
+// such that the pods available in Slurm do not drop below the replicas.
+func (r *NodeSetReconciler) getSurgeBudget(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	newPods, oldPods []*corev1.Pod,
+) int {
+	logger := log.FromContext(ctx)
+
+	numAvailable, err := r.countAvailablePods(ctx, nodeset, newPods)
+	if err != nil {
+		logger.Error(err, "failed to determine available pods", "NodeSet", klog.KObj(nodeset))
+		return 0
+	}
+
+	var numHealthy int
+	for _, pod := range oldPods {
+		if podutils.IsHealthy(pod) {
+			numHealthy++
+		}
+	}
+
+	replicaCount := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
+	numOldNeeded := max(replicaCount-numAvailable, 0)
+	return max(numHealthy-numOldNeeded, 0)
+}
+
+// countAvailablePods returns the number of pods whose Slurm node is available.
+func (r *NodeSetReconciler) countAvailablePods(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) (int, error) {
+	var numAvailable int
+	for _, pod := range pods {
+		isAvailable, err := r.slurmControl.IsNodeAvailable(ctx, nodeset, pod)
+		if err != nil {
+			return 0, err
+		}
+		if isAvailable {
+			numAvailable++
+		}
+	}
+	return numAvailable, nil
+}
+
+// splitSurgePods returns the surge pods and the replica pods. Surge pods are
+// recognized by their label, regardless of their ordinal.
+func splitSurgePods(pods []*corev1.Pod) (surgePods, replicaPods []*corev1.Pod) {
+	for _, pod := range pods {
+		if podutils.IsPodSurge(pod) {
+			surgePods = append(surgePods, pod)
+		} else {
+			replicaPods = append(replicaPods, pod)
+		}
+	}
+	return surgePods, replicaPods
+}
+
 // findUpdatedPods looks at non-deleted pods and returns two lists, new and old pods, given the hash.
 func findUpdatedPods(pods []*corev1.Pod, hash string) (newPods, oldPods []*corev1.Pod) {
 	for _, pod := range pods {
@@ -1676,6 +2190,24 @@ func (r *NodeSetReconciler) syncSshConfig(
 	return nil
 }
 
+// syncNodeConfig manages the node-scoped config files of the NodeSet, which
+// its pods mount.
+func (r *NodeSetReconciler) syncNodeConfig(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+) error {
+	config, err := r.builder.BuildWorkerNodeConfig(nodeset)
+	if err != nil {
+		return fmt.Errorf("failed to build node config: %w", err)
+	}
+
+	if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, nodeset, config, true); err != nil {
+		return fmt.Errorf("failed to sync node config (%s): %w", klog.KObj(config), err)
+	}
+
+	return nil
+}
+
 // syncScheduledUpdate will synchronize rolling updates for NodeSet pods
 // based on reservations
 func (r *NodeSetReconciler) syncScheduledUpdate(
@@ -134,6 +134,17 @@ func (r *NodeSetReconciler) syncNodeSetStatus(
 		Conditions:          []metav1.Condition{},
 	}
 	newStatus.Conditions = append(newStatus.Conditions, nodeset.Status.Conditions...)
+	if currentRevision != nil {
+		newStatus.CurrentRevision = currentRevision.Name
+	}
+	if updateRevision != nil {
+		newStatus.UpdateRevision = updateRevision.Name
+	}
+	// The rolling update is complete when all pods are running and ready at
+	// the update revision, which then becomes the current revision.
+	if newStatus.UpdatedReplicas == newStatus.Replicas && newStatus.ReadyReplicas == newStatus.Replicas {
+		newStatus.CurrentRevision = newStatus.UpdateRevision
+	}
 
 	if err := r.applyReservationCondition(ctx, nodeset, &newStatus.Conditions); err != nil {
 		return err
@@ -393,6 +393,78 @@ func TestNodeSetReconciler_syncNodeSetStatus(t *testing.T) {
 				wantErr: false,
 			}
 		}(),
+		func() testCaseFields {
+			const oldHash = "67890"
+			nodeset := newNodeSet("foo", controller.Name, 2)
+			pod0 := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, oldHash)
+			pod0 = makePodHealthy(pod0)
+			pod1 := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, hash)
+			pod1 = makePodHealthy(pod1)
+			pods := []*corev1.Pod{pod0, pod1}
+			podList := &corev1.PodList{
+				Items: structutils.DereferenceList(pods),
+			}
+			currentRevision := &appsv1.ControllerRevision{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo-" + oldHash,
+					Labels: map[string]string{
+						history.ControllerRevisionHashLabel: oldHash,
+					},
+				},
+			}
+			updateRevision := &appsv1.ControllerRevision{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "foo-" + hash,
+					Labels: map[string]string{
+						history.ControllerRevisionHashLabel: hash,
+					},
+				},
+			}
+			c := fake.NewClientBuilder().WithRuntimeObjects(nodeset, podList, currentRevision, updateRevision).WithStatusSubresource(nodeset).Build()
+			slurmNodeList := &slurmtypes.V0044NodeList{
+				Items: func(pods []*corev1.Pod) []slurmtypes.V0044Node {
+					nodeList := make([]slurmtypes.V0044Node, 0, len(pods))
+					for _, pod := range pods {
+						slurmNode := newNodeSetPodSlurmNode(pod)
+						nodeList = append(nodeList, *slurmNode)
+					}
+					return nodeList
+				}(pods),
+			}
+			sc := newFakeClientList(slurminterceptor.Funcs{}, slurmNodeList)
+			clientMap := newClientMap(controller.Name, sc)
+
+			return testCaseFields{
+				name: "Partitioned, canary updated",
+				fields: fields{
+					Client:    c,
+					ClientMap: clientMap,
+				},
+				args: args{
+					ctx:             context.TODO(),
+					nodeset:         nodeset,
+					pods:            pods,
+					currentRevision: currentRevision,
+					updateRevision:  updateRevision,
+					collisionCount:  0,
+					hash:            hash,
+				},
+				wantStatus: &slinkyv1beta1.NodeSetStatus{
+					Replicas:          2,
+					ReadyReplicas:     2,
+					AvailableReplicas: 2,
+					UpdatedReplicas:   1,
+					Desired:           2,
+					SlurmIdle:         2,
+					NodeSetHash:       "12345",
+					CurrentRevision:   "foo-67890",
+					UpdateRevision:    "foo-12345",
+					CollisionCount:    ptr.To[int32](0),
+					Selector:          "app.kubernetes.io/instance=foo,app.kubernetes.io/name=slurmd",
+				},
+				wantErr: false,
+			}
+		}(),
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
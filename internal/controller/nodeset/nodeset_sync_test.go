@@ -6,6 +6,7 @@ package nodeset
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"slices"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -938,6 +940,259 @@ func TestNodeSetReconciler_syncNodeSetPods(t *testing.T) {
 	}
 }
 
+func TestNodeSetReconciler_syncNodeSetPods_partition(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	currentNodeSet := newNodeSet("foo", controller.Name, 2)
+	currentNodeSet.Spec.UpdateStrategy.Type = slinkyv1beta1.RollingUpdateNodeSetStrategyType
+	currentNodeSet.Spec.UpdateStrategy.RollingUpdate.Partition = ptr.To[int32](1)
+	currentRevision, err := newRevision(currentNodeSet, 1, ptr.To[int32](0))
+	if err != nil {
+		t.Fatalf("Failed to initialize test. Failed to create revision: %v", err)
+	}
+	currentRevision.Namespace = currentNodeSet.Namespace
+	currentHash := historycontrol.GetRevision(currentRevision.GetLabels())
+
+	updateNodeSet := currentNodeSet.DeepCopy()
+	updateNodeSet.Spec.Slurmd.Image = "slurmd:canary"
+	updateRevision, err := newRevision(updateNodeSet, 2, ptr.To[int32](0))
+	if err != nil {
+		t.Fatalf("Failed to initialize test. Failed to create revision: %v", err)
+	}
+	updateHash := historycontrol.GetRevision(updateRevision.GetLabels())
+	updateNodeSet.Status.CurrentRevision = currentRevision.Name
+	updateNodeSet.Status.UpdateRevision = updateRevision.Name
+
+	tests := []struct {
+		name      string
+		ordinal   int
+		revision  *appsv1.ControllerRevision
+		wantHash  string
+		wantImage string
+	}{
+		{
+			name:      "Pod below the partition is recreated at the current revision",
+			ordinal:   0,
+			revision:  currentRevision,
+			wantHash:  currentHash,
+			wantImage: "slurmd",
+		},
+		{
+			name:      "Pod at the partition is recreated at the update revision",
+			ordinal:   1,
+			revision:  currentRevision,
+			wantHash:  updateHash,
+			wantImage: "slurmd:canary",
+		},
+		{
+			name:      "Pod below the partition without current revision uses the update revision",
+			ordinal:   0,
+			wantHash:  updateHash,
+			wantImage: "slurmd:canary",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			// The other pod is left, the pod of the ordinal was deleted.
+			otherOrdinal := 1 - tt.ordinal
+			otherHash := currentHash
+			if otherOrdinal >= 1 {
+				otherHash = updateHash
+			}
+			otherPod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), updateNodeSet, controller, otherOrdinal, otherHash)
+			makePodHealthy(otherPod)
+			objects := []runtime.Object{controller.DeepCopy(), updateNodeSet.DeepCopy(), otherPod.DeepCopy()}
+			if tt.revision != nil {
+				objects = append(objects, tt.revision.DeepCopy())
+			}
+			c := fake.NewFakeClient(objects...)
+			nodeList := &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{*newNodeSetPodSlurmNode(otherPod)},
+			}
+			sclient := newFakeClientList(sinterceptor.Funcs{}, nodeList)
+			r := newNodeSetController(c, newClientMap(controller.Name, sclient))
+
+			if err := r.syncNodeSetPods(ctx, updateNodeSet.DeepCopy(), []*corev1.Pod{otherPod.DeepCopy()}, updateHash); err != nil {
+				t.Fatalf("NodeSetReconciler.syncNodeSetPods() error = %v", err)
+			}
+
+			podList := &corev1.PodList{}
+			if err := c.List(ctx, podList, client.InNamespace(updateNodeSet.Namespace)); err != nil {
+				t.Fatalf("Failed to list pods: %v", err)
+			}
+			var pod *corev1.Pod
+			for i := range podList.Items {
+				if nodesetutils.GetOrdinal(&podList.Items[i]) == tt.ordinal {
+					pod = &podList.Items[i]
+				}
+			}
+			if pod == nil {
+				t.Fatalf("Pod with ordinal %d was not recreated", tt.ordinal)
+			}
+			if got := historycontrol.GetRevision(pod.GetLabels()); got != tt.wantHash {
+				t.Errorf("Pod revision = %v, want %v", got, tt.wantHash)
+			}
+			idx := slices.IndexFunc(pod.Spec.Containers, func(c corev1.Container) bool {
+				return c.Name == labels.WorkerApp
+			})
+			if idx < 0 {
+				t.Fatalf("Pod has no %s container", labels.WorkerApp)
+			}
+			if got := pod.Spec.Containers[idx].Image; got != tt.wantImage {
+				t.Errorf("Pod image = %v, want %v", got, tt.wantImage)
+			}
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncPowerSavePods(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	hash := "test-hash"
+	newCloudNode := func(name string, states ...slurmapi.V0044NodeState) slurmtypes.V0044Node {
+		return slurmtypes.V0044Node{
+			V0044Node: slurmapi.V0044Node{
+				Name:     ptr.To(name),
+				Features: ptr.To(slurmapi.V0044CsvString{"foo"}),
+				State:    ptr.To(append([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateCLOUD}, states...)),
+			},
+		}
+	}
+	newPowerSaveNodeSet := func(replicas int32) *slinkyv1beta1.NodeSet {
+		nodeset := newNodeSet("foo", controller.Name, replicas)
+		nodeset.Spec.PowerSave.Enabled = true
+		return nodeset
+	}
+	type fields struct {
+		nodeList *slurmtypes.V0044NodeList
+	}
+	type args struct {
+		nodeset *slinkyv1beta1.NodeSet
+		pods    []int
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantScaled bool
+		wantPods   []string
+	}{
+		{
+			name: "All Slurm nodes suspended",
+			fields: fields{
+				nodeList: &slurmtypes.V0044NodeList{
+					Items: []slurmtypes.V0044Node{
+						newCloudNode("foo-0", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStatePOWEREDDOWN),
+						newCloudNode("foo-1", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStatePOWEREDDOWN),
+					},
+				},
+			},
+			args: args{
+				nodeset: newPowerSaveNodeSet(2),
+			},
+			wantScaled: false,
+			wantPods:   []string{},
+		},
+		{
+			name: "Create pods for resumed Slurm nodes",
+			fields: fields{
+				nodeList: &slurmtypes.V0044NodeList{
+					Items: []slurmtypes.V0044Node{
+						newCloudNode("foo-0", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStatePOWEREDDOWN),
+						newCloudNode("foo-1", slurmapi.V0044NodeStateALLOCATED, slurmapi.V0044NodeStatePOWERINGUP),
+						newCloudNode("foo-2", slurmapi.V0044NodeStateALLOCATED, slurmapi.V0044NodeStatePOWERINGUP),
+					},
+				},
+			},
+			args: args{
+				nodeset: newPowerSaveNodeSet(3),
+			},
+			wantScaled: true,
+			wantPods:   []string{"foo-1", "foo-2"},
+		},
+		{
+			name: "Delete pods for suspended Slurm nodes",
+			fields: fields{
+				nodeList: &slurmtypes.V0044NodeList{
+					Items: []slurmtypes.V0044Node{
+						newCloudNode("foo-0", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStatePOWERINGDOWN),
+						newCloudNode("foo-1", slurmapi.V0044NodeStateALLOCATED),
+					},
+				},
+			},
+			args: args{
+				nodeset: newPowerSaveNodeSet(2),
+				pods:    []int{0, 1},
+			},
+			wantScaled: true,
+			wantPods:   []string{"foo-1"},
+		},
+		{
+			name: "Keep pods for resumed Slurm nodes",
+			fields: fields{
+				nodeList: &slurmtypes.V0044NodeList{
+					Items: []slurmtypes.V0044Node{
+						newCloudNode("foo-0", slurmapi.V0044NodeStateIDLE),
+						newCloudNode("foo-1", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStatePOWEREDDOWN),
+					},
+				},
+			},
+			args: args{
+				nodeset: newPowerSaveNodeSet(2),
+				pods:    []int{0},
+			},
+			wantScaled: false,
+			wantPods:   []string{"foo-0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			objs := []client.Object{controller.DeepCopy(), tt.args.nodeset.DeepCopy()}
+			pods := []*corev1.Pod{}
+			for _, ordinal := range tt.args.pods {
+				pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), tt.args.nodeset, controller, ordinal, hash)
+				makePodHealthy(pod)
+				objs = append(objs, pod.DeepCopy())
+				pods = append(pods, pod)
+			}
+			c := fake.NewClientBuilder().WithObjects(objs...).Build()
+			sclient := newFakeClientList(sinterceptor.Funcs{}, tt.fields.nodeList)
+			r := newNodeSetController(c, newClientMap(controller.Name, sclient))
+
+			scaled, err := r.syncPowerSavePods(ctx, tt.args.nodeset, pods, hash)
+			if err != nil {
+				t.Fatalf("NodeSetReconciler.syncPowerSavePods() error = %v", err)
+			}
+			if scaled != tt.wantScaled {
+				t.Errorf("NodeSetReconciler.syncPowerSavePods() = %v, want %v", scaled, tt.wantScaled)
+			}
+
+			podList := &corev1.PodList{}
+			if err := c.List(ctx, podList); err != nil {
+				t.Fatalf("Failed to list pods: %v", err)
+			}
+			got := []string{}
+			for _, pod := range podList.Items {
+				got = append(got, pod.Name)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.wantPods) {
+				t.Errorf("pods = %v, want %v", got, tt.wantPods)
+			}
+		})
+	}
+}
+
 func TestNodeSetReconciler_processCondemned(t *testing.T) {
 	controller := &slinkyv1beta1.Controller{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1382,6 +1637,245 @@ func TestNodeSetReconciler_processCondemned(t *testing.T) {
 	}
 }
 
+func TestNodeSetReconciler_syncDrainTimeout(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	newDrainingPod := func(nodeset *slinkyv1beta1.NodeSet, drainStart, deadline time.Time) *corev1.Pod {
+		pod := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, ""))
+		pod.Annotations[slinkyv1beta1.AnnotationPodCordon] = "true"
+		if !drainStart.IsZero() {
+			pod.Annotations[slinkyv1beta1.AnnotationPodDrainStartTime] = drainStart.Format(time.RFC3339)
+		}
+		if !deadline.IsZero() {
+			pod.Annotations[slinkyv1beta1.AnnotationPodDeadline] = deadline.Format(time.RFC3339)
+		}
+		return pod
+	}
+	newSlurmClient := func(pod *corev1.Pod) slurmclient.Client {
+		slurmNode := newNodeSetPodSlurmNode(pod)
+		slurmNode.State = ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateALLOCATED, slurmapi.V0044NodeStateDRAIN})
+		slurmNodeList := &slurmtypes.V0044NodeList{
+			Items: []slurmtypes.V0044Node{*slurmNode},
+		}
+		slurmJobList := &slurmtypes.V0044JobInfoList{
+			Items: []slurmtypes.V0044JobInfo{
+				{
+					V0044JobInfo: slurmapi.V0044JobInfo{
+						JobId:    ptr.To[int32](1),
+						JobState: ptr.To([]slurmapi.V0044JobInfoJobState{slurmapi.V0044JobInfoJobStateRUNNING}),
+						Nodes:    ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+					},
+				},
+			},
+		}
+		return newFakeClientList(sinterceptor.Funcs{}, slurmNodeList, slurmJobList)
+	}
+	now := time.Now()
+	type fields struct {
+		Client      client.Client
+		SlurmClient slurmclient.Client
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+	}
+	type testCaseFields struct {
+		name           string
+		fields         fields
+		args           args
+		wantDrainStart bool
+		wantPodDeleted bool
+		wantNodeDown   bool
+		wantJobs       int
+		wantErr        bool
+	}
+	tests := []testCaseFields{
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 1)
+			pod := newDrainingPod(nodeset, time.Time{}, now.Add(time.Hour))
+			return testCaseFields{
+				name: "No timeout",
+				fields: fields{
+					Client:      fake.NewFakeClient(nodeset, pod),
+					SlurmClient: newSlurmClient(pod),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+					pod:     pod,
+				},
+				wantJobs: 1,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 1)
+			nodeset.Spec.DrainPolicy.Timeout = &metav1.Duration{Duration: time.Hour}
+			pod := newDrainingPod(nodeset, time.Time{}, now.Add(time.Hour))
+			return testCaseFields{
+				name: "Record drain start",
+				fields: fields{
+					Client:      fake.NewFakeClient(nodeset, pod),
+					SlurmClient: newSlurmClient(pod),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+					pod:     pod,
+				},
+				wantDrainStart: true,
+				wantJobs:       1,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 1)
+			nodeset.Spec.DrainPolicy.Timeout = &metav1.Duration{Duration: time.Hour}
+			pod := newDrainingPod(nodeset, now.Add(-time.Minute), now.Add(time.Hour))
+			return testCaseFields{
+				name: "Timeout not expired",
+				fields: fields{
+					Client:      fake.NewFakeClient(nodeset, pod),
+					SlurmClient: newSlurmClient(pod),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+					pod:     pod,
+				},
+				wantDrainStart: true,
+				wantJobs:       1,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 1)
+			nodeset.Spec.DrainPolicy.Timeout = &metav1.Duration{Duration: time.Hour}
+			pod := newDrainingPod(nodeset, now.Add(-2*time.Hour), now.Add(time.Hour))
+			return testCaseFields{
+				name: "Timeout expired, requeue",
+				fields: fields{
+					Client:      fake.NewFakeClient(nodeset, pod),
+					SlurmClient: newSlurmClient(pod),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+					pod:     pod,
+				},
+				wantDrainStart: true,
+				wantNodeDown:   true,
+				wantJobs:       1,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 1)
+			nodeset.Spec.DrainPolicy.Timeout = &metav1.Duration{Duration: time.Hour}
+			pod := newDrainingPod(nodeset, now.Add(-2*time.Hour), time.Time{})
+			return testCaseFields{
+				name: "Timeout expired, no deadline",
+				fields: fields{
+					Client:      fake.NewFakeClient(nodeset, pod),
+					SlurmClient: newSlurmClient(pod),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+					pod:     pod,
+				},
+				wantDrainStart: true,
+				wantJobs:       1,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 1)
+			nodeset.Spec.DrainPolicy.Timeout = &metav1.Duration{Duration: time.Hour}
+			nodeset.Spec.DrainPolicy.TimeoutAction = slinkyv1beta1.DrainTimeoutActionCancel
+			pod := newDrainingPod(nodeset, now.Add(-2*time.Hour), now.Add(time.Hour))
+			return testCaseFields{
+				name: "Timeout expired, cancel",
+				fields: fields{
+					Client:      fake.NewFakeClient(nodeset, pod),
+					SlurmClient: newSlurmClient(pod),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+					pod:     pod,
+				},
+				wantDrainStart: true,
+				wantJobs:       0,
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 1)
+			nodeset.Spec.DrainPolicy.Timeout = &metav1.Duration{Duration: time.Hour}
+			nodeset.Spec.DrainPolicy.TimeoutAction = slinkyv1beta1.DrainTimeoutActionForceDelete
+			pod := newDrainingPod(nodeset, now.Add(-2*time.Hour), time.Time{})
+			return testCaseFields{
+				name: "Timeout expired, force delete",
+				fields: fields{
+					Client:      fake.NewFakeClient(nodeset, pod),
+					SlurmClient: newSlurmClient(pod),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+					pod:     pod,
+				},
+				wantPodDeleted: true,
+				wantJobs:       1,
+			}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientMap := newClientMap(controller.Name, tt.fields.SlurmClient)
+			r := newNodeSetController(tt.fields.Client, clientMap)
+			if err := r.syncDrainTimeout(tt.args.ctx, tt.args.nodeset, tt.args.pod); (err != nil) != tt.wantErr {
+				t.Errorf("NodeSetReconciler.syncDrainTimeout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			checkPod := &corev1.Pod{}
+			if err := r.Get(tt.args.ctx, client.ObjectKeyFromObject(tt.args.pod), checkPod); err != nil {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("failed to get pod: %v", err)
+				}
+				if !tt.wantPodDeleted {
+					t.Errorf("pod was deleted")
+				}
+			} else {
+				if tt.wantPodDeleted {
+					t.Errorf("pod was not deleted")
+				}
+				_, gotDrainStart := checkPod.Annotations[slinkyv1beta1.AnnotationPodDrainStartTime]
+				if gotDrainStart != tt.wantDrainStart {
+					t.Errorf("drain start annotation = %v, want %v", gotDrainStart, tt.wantDrainStart)
+				}
+			}
+
+			slurmNode := &slurmtypes.V0044Node{}
+			key := slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(tt.args.pod))
+			if err := tt.fields.SlurmClient.Get(tt.args.ctx, key, slurmNode); err != nil {
+				t.Fatalf("failed to get slurm node: %v", err)
+			}
+			if gotNodeDown := slurmNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDOWN); gotNodeDown != tt.wantNodeDown {
+				t.Errorf("slurm node down = %v, want %v", gotNodeDown, tt.wantNodeDown)
+			}
+
+			slurmJobList := &slurmtypes.V0044JobInfoList{}
+			if err := tt.fields.SlurmClient.List(tt.args.ctx, slurmJobList); err != nil {
+				t.Fatalf("failed to list slurm jobs: %v", err)
+			}
+			if len(slurmJobList.Items) != tt.wantJobs {
+				t.Errorf("slurm jobs = %v, want %v", len(slurmJobList.Items), tt.wantJobs)
+			}
+		})
+	}
+}
+
 func TestNodeSetReconciler_syncCordon(t *testing.T) {
 	controller := &slinkyv1beta1.Controller{
 		ObjectMeta: metav1.ObjectMeta{
@@ -3179,7 +3673,8 @@ func TestNodeSetReconciler_splitUpdatePods(t *testing.T) {
 	now := metav1.Now()
 	const hash = "12345"
 	type fields struct {
-		Client client.Client
+		Client    client.Client
+		ClientMap *clientmap.ClientMap
 	}
 	type args struct {
 		ctx     context.Context
@@ -3293,11 +3788,186 @@ func TestNodeSetReconciler_splitUpdatePods(t *testing.T) {
 			wantPodsToDelete: []string{},
 			wantPodsToKeep:   []string{"pod-0", "pod-1"},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			r := newNodeSetController(tt.fields.Client, nil)
-			gotPodsToDelete, gotPodsToKeep := r.splitUpdatePods(tt.args.ctx, tt.args.nodeset, tt.args.pods, tt.args.hash)
+		{
+			name: "RollingUpdate, with partition",
+			fields: fields{
+				Client: fake.NewFakeClient(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				nodeset: func() *slinkyv1beta1.NodeSet {
+					nodeset := newNodeSet("foo", controller.Name, 3)
+					nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.RollingUpdateNodeSetStrategyType
+					nodeset.Spec.UpdateStrategy.RollingUpdate = slinkyv1beta1.RollingUpdateNodeSetStrategy{
+						MaxUnavailable: ptr.To(intstr.FromString("100%")),
+						Partition:      ptr.To[int32](2),
+					}
+					return nodeset
+				}(),
+				pods: func() []*corev1.Pod {
+					pods := make([]*corev1.Pod, 0, 3)
+					for i := range 3 {
+						pod := &corev1.Pod{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: fmt.Sprintf("foo-%d", i),
+								Labels: map[string]string{
+									history.ControllerRevisionHashLabel: "",
+								},
+							},
+						}
+						pods = append(pods, makePodHealthy(pod))
+					}
+					return pods
+				}(),
+				hash: hash,
+			},
+			wantPodsToDelete: []string{"foo-2"},
+			wantPodsToKeep:   []string{"foo-0", "foo-1"},
+		},
+		{
+			name: "RollingUpdate, with partition and terminating pod",
+			fields: fields{
+				Client: fake.NewFakeClient(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				nodeset: func() *slinkyv1beta1.NodeSet {
+					nodeset := newNodeSet("foo", controller.Name, 3)
+					nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.RollingUpdateNodeSetStrategyType
+					nodeset.Spec.UpdateStrategy.RollingUpdate = slinkyv1beta1.RollingUpdateNodeSetStrategy{
+						MaxUnavailable: ptr.To(intstr.FromString("100%")),
+						Partition:      ptr.To[int32](2),
+					}
+					return nodeset
+				}(),
+				pods: func() []*corev1.Pod {
+					pods := make([]*corev1.Pod, 0, 3)
+					for i := range 3 {
+						pod := &corev1.Pod{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: fmt.Sprintf("foo-%d", i),
+								Labels: map[string]string{
+									history.ControllerRevisionHashLabel: "",
+								},
+							},
+						}
+						pods = append(pods, makePodHealthy(pod))
+					}
+					pods[0].DeletionTimestamp = &now
+					return pods
+				}(),
+				hash: hash,
+			},
+			wantPodsToDelete: []string{"foo-2"},
+			wantPodsToKeep:   []string{"foo-1"},
+		},
+		func() struct {
+			name             string
+			fields           fields
+			args             args
+			wantPodsToDelete []string
+			wantPodsToKeep   []string
+		} {
+			nodeset := newNodeSet("foo", controller.Name, 2)
+			nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.RollingUpdateNodeSetStrategyType
+			nodeset.Spec.UpdateStrategy.RollingUpdate = slinkyv1beta1.RollingUpdateNodeSetStrategy{
+				MaxUnavailable: ptr.To(intstr.FromString("100%")),
+				MaxSurge:       ptr.To(intstr.FromInt32(1)),
+			}
+			pods := []*corev1.Pod{
+				makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")),
+				makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, "")),
+				makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 2, hash)),
+			}
+			pods[2].Labels[slinkyv1beta1.LabelNodeSetPodSurge] = "true"
+			slurmNodeList := &slurmtypes.V0044NodeList{
+				Items: func(pods ...*corev1.Pod) []slurmtypes.V0044Node {
+					nodeList := make([]slurmtypes.V0044Node, 0, len(pods))
+					for _, pod := range pods {
+						nodeList = append(nodeList, *newNodeSetPodSlurmNode(pod))
+					}
+					return nodeList
+				}(pods[2]),
+			}
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, slurmNodeList)
+			return struct {
+				name             string
+				fields           fields
+				args             args
+				wantPodsToDelete []string
+				wantPodsToKeep   []string
+			}{
+				name: "RollingUpdate, with surge available",
+				fields: fields{
+					Client:    fake.NewFakeClient(),
+					ClientMap: newClientMap(controller.Name, slurmClient),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+					pods:    pods,
+					hash:    hash,
+				},
+				wantPodsToDelete: []string{"foo-1"},
+				wantPodsToKeep:   []string{"foo-0", "foo-2"},
+			}
+		}(),
+		func() struct {
+			name             string
+			fields           fields
+			args             args
+			wantPodsToDelete []string
+			wantPodsToKeep   []string
+		} {
+			nodeset := newNodeSet("foo", controller.Name, 2)
+			nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.RollingUpdateNodeSetStrategyType
+			nodeset.Spec.UpdateStrategy.RollingUpdate = slinkyv1beta1.RollingUpdateNodeSetStrategy{
+				MaxUnavailable: ptr.To(intstr.FromString("100%")),
+				MaxSurge:       ptr.To(intstr.FromInt32(1)),
+			}
+			pods := []*corev1.Pod{
+				makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")),
+				makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, "")),
+				makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 2, hash)),
+			}
+			pods[2].Labels[slinkyv1beta1.LabelNodeSetPodSurge] = "true"
+			slurmNodeList := &slurmtypes.V0044NodeList{
+				Items: func(pods ...*corev1.Pod) []slurmtypes.V0044Node {
+					nodeList := make([]slurmtypes.V0044Node, 0, len(pods))
+					for _, pod := range pods {
+						nodeList = append(nodeList, *newNodeSetPodSlurmNode(pod))
+					}
+					return nodeList
+				}(),
+			}
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, slurmNodeList)
+			return struct {
+				name             string
+				fields           fields
+				args             args
+				wantPodsToDelete []string
+				wantPodsToKeep   []string
+			}{
+				name: "RollingUpdate, with surge unavailable",
+				fields: fields{
+					Client:    fake.NewFakeClient(),
+					ClientMap: newClientMap(controller.Name, slurmClient),
+				},
+				args: args{
+					ctx:     context.TODO(),
+					nodeset: nodeset,
+					pods:    pods,
+					hash:    hash,
+				},
+				wantPodsToDelete: []string{},
+				wantPodsToKeep:   []string{"foo-0", "foo-1", "foo-2"},
+			}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newNodeSetController(tt.fields.Client, tt.fields.ClientMap)
+			gotPodsToDelete, gotPodsToKeep := r.splitUpdatePods(tt.args.ctx, tt.args.nodeset, tt.args.pods, tt.args.hash)
 
 			gotPodsToDeleteOrdered := make([]string, len(gotPodsToDelete))
 			for i := range gotPodsToDelete {
@@ -3320,6 +3990,209 @@ func TestNodeSetReconciler_splitUpdatePods(t *testing.T) {
 	}
 }
 
+func TestNodeSetReconciler_syncSurgePods(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	const hash = "12345"
+	type fields struct {
+		Client    client.Client
+		ClientMap *clientmap.ClientMap
+	}
+	type args struct {
+		ctx      context.Context
+		nodeset  *slinkyv1beta1.NodeSet
+		pods     []*corev1.Pod
+		hash     string
+		maxSurge int
+	}
+	type testCaseFields struct {
+		name             string
+		fields           fields
+		args             args
+		wantSurgePods    []string
+		wantCordonedPods []string
+		wantErr          bool
+	}
+	tests := []testCaseFields{
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 2)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(1))
+			pod0 := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, ""))
+			pod1 := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, ""))
+			slurmClient := newFakeClientList(sinterceptor.Funcs{})
+			return testCaseFields{
+				name: "Create surge pods",
+				fields: fields{
+					Client:    fake.NewFakeClient(controller, nodeset, pod0, pod1),
+					ClientMap: newClientMap(controller.Name, slurmClient),
+				},
+				args: args{
+					ctx:      context.TODO(),
+					nodeset:  nodeset,
+					pods:     []*corev1.Pod{pod0, pod1},
+					hash:     hash,
+					maxSurge: 1,
+				},
+				wantSurgePods:    []string{"foo-2"},
+				wantCordonedPods: []string{},
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 2)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(1))
+			pod0 := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, hash))
+			pod1 := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, hash))
+			pod2 := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 2, hash))
+			pod2.Labels[slinkyv1beta1.LabelNodeSetPodSurge] = "true"
+			slurmNodeList := &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					*newNodeSetPodSlurmNode(pod0),
+					*newNodeSetPodSlurmNode(pod1),
+					*newNodeSetPodSlurmNode(pod2),
+				},
+			}
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, slurmNodeList)
+			return testCaseFields{
+				name: "Delete surge pods after update",
+				fields: fields{
+					Client:    fake.NewFakeClient(controller, nodeset, pod0, pod1, pod2),
+					ClientMap: newClientMap(controller.Name, slurmClient),
+				},
+				args: args{
+					ctx:      context.TODO(),
+					nodeset:  nodeset,
+					pods:     []*corev1.Pod{pod0, pod1, pod2},
+					hash:     hash,
+					maxSurge: 1,
+				},
+				wantSurgePods:    []string{"foo-2"},
+				wantCordonedPods: []string{"foo-2"},
+			}
+		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", controller.Name, 2)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(1))
+			pod0 := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, hash))
+			pod1 := makePodCreated(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, hash))
+			pod2 := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 2, hash))
+			pod2.Labels[slinkyv1beta1.LabelNodeSetPodSurge] = "true"
+			slurmNodeList := &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					*newNodeSetPodSlurmNode(pod0),
+					*newNodeSetPodSlurmNode(pod2),
+				},
+			}
+			slurmClient := newFakeClientList(sinterceptor.Funcs{}, slurmNodeList)
+			return testCaseFields{
+				name: "Keep surge pods until replicas are available",
+				fields: fields{
+					Client:    fake.NewFakeClient(controller, nodeset, pod0, pod1, pod2),
+					ClientMap: newClientMap(controller.Name, slurmClient),
+				},
+				args: args{
+					ctx:      context.TODO(),
+					nodeset:  nodeset,
+					pods:     []*corev1.Pod{pod0, pod1, pod2},
+					hash:     hash,
+					maxSurge: 1,
+				},
+				wantSurgePods:    []string{"foo-2"},
+				wantCordonedPods: []string{},
+			}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newNodeSetController(tt.fields.Client, tt.fields.ClientMap)
+			if err := r.syncSurgePods(tt.args.ctx, tt.args.nodeset, tt.args.pods, tt.args.hash, tt.args.maxSurge); (err != nil) != tt.wantErr {
+				t.Errorf("NodeSetReconciler.syncSurgePods() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			podList := &corev1.PodList{}
+			if err := r.List(tt.args.ctx, podList); err != nil {
+				t.Fatalf("failed to list pods: %v", err)
+			}
+			gotSurgePods := []string{}
+			gotCordonedPods := []string{}
+			for _, pod := range podList.Items {
+				if podutils.IsPodSurge(&pod) {
+					gotSurgePods = append(gotSurgePods, pod.Name)
+				}
+				if podutils.IsPodCordon(&pod) {
+					gotCordonedPods = append(gotCordonedPods, pod.Name)
+				}
+			}
+			slices.Sort(gotSurgePods)
+			slices.Sort(gotCordonedPods)
+			if diff := cmp.Diff(tt.wantSurgePods, gotSurgePods); diff != "" {
+				t.Errorf("surge pods (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantCordonedPods, gotCordonedPods); diff != "" {
+				t.Errorf("cordoned pods (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_splitSurgePods(t *testing.T) {
+	newPod := func(name string, surge bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{},
+			},
+		}
+		if surge {
+			pod.Labels[slinkyv1beta1.LabelNodeSetPodSurge] = "true"
+		}
+		return pod
+	}
+	tests := []struct {
+		name            string
+		pods            []*corev1.Pod
+		wantSurgePods   []string
+		wantReplicaPods []string
+	}{
+		{
+			name:            "No surge pods",
+			pods:            []*corev1.Pod{newPod("foo-0", false), newPod("foo-1", false)},
+			wantSurgePods:   []string{},
+			wantReplicaPods: []string{"foo-0", "foo-1"},
+		},
+		{
+			name:            "Surge pod by label",
+			pods:            []*corev1.Pod{newPod("foo-0", false), newPod("foo-1", true), newPod("foo-3", false)},
+			wantSurgePods:   []string{"foo-1"},
+			wantReplicaPods: []string{"foo-0", "foo-3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSurgePods, gotReplicaPods := splitSurgePods(tt.pods)
+
+			gotSurgePodNames := make([]string, len(gotSurgePods))
+			for i := range gotSurgePods {
+				gotSurgePodNames[i] = gotSurgePods[i].Name
+			}
+			gotReplicaPodNames := make([]string, len(gotReplicaPods))
+			for i := range gotReplicaPods {
+				gotReplicaPodNames[i] = gotReplicaPods[i].Name
+			}
+
+			if diff := cmp.Diff(tt.wantSurgePods, gotSurgePodNames); diff != "" {
+				t.Errorf("gotSurgePods (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantReplicaPods, gotReplicaPodNames); diff != "" {
+				t.Errorf("gotReplicaPods (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func Test_findUpdatedPods(t *testing.T) {
 	type args struct {
 		pods []*corev1.Pod
@@ -3428,6 +4301,69 @@ func TestNodeSetReconciler_syncClusterWorkerService(t *testing.T) {
 	}
 }
 
+func TestNodeSetReconciler_syncNodeConfig(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	withConfigFile := func(nodeset *slinkyv1beta1.NodeSet) *slinkyv1beta1.NodeSet {
+		nodeset.Spec.ConfigFileRefs = []corev1.LocalObjectReference{{Name: "gpu-config"}}
+		return nodeset
+	}
+	type fields struct {
+		Client client.Client
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				Client: fake.NewFakeClient(),
+			},
+			args: args{
+				ctx:     context.TODO(),
+				nodeset: newNodeSet("gpu-1", controller.Name, 2),
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing configMap",
+			fields: fields{
+				Client: fake.NewFakeClient(),
+			},
+			args: args{
+				ctx:     context.TODO(),
+				nodeset: withConfigFile(newNodeSet("gpu-1", controller.Name, 2)),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newNodeSetController(tt.fields.Client, nil)
+			if err := r.syncNodeConfig(tt.args.ctx, tt.args.nodeset); (err != nil) != tt.wantErr {
+				t.Errorf("NodeSetReconciler.syncNodeConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			config := &corev1.ConfigMap{}
+			if err := tt.fields.Client.Get(tt.args.ctx, tt.args.nodeset.NodeConfigKey(), config); err != nil {
+				t.Errorf("failed to get node config: %v", err)
+			}
+		})
+	}
+}
+
 func Test_isNodeCordoned(t *testing.T) {
 
 	type fields struct {
@@ -3811,13 +4747,36 @@ func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 	pod2 := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, "")
 	pod2.Spec.NodeName = node2.Name
 
+	nodesetWithLabels := nodeset.DeepCopy()
+	nodesetWithLabels.Spec.TopologyLabels = []slinkyv1beta1.NodeSetTopologyLabel{
+		{Topology: "topo-switch", Key: "topology.kubernetes.io/zone"},
+		{Topology: "topo-block", Key: "example.com/rack"},
+	}
+	newSlurmClientMap := func() *clientmap.ClientMap {
+		nodeList := &slurmtypes.V0044NodeList{
+			Items: []slurmtypes.V0044Node{
+				{
+					V0044Node: slurmapi.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod2)),
+						State: ptr.To([]slurmapi.V0044NodeState{
+							slurmapi.V0044NodeStateIDLE,
+						}),
+					},
+				},
+			},
+		}
+		sclient := newFakeClientList(sinterceptor.Funcs{}, nodeList)
+		return newClientMap(controller.Name, sclient)
+	}
+
 	tests := []struct {
-		name      string
-		client    client.Client
-		clientMap *clientmap.ClientMap
-		nodeset   *slinkyv1beta1.NodeSet
-		pods      []*corev1.Pod
-		wantErr   bool
+		name             string
+		client           client.Client
+		clientMap        *clientmap.ClientMap
+		nodeset          *slinkyv1beta1.NodeSet
+		pods             []*corev1.Pod
+		wantTopologySpec string
+		wantErr          bool
 	}{
 		{
 			name:      "pending",
@@ -3826,6 +4785,21 @@ func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 			nodeset:   nodeset,
 			pods:      []*corev1.Pod{pod.DeepCopy()},
 		},
+		{
+			name: "allocated, topology labels",
+			client: func() client.Client {
+				node2 := node2.DeepCopy()
+				node2.Labels = map[string]string{
+					"topology.kubernetes.io/zone": "zone-a",
+					"example.com/rack":            "rack-1",
+				}
+				return fake.NewFakeClient(node.DeepCopy(), node2, pod2.DeepCopy())
+			}(),
+			clientMap:        newSlurmClientMap(),
+			nodeset:          nodesetWithLabels,
+			pods:             []*corev1.Pod{pod2.DeepCopy()},
+			wantTopologySpec: "topo-block:b0,topo-switch:zone-a",
+		},
 		{
 			name:   "allocated",
 			client: fake.NewFakeClient(node.DeepCopy(), node2.DeepCopy(), pod2.DeepCopy()),
@@ -3845,8 +4819,9 @@ func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 				sclient := newFakeClientList(sinterceptor.Funcs{}, nodeList)
 				return newClientMap(controller.Name, sclient)
 			}(),
-			nodeset: nodeset,
-			pods:    []*corev1.Pod{pod2.DeepCopy()},
+			nodeset:          nodeset,
+			pods:             []*corev1.Pod{pod2.DeepCopy()},
+			wantTopologySpec: "topo-block:b0",
 		},
 	}
 	for _, tt := range tests {
@@ -3876,7 +4851,7 @@ func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 				if err := tt.client.Get(ctx, checkNodeKey, checkNode); err != nil {
 					t.Errorf("Get() failed: %v", err)
 				}
-				topologySpec := checkNode.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec]
+				topologySpec := tt.wantTopologySpec
 				if !apiequality.Semantic.DeepEqual(checkPod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec], topologySpec) {
 					t.Errorf("pod and node topology are incongruent: node = '%v' ; pod = '%v'", topologySpec, checkPod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec])
 				}
@@ -3902,6 +4877,178 @@ func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 	}
 }
 
+func TestNodeSetReconciler_syncSlurmFeatures(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+			Labels: map[string]string{
+				"nvidia.com/gpu.product":      "NVIDIA-H100-80GB-HBM3",
+				"topology.kubernetes.io/zone": "zone-a",
+			},
+		},
+	}
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 2)
+	nodesetWithLabels := nodeset.DeepCopy()
+	nodesetWithLabels.Spec.FeatureLabels = []slinkyv1beta1.NodeSetFeatureLabel{
+		{
+			Key:    "nvidia.com/gpu.product",
+			Values: map[string]string{"NVIDIA-H100-80GB-HBM3": "h100"},
+		},
+		{Key: "topology.kubernetes.io/zone"},
+	}
+	nodesetWithClaims := nodeset.DeepCopy()
+	nodesetWithClaims.Spec.ResourceClaimTemplates = []slinkyv1beta1.NodeSetResourceClaimTemplate{
+		{Name: "gpu", Features: []string{"nvlink"}},
+		{Name: "nic", Features: []string{"rdma"}},
+	}
+	pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
+	pod2 := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, "")
+	pod2.Spec.NodeName = node.Name
+	allocatedClaim := &resourcev1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: nodeset.Namespace,
+			Name:      nodesetutils.GetResourceClaimNameOrdinal(nodeset, &nodesetWithClaims.Spec.ResourceClaimTemplates[0], "1"),
+		},
+		Status: resourcev1.ResourceClaimStatus{
+			Allocation: &resourcev1.AllocationResult{},
+		},
+	}
+	pendingClaim := &resourcev1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: nodeset.Namespace,
+			Name:      nodesetutils.GetResourceClaimNameOrdinal(nodeset, &nodesetWithClaims.Spec.ResourceClaimTemplates[1], "1"),
+		},
+	}
+	newSlurmClientMap := func() *clientmap.ClientMap {
+		nodeList := &slurmtypes.V0044NodeList{
+			Items: []slurmtypes.V0044Node{
+				{
+					V0044Node: slurmapi.V0044Node{
+						Name:     ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						Features: ptr.To(slurmapi.V0044CsvString{"foo"}),
+					},
+				},
+				{
+					V0044Node: slurmapi.V0044Node{
+						Name:     ptr.To(nodesetutils.GetSlurmNodeName(pod2)),
+						Features: ptr.To(slurmapi.V0044CsvString{"foo"}),
+					},
+				},
+			},
+		}
+		sclient := newFakeClientList(sinterceptor.Funcs{}, nodeList)
+		return newClientMap(controller.Name, sclient)
+	}
+
+	tests := []struct {
+		name         string
+		client       client.Client
+		clientMap    *clientmap.ClientMap
+		nodeset      *slinkyv1beta1.NodeSet
+		pods         []*corev1.Pod
+		wantFeatures map[string][]string
+		wantErr      bool
+	}{
+		{
+			name:      "no featureLabels",
+			client:    fake.NewFakeClient(node.DeepCopy(), pod2.DeepCopy()),
+			clientMap: newSlurmClientMap(),
+			nodeset:   nodeset,
+			pods:      []*corev1.Pod{pod2.DeepCopy()},
+			wantFeatures: map[string][]string{
+				nodesetutils.GetSlurmNodeName(pod2): {"foo"},
+			},
+		},
+		{
+			name:      "pending",
+			client:    fake.NewFakeClient(node.DeepCopy(), pod.DeepCopy()),
+			clientMap: newSlurmClientMap(),
+			nodeset:   nodesetWithLabels,
+			pods:      []*corev1.Pod{pod.DeepCopy()},
+			wantFeatures: map[string][]string{
+				nodesetutils.GetSlurmNodeName(pod): {"foo"},
+			},
+		},
+		{
+			name:      "allocated",
+			client:    fake.NewFakeClient(node.DeepCopy(), pod2.DeepCopy()),
+			clientMap: newSlurmClientMap(),
+			nodeset:   nodesetWithLabels,
+			pods:      []*corev1.Pod{pod2.DeepCopy()},
+			wantFeatures: map[string][]string{
+				nodesetutils.GetSlurmNodeName(pod2): {"foo", "h100", "zone-a"},
+			},
+		},
+		{
+			name:   "unchanged features",
+			client: fake.NewFakeClient(node.DeepCopy(), pod2.DeepCopy()),
+			clientMap: func() *clientmap.ClientMap {
+				nodeList := &slurmtypes.V0044NodeList{
+					Items: []slurmtypes.V0044Node{
+						{
+							V0044Node: slurmapi.V0044Node{
+								Name:     ptr.To(nodesetutils.GetSlurmNodeName(pod2)),
+								Features: ptr.To(slurmapi.V0044CsvString{"zone-a", "h100", "foo"}),
+							},
+						},
+					},
+				}
+				// The Slurm node is not updated when its features are identical.
+				sclient := newFakeClientList(sinterceptor.Funcs{
+					Update: func(ctx context.Context, obj slurmobject.Object, req any, opts ...slurmclient.UpdateOption) error {
+						return errors.New("unexpected update")
+					},
+				}, nodeList)
+				return newClientMap(controller.Name, sclient)
+			}(),
+			nodeset: nodesetWithLabels,
+			pods:    []*corev1.Pod{pod2.DeepCopy()},
+			wantFeatures: map[string][]string{
+				nodesetutils.GetSlurmNodeName(pod2): {"zone-a", "h100", "foo"},
+			},
+		},
+		{
+			name:      "allocated resource claims",
+			client:    fake.NewFakeClient(node.DeepCopy(), pod2.DeepCopy(), allocatedClaim.DeepCopy(), pendingClaim.DeepCopy()),
+			clientMap: newSlurmClientMap(),
+			nodeset:   nodesetWithClaims,
+			pods:      []*corev1.Pod{pod2.DeepCopy()},
+			wantFeatures: map[string][]string{
+				nodesetutils.GetSlurmNodeName(pod2): {"foo", "nvlink"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := newNodeSetController(tt.client, tt.clientMap)
+			if err := r.syncSlurmFeatures(ctx, tt.nodeset, tt.pods); (err != nil) != tt.wantErr {
+				t.Errorf("syncSlurmFeatures() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			mapKey := types.NamespacedName{
+				Namespace: tt.nodeset.Namespace,
+				Name:      tt.nodeset.Spec.ControllerRef.Name,
+			}
+			sclient := tt.clientMap.Get(mapKey)
+			for nodeName, want := range tt.wantFeatures {
+				slurmNode := &slurmtypes.V0044Node{}
+				if err := sclient.Get(ctx, slurmclient.ObjectKey(nodeName), slurmNode); err != nil {
+					t.Fatalf("Get() failed: %v", err)
+				}
+				got := []string(ptr.Deref(slurmNode.Features, slurmapi.V0044CsvString{}))
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("slurm node %s features (-want,+got):\n%s", nodeName, diff)
+				}
+			}
+		})
+	}
+}
+
 func TestGetNodesToDaemonPods(t *testing.T) {
 	nodeset := newNodeSet("foo", "ctrl", 1)
 	nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
@@ -12,6 +12,7 @@ import (
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -43,6 +44,7 @@ var (
 type PodControlInterface interface {
 	CreateNodeSetPod(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
 	DeleteNodeSetPod(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
+	ForceDeleteNodeSetPod(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
 	UpdateNodeSetPod(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
 
 	PodPVCsMatchRetentionPolicy(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error)
@@ -59,17 +61,21 @@ type realPodControl struct {
 
 // CreateNodeSetPod implements PodControlInterface.
 func (r *realPodControl) CreateNodeSetPod(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
-	// Create the Pod's PVCs prior to creating the Pod
+	// Create the Pod's PVCs and ResourceClaims prior to creating the Pod
 	if err := r.createPersistentVolumeClaims(ctx, nodeset, pod); err != nil {
 		r.recordPodEvent(eventCreate, nodeset, pod, err)
 		return err
 	}
-	// If we created the PVCs attempt to create the Pod
+	if err := r.createResourceClaims(ctx, nodeset, pod); err != nil {
+		r.recordPodEvent(eventCreate, nodeset, pod, err)
+		return err
+	}
+	// If we created the claims attempt to create the Pod
 	err := r.podControl.CreateThisPod(ctx, pod, nodeset)
 	if apierrors.IsAlreadyExists(err) {
 		return err
 	}
-	// Set PVC policy as much as is possible at this point.
+	// Set claim policy as much as is possible at this point.
 	if err := r.UpdatePodPVCsForRetentionPolicy(ctx, nodeset, pod); err != nil {
 		r.recordPodEvent(eventUpdate, nodeset, pod, err)
 		return err
@@ -82,6 +88,13 @@ func (r *realPodControl) DeleteNodeSetPod(ctx context.Context, nodeset *slinkyv1
 	return r.podControl.DeletePod(ctx, pod.Namespace, pod.Name, nodeset)
 }
 
+// ForceDeleteNodeSetPod implements PodControlInterface.
+func (r *realPodControl) ForceDeleteNodeSetPod(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
+	err := r.Delete(ctx, pod, client.GracePeriodSeconds(0))
+	r.recordPodEvent(eventDelete, nodeset, pod, err)
+	return err
+}
+
 // UpdatePod implements PodControlInterface.
 func (r *realPodControl) UpdateNodeSetPod(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
 	attemptedUpdate := false
@@ -149,35 +162,20 @@ func (r *realPodControl) UpdateNodeSetPod(ctx context.Context, nodeset *slinkyv1
 	return err
 }
 
-// PodPVCsMatchRetentionPolicy returns false if the PVCs for pod are not consistent with nodeset's PVC deletion policy.
-// An error is returned if something is not consistent. This is expected if the pod is being otherwise updated,
-// but a problem otherwise (see usage of this method in UpdateNodeSetPod).
+// PodPVCsMatchRetentionPolicy returns false if the PVCs and ResourceClaims for pod are not consistent with nodeset's
+// PVC deletion policy. An error is returned if something is not consistent. This is expected if the pod is being
+// otherwise updated, but a problem otherwise (see usage of this method in UpdateNodeSetPod).
 func (r *realPodControl) PodPVCsMatchRetentionPolicy(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error) {
 	logger := klog.FromContext(ctx)
-	var paddedOrdinal string
-	if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeStatefulset {
-		ordinal := nodesetutils.GetOrdinal(pod)
-		paddedOrdinal = nodesetutils.GetPaddedOrdinal(nodeset, ordinal)
-	}
-	templates := nodeset.Spec.VolumeClaimTemplates
-	for i := range templates {
-		var claimName string
-		if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeStatefulset {
-			claimName = nodesetutils.GetPersistentVolumeClaimNameOrdinal(nodeset, &templates[i], paddedOrdinal)
-		} else {
-			claimName = nodesetutils.GetPersistentVolumeClaimNameNodeName(nodeset, &templates[i], pod.Labels[slinkyv1beta1.LabelNodeSetPodHostname])
-		}
-		claim := &corev1.PersistentVolumeClaim{}
-		claimId := types.NamespacedName{
-			Namespace: nodeset.Namespace,
-			Name:      claimName,
-		}
+	for _, expected := range getPodClaims(nodeset, pod) {
+		claim := newClaimObject(expected)
+		claimId := client.ObjectKeyFromObject(expected)
 		err := r.Get(ctx, claimId, claim)
 		switch {
 		case apierrors.IsNotFound(err):
-			klog.FromContext(ctx).V(4).Info("Expected claim missing, continuing to pick up in next iteration", "PVC", klog.KObj(claim))
+			logger.V(4).Info("Expected claim missing, continuing to pick up in next iteration", "claim", klog.KObj(expected))
 		case err != nil:
-			return false, fmt.Errorf("could not retrieve claim %s for %s when checking PVC deletion policy", claimName, pod.Name)
+			return false, fmt.Errorf("could not retrieve claim %s for %s when checking PVC deletion policy", claimId.Name, pod.Name)
 		default:
 			if !isClaimOwnerUpToDate(logger, claim, nodeset, pod) {
 				return false, nil
@@ -190,41 +188,26 @@ func (r *realPodControl) PodPVCsMatchRetentionPolicy(ctx context.Context, nodese
 // UpdatePodPVCsForRetentionPolicy implements PodControlInterface.
 func (r *realPodControl) UpdatePodPVCsForRetentionPolicy(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
 	logger := klog.FromContext(ctx)
-	var paddedOrdinal string
-	if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeStatefulset {
-		ordinal := nodesetutils.GetOrdinal(pod)
-		paddedOrdinal = nodesetutils.GetPaddedOrdinal(nodeset, ordinal)
-	}
-	templates := nodeset.Spec.VolumeClaimTemplates
-	for i := range templates {
-		var claimName string
-		if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeStatefulset {
-			claimName = nodesetutils.GetPersistentVolumeClaimNameOrdinal(nodeset, &templates[i], paddedOrdinal)
-		} else {
-			claimName = nodesetutils.GetPersistentVolumeClaimNameNodeName(nodeset, &templates[i], pod.Labels[slinkyv1beta1.LabelNodeSetPodHostname])
-		}
-		claimId := types.NamespacedName{
-			Namespace: nodeset.Namespace,
-			Name:      claimName,
-		}
-		claim := &corev1.PersistentVolumeClaim{}
+	for _, expected := range getPodClaims(nodeset, pod) {
+		claim := newClaimObject(expected)
+		claimId := client.ObjectKeyFromObject(expected)
 		err := r.Get(ctx, claimId, claim)
 		switch {
 		case apierrors.IsNotFound(err):
-			logger.V(4).Info("Expected claim missing, continuing to pick up in next iteration", "PVC", klog.KObj(claim))
+			logger.V(4).Info("Expected claim missing, continuing to pick up in next iteration", "claim", klog.KObj(expected))
 		case err != nil:
-			return fmt.Errorf("could not retrieve claim %s not found for %s when checking PVC deletion policy: %w", claimName, pod.Name, err)
+			return fmt.Errorf("could not retrieve claim %s not found for %s when checking PVC deletion policy: %w", claimId.Name, pod.Name, err)
 		default:
 			if hasUnexpectedController(claim, nodeset, pod) {
 				// Add an event so the user knows they're in a strange configuration. The claim will be cleaned up below.
-				msg := fmt.Sprintf("PersistentVolumeClaim %s has a conflicting OwnerReference that acts as a managing controller, the retention policy is ignored for this claim", claimName)
+				msg := fmt.Sprintf("%s %s has a conflicting OwnerReference that acts as a managing controller, the retention policy is ignored for this claim", claimKind(claim), claimId.Name)
 				r.recorder.Eventf(nodeset, claim, corev1.EventTypeWarning, "ConflictingController", "Info", msg)
 			}
 			if !isClaimOwnerUpToDate(logger, claim, nodeset, pod) {
-				claim = claim.DeepCopy() // Make a copy so we don't mutate the shared cache.
+				claim = claim.DeepCopyObject().(client.Object) // Make a copy so we don't mutate the shared cache.
 				updateClaimOwnerRefForSetAndPod(logger, claim, nodeset, pod)
 				if err := r.Update(ctx, claim); err != nil {
-					return fmt.Errorf("could not update claim %s for delete policy ownerRefs: %w", claimName, err)
+					return fmt.Errorf("could not update claim %s for delete policy ownerRefs: %w", claimId.Name, err)
 				}
 			}
 		}
@@ -232,22 +215,18 @@ func (r *realPodControl) UpdatePodPVCsForRetentionPolicy(ctx context.Context, no
 	return nil
 }
 
-// IsPodPVCsStale returns true for a stale PVC that should block pod creation. If the scaling
-// policy is deletion, and a PVC has an ownerRef that does not match the pod, the PVC is stale. This
+// IsPodPVCsStale returns true for a stale PVC or ResourceClaim that should block pod creation. If the scaling
+// policy is deletion, and a claim has an ownerRef that does not match the pod, the claim is stale. This
 // includes pods whose UID has not been created.
 func (r *realPodControl) IsPodPVCsStale(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error) {
 	policy := getPersistentVolumeClaimRetentionPolicy(nodeset)
 	if policy.WhenScaled == slinkyv1beta1.RetainPersistentVolumeClaimRetentionPolicyType {
-		// PVCs are meant to be reused and so can't be stale.
+		// Claims are meant to be reused and so can't be stale.
 		return false, nil
 	}
-	for _, claim := range nodesetutils.GetPersistentVolumeClaims(nodeset, pod) {
-		pvc := &corev1.PersistentVolumeClaim{}
-		pvcId := types.NamespacedName{
-			Namespace: claim.Namespace,
-			Name:      claim.Name,
-		}
-		err := r.Get(ctx, pvcId, pvc)
+	for _, expected := range getPodClaims(nodeset, pod) {
+		claim := newClaimObject(expected)
+		err := r.Get(ctx, client.ObjectKeyFromObject(expected), claim)
 		switch {
 		case apierrors.IsNotFound(err):
 			// If the claim doesn't exist yet, it can't be stale.
@@ -255,7 +234,7 @@ func (r *realPodControl) IsPodPVCsStale(ctx context.Context, nodeset *slinkyv1be
 		case err != nil:
 			return false, err
 		default:
-			if hasStaleOwnerRef(pvc, pod, podGVK) {
+			if hasStaleOwnerRef(claim, pod, podGVK) {
 				return true, nil
 			}
 		}
@@ -315,25 +294,91 @@ func (r *realPodControl) createPersistentVolumeClaims(ctx context.Context, nodes
 	return errorutils.NewAggregate(errs)
 }
 
-// recordClaimEvent records an event for verb applied to the PersistentVolumeClaim of a Pod in a NodeSet. If err is
+// createResourceClaims creates all of the required ResourceClaims for pod, which must be a member of nodeset. If all
+// of the claims for Pod are successfully created, the returned error is nil. If creation fails, this method may be
+// called again until no error is returned. The spec of an existing claim is immutable and is left as is.
+func (r *realPodControl) createResourceClaims(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
+	var errs []error
+	for _, claim := range nodesetutils.GetResourceClaims(nodeset, pod) {
+		claimId := types.NamespacedName{
+			Namespace: nodeset.Namespace,
+			Name:      claim.Name,
+		}
+		existing := &resourcev1.ResourceClaim{}
+		err := r.Get(ctx, claimId, existing)
+		switch {
+		case apierrors.IsNotFound(err):
+			if err := r.Create(ctx, &claim); err != nil {
+				errs = append(errs, fmt.Errorf("failed to create ResourceClaim %s: %w", claim.Name, err))
+			}
+			if err == nil || !apierrors.IsAlreadyExists(err) {
+				r.recordClaimEvent(eventCreate, nodeset, pod, &claim, err)
+			}
+		case err != nil:
+			errs = append(errs, fmt.Errorf("failed to retrieve ResourceClaim %s: %w", claim.Name, err))
+			r.recordClaimEvent(eventCreate, nodeset, pod, &claim, err)
+		default:
+			if existing.DeletionTimestamp != nil {
+				errs = append(errs, fmt.Errorf("resourceclaim %s is being deleted", claim.Name))
+			}
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// recordClaimEvent records an event for verb applied to the PersistentVolumeClaim or ResourceClaim of a Pod in a NodeSet. If err is
 // nil the generated event will have a reason of corev1.EventTypeNormal. If err is not nil the generated event will have a
 // reason of corev1.EventTypeWarning.
-func (r *realPodControl) recordClaimEvent(verb string, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, claim *corev1.PersistentVolumeClaim, err error) {
+func (r *realPodControl) recordClaimEvent(verb string, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, claim client.Object, err error) {
 	caser := cases.Title(language.English)
 	verbStr := caser.String(verb)
 	if err == nil {
 		reason := fmt.Sprintf("Successful%s", caser.String(verb))
 		message := fmt.Sprintf("%s Claim: %s Pod %s",
-			strings.ToLower(verb), claim.Name, pod.Name)
+			strings.ToLower(verb), claim.GetName(), pod.Name)
 		r.recorder.Eventf(nodeset, claim, corev1.EventTypeNormal, reason, verbStr, message)
 	} else {
 		reason := fmt.Sprintf("Failed%s", caser.String(verb))
 		message := fmt.Sprintf("%s Claim: %s for Pod %s failed: %s",
-			strings.ToLower(verb), claim.Name, pod.Name, err)
+			strings.ToLower(verb), claim.GetName(), pod.Name, err)
 		r.recorder.Eventf(nodeset, claim, corev1.EventTypeWarning, reason, verbStr, message)
 	}
 }
 
+// getPodClaims returns the PersistentVolumeClaims and ResourceClaims of pod, as defined in nodeset.
+func getPodClaims(nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) []client.Object {
+	pvcs := nodesetutils.GetPersistentVolumeClaims(nodeset, pod)
+	resourceClaims := nodesetutils.GetResourceClaims(nodeset, pod)
+	claims := make([]client.Object, 0, len(pvcs)+len(resourceClaims))
+	for _, claim := range pvcs {
+		claims = append(claims, &claim)
+	}
+	for _, claim := range resourceClaims {
+		claims = append(claims, &claim)
+	}
+	return claims
+}
+
+// newClaimObject returns an empty object of the same kind as claim.
+func newClaimObject(claim client.Object) client.Object {
+	switch claim.(type) {
+	case *resourcev1.ResourceClaim:
+		return &resourcev1.ResourceClaim{}
+	default:
+		return &corev1.PersistentVolumeClaim{}
+	}
+}
+
+// claimKind returns the kind of claim for messages.
+func claimKind(claim client.Object) string {
+	switch claim.(type) {
+	case *resourcev1.ResourceClaim:
+		return "ResourceClaim"
+	default:
+		return "PersistentVolumeClaim"
+	}
+}
+
 var _ PodControlInterface = &realPodControl{}
 
 func NewPodControl(client client.Client, recorder events.EventRecorder) PodControlInterface {
@@ -354,7 +399,7 @@ func NewPodControl(client client.Client, recorder events.EventRecorder) PodContr
 // - Retain on scaling and delete on nodeset deletion: owner ref on the nodeset only.
 // - Delete on scaling and retain on nodeset deletion: owner ref on the pod only.
 // - Delete on scaling and nodeset deletion: owner refs on both nodeset and pod.
-func isClaimOwnerUpToDate(logger klog.Logger, claim *corev1.PersistentVolumeClaim, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) bool {
+func isClaimOwnerUpToDate(logger klog.Logger, claim metav1.Object, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) bool {
 	if hasStaleOwnerRef(claim, nodeset, slinkyv1beta1.NodeSetGVK) || hasStaleOwnerRef(claim, pod, podGVK) {
 		// The claim is being managed by previous, presumably deleted, version of the controller. It should not be touched.
 		return true
@@ -413,7 +458,7 @@ func isClaimOwnerUpToDate(logger klog.Logger, claim *corev1.PersistentVolumeClai
 // hasUnexpectedController returns true if the nodeset has a retention policy and there is a controller
 // for the claim that's not the nodeset or pod. Since the retention policy may have been changed, it is
 // always valid for the nodeset or pod to be a controller.
-func hasUnexpectedController(claim *corev1.PersistentVolumeClaim, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) bool {
+func hasUnexpectedController(claim metav1.Object, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) bool {
 	policy := getPersistentVolumeClaimRetentionPolicy(nodeset)
 	const retain = slinkyv1beta1.RetainPersistentVolumeClaimRetentionPolicyType
 	if policy.WhenScaled == retain && policy.WhenDeleted == retain {
@@ -446,7 +491,7 @@ func hasUnexpectedController(claim *corev1.PersistentVolumeClaim, nodeset *slink
 }
 
 // hasNonControllerOwner returns true if the pod or nodeset is an owner but not controller of the claim.
-func hasNonControllerOwner(claim *corev1.PersistentVolumeClaim, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) bool {
+func hasNonControllerOwner(claim metav1.Object, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) bool {
 	for _, ownerRef := range claim.GetOwnerReferences() {
 		if ownerRef.UID == nodeset.GetUID() || ownerRef.UID == pod.GetUID() {
 			if ownerRef.Controller == nil || !*ownerRef.Controller {
@@ -472,7 +517,7 @@ func removeRefs(refs []metav1.OwnerReference, predicate func(ref *metav1.OwnerRe
 // updateClaimOwnerRefForSetAndPod updates the ownerRefs for the claim according to the deletion policy of
 // the NodeSet. Returns true if the claim was changed and should be updated and false otherwise.
 // isClaimOwnerUpToDate should be called before this to avoid an expensive update operation.
-func updateClaimOwnerRefForSetAndPod(logger klog.Logger, claim *corev1.PersistentVolumeClaim, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) {
+func updateClaimOwnerRefForSetAndPod(logger klog.Logger, claim metav1.Object, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) {
 	refs := claim.GetOwnerReferences()
 
 	unexpectedController := hasUnexpectedController(claim, nodeset, pod)
@@ -545,7 +590,7 @@ func hasOwnerRef(target, owner metav1.Object) bool {
 
 // hasStaleOwnerRef returns true if target has a ref to owner that appears to be stale, that is,
 // the ref matches the object but not the UID.
-func hasStaleOwnerRef(target *corev1.PersistentVolumeClaim, obj metav1.Object, gvk schema.GroupVersionKind) bool {
+func hasStaleOwnerRef(target metav1.Object, obj metav1.Object, gvk schema.GroupVersionKind) bool {
 	for _, ownerRef := range target.GetOwnerReferences() {
 		if matchesRef(&ownerRef, obj, gvk) {
 			return ownerRef.UID != obj.GetUID()
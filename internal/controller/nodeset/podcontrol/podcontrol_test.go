@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -267,6 +268,66 @@ func Test_realPodControl_DeleteNodeSetPod(t *testing.T) {
 	}
 }
 
+func Test_realPodControl_ForceDeleteNodeSetPod(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+	nodeset := newNodeSet(2)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
+	type fields struct {
+		Client   client.Client
+		recorder events.EventRecorder
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Non-existent pod",
+			fields: fields{
+				Client:   fake.NewFakeClient(),
+				recorder: events.NewFakeRecorder(10),
+			},
+			args: args{
+				ctx:     context.TODO(),
+				nodeset: nodeset,
+				pod:     &corev1.Pod{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Existing pod",
+			fields: fields{
+				Client:   fake.NewFakeClient(pod.DeepCopy()),
+				recorder: events.NewFakeRecorder(10),
+			},
+			args: args{
+				ctx:     context.TODO(),
+				nodeset: nodeset,
+				pod:     pod.DeepCopy(),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewPodControl(tt.fields.Client, tt.fields.recorder)
+			if err := r.ForceDeleteNodeSetPod(tt.args.ctx, tt.args.nodeset, tt.args.pod); (err != nil) != tt.wantErr {
+				t.Errorf("realPodControl.ForceDeleteNodeSetPod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_realPodControl_UpdateNodeSetPod(t *testing.T) {
 	controller := &slinkyv1beta1.Controller{
 		ObjectMeta: metav1.ObjectMeta{
@@ -736,6 +797,178 @@ func Test_realPodControl_createPersistentVolumeClaims(t *testing.T) {
 	}
 }
 
+func newNodeSetWithResourceClaims(replicas int32) *slinkyv1beta1.NodeSet {
+	nodeset := newNodeSet(replicas)
+	nodeset.Spec.VolumeClaimTemplates = nil
+	nodeset.Spec.ResourceClaimTemplates = []slinkyv1beta1.NodeSetResourceClaimTemplate{
+		{
+			Name: "gpu",
+			Spec: resourcev1.ResourceClaimSpec{
+				Devices: resourcev1.DeviceClaim{
+					Requests: []resourcev1.DeviceRequest{
+						{
+							Name: "gpu",
+							Exactly: &resourcev1.ExactDeviceRequest{
+								DeviceClassName: "gpu.example.com",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return nodeset
+}
+
+func Test_realPodControl_createResourceClaims(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+	nodeset := newNodeSetWithResourceClaims(1)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
+	claim := &resourcev1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "gpu-foo-0",
+		},
+	}
+	type fields struct {
+		Client   client.Client
+		recorder events.EventRecorder
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantErr   bool
+		wantClaim bool
+	}{
+		{
+			name: "Create",
+			fields: fields{
+				Client:   fake.NewFakeClient(nodeset.DeepCopy()),
+				recorder: events.NewFakeRecorder(10),
+			},
+			args: args{
+				ctx:     context.TODO(),
+				nodeset: nodeset.DeepCopy(),
+				pod:     pod.DeepCopy(),
+			},
+			wantErr:   false,
+			wantClaim: true,
+		},
+		{
+			name: "Already Exists",
+			fields: fields{
+				Client:   fake.NewFakeClient(nodeset.DeepCopy(), claim.DeepCopy()),
+				recorder: events.NewFakeRecorder(10),
+			},
+			args: args{
+				ctx:     context.TODO(),
+				nodeset: nodeset.DeepCopy(),
+				pod:     pod.DeepCopy(),
+			},
+			wantErr:   false,
+			wantClaim: true,
+		},
+		{
+			name: "Deletion",
+			fields: fields{
+				Client: func() client.Client {
+					claim := claim.DeepCopy()
+					claim.DeletionTimestamp = ptr.To(metav1.Now())
+					claim.Finalizers = append(claim.Finalizers, "foo")
+					return fake.NewFakeClient(nodeset.DeepCopy(), claim)
+				}(),
+				recorder: events.NewFakeRecorder(10),
+			},
+			args: args{
+				ctx:     context.TODO(),
+				nodeset: nodeset.DeepCopy(),
+				pod:     pod.DeepCopy(),
+			},
+			wantErr:   true,
+			wantClaim: true,
+		},
+		{
+			name: "Create Error",
+			fields: fields{
+				Client: fake.NewClientBuilder().
+					WithInterceptorFuncs(interceptor.Funcs{
+						Create: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+							return http.ErrServerClosed
+						},
+					}).
+					WithRuntimeObjects(nodeset.DeepCopy()).
+					Build(),
+				recorder: events.NewFakeRecorder(10),
+			},
+			args: args{
+				ctx:     context.TODO(),
+				nodeset: nodeset.DeepCopy(),
+				pod:     pod.DeepCopy(),
+			},
+			wantErr:   true,
+			wantClaim: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newPodControl(tt.fields.Client, tt.fields.recorder)
+			if err := r.createResourceClaims(tt.args.ctx, tt.args.nodeset, tt.args.pod); (err != nil) != tt.wantErr {
+				t.Errorf("realPodControl.createResourceClaims() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			err := tt.fields.Client.Get(tt.args.ctx, client.ObjectKeyFromObject(claim), &resourcev1.ResourceClaim{})
+			if gotClaim := err == nil; gotClaim != tt.wantClaim {
+				t.Errorf("realPodControl.createResourceClaims() claim exists = %v, want %v", gotClaim, tt.wantClaim)
+			}
+		})
+	}
+}
+
+func Test_realPodControl_UpdatePodPVCsForRetentionPolicy_ResourceClaims(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+	nodeset := newNodeSetWithResourceClaims(1)
+	nodeset.Spec.PersistentVolumeClaimRetentionPolicy.WhenDeleted = slinkyv1beta1.DeletePersistentVolumeClaimRetentionPolicyType
+	pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
+	claim := &resourcev1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "gpu-foo-0",
+		},
+	}
+	c := fake.NewFakeClient(nodeset.DeepCopy(), pod.DeepCopy(), claim.DeepCopy())
+	r := NewPodControl(c, events.NewFakeRecorder(10))
+
+	if match, err := r.PodPVCsMatchRetentionPolicy(context.TODO(), nodeset, pod); err != nil || match {
+		t.Fatalf("realPodControl.PodPVCsMatchRetentionPolicy() = %v, %v, want false", match, err)
+	}
+	if err := r.UpdatePodPVCsForRetentionPolicy(context.TODO(), nodeset, pod); err != nil {
+		t.Fatalf("realPodControl.UpdatePodPVCsForRetentionPolicy() error = %v", err)
+	}
+	if match, err := r.PodPVCsMatchRetentionPolicy(context.TODO(), nodeset, pod); err != nil || !match {
+		t.Fatalf("realPodControl.PodPVCsMatchRetentionPolicy() = %v, %v, want true", match, err)
+	}
+	got := &resourcev1.ResourceClaim{}
+	if err := c.Get(context.TODO(), client.ObjectKeyFromObject(claim), got); err != nil {
+		t.Fatalf("failed to get ResourceClaim: %v", err)
+	}
+	if !hasOwnerRef(got, nodeset) {
+		t.Errorf("ResourceClaim ownerReferences = %v, want NodeSet", got.OwnerReferences)
+	}
+}
+
 func Test_isClaimOwnerUpToDate(t *testing.T) {
 	testCases := []struct {
 		name            string
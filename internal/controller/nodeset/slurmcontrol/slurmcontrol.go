@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
 	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/podinfo"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/reservationutils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/timestore"
 	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
@@ -43,14 +45,22 @@ type SlurmControlInterface interface {
 	UpdateNodeWithPodInfo(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
 	// UpdateNodeTopology handles updating the Node with its topologySpec.
 	UpdateNodeTopology(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, topologySpec string) error
+	// UpdateNodeFeatures handles updating the Node with its features.
+	UpdateNodeFeatures(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, features []string) error
 	// MakeNodeDrain handles adding the DRAIN state to the slurm node.
 	MakeNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string, overrideReason bool) error
 	// MakeNodeUndrain handles removing the DRAIN state from the slurm node.
 	MakeNodeUndrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error
+	// RequeueNodeJobs cancels the running jobs of the slurm node, and requeues those which allow it.
+	RequeueNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error
+	// CancelNodeJobs cancels the running jobs of the slurm node.
+	CancelNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
 	// IsNodeDrain checks if the slurm node has the DRAIN state.
 	IsNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error)
 	// IsNodeDrained checks if the slurm node is drained.
 	IsNodeDrained(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error)
+	// IsNodeAvailable checks if the slurm node is registered and available for work.
+	IsNodeAvailable(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error)
 	// IsNodeDownForUnresponsive checks if the slurm node is unresponsive
 	IsNodeDownForUnresponsive(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error)
 	// IsNodeReasonOurs reports if the node reason was set by the operator.
@@ -59,6 +69,12 @@ type SlurmControlInterface interface {
 	CalculateNodeStatus(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (SlurmNodeStatus, error)
 	// GetNodeDeadlines returns a map of node to its deadline time.Time calculated from running jobs.
 	GetNodeDeadlines(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (*timestore.TimeStore, error)
+	// GetNodeSetDemand returns the pending job demand and idle nodes of the NodeSet partition.
+	GetNodeSetDemand(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (SlurmNodeSetDemand, error)
+	// GetNodeFeatures returns a map of Slurm node name to its features, for the NodeSet pods.
+	GetNodeFeatures(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (map[string][]string, error)
+	// GetNodePowerStates returns the power state of the Slurm cloud nodes of the NodeSet.
+	GetNodePowerStates(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (map[string]SlurmNodePowerState, error)
 	// GetNodesForPods returns a list of Slurm nodes associated with the NodeSet pods.
 	GetNodesForPods(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) ([]string, bool, error)
 	// CheckReservationForNodeSet returns true when a reservation exists for a NodeSet
@@ -202,6 +218,47 @@ func (r *realSlurmControl) UpdateNodeTopology(ctx context.Context, nodeset *slin
 	return nil
 }
 
+// UpdateNodeFeatures implements SlurmControlInterface.
+func (r *realSlurmControl) UpdateNodeFeatures(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, features []string) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do UpdateNodeFeatures()",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	nodeFeatures := set.New(ptr.Deref(slurmNode.Features, slurmapi.V0044CsvString{})...)
+	if nodeFeatures.Equal(set.New(features...)) {
+		logger.V(3).Info("Node features are identical to request, skipping update request",
+			"node", slurmNode.GetKey(), "features", features)
+		return nil
+	}
+
+	logger.Info("Update Slurm Node features", "Node", slurmNode.GetKey(), "features", features)
+	req := slurmapi.V0044UpdateNodeMsg{
+		Features: ptr.To(slurmapi.V0044CsvString(features)),
+	}
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
 const nodeReasonPrefix = "slurm-operator: "
 
 // MakeNodeDrain implements SlurmControlInterface.
@@ -306,6 +363,95 @@ func (r *realSlurmControl) MakeNodeUndrain(ctx context.Context, nodeset *slinkyv
 	return nil
 }
 
+// RequeueNodeJobs implements SlurmControlInterface.
+func (r *realSlurmControl) RequeueNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do RequeueNodeJobs()",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	if reason == "" {
+		reason = "unknown"
+	}
+
+	// The Slurm API cannot requeue a running job. Instead, the running jobs of
+	// a node which is set DOWN are killed, and Slurm requeues the jobs which
+	// allow it (e.g. JobRequeue=1 or `--requeue`), the others are cancelled.
+	// The DRAIN state is kept, such that the node takes no new jobs.
+	// https://slurm.schedmd.com/scontrol.html#OPT_State
+	logger.V(1).Info("make slurm node down, cancel and requeue running jobs",
+		"pod", klog.KObj(pod))
+	req := slurmapi.V0044UpdateNodeMsg{
+		State:  ptr.To([]slurmapi.V0044UpdateNodeMsgState{slurmapi.V0044UpdateNodeMsgStateDOWN}),
+		Reason: ptr.To(FormatNodeReason(reason)),
+	}
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if tolerateError(err) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// CancelNodeJobs implements SlurmControlInterface.
+func (r *realSlurmControl) CancelNodeJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do CancelNodeJobs()",
+			"pod", klog.KObj(pod))
+		return nil
+	}
+
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList); err != nil {
+		return err
+	}
+
+	slurmNodeName := nodesetutils.GetSlurmNodeName(pod)
+	for _, job := range jobList.Items {
+		if !job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStateRUNNING) {
+			continue
+		}
+		slurmNodeNames, err := hostlist.Expand(ptr.Deref(job.Nodes, ""))
+		if err != nil {
+			logger.Error(err, "failed to expand job node hostlist",
+				"job", ptr.Deref(job.JobId, 0))
+			return err
+		}
+		if !slices.Contains(slurmNodeNames, slurmNodeName) {
+			continue
+		}
+
+		logger.V(1).Info("cancel slurm job",
+			"pod", klog.KObj(pod), "job", ptr.Deref(job.JobId, 0))
+		if err := slurmClient.Delete(ctx, &job); err != nil {
+			if tolerateError(err) {
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // IsNodeDrain implements SlurmControlInterface.
 func (r *realSlurmControl) IsNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error) {
 	logger := log.FromContext(ctx)
@@ -359,6 +505,36 @@ func (r *realSlurmControl) IsNodeDrained(ctx context.Context, nodeset *slinkyv1b
 	return isDrained, nil
 }
 
+// IsNodeAvailable implements SlurmControlInterface.
+func (r *realSlurmControl) IsNodeAvailable(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do IsNodeAvailable()",
+			"pod", klog.KObj(pod))
+		return false, nil
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if tolerateError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// Available is when a registered node became IDLE, and may have since taken work,
+	// but is not being drained or marked as down.
+	stateSet := slurmNode.GetStateAsSet()
+	isUp := stateSet.HasAny(slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateALLOCATED, slurmapi.V0044NodeStateMIXED)
+	isUnavailable := stateSet.HasAny(slurmapi.V0044NodeStateDOWN, slurmapi.V0044NodeStateDRAIN, slurmapi.V0044NodeStateFUTURE)
+	isAvailable := isUp && !isUnavailable
+
+	return isAvailable, nil
+}
+
 // IsNodeDownForUnresponsive implements SlurmControlInterface.
 func (r *realSlurmControl) IsNodeDownForUnresponsive(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error) {
 	logger := log.FromContext(ctx)
@@ -611,6 +787,183 @@ func (r *realSlurmControl) GetNodeDeadlines(ctx context.Context, nodeset *slinky
 	return ts, nil
 }
 
+// SlurmNodeSetDemand represents the Slurm workload demand for a NodeSet partition.
+type SlurmNodeSetDemand struct {
+	// PendingNodes is the number of nodes requested by pending jobs that can
+	// be satisfied by adding nodes to the partition.
+	PendingNodes int32
+	// Registered is the number of Slurm nodes that are backed by NodeSet pods.
+	Registered int32
+	// Idle is the number of registered Slurm nodes that are not doing any work.
+	Idle int32
+	// IdleSince maps each idle Slurm node to when it was last busy. The time
+	// is zero when Slurm has not reported it (e.g. the node was never busy).
+	IdleSince map[string]time.Time
+}
+
+// pendingReasons are the job state reasons which indicate a pending job is
+// waiting on resources, rather than on a dependency, hold, or limit.
+var pendingReasons = set.New(
+	"None",
+	"Priority",
+	"Resources",
+)
+
+// GetNodeSetDemand implements SlurmControlInterface.
+func (r *realSlurmControl) GetNodeSetDemand(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (SlurmNodeSetDemand, error) {
+	logger := log.FromContext(ctx)
+	demand := SlurmNodeSetDemand{
+		IdleSince: make(map[string]time.Time),
+	}
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetNodeSetDemand()")
+		return demand, nil
+	}
+
+	partitionName := common.GetSlurmNodeSetName(nodeset)
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList); err != nil {
+		if !tolerateError(err) {
+			return demand, err
+		}
+	}
+	for _, job := range jobList.Items {
+		if !job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStatePENDING) {
+			continue
+		}
+		if !pendingReasons.Has(ptr.Deref(job.StateReason, "")) {
+			continue
+		}
+		partitions := strings.Split(ptr.Deref(job.Partition, ""), ",")
+		if !set.New(partitions...).Has(partitionName) {
+			continue
+		}
+		nodeCount_NoVal := ptr.Deref(job.NodeCount, slurmapi.V0044Uint32NoValStruct{})
+		nodeCount := max(ptr.Deref(nodeCount_NoVal.Number, 0), 1)
+		demand.PendingNodes += nodeCount
+	}
+
+	podNodeNameSet := set.New[string]()
+	for _, pod := range pods {
+		podNodeNameSet.Insert(nodesetutils.GetSlurmNodeName(pod))
+	}
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil {
+		if tolerateError(err) {
+			return demand, nil
+		}
+		return demand, err
+	}
+	for _, node := range nodeList.Items {
+		nodeName := ptr.Deref(node.Name, "")
+		if !podNodeNameSet.Has(nodeName) {
+			continue
+		}
+		demand.Registered++
+		isBusy := node.GetStateAsSet().HasAny(slurmapi.V0044NodeStateALLOCATED, slurmapi.V0044NodeStateMIXED, slurmapi.V0044NodeStateCOMPLETING)
+		isIdle := node.GetStateAsSet().Has(slurmapi.V0044NodeStateIDLE) &&
+			!node.GetStateAsSet().HasAny(slurmapi.V0044NodeStateDRAIN, slurmapi.V0044NodeStateDOWN)
+		if isBusy || !isIdle {
+			continue
+		}
+		demand.Idle++
+		lastBusy_NoVal := ptr.Deref(node.LastBusy, slurmapi.V0044Uint64NoValStruct{})
+		idleSince := time.Time{}
+		if lastBusy := ptr.Deref(lastBusy_NoVal.Number, 0); lastBusy > 0 {
+			idleSince = time.Unix(lastBusy, 0)
+		}
+		demand.IdleSince[nodeName] = idleSince
+	}
+
+	return demand, nil
+}
+
+// SlurmNodePowerState is the power state of a Slurm node, as driven by Slurm power saving.
+type SlurmNodePowerState string
+
+const (
+	// SlurmNodePowerStateResumed indicates the Slurm node is powered up or being resumed.
+	SlurmNodePowerStateResumed SlurmNodePowerState = "Resumed"
+	// SlurmNodePowerStateSuspended indicates the Slurm node is powered down or being suspended.
+	SlurmNodePowerStateSuspended SlurmNodePowerState = "Suspended"
+)
+
+// GetNodePowerStates implements SlurmControlInterface.
+func (r *realSlurmControl) GetNodePowerStates(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (map[string]SlurmNodePowerState, error) {
+	logger := log.FromContext(ctx)
+	powerStates := make(map[string]SlurmNodePowerState)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetNodePowerStates()")
+		return powerStates, nil
+	}
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil {
+		if tolerateError(err) {
+			return powerStates, nil
+		}
+		return nil, err
+	}
+
+	name := common.GetSlurmNodeSetName(nodeset)
+	for _, node := range nodeList.Items {
+		if !node.GetStateAsSet().Has(slurmapi.V0044NodeStateCLOUD) {
+			continue
+		}
+		features := set.New(ptr.Deref(node.Features, slurmapi.V0044CsvString{})...)
+		if !features.Has(name) {
+			continue
+		}
+		nodeName := ptr.Deref(node.Name, "")
+		if node.GetStateAsSet().HasAny(slurmapi.V0044NodeStatePOWEREDDOWN, slurmapi.V0044NodeStatePOWERINGDOWN) {
+			powerStates[nodeName] = SlurmNodePowerStateSuspended
+		} else {
+			powerStates[nodeName] = SlurmNodePowerStateResumed
+		}
+	}
+
+	return powerStates, nil
+}
+
+// GetNodeFeatures implements SlurmControlInterface.
+func (r *realSlurmControl) GetNodeFeatures(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (map[string][]string, error) {
+	logger := log.FromContext(ctx)
+	nodeFeatures := make(map[string][]string)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetNodeFeatures()")
+		return nodeFeatures, nil
+	}
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil {
+		if tolerateError(err) {
+			return nodeFeatures, nil
+		}
+		return nil, err
+	}
+
+	slurmNodeNames := set.New[string]()
+	for _, pod := range pods {
+		slurmNodeNames.Insert(nodesetutils.GetSlurmNodeName(pod))
+	}
+	for _, node := range nodeList.Items {
+		nodeName := ptr.Deref(node.Name, "")
+		if !slurmNodeNames.Has(nodeName) {
+			continue
+		}
+		nodeFeatures[nodeName] = ptr.Deref(node.Features, slurmapi.V0044CsvString{})
+	}
+
+	return nodeFeatures, nil
+}
+
 // GetNodesForPods implements SlurmControlInterface.
 func (r *realSlurmControl) GetNodesForPods(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) ([]string, bool, error) {
 	logger := log.FromContext(ctx)
@@ -902,7 +1255,7 @@ func (r *realSlurmControl) SyncReservationForNodeSet(ctx context.Context, nodese
 			coreOldReservationInfo.Flags = ptr.To(set.New(*coreOldReservationInfo.Flags...).SortedList())
 		}
 
-		reservationActive = isReservationActive(*oldReservationInfo)
+		reservationActive = reservationutils.IsActive(*oldReservationInfo)
 		startTimeChanged = !nodeset.Spec.UpdateStrategy.ScheduledUpdate.StartTime.Time.Equal(startTime)
 
 		// We should honor existing start times for reoccuring reservations, Slurm updates them for us.
@@ -922,8 +1275,8 @@ func (r *realSlurmControl) SyncReservationForNodeSet(ctx context.Context, nodese
 		//    first occurrence.
 		oldStartTime := time.Unix(*oldReservationInfo.StartTime.Number, 0)
 		if oldStartTime.After(time.Unix(*reservationDesc.StartTime.Number, 0)) {
-			oldResIsReoccuring := reservationHasFlags(*oldReservationInfo, reoccuringInfoFlags, true)
-			newResIsReoccuring := reservationHasFlags(newReservationInfo, reoccuringInfoFlags, true)
+			oldResIsReoccuring := reservationutils.HasFlags(*oldReservationInfo, reservationutils.ReoccuringInfoFlags, true)
+			newResIsReoccuring := reservationutils.HasFlags(newReservationInfo, reservationutils.ReoccuringInfoFlags, true)
 			if (oldResIsReoccuring && newResIsReoccuring) && !startTimeChanged {
 				newStartTime := oldStartTime.Unix()
 				reservationDesc.StartTime.Number = &newStartTime
@@ -944,7 +1297,7 @@ func (r *realSlurmControl) SyncReservationForNodeSet(ctx context.Context, nodese
 		}
 
 		// Adding and removing nodes from an active reservation is permitted by Slurm, this should be done to maintain sync
-		if reservationActive && !isNodeListMatch(ptr.Deref(oldReservationInfo, slurmtypes.V0044ReservationInfo{}), newReservationInfo) {
+		if reservationActive && !reservationutils.IsNodeListMatch(ptr.Deref(oldReservationInfo, slurmtypes.V0044ReservationInfo{}), newReservationInfo) {
 			err := updateReservationNodes(ctx, slurmClient, oldReservationInfo, reservationDesc.NodeList)
 			if !tolerateError(err) {
 				return fmt.Errorf("SyncReservationForNodeSet() failed to Update Reservation=%s for NodeSet=%s with error=%w", *reservationDesc.Name, nodeset.Name, err)
@@ -954,7 +1307,7 @@ func (r *realSlurmControl) SyncReservationForNodeSet(ctx context.Context, nodese
 		return nil
 
 	case !slurmReservationExists && !created:
-		forceStart := reservationHasFlags(newReservationInfo, []slurmapi.V0044ReservationInfoFlags{slurmapi.V0044ReservationInfoFlagsFORCESTART}, true)
+		forceStart := reservationutils.HasFlags(newReservationInfo, []slurmapi.V0044ReservationInfoFlags{slurmapi.V0044ReservationInfoFlagsFORCESTART}, true)
 		pastStartTime := nodeset.Spec.UpdateStrategy.ScheduledUpdate.StartTime.Time.Before(time.Now())
 
 		if forceStart || !pastStartTime {
@@ -970,18 +1323,6 @@ func (r *realSlurmControl) SyncReservationForNodeSet(ctx context.Context, nodese
 	return nil
 }
 
-func isNodeListMatch(old slurmtypes.V0044ReservationInfo, new slurmtypes.V0044ReservationInfo) bool {
-	if old.NodeList != nil {
-		newNodeList, _ := hostlist.Expand(*new.NodeList)
-		oldNodeList, _ := hostlist.Expand(*old.NodeList)
-		if apiequality.Semantic.DeepEqual(newNodeList, oldNodeList) {
-			return true
-		}
-	}
-
-	return false
-}
-
 func updateReservationNodes(ctx context.Context, slurmClient slurmclient.Client, reservation *slurmtypes.V0044ReservationInfo, nodelist *slurmapi.V0044HostlistString) error {
 
 	var flags []slurmapi.V0044ReservationDescMsgFlags
@@ -1008,22 +1349,6 @@ func updateReservationNodes(ctx context.Context, slurmClient slurmclient.Client,
 	return nil
 }
 
-// isReservationActive() returns a boolean value based on whether the reservation
-// that it is passed is currently running. Once the Slurm RestAPI provides a
-// slurmapi.V0044ReservationInfoStatus field, this helper function should be
-// deleted in favor of using that field directly.
-func isReservationActive(reservation slurmtypes.V0044ReservationInfo) bool {
-	start := time.Unix(*reservation.StartTime.Number, 0)
-	end := time.Unix(*reservation.EndTime.Number, 0)
-
-	now := time.Now().In(time.UTC)
-	if start.Before(now) && end.After(now) {
-		return true
-	}
-
-	return false
-}
-
 // getReservationStatus() returns the boolean and time value from the NodeSet's
 // ReservationCreated Condition, if set
 func getReservationStatus(nodeset *slinkyv1beta1.NodeSet) (bool, time.Time) {
@@ -1041,30 +1366,6 @@ func getReservationStatus(nodeset *slinkyv1beta1.NodeSet) (bool, time.Time) {
 	return false, time.Time{}
 }
 
-var reoccuringInfoFlags = []slurmapi.V0044ReservationInfoFlags{
-	slurmapi.V0044ReservationInfoFlagsHOURLY,
-	slurmapi.V0044ReservationInfoFlagsDAILY,
-	slurmapi.V0044ReservationInfoFlagsWEEKLY,
-	slurmapi.V0044ReservationInfoFlagsWEEKEND,
-	slurmapi.V0044ReservationInfoFlagsWEEKDAY,
-}
-
-func reservationHasFlags(reservation slurmtypes.V0044ReservationInfo, flags []slurmapi.V0044ReservationInfoFlags, anyFlags bool) bool {
-	if reservation.Flags == nil && len(flags) > 0 {
-		return false
-	}
-
-	flagSet := set.New(flags...)
-	resFlags := set.New(*reservation.Flags...)
-	intersection := flagSet.Intersection(resFlags)
-
-	if anyFlags {
-		return intersection.Len() > 0
-	} else {
-		return intersection.Len() == len(flags)
-	}
-}
-
 var (
 	resInfoFlags = []slurmapi.V0044ReservationInfoFlags{
 		slurmapi.V0044ReservationInfoFlagsMAINT,
@@ -1080,12 +1381,12 @@ func formatReservationForSchedule(name string, schedule slinkyv1beta1.ScheduledU
 	var reservationInfo slurmtypes.V0044ReservationInfo
 	var reservation slurmapi.V0044ReservationDescMsg
 
-	startTime := timeToUint64NoVal(schedule.StartTime.In(time.UTC))
-	endTime := timeToUint64NoVal(schedule.StartTime.In(time.UTC).Add(schedule.Duration.Duration))
-	duration := durationToUint32NoVal(schedule.Duration.Duration)
+	startTime := reservationutils.TimeToUint64NoVal(schedule.StartTime.In(time.UTC))
+	endTime := reservationutils.TimeToUint64NoVal(schedule.StartTime.In(time.UTC).Add(schedule.Duration.Duration))
+	duration := reservationutils.DurationToUint32NoVal(schedule.Duration.Duration)
 
-	descFlags := parseReservationFlags(schedule.Flags, resDescFlags)
-	infoFlags := parseReservationFlags(schedule.Flags, resInfoFlags)
+	descFlags := reservationutils.ParseFlags(schedule.Flags, resDescFlags)
+	infoFlags := reservationutils.ParseFlags(schedule.Flags, resInfoFlags)
 
 	reservationInfo = slurmtypes.V0044ReservationInfo{
 		V0044ReservationInfo: slurmapi.V0044ReservationInfo{
@@ -1107,35 +1408,6 @@ func formatReservationForSchedule(name string, schedule slinkyv1beta1.ScheduledU
 	return reservation, reservationInfo, nil
 }
 
-func timeToUint64NoVal(t time.Time) slurmapi.V0044Uint64NoValStruct {
-	return slurmapi.V0044Uint64NoValStruct{
-		Infinite: new(false),
-		Number:   ptr.To(t.Unix()),
-		Set:      new(true),
-	}
-}
-
-func durationToUint32NoVal(d time.Duration) slurmapi.V0044Uint32NoValStruct {
-	return slurmapi.V0044Uint32NoValStruct{
-		Infinite: new(false),
-		Number:   ptr.To(int32(d.Minutes())),
-		Set:      new(true),
-	}
-}
-
-func parseReservationFlags[T slurmapi.V0044ReservationInfoFlags | slurmapi.V0044ReservationDescMsgFlags](flags []string, reqFlags []T) []T {
-	// Required flags
-	flagSet := set.New(reqFlags...)
-
-	// User flags
-	for _, flag := range flags {
-		f := T(strings.ToUpper(flag))
-		flagSet.Insert(f)
-	}
-
-	return flagSet.SortedList()
-}
-
 func (r *realSlurmControl) lookupClient(nodeset *slinkyv1beta1.NodeSet) slurmclient.Client {
 	key := ktypes.NamespacedName{
 		Namespace: nodeset.Namespace,
@@ -54,6 +54,9 @@ func slurmUpdateFn(_ context.Context, obj object.Object, req any, opts ...client
 		o.Comment = r.Comment
 		o.Reason = r.Reason
 		o.Topology = r.TopologyStr
+		if r.Features != nil {
+			o.Features = r.Features
+		}
 	case *types.V0044ReservationInfo:
 		_, ok := req.(api.V0044ReservationDescMsg)
 		if !ok {
@@ -286,6 +289,261 @@ func Test_realSlurmControl_MakeNodeDrain(t *testing.T) {
 	}
 }
 
+func Test_realSlurmControl_RequeueNodeJobs(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
+	slurmNodeName := nodesetutils.GetSlurmNodeName(pod)
+	type fields struct {
+		node    *types.V0044Node
+		jobList *types.V0044JobInfoList
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+		reason  string
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		wantDown    bool
+		wantReason  string
+		wantJobsIds []int32
+		wantErr     bool
+	}{
+		{
+			name: "draining, make down",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateALLOCATED,
+							api.V0044NodeStateDRAIN,
+						}),
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				reason:  "timeout",
+			},
+			wantDown:    true,
+			wantReason:  FormatNodeReason("timeout"),
+			wantJobsIds: []int32{},
+		},
+		{
+			name: "draining with running jobs, make down",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(slurmNodeName),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateMIXED,
+							api.V0044NodeStateDRAIN,
+						}),
+					},
+				},
+				jobList: &types.V0044JobInfoList{
+					Items: []types.V0044JobInfo{
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:    ptr.To[int32](1),
+								JobState: ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+								Nodes:    ptr.To(slurmNodeName),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:    ptr.To[int32](2),
+								JobState: ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+								Nodes:    ptr.To("other"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				reason:  "timeout",
+			},
+			wantDown:    true,
+			wantReason:  FormatNodeReason("timeout"),
+			wantJobsIds: []int32{1, 2},
+		},
+		{
+			name: "node not found",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To("other"),
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				reason:  "timeout",
+			},
+			wantJobsIds: []int32{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobList := tt.fields.jobList
+			if jobList == nil {
+				jobList = &types.V0044JobInfoList{}
+			}
+			sclient := fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).WithObjects(tt.fields.node).WithLists(jobList).Build()
+			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
+			r := NewSlurmControl(newSlurmClientMap(controllerName, sclient))
+			if err := r.RequeueNodeJobs(tt.args.ctx, tt.args.nodeset, tt.args.pod, tt.args.reason); (err != nil) != tt.wantErr {
+				t.Errorf("RequeueNodeJobs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			checkNode := &types.V0044Node{}
+			if err := sclient.Get(ctx, tt.fields.node.GetKey(), checkNode); err != nil {
+				t.Fatalf("client.Get() err = %v", err)
+			}
+			isDown := checkNode.GetStateAsSet().Has(api.V0044NodeStateDOWN)
+			if isDown != tt.wantDown {
+				t.Errorf("RequeueNodeJobs() down = %v, want %v", isDown, tt.wantDown)
+			}
+			nodeReason := ptr.Deref(checkNode.Reason, "")
+			if nodeReason != tt.wantReason {
+				t.Errorf("RequeueNodeJobs() reason = '%s', want = '%s'", nodeReason, tt.wantReason)
+			}
+			if isDown && !checkNode.GetStateAsSet().Has(api.V0044NodeStateDRAIN) {
+				t.Errorf("RequeueNodeJobs() drain = false, want true")
+			}
+			// The jobs are requeued by Slurm, not cancelled through the job API.
+			checkJobList := &types.V0044JobInfoList{}
+			if err := sclient.List(ctx, checkJobList); err != nil {
+				t.Fatalf("client.List() err = %v", err)
+			}
+			jobIds := make([]int32, 0, len(checkJobList.Items))
+			for _, job := range checkJobList.Items {
+				jobIds = append(jobIds, ptr.Deref(job.JobId, 0))
+			}
+			slices.Sort(jobIds)
+			if !apiequality.Semantic.DeepEqual(jobIds, tt.wantJobsIds) {
+				t.Errorf("RequeueNodeJobs() jobIds = %v, want %v", jobIds, tt.wantJobsIds)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_CancelNodeJobs(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
+	slurmNodeName := nodesetutils.GetSlurmNodeName(pod)
+	type fields struct {
+		jobList *types.V0044JobInfoList
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		wantJobsIds []int32
+		wantErr     bool
+	}{
+		{
+			name: "no jobs",
+			fields: fields{
+				jobList: &types.V0044JobInfoList{},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			wantJobsIds: []int32{},
+		},
+		{
+			name: "cancel running jobs on node",
+			fields: fields{
+				jobList: &types.V0044JobInfoList{
+					Items: []types.V0044JobInfo{
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:    ptr.To[int32](1),
+								JobState: ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+								Nodes:    ptr.To(slurmNodeName),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:    ptr.To[int32](2),
+								JobState: ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+								Nodes:    ptr.To("other"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:    ptr.To[int32](3),
+								JobState: ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Nodes:    ptr.To(slurmNodeName),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			wantJobsIds: []int32{2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithLists(tt.fields.jobList).Build()
+			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
+			r := NewSlurmControl(newSlurmClientMap(controllerName, sclient))
+			if err := r.CancelNodeJobs(tt.args.ctx, tt.args.nodeset, tt.args.pod); (err != nil) != tt.wantErr {
+				t.Errorf("CancelNodeJobs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			checkJobList := &types.V0044JobInfoList{}
+			if err := sclient.List(ctx, checkJobList); err != nil {
+				t.Fatalf("client.List() err = %v", err)
+			}
+			jobIds := make([]int32, 0, len(checkJobList.Items))
+			for _, job := range checkJobList.Items {
+				jobIds = append(jobIds, ptr.Deref(job.JobId, 0))
+			}
+			slices.Sort(jobIds)
+			if !apiequality.Semantic.DeepEqual(jobIds, tt.wantJobsIds) {
+				t.Errorf("CancelNodeJobs() jobIds = %v, want %v", jobIds, tt.wantJobsIds)
+			}
+		})
+	}
+}
+
 func Test_realSlurmControl_MakeNodeUndrain(t *testing.T) {
 	ctx := context.Background()
 	controller := &slinkyv1beta1.Controller{
@@ -460,6 +718,87 @@ func Test_realSlurmControl_UpdateNodeTopology(t *testing.T) {
 	}
 }
 
+func Test_realSlurmControl_UpdateNodeFeatures(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
+	type fields struct {
+		node *types.V0044Node
+	}
+	type args struct {
+		ctx      context.Context
+		nodeset  *slinkyv1beta1.NodeSet
+		pod      *corev1.Pod
+		features []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "identical",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name:     ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						Features: ptr.To(api.V0044CsvString{"foo", "bar"}),
+					},
+				},
+			},
+			args: args{
+				ctx:      ctx,
+				nodeset:  nodeset,
+				pod:      pod,
+				features: []string{"bar", "foo"},
+			},
+		},
+		{
+			name: "smoke",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name:     ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						Features: ptr.To(api.V0044CsvString{"foo"}),
+					},
+				},
+			},
+			args: args{
+				ctx:      ctx,
+				nodeset:  nodeset,
+				pod:      pod,
+				features: []string{"foo", "h100"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).WithObjects(tt.fields.node).Build()
+			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
+			r := NewSlurmControl(newSlurmClientMap(controllerName, sclient))
+			if err := r.UpdateNodeFeatures(tt.args.ctx, tt.args.nodeset, tt.args.pod, tt.args.features); (err != nil) != tt.wantErr {
+				t.Errorf("UpdateNodeFeatures() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			checkNode := &types.V0044Node{}
+			if err := sclient.Get(ctx, tt.fields.node.GetKey(), checkNode); err != nil {
+				if !tolerateError(err) {
+					t.Fatalf("client.Get() = %v", err)
+				}
+			}
+			got := set.New(ptr.Deref(checkNode.Features, api.V0044CsvString{})...)
+			if !got.Equal(set.New(tt.args.features...)) {
+				t.Fatalf("UpdateNodeFeatures() features = %v", got.SortedList())
+			}
+		})
+	}
+}
+
 func Test_realSlurmControl_IsNodeDrain(t *testing.T) {
 	ctx := context.Background()
 	controller := &slinkyv1beta1.Controller{
@@ -834,7 +1173,7 @@ func Test_realSlurmControl_IsNodeDrained(t *testing.T) {
 	}
 }
 
-func Test_realSlurmControl_IsNodeDownForUnresponsive(t *testing.T) {
+func Test_realSlurmControl_IsNodeAvailable(t *testing.T) {
 	ctx := context.Background()
 	controller := &slinkyv1beta1.Controller{
 		ObjectMeta: metav1.ObjectMeta{
@@ -879,8 +1218,7 @@ func Test_realSlurmControl_IsNodeDownForUnresponsive(t *testing.T) {
 				nodeset: nodeset,
 				pod:     pod,
 			},
-			want:    false,
-			wantErr: false,
+			want: true,
 		},
 		{
 			name: "MIXED",
@@ -903,7 +1241,7 @@ func Test_realSlurmControl_IsNodeDownForUnresponsive(t *testing.T) {
 				nodeset: nodeset,
 				pod:     pod,
 			},
-			want: false,
+			want: true,
 		},
 		{
 			name: "DOWN",
@@ -953,14 +1291,14 @@ func Test_realSlurmControl_IsNodeDownForUnresponsive(t *testing.T) {
 			want: false,
 		},
 		{
-			name: "MIXED+DRAIN",
+			name: "FUTURE",
 			fields: func() fields {
 				node := &types.V0044Node{
 					V0044Node: api.V0044Node{
 						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
 						State: ptr.To([]api.V0044NodeState{
-							api.V0044NodeStateMIXED,
-							api.V0044NodeStateDRAIN,
+							api.V0044NodeStateIDLE,
+							api.V0044NodeStateFUTURE,
 						}),
 					},
 				}
@@ -977,21 +1315,192 @@ func Test_realSlurmControl_IsNodeDownForUnresponsive(t *testing.T) {
 			want: false,
 		},
 		{
-			name: "ALLOC+DRAIN",
-			fields: func() fields {
-				node := &types.V0044Node{
-					V0044Node: api.V0044Node{
-						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
-						State: ptr.To([]api.V0044NodeState{
-							api.V0044NodeStateALLOCATED,
-							api.V0044NodeStateDRAIN,
-						}),
-					},
-				}
-				sclient := fake.NewClientBuilder().WithObjects(node).Build()
-				return fields{
-					clientMap: newSlurmClientMap(controller.Name, sclient),
-				}
+			name: "Not registered",
+			fields: fields{
+				clientMap: newSlurmClientMap(controller.Name, fake.NewFakeClient()),
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &realSlurmControl{
+				clientMap: tt.fields.clientMap,
+			}
+			got, err := r.IsNodeAvailable(tt.args.ctx, tt.args.nodeset, tt.args.pod)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("realSlurmControl.IsNodeAvailable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("realSlurmControl.IsNodeAvailable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_IsNodeDownForUnresponsive(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
+	type fields struct {
+		clientMap *clientmap.ClientMap
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "IDLE",
+			fields: func() fields {
+				node := &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateIDLE,
+						}),
+					},
+				}
+				sclient := fake.NewClientBuilder().WithObjects(node).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "MIXED",
+			fields: func() fields {
+				node := &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateMIXED,
+						}),
+					},
+				}
+				sclient := fake.NewClientBuilder().WithObjects(node).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			want: false,
+		},
+		{
+			name: "DOWN",
+			fields: func() fields {
+				node := &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateDOWN,
+						}),
+					},
+				}
+				sclient := fake.NewClientBuilder().WithObjects(node).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			want: false,
+		},
+		{
+			name: "IDLE+DRAIN",
+			fields: func() fields {
+				node := &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateIDLE,
+							api.V0044NodeStateDRAIN,
+						}),
+					},
+				}
+				sclient := fake.NewClientBuilder().WithObjects(node).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			want: false,
+		},
+		{
+			name: "MIXED+DRAIN",
+			fields: func() fields {
+				node := &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateMIXED,
+							api.V0044NodeStateDRAIN,
+						}),
+					},
+				}
+				sclient := fake.NewClientBuilder().WithObjects(node).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			want: false,
+		},
+		{
+			name: "ALLOC+DRAIN",
+			fields: func() fields {
+				node := &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateALLOCATED,
+							api.V0044NodeStateDRAIN,
+						}),
+					},
+				}
+				sclient := fake.NewClientBuilder().WithObjects(node).Build()
+				return fields{
+					clientMap: newSlurmClientMap(controller.Name, sclient),
+				}
 			}(),
 			args: args{
 				ctx:     ctx,
@@ -2214,6 +2723,391 @@ func Test_realSlurmControl_GetNodeDeadlines(t *testing.T) {
 	}
 }
 
+func Test_realSlurmControl_GetNodeSetDemand(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 3)
+	kclient := kubefake.NewFakeClient()
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kclient, nodeset, controller, 0, "")
+	pod2 := nodesetutils.NewNodeSetStatefulSetPod(kclient, nodeset, controller, 1, "")
+	pod3 := nodesetutils.NewNodeSetStatefulSetPod(kclient, nodeset, controller, 2, "")
+	pods := []*corev1.Pod{pod, pod2, pod3}
+	lastBusy := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	type fields struct {
+		nodeList *types.V0044NodeList
+		jobList  *types.V0044JobInfoList
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pods    []*corev1.Pod
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    SlurmNodeSetDemand
+		wantErr bool
+	}{
+		{
+			name: "Empty",
+			fields: fields{
+				nodeList: &types.V0044NodeList{},
+				jobList:  &types.V0044JobInfoList{},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pods:    pods,
+			},
+			want: SlurmNodeSetDemand{
+				IdleSince: map[string]time.Time{},
+			},
+		},
+		{
+			name: "Pending jobs and idle nodes",
+			fields: fields{
+				nodeList: &types.V0044NodeList{
+					Items: []types.V0044Node{
+						{
+							V0044Node: api.V0044Node{
+								Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+								State: ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE}),
+								LastBusy: ptr.To(api.V0044Uint64NoValStruct{
+									Number: ptr.To(lastBusy.Unix()),
+								}),
+							},
+						},
+						{
+							V0044Node: api.V0044Node{
+								Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod2)),
+								State: ptr.To([]api.V0044NodeState{api.V0044NodeStateMIXED}),
+							},
+						},
+						{
+							V0044Node: api.V0044Node{
+								Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod3)),
+								State: ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStateDRAIN}),
+							},
+						},
+						{
+							V0044Node: api.V0044Node{
+								Name:  ptr.To("other"),
+								State: ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE}),
+							},
+						},
+					},
+				},
+				jobList: &types.V0044JobInfoList{
+					Items: []types.V0044JobInfo{
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:       ptr.To[int32](1),
+								JobState:    ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								StateReason: ptr.To("Resources"),
+								Partition:   ptr.To("foo"),
+								NodeCount:   ptr.To(api.V0044Uint32NoValStruct{Number: ptr.To[int32](2)}),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:       ptr.To[int32](2),
+								JobState:    ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								StateReason: ptr.To("Priority"),
+								Partition:   ptr.To("bar,foo"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:       ptr.To[int32](3),
+								JobState:    ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								StateReason: ptr.To("Dependency"),
+								Partition:   ptr.To("foo"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:       ptr.To[int32](4),
+								JobState:    ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								StateReason: ptr.To("Resources"),
+								Partition:   ptr.To("bar"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](5),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+								Partition: ptr.To("foo"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pods:    pods,
+			},
+			want: SlurmNodeSetDemand{
+				PendingNodes: 3,
+				Registered:   3,
+				Idle:         1,
+				IdleSince: map[string]time.Time{
+					nodesetutils.GetSlurmNodeName(pod): lastBusy,
+				},
+			},
+		},
+		{
+			name: "Idle node without last busy time",
+			fields: fields{
+				nodeList: &types.V0044NodeList{
+					Items: []types.V0044Node{
+						{
+							V0044Node: api.V0044Node{
+								Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+								State: ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE}),
+								LastBusy: ptr.To(api.V0044Uint64NoValStruct{
+									Number: ptr.To[int64](0),
+								}),
+							},
+						},
+					},
+				},
+				jobList: &types.V0044JobInfoList{},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pods:    pods,
+			},
+			want: SlurmNodeSetDemand{
+				Registered: 1,
+				Idle:       1,
+				IdleSince: map[string]time.Time{
+					nodesetutils.GetSlurmNodeName(pod): {},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).WithLists(tt.fields.nodeList, tt.fields.jobList).Build()
+			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
+			r := NewSlurmControl(newSlurmClientMap(controllerName, sclient))
+			got, err := r.GetNodeSetDemand(tt.args.ctx, tt.args.nodeset, tt.args.pods)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetNodeSetDemand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetNodeSetDemand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_GetNodePowerStates(t *testing.T) {
+	ctx := context.Background()
+	nodeset := newNodeSet("foo", "slurm", 3)
+	type fields struct {
+		nodeList *types.V0044NodeList
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    map[string]SlurmNodePowerState
+		wantErr bool
+	}{
+		{
+			name: "Empty",
+			fields: fields{
+				nodeList: &types.V0044NodeList{},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+			},
+			want: map[string]SlurmNodePowerState{},
+		},
+		{
+			name: "Cloud nodes",
+			fields: fields{
+				nodeList: &types.V0044NodeList{
+					Items: []types.V0044Node{
+						{
+							V0044Node: api.V0044Node{
+								Name:     ptr.To("foo-0"),
+								Features: ptr.To(api.V0044CsvString{"foo"}),
+								State:    ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStateCLOUD, api.V0044NodeStatePOWEREDDOWN}),
+							},
+						},
+						{
+							V0044Node: api.V0044Node{
+								Name:     ptr.To("foo-1"),
+								Features: ptr.To(api.V0044CsvString{"foo"}),
+								State:    ptr.To([]api.V0044NodeState{api.V0044NodeStateALLOCATED, api.V0044NodeStateCLOUD, api.V0044NodeStatePOWERINGUP}),
+							},
+						},
+						{
+							V0044Node: api.V0044Node{
+								Name:     ptr.To("foo-2"),
+								Features: ptr.To(api.V0044CsvString{"foo", "bar"}),
+								State:    ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStateCLOUD, api.V0044NodeStatePOWERINGDOWN}),
+							},
+						},
+						{
+							V0044Node: api.V0044Node{
+								Name:     ptr.To("foo-3"),
+								Features: ptr.To(api.V0044CsvString{"foo"}),
+								State:    ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStateCLOUD}),
+							},
+						},
+						{
+							V0044Node: api.V0044Node{
+								Name:     ptr.To("bar-0"),
+								Features: ptr.To(api.V0044CsvString{"bar"}),
+								State:    ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStateCLOUD}),
+							},
+						},
+						{
+							V0044Node: api.V0044Node{
+								Name:     ptr.To("dynamic-0"),
+								Features: ptr.To(api.V0044CsvString{"foo"}),
+								State:    ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStateDYNAMICNORM}),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+			},
+			want: map[string]SlurmNodePowerState{
+				"foo-0": SlurmNodePowerStateSuspended,
+				"foo-1": SlurmNodePowerStateResumed,
+				"foo-2": SlurmNodePowerStateSuspended,
+				"foo-3": SlurmNodePowerStateResumed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithLists(tt.fields.nodeList).Build()
+			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
+			r := NewSlurmControl(newSlurmClientMap(controllerName, sclient))
+			got, err := r.GetNodePowerStates(tt.args.ctx, tt.args.nodeset)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetNodePowerStates() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("GetNodePowerStates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_GetNodeFeatures(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 2)
+	pod0 := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
+	pod1 := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 1, "")
+	type fields struct {
+		nodeList *types.V0044NodeList
+	}
+	type args struct {
+		ctx     context.Context
+		nodeset *slinkyv1beta1.NodeSet
+		pods    []*corev1.Pod
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "Empty",
+			fields: fields{
+				nodeList: &types.V0044NodeList{},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pods:    []*corev1.Pod{pod0, pod1},
+			},
+			want: map[string][]string{},
+		},
+		{
+			name: "Nodes of pods",
+			fields: fields{
+				nodeList: &types.V0044NodeList{
+					Items: []types.V0044Node{
+						{
+							V0044Node: api.V0044Node{
+								Name:     ptr.To(nodesetutils.GetSlurmNodeName(pod0)),
+								Features: ptr.To(api.V0044CsvString{"foo", "h100"}),
+							},
+						},
+						{
+							V0044Node: api.V0044Node{
+								Name: ptr.To(nodesetutils.GetSlurmNodeName(pod1)),
+							},
+						},
+						{
+							V0044Node: api.V0044Node{
+								Name:     ptr.To("bar-0"),
+								Features: ptr.To(api.V0044CsvString{"bar"}),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pods:    []*corev1.Pod{pod0, pod1},
+			},
+			want: map[string][]string{
+				nodesetutils.GetSlurmNodeName(pod0): {"foo", "h100"},
+				nodesetutils.GetSlurmNodeName(pod1): {},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithLists(tt.fields.nodeList).Build()
+			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
+			r := NewSlurmControl(newSlurmClientMap(controllerName, sclient))
+			got, err := r.GetNodeFeatures(tt.args.ctx, tt.args.nodeset, tt.args.pods)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetNodeFeatures() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("GetNodeFeatures() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_realSlurmControl_GetNodesForPods(t *testing.T) {
 	controller := &slinkyv1beta1.Controller{
 		ObjectMeta: metav1.ObjectMeta{
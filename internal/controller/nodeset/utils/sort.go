@@ -135,6 +135,25 @@ func SplitActivePods(pods []*corev1.Pod, partition int) (pods1, pods2 []*corev1.
 	return pods1, pods2
 }
 
+// SplitPartitionedPods returns two list of pods split by the update partition.
+// Pods with an ordinal below the partition are protected from updates.
+func SplitPartitionedPods(pods []*corev1.Pod, partition int) (protectedPods, partitionedPods []*corev1.Pod) {
+	if partition <= 0 {
+		return nil, pods
+	}
+
+	for _, pod := range pods {
+		ordinal := GetOrdinal(pod)
+		if ordinal >= 0 && ordinal < partition {
+			protectedPods = append(protectedPods, pod)
+		} else {
+			partitionedPods = append(partitionedPods, pod)
+		}
+	}
+
+	return protectedPods, partitionedPods
+}
+
 // ExcludePods returns pods with any pod whose UID appears in exclude removed.
 func ExcludePods(pods, exclude []*corev1.Pod) []*corev1.Pod {
 	if len(exclude) == 0 {
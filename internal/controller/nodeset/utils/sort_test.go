@@ -442,6 +442,99 @@ func TestSplitActivePods(t *testing.T) {
 	}
 }
 
+func TestSplitPartitionedPods(t *testing.T) {
+	type args struct {
+		pods      []*corev1.Pod
+		partition int
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		wantProtectedPodNames   []string
+		wantPartitionedPodNames []string
+	}{
+		{
+			name: "Empty",
+			args: args{
+				pods:      nil,
+				partition: 1,
+			},
+			wantProtectedPodNames:   []string{},
+			wantPartitionedPodNames: []string{},
+		},
+		{
+			name: "Partition 0",
+			args: args{
+				pods: []*corev1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Name: "foo-0"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "foo-1"}},
+				},
+				partition: 0,
+			},
+			wantProtectedPodNames:   []string{},
+			wantPartitionedPodNames: []string{"foo-0", "foo-1"},
+		},
+		{
+			name: "Partition 1",
+			args: args{
+				pods: []*corev1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Name: "foo-1"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "foo-0"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "foo-2"}},
+				},
+				partition: 1,
+			},
+			wantProtectedPodNames:   []string{"foo-0"},
+			wantPartitionedPodNames: []string{"foo-1", "foo-2"},
+		},
+		{
+			name: "Partition beyond replicas",
+			args: args{
+				pods: []*corev1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Name: "foo-0"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "foo-1"}},
+				},
+				partition: 5,
+			},
+			wantProtectedPodNames:   []string{"foo-0", "foo-1"},
+			wantPartitionedPodNames: []string{},
+		},
+		{
+			name: "No ordinal",
+			args: args{
+				pods: []*corev1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Name: "foo-abcde"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "foo-0"}},
+				},
+				partition: 2,
+			},
+			wantProtectedPodNames:   []string{"foo-0"},
+			wantPartitionedPodNames: []string{"foo-abcde"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotProtectedPods, gotPartitionedPods := SplitPartitionedPods(tt.args.pods, tt.args.partition)
+
+			gotProtectedPodNames := make([]string, len(gotProtectedPods))
+			for i := range gotProtectedPods {
+				gotProtectedPodNames[i] = gotProtectedPods[i].Name
+			}
+			gotPartitionedPodNames := make([]string, len(gotPartitionedPods))
+			for i := range gotPartitionedPods {
+				gotPartitionedPodNames[i] = gotPartitionedPods[i].Name
+			}
+
+			if diff := cmp.Diff(tt.wantProtectedPodNames, gotProtectedPodNames); diff != "" {
+				t.Errorf("Protected pod names (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantPartitionedPodNames, gotPartitionedPodNames); diff != "" {
+				t.Errorf("Partitioned pod names (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestExcludePods(t *testing.T) {
 	pod := func(name, uid string) *corev1.Pod {
 		return &corev1.Pod{
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
@@ -23,6 +24,7 @@ import (
 	k8scontroller "k8s.io/kubernetes/pkg/controller"
 	daemonutils "k8s.io/kubernetes/pkg/controller/daemon/util"
 	"k8s.io/kubernetes/pkg/features"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -47,6 +49,7 @@ func NewNodeSetStatefulSetPod(
 	pod.Name = GetOrdinalPodName(nodeset, ordinal)
 	initIdentity(nodeset, pod)
 	UpdateStorage(nodeset, pod)
+	UpdateResourceClaims(nodeset, pod)
 
 	if revisionHash != "" {
 		historycontrol.SetRevision(pod.Labels, revisionHash)
@@ -111,6 +114,7 @@ func NewNodeSetDaemonSetPod(
 
 	initIdentity(nodeset, pod)
 	UpdateStorage(nodeset, pod)
+	UpdateResourceClaims(nodeset, pod)
 
 	if revisionHash != "" {
 		historycontrol.SetRevision(pod.Labels, revisionHash)
@@ -220,6 +224,27 @@ func UpdateStorage(nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) {
 	pod.Spec.Volumes = newVolumes
 }
 
+// UpdateResourceClaims updates pod's ResourceClaims to reference the ResourceClaims of nodeset's templates. If pod has
+// conflicting ResourceClaims these are replaced with ones that conform to the nodeset's templates.
+func UpdateResourceClaims(nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) {
+	currentClaims := pod.Spec.ResourceClaims
+	claims := GetResourceClaims(nodeset, pod)
+	newClaims := make([]corev1.PodResourceClaim, 0, len(claims))
+	for _, template := range nodeset.Spec.ResourceClaimTemplates {
+		claim := claims[template.Name]
+		newClaims = append(newClaims, corev1.PodResourceClaim{
+			Name:              template.Name,
+			ResourceClaimName: ptr.To(claim.Name),
+		})
+	}
+	for i := range currentClaims {
+		if _, ok := claims[currentClaims[i].Name]; !ok {
+			newClaims = append(newClaims, currentClaims[i])
+		}
+	}
+	pod.Spec.ResourceClaims = newClaims
+}
+
 // updateNodeSetPodAntiAffinity will add PodAntiAffinity such that a Kube node can only have one NodeSet pod.
 func updateNodeSetPodAntiAffinity(affinity *corev1.Affinity) *corev1.Affinity {
 	labelSelectorRequirement := metav1.LabelSelectorRequirement{
@@ -330,6 +355,15 @@ func GetOrdinalPodName(nodeset *slinkyv1beta1.NodeSet, ordinal int) string {
 	return fmt.Sprintf("%s-%s", nodeset.Name, paddedOrdinal)
 }
 
+// GetOrdinalSlurmNodeName gets the Slurm node name of nodeset's child Pod with an ordinal index of ordinal.
+// It is only valid when `scalingMode=StatefulSet` and the Pod does not use the host network.
+func GetOrdinalSlurmNodeName(nodeset *slinkyv1beta1.NodeSet, ordinal int) string {
+	if hostname := nodeset.Spec.Template.PodSpecWrapper.Hostname; hostname != "" {
+		return fmt.Sprintf("%s%s", hostname, GetPaddedOrdinal(nodeset, ordinal))
+	}
+	return GetOrdinalPodName(nodeset, ordinal)
+}
+
 // GetSlurmNodeName returns the Slurm node name.
 func GetSlurmNodeName(pod *corev1.Pod) string {
 	if pod.Labels[slinkyv1beta1.LabelNodeSetScalingMode] == string(slinkyv1beta1.ScalingModeStatefulset) {
@@ -438,6 +472,49 @@ func GetPersistentVolumeClaimNameNodeName(nodeset *slinkyv1beta1.NodeSet, claim
 	return fmt.Sprintf("%s-%s-%s", claim.Name, nodeset.Name, nodeName)
 }
 
+// GetResourceClaims gets a map of ResourceClaims to their template names, as defined in nodeset. The returned
+// ResourceClaims are each constructed with the name specific to the Pod, like GetPersistentVolumeClaims.
+func GetResourceClaims(nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) map[string]resourcev1.ResourceClaim {
+	var paddedOrdinal string
+	if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeStatefulset {
+		ordinal := GetOrdinal(pod)
+		paddedOrdinal = GetPaddedOrdinal(nodeset, ordinal)
+	}
+	templates := nodeset.Spec.ResourceClaimTemplates
+	selectorLabels := labels.NewBuilder().WithWorkerSelectorLabels(nodeset).Build()
+	claims := make(map[string]resourcev1.ResourceClaim, len(templates))
+	for i := range templates {
+		template := &templates[i]
+		claim := resourcev1.ResourceClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   nodeset.Namespace,
+				Labels:      labels.NewBuilder().WithLabels(template.Metadata.Labels).WithLabels(selectorLabels).Build(),
+				Annotations: maps.Clone(template.Metadata.Annotations),
+			},
+			Spec: *template.Spec.DeepCopy(),
+		}
+		if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeStatefulset {
+			claim.Name = GetResourceClaimNameOrdinal(nodeset, template, paddedOrdinal)
+		} else {
+			claim.Name = GetResourceClaimNameNodeName(nodeset, template, pod.Labels[slinkyv1beta1.LabelNodeSetPodHostname])
+		}
+		claims[template.Name] = claim
+	}
+	return claims
+}
+
+// GetResourceClaimNameOrdinal gets the name of ResourceClaim for a Pod with an ordinal index of ordinal. template
+// must be from nodeset's ResourceClaimTemplates.
+func GetResourceClaimNameOrdinal(nodeset *slinkyv1beta1.NodeSet, template *slinkyv1beta1.NodeSetResourceClaimTemplate, paddedOrdinal string) string {
+	return fmt.Sprintf("%s-%s-%s", template.Name, nodeset.Name, paddedOrdinal)
+}
+
+// GetResourceClaimNameNodeName gets the name of ResourceClaim for a Pod with a node name. template must be from
+// nodeset's ResourceClaimTemplates.
+func GetResourceClaimNameNodeName(nodeset *slinkyv1beta1.NodeSet, template *slinkyv1beta1.NodeSetResourceClaimTemplate, nodeName string) string {
+	return fmt.Sprintf("%s-%s-%s", template.Name, nodeset.Name, nodeName)
+}
+
 // SetOwnerReferences modifies the object with all NodeSets as non-controller owners.
 func SetOwnerReferences(r client.Client, ctx context.Context, object metav1.Object, clusterName string) error {
 	nodesetList := &slinkyv1beta1.NodeSetList{}
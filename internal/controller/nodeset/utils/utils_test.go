@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -396,6 +397,42 @@ func TestOrdinalGetPodName(t *testing.T) {
 	}
 }
 
+func TestGetOrdinalSlurmNodeName(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+	nodesetWithHostname := newNodeSet("bar")
+	nodesetWithHostname.Spec.Template.PodSpecWrapper.Hostname = "gpu-"
+	nodesetWithHostname.Spec.OrdinalPadding = 2
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		ordinal int
+	}{
+		{
+			name:    "pod name",
+			nodeset: newNodeSet("foo"),
+			ordinal: 0,
+		},
+		{
+			name:    "hostname",
+			nodeset: nodesetWithHostname,
+			ordinal: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := NewNodeSetStatefulSetPod(fake.NewFakeClient(), tt.nodeset, controller, tt.ordinal, "")
+			want := GetSlurmNodeName(pod)
+			if got := GetOrdinalSlurmNodeName(tt.nodeset, tt.ordinal); got != want {
+				t.Errorf("GetOrdinalSlurmNodeName() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func TestGetSlurmNodeName(t *testing.T) {
 	controller := &slinkyv1beta1.Controller{
 		ObjectMeta: metav1.ObjectMeta{
@@ -945,6 +982,109 @@ func TestGetPersistentVolumeClaims(t *testing.T) {
 	}
 }
 
+func newNodeSetWithResourceClaims(nodeset *slinkyv1beta1.NodeSet) *slinkyv1beta1.NodeSet {
+	nodeset.Spec.ResourceClaimTemplates = []slinkyv1beta1.NodeSetResourceClaimTemplate{
+		{
+			Name: "gpu",
+			Metadata: slinkyv1beta1.Metadata{
+				Annotations: map[string]string{"foo": "bar"},
+			},
+			Spec: resourcev1.ResourceClaimSpec{
+				Devices: resourcev1.DeviceClaim{
+					Requests: []resourcev1.DeviceRequest{
+						{
+							Name: "gpu",
+							Exactly: &resourcev1.ExactDeviceRequest{
+								DeviceClassName: "gpu.example.com",
+								Count:           2,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return nodeset
+}
+
+func TestGetResourceClaims(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+	type args struct {
+		nodeset *slinkyv1beta1.NodeSet
+		pod     *corev1.Pod
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantNames map[string]string
+	}{
+		{
+			name: "Without Claims",
+			args: args{
+				nodeset: newNodeSet("foo"),
+				pod:     NewNodeSetStatefulSetPod(fake.NewFakeClient(), newNodeSet("foo"), controller, 0, ""),
+			},
+			wantNames: map[string]string{},
+		},
+		{
+			name: "Statefulset",
+			args: args{
+				nodeset: newNodeSetWithResourceClaims(newNodeSet("foo")),
+				pod:     NewNodeSetStatefulSetPod(fake.NewFakeClient(), newNodeSetWithResourceClaims(newNodeSet("foo")), controller, 1, ""),
+			},
+			wantNames: map[string]string{"gpu": "gpu-foo-1"},
+		},
+		{
+			name: "Daemonset",
+			args: args{
+				nodeset: newNodeSetWithResourceClaims(newNodeSetDaemonset("foo", "")),
+				pod:     NewNodeSetDaemonSetPod(fake.NewFakeClient(), newNodeSetWithResourceClaims(newNodeSetDaemonset("foo", "")), controller, "node1", "", ""),
+			},
+			wantNames: map[string]string{"gpu": "gpu-foo-node1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetResourceClaims(tt.args.nodeset, tt.args.pod)
+			gotNames := make(map[string]string, len(got))
+			for name, claim := range got {
+				gotNames[name] = claim.Name
+				if claim.Namespace != tt.args.nodeset.Namespace {
+					t.Errorf("GetResourceClaims() namespace = %v, want %v", claim.Namespace, tt.args.nodeset.Namespace)
+				}
+				wantLabels := labels.NewBuilder().WithWorkerSelectorLabels(tt.args.nodeset).Build()
+				if !apiequality.Semantic.DeepEqual(claim.Labels, wantLabels) {
+					t.Errorf("GetResourceClaims() labels = %v, want %v", claim.Labels, wantLabels)
+				}
+				if claim.Annotations["foo"] != "bar" {
+					t.Errorf("GetResourceClaims() annotations = %v, want foo=bar", claim.Annotations)
+				}
+				if !apiequality.Semantic.DeepEqual(claim.Spec, tt.args.nodeset.Spec.ResourceClaimTemplates[0].Spec) {
+					t.Errorf("GetResourceClaims() spec = %v, want %v", claim.Spec, tt.args.nodeset.Spec.ResourceClaimTemplates[0].Spec)
+				}
+			}
+			if !apiequality.Semantic.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("GetResourceClaims() names = %v, want %v", gotNames, tt.wantNames)
+			}
+			var gotPodClaims []string
+			for _, podClaim := range tt.args.pod.Spec.ResourceClaims {
+				gotPodClaims = append(gotPodClaims, podClaim.Name+"="+ptr.Deref(podClaim.ResourceClaimName, ""))
+			}
+			var wantPodClaims []string
+			for name, claimName := range tt.wantNames {
+				wantPodClaims = append(wantPodClaims, name+"="+claimName)
+			}
+			if !apiequality.Semantic.DeepEqual(gotPodClaims, wantPodClaims) {
+				t.Errorf("Pod.Spec.ResourceClaims = %v, want %v", gotPodClaims, wantPodClaims)
+			}
+		})
+	}
+}
+
 func TestGetPersistentVolumeClaimNameOrdinal(t *testing.T) {
 	type args struct {
 		nodeset       *slinkyv1beta1.NodeSet
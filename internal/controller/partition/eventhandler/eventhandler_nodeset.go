@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+func NewNodeSetEventHandler(reader client.Reader) *NodeSetEventHandler {
+	return &NodeSetEventHandler{
+		Reader: reader,
+	}
+}
+
+var _ handler.EventHandler = &NodeSetEventHandler{}
+
+type NodeSetEventHandler struct {
+	client.Reader
+}
+
+// Create implements handler.TypedEventHandler.
+func (e *NodeSetEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+// Delete implements handler.TypedEventHandler.
+func (e *NodeSetEventHandler) Delete(
+	ctx context.Context,
+	evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+// Generic implements handler.TypedEventHandler.
+func (e *NodeSetEventHandler) Generic(
+	ctx context.Context,
+	evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+// Update implements handler.TypedEventHandler.
+func (e *NodeSetEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.ObjectNew, q)
+}
+
+// enqueueRequest enqueues every Partition of the NodeSet's Controller. Membership
+// is not checked, so Partitions that no longer select the NodeSet are also updated.
+func (e *NodeSetEventHandler) enqueueRequest(
+	ctx context.Context,
+	obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	logger := log.FromContext(ctx)
+
+	nodeset, ok := obj.(*slinkyv1beta1.NodeSet)
+	if !ok {
+		return
+	}
+
+	list := &slinkyv1beta1.PartitionList{}
+	if err := e.List(ctx, list, client.InNamespace(nodeset.Namespace)); err != nil {
+		logger.Error(err, "failed to list Partitions referencing NodeSet Controller")
+		return
+	}
+
+	nodesetRefKey := types.NamespacedName{
+		Namespace: nodeset.Namespace,
+		Name:      nodeset.Spec.ControllerRef.Name,
+	}
+	for _, item := range list.Items {
+		refKey := types.NamespacedName{
+			Namespace: item.Namespace,
+			Name:      item.Spec.ControllerRef.Name,
+		}
+		if refresolver.IsKeyMatch(refKey, nodesetRefKey) {
+			objectutils.EnqueueRequest(q, &item)
+		}
+	}
+}
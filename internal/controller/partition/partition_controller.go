@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/partition/eventhandler"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+const (
+	ControllerName = "partition-controller"
+)
+
+func init() {
+	flag.IntVar(&maxConcurrentReconciles, "partition-workers", maxConcurrentReconciles, "Max concurrent workers for Partition controller.")
+}
+
+var (
+	maxConcurrentReconciles = 1
+
+	// this is a short cut for any sub-functions to notify the reconcile how long to wait to requeue
+	durationStore = durationstore.NewDurationStore(durationstore.Greater)
+)
+
+// PartitionReconciler reconciles a Partition object
+type PartitionReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	refResolver *refresolver.RefResolver
+}
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=partitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=partitions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *PartitionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, retErr error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Started syncing Partition", "request", req)
+
+	startTime := time.Now()
+	defer func() {
+		if retErr == nil {
+			if res.RequeueAfter > 0 {
+				logger.Info("Finished syncing Partition", "duration", time.Since(startTime), "result", res)
+			} else {
+				logger.Info("Finished syncing Partition", "duration", time.Since(startTime))
+			}
+		} else {
+			logger.Info("Finished syncing Partition", "duration", time.Since(startTime), "error", retErr)
+		}
+		// clean the duration store
+		_ = durationStore.Pop(req.String())
+	}()
+
+	retErr = r.Sync(ctx, req)
+	res = reconcile.Result{
+		RequeueAfter: durationStore.Pop(req.String()),
+	}
+	return res, retErr
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PartitionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(ControllerName).
+		For(&slinkyv1beta1.Partition{}).
+		Watches(&slinkyv1beta1.NodeSet{}, eventhandler.NewNodeSetEventHandler(r.Client)).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+		}).
+		Complete(r)
+}
+
+func NewReconciler(c client.Client) *PartitionReconciler {
+	s := c.Scheme()
+	return &PartitionReconciler{
+		Client: c,
+		Scheme: s,
+
+		refResolver: refresolver.New(c),
+	}
+}
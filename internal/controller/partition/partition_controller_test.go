@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	testutils "github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("Partition Controller", func() {
+	Context("When reconciling a Partition", func() {
+		var name = testutils.GenerateResourceName(5)
+		var partition *slinkyv1beta1.Partition
+		var nodeset *slinkyv1beta1.NodeSet
+		var controller *slinkyv1beta1.Controller
+
+		BeforeEach(func() {
+			controller = testutils.NewController(name, corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset = testutils.NewNodeset(name, controller, 1)
+			partition = testutils.NewPartition(name, controller, nodeset)
+			Expect(k8sClient.Create(ctx, nodeset.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, partition.DeepCopy())).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, partition)
+			_ = k8sClient.Delete(ctx, nodeset)
+		})
+
+		It("Should report member NodeSets in status", func(ctx SpecContext) {
+			By("Expecting Partition status NodeSets")
+			partitionKey := client.ObjectKeyFromObject(partition)
+			Eventually(func(g Gomega) {
+				checkPartition := &slinkyv1beta1.Partition{}
+				g.Expect(k8sClient.Get(ctx, partitionKey, checkPartition)).To(Succeed())
+				g.Expect(checkPartition.Status.NodeSets).To(ConsistOf(nodeset.Name))
+			}, testutils.Timeout, testutils.Interval).Should(Succeed())
+		}, SpecTimeout(testutils.Timeout))
+	})
+})
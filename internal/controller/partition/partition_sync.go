@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// Sync implements control logic for synchronizing a Partition.
+//
+// The Partition is rendered into the slurm.conf by the Controller controller,
+// hence only the observed state of member NodeSets is synchronized here.
+func (r *PartitionReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	partition := &slinkyv1beta1.Partition{}
+	if err := r.Get(ctx, req.NamespacedName, partition); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Partition has been deleted", "request", req)
+			return nil
+		}
+		return err
+	}
+	partition = partition.DeepCopy()
+
+	if !partition.DeletionTimestamp.IsZero() {
+		logger.Info("Partition is being deleted, skipping sync", "request", req)
+		return nil
+	}
+
+	nodesetList, err := r.refResolver.GetNodeSetsForPartition(ctx, partition)
+	if err != nil {
+		return fmt.Errorf("failed to get NodeSets for Partition(%s): %w", klog.KObj(partition), err)
+	}
+
+	return r.syncStatus(ctx, partition, nodesetList)
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// syncStatus handles determining and updating the status.
+func (r *PartitionReconciler) syncStatus(
+	ctx context.Context,
+	partition *slinkyv1beta1.Partition,
+	nodesetList *slinkyv1beta1.NodeSetList,
+) error {
+	logger := log.FromContext(ctx)
+
+	newStatus := calculateStatus(partition, nodesetList)
+
+	if apiequality.Semantic.DeepEqual(partition.Status, newStatus) {
+		logger.V(2).Info("Partition Status has not changed, skipping status update",
+			"partition", klog.KObj(partition), "status", partition.Status)
+		return nil
+	}
+
+	if err := r.updateStatus(ctx, partition, &newStatus); err != nil {
+		return fmt.Errorf("error updating Partition(%s) status: %w",
+			klog.KObj(partition), err)
+	}
+
+	return nil
+}
+
+// calculateStatus aggregates the status of the member NodeSets.
+func calculateStatus(
+	partition *slinkyv1beta1.Partition,
+	nodesetList *slinkyv1beta1.NodeSetList,
+) slinkyv1beta1.PartitionStatus {
+	newStatus := slinkyv1beta1.PartitionStatus{
+		ObservedGeneration: partition.Generation,
+		Conditions:         []metav1.Condition{},
+	}
+	newStatus.Conditions = append(newStatus.Conditions, partition.Status.Conditions...)
+
+	for _, nodeset := range nodesetList.Items {
+		newStatus.NodeSets = append(newStatus.NodeSets, nodeset.Name)
+		newStatus.Nodes += nodeset.Status.Replicas
+		newStatus.SlurmIdle += nodeset.Status.SlurmIdle
+		newStatus.SlurmAllocated += nodeset.Status.SlurmAllocated
+		newStatus.SlurmDown += nodeset.Status.SlurmDown
+		newStatus.SlurmDrain += nodeset.Status.SlurmDrain
+	}
+	sort.Strings(newStatus.NodeSets)
+
+	return newStatus
+}
+
+func (r *PartitionReconciler) updateStatus(
+	ctx context.Context,
+	partition *slinkyv1beta1.Partition,
+	newStatus *slinkyv1beta1.PartitionStatus,
+) error {
+	logger := log.FromContext(ctx)
+
+	namespacedName := types.NamespacedName{
+		Namespace: partition.GetNamespace(),
+		Name:      partition.GetName(),
+	}
+
+	logger.V(1).Info("Pending Partition Status update",
+		"partition", klog.KObj(partition), "newStatus", newStatus)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.Partition{}
+		if err := r.Get(ctx, namespacedName, toUpdate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		toUpdate.Status = *newStatus
+		return r.Status().Update(ctx, toUpdate)
+	})
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newNodeSetWithStatus(name string, controller *slinkyv1beta1.Controller, status slinkyv1beta1.NodeSetStatus) *slinkyv1beta1.NodeSet {
+	nodeset := testutils.NewNodeset(name, controller, status.Replicas)
+	nodeset.Status = status
+	return nodeset
+}
+
+func Test_calculateStatus(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	tests := []struct {
+		name        string
+		partition   *slinkyv1beta1.Partition
+		nodesetList *slinkyv1beta1.NodeSetList
+		want        slinkyv1beta1.PartitionStatus
+	}{
+		{
+			name:        "empty",
+			partition:   testutils.NewPartition("all", controller),
+			nodesetList: &slinkyv1beta1.NodeSetList{},
+			want: slinkyv1beta1.PartitionStatus{
+				Conditions: []metav1.Condition{},
+			},
+		},
+		{
+			name:      "aggregate",
+			partition: testutils.NewPartition("all", controller),
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					*newNodeSetWithStatus("gpu", controller, slinkyv1beta1.NodeSetStatus{
+						Replicas:       2,
+						SlurmAllocated: 1,
+						SlurmDrain:     1,
+					}),
+					*newNodeSetWithStatus("cpu", controller, slinkyv1beta1.NodeSetStatus{
+						Replicas:  3,
+						SlurmIdle: 2,
+						SlurmDown: 1,
+					}),
+				},
+			},
+			want: slinkyv1beta1.PartitionStatus{
+				NodeSets:       []string{"cpu", "gpu"},
+				Nodes:          5,
+				SlurmIdle:      2,
+				SlurmAllocated: 1,
+				SlurmDown:      1,
+				SlurmDrain:     1,
+				Conditions:     []metav1.Condition{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateStatus(tt.partition, tt.nodesetList)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func TestPartitionReconciler_Sync(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	nodeset := newNodeSetWithStatus("cpu", controller, slinkyv1beta1.NodeSetStatus{
+		Replicas:  2,
+		SlurmIdle: 2,
+	})
+	otherNodeSet := newNodeSetWithStatus("gpu", controller, slinkyv1beta1.NodeSetStatus{
+		Replicas: 4,
+	})
+	partition := testutils.NewPartition("cpu", controller, nodeset)
+	tests := []struct {
+		name    string
+		client  client.Client
+		want    slinkyv1beta1.PartitionStatus
+		wantErr bool
+	}{
+		{
+			name: "update status",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(nodeset.DeepCopy(), otherNodeSet.DeepCopy(), partition.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.Partition{}).
+				Build(),
+			want: slinkyv1beta1.PartitionStatus{
+				NodeSets:  []string{"cpu"},
+				Nodes:     2,
+				SlurmIdle: 2,
+			},
+		},
+		{
+			name: "not found",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				Build(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r := NewReconciler(tt.client)
+			key := client.ObjectKeyFromObject(partition)
+			err := r.Sync(ctx, reconcile.Request{NamespacedName: key})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			checkPartition := &slinkyv1beta1.Partition{}
+			if err := tt.client.Get(ctx, key, checkPartition); err != nil {
+				return
+			}
+			require.Equal(t, tt.want.NodeSets, checkPartition.Status.NodeSets)
+			require.Equal(t, tt.want.Nodes, checkPartition.Status.Nodes)
+			require.Equal(t, tt.want.SlurmIdle, checkPartition.Status.SlurmIdle)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package qos
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/qos/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+const (
+	ControllerName = "qos-controller"
+)
+
+func init() {
+	flag.IntVar(&maxConcurrentReconciles, "qos-workers", maxConcurrentReconciles, "Max concurrent workers for QOS controller.")
+	flag.DurationVar(&syncPeriod, "qos-sync-period", syncPeriod, "The period between Slurm QOS synchronizations.")
+}
+
+var (
+	maxConcurrentReconciles = 1
+
+	// syncPeriod is how often the Slurm QOS is synchronized, to correct drift.
+	syncPeriod = 5 * time.Minute
+
+	// this is a short cut for any sub-functions to notify the reconcile how long to wait to requeue
+	durationStore = durationstore.NewDurationStore(durationstore.Less)
+)
+
+// QOSReconciler reconciles a QOS object
+type QOSReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	ClientMap *clientmap.ClientMap
+
+	refResolver   *refresolver.RefResolver
+	slurmControl  slurmcontrol.SlurmControlInterface
+	eventRecorder events.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=qoses,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=qoses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=qoses/finalizers,verbs=update
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=accountings,verbs=get;list;watch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *QOSReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, retErr error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Started syncing QOS", "request", req)
+
+	startTime := time.Now()
+	defer func() {
+		if retErr == nil {
+			if res.RequeueAfter > 0 {
+				logger.Info("Finished syncing QOS", "duration", time.Since(startTime), "result", res)
+			} else {
+				logger.Info("Finished syncing QOS", "duration", time.Since(startTime))
+			}
+		} else {
+			logger.Info("Finished syncing QOS", "duration", time.Since(startTime), "error", retErr)
+		}
+		// clean the duration store
+		_ = durationStore.Pop(req.String())
+	}()
+
+	retErr = r.Sync(ctx, req)
+	res = reconcile.Result{
+		RequeueAfter: durationStore.Pop(req.String()),
+	}
+	return res, retErr
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *QOSReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.eventRecorder = mgr.GetEventRecorder(ControllerName)
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(ControllerName).
+		For(&slinkyv1beta1.QOS{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+		}).
+		Complete(r)
+}
+
+func NewReconciler(c client.Client, cm *clientmap.ClientMap) *QOSReconciler {
+	s := c.Scheme()
+	if cm == nil {
+		panic("ClientMap cannot be nil")
+	}
+	return &QOSReconciler{
+		Client: c,
+		Scheme: s,
+
+		ClientMap: cm,
+
+		refResolver:   refresolver.New(c),
+		slurmControl:  slurmcontrol.NewSlurmControl(cm),
+		eventRecorder: events.NewFakeRecorder(100),
+	}
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package qos
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slurmfake "github.com/SlinkyProject/slurm-client/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	testutils "github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("QOS Controller", func() {
+	Context("When reconciling a QOS", func() {
+		var name = testutils.GenerateResourceName(5)
+		var qos *slinkyv1beta1.QOS
+		var controller *slinkyv1beta1.Controller
+
+		BeforeEach(func() {
+			controller = testutils.NewController(name, corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			qos = testutils.NewQOS(name, controller)
+			qos.Spec.Priority = ptr.To[int32](100)
+			slurmClient := slurmfake.NewFakeClient()
+			clientMap.Add(types.NamespacedName{Namespace: corev1.NamespaceDefault, Name: controller.Name}, slurmClient)
+			Expect(k8sClient.Create(ctx, controller.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, qos.DeepCopy())).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, qos)
+			_ = k8sClient.Delete(ctx, controller)
+		})
+
+		It("Should create the Slurm QOS", func(ctx SpecContext) {
+			By("Expecting QOS status priority")
+			qosKey := client.ObjectKeyFromObject(qos)
+			Eventually(func(g Gomega) {
+				checkQos := &slinkyv1beta1.QOS{}
+				g.Expect(k8sClient.Get(ctx, qosKey, checkQos)).To(Succeed())
+				g.Expect(checkQos.Finalizers).To(ContainElement(slinkyv1beta1.FinalizerQOS))
+				g.Expect(checkQos.Status.Priority).To(Equal(ptr.To[int32](100)))
+			}, testutils.Timeout, testutils.Interval).Should(Succeed())
+		}, SpecTimeout(testutils.Timeout))
+	})
+})
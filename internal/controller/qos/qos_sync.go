@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package qos
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/slurmdbsync"
+)
+
+// Sync implements control logic for synchronizing a QOS.
+func (r *QOSReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	qos := &slinkyv1beta1.QOS{}
+	if err := r.Get(ctx, req.NamespacedName, qos); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("QOS has been deleted", "request", req)
+			return nil
+		}
+		return err
+	}
+	qos = qos.DeepCopy()
+
+	syncer := &slurmdbsync.Syncer[*slinkyv1beta1.QOS]{
+		Client:        r.Client,
+		RefResolver:   r.refResolver,
+		EventRecorder: r.eventRecorder,
+		DurationStore: durationStore,
+		SyncPeriod:    syncPeriod,
+		Kind:          "QOS",
+		Finalizer:     slinkyv1beta1.FinalizerQOS,
+		SlurmdbRefFn: func(qos *slinkyv1beta1.QOS) slinkyv1beta1.SlurmdbRef {
+			return qos.Spec.SlurmdbRef
+		},
+		SyncFn:   r.slurmControl.SyncQOS,
+		DeleteFn: r.slurmControl.DeleteQOS,
+		StatusFn: r.syncStatus,
+	}
+	return syncer.Sync(ctx, qos)
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package qos
+
+import (
+	"context"
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// syncStatus handles determining and updating the status.
+func (r *QOSReconciler) syncStatus(
+	ctx context.Context,
+	qos *slinkyv1beta1.QOS,
+	controller *slinkyv1beta1.Controller,
+	errors ...error,
+) error {
+	logger := log.FromContext(ctx)
+
+	var slurmQos *slurmtypes.V0044Qos
+	if controller != nil {
+		var err error
+		slurmQos, err = r.slurmControl.GetQOS(ctx, controller, qos)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	newStatus := calculateStatus(qos, slurmQos, utilerrors.NewAggregate(errors))
+
+	if apiequality.Semantic.DeepEqual(qos.Status, newStatus) {
+		logger.V(2).Info("QOS Status has not changed, skipping status update",
+			"qos", klog.KObj(qos), "status", qos.Status)
+		return utilerrors.NewAggregate(errors)
+	}
+
+	if err := r.updateStatus(ctx, qos, &newStatus); err != nil {
+		errors = append(errors, fmt.Errorf("error updating QOS(%s) status: %w",
+			klog.KObj(qos), err))
+	}
+
+	return utilerrors.NewAggregate(errors)
+}
+
+// calculateStatus returns the QOS status given the Slurm QOS, which is nil
+// when it does not exist.
+func calculateStatus(
+	qos *slinkyv1beta1.QOS,
+	slurmQos *slurmtypes.V0044Qos,
+	syncErr error,
+) slinkyv1beta1.QOSStatus {
+	newStatus := slinkyv1beta1.QOSStatus{
+		ObservedGeneration: qos.Generation,
+		Conditions:         []metav1.Condition{},
+	}
+	newStatus.Conditions = append(newStatus.Conditions, qos.Status.Conditions...)
+
+	condition := metav1.Condition{
+		Type:               slurmconditions.SlurmdbConditionSynced,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: qos.Generation,
+	}
+
+	if slurmQos != nil {
+		newStatus.Priority = slurmdbutils.Uint32NoValToInt32(slurmQos.Priority)
+		newStatus.Limits = slurmdbutils.GetQosLimits(slurmQos.V0044Qos)
+	}
+
+	switch {
+	case syncErr != nil:
+		condition.Reason = "SyncFailed"
+		condition.Message = syncErr.Error()
+	case slurmQos != nil:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Synced"
+	default:
+		condition.Reason = "NotFound"
+		condition.Message = "The Slurm QOS does not exist."
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, condition)
+
+	return newStatus
+}
+
+func (r *QOSReconciler) updateStatus(
+	ctx context.Context,
+	qos *slinkyv1beta1.QOS,
+	newStatus *slinkyv1beta1.QOSStatus,
+) error {
+	logger := log.FromContext(ctx)
+
+	namespacedName := types.NamespacedName{
+		Namespace: qos.GetNamespace(),
+		Name:      qos.GetName(),
+	}
+
+	logger.V(1).Info("Pending QOS Status update",
+		"qos", klog.KObj(qos), "newStatus", newStatus)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.QOS{}
+		if err := r.Get(ctx, namespacedName, toUpdate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		toUpdate.Status = *newStatus
+		return r.Status().Update(ctx, toUpdate)
+	})
+}
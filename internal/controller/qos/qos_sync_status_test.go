@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package qos
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func Test_calculateStatus(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	limits := slinkyv1beta1.QOSLimits{
+		MaxJobsPerUser: ptr.To[int32](10),
+		MaxTRESPerUser: map[string]int64{"gres/gpu": 8},
+	}
+	slurmQos := &slurmtypes.V0044Qos{
+		V0044Qos: slurmapi.V0044Qos{
+			Name:     ptr.To("high"),
+			Priority: slurmdbutils.Int32ToUint32NoVal(ptr.To[int32](100)),
+		},
+	}
+	slurmdbutils.SetQosLimits(&slurmQos.V0044Qos, limits, slinkyv1beta1.QOSLimits{})
+	type args struct {
+		qos      *slinkyv1beta1.QOS
+		slurmQos *slurmtypes.V0044Qos
+		syncErr  error
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantPriority *int32
+		wantLimits   slinkyv1beta1.QOSLimits
+		wantStatus   metav1.ConditionStatus
+		wantReason   string
+	}{
+		{
+			name: "synced",
+			args: args{
+				qos:      testutils.NewQOS("high", controller),
+				slurmQos: slurmQos,
+			},
+			wantPriority: ptr.To[int32](100),
+			wantLimits:   limits,
+			wantStatus:   metav1.ConditionTrue,
+			wantReason:   "Synced",
+		},
+		{
+			name: "not found",
+			args: args{
+				qos: testutils.NewQOS("high", controller),
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "NotFound",
+		},
+		{
+			name: "sync failed",
+			args: args{
+				qos:      testutils.NewQOS("high", controller),
+				slurmQos: slurmQos,
+				syncErr:  errors.New("failed"),
+			},
+			wantPriority: ptr.To[int32](100),
+			wantLimits:   limits,
+			wantStatus:   metav1.ConditionFalse,
+			wantReason:   "SyncFailed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateStatus(tt.args.qos, tt.args.slurmQos, tt.args.syncErr)
+			require.Equal(t, tt.wantPriority, got.Priority)
+			require.Equal(t, tt.wantLimits, got.Limits)
+			require.Len(t, got.Conditions, 1)
+			require.Equal(t, slurmconditions.SlurmdbConditionSynced, got.Conditions[0].Type)
+			require.Equal(t, tt.wantStatus, got.Conditions[0].Status)
+			require.Equal(t, tt.wantReason, got.Conditions[0].Reason)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package qos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmfake "github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newClientMap(controllerName string, client slurmclient.Client) *clientmap.ClientMap {
+	cm := clientmap.NewClientMap()
+	key := types.NamespacedName{
+		Namespace: corev1.NamespaceDefault,
+		Name:      controllerName,
+	}
+	cm.Add(key, client)
+	return cm
+}
+
+func TestQOSReconciler_Sync(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	qos := testutils.NewQOS("high", controller)
+	qos.Spec.Priority = ptr.To[int32](100)
+	qosNoController := testutils.NewQOS("high", testutils.NewController("other", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil))
+	deletedQos := qos.DeepCopy()
+	deletedQos.Finalizers = []string{slinkyv1beta1.FinalizerQOS}
+	deletedQos.DeletionTimestamp = ptr.To(metav1.Now())
+	tests := []struct {
+		name        string
+		client      client.Client
+		slurmClient slurmclient.Client
+		wantFound   bool
+		wantReason  string
+		wantErr     bool
+	}{
+		{
+			name: "create",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(controller.DeepCopy(), qos.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.QOS{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(),
+			wantFound:   true,
+			wantReason:  "Synced",
+		},
+		{
+			name: "controller not found",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(qosNoController.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.QOS{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(),
+			wantFound:   false,
+			wantReason:  "SyncFailed",
+			wantErr:     true,
+		},
+		{
+			name: "delete",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(controller.DeepCopy(), deletedQos.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.QOS{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(&slurmtypes.V0044Qos{
+				V0044Qos: slurmapi.V0044Qos{
+					Name: ptr.To(qos.Name),
+				},
+			}),
+			wantFound: false,
+		},
+		{
+			name: "not found",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(),
+			wantFound:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r := NewReconciler(tt.client, newClientMap(controller.Name, tt.slurmClient))
+			key := client.ObjectKeyFromObject(qos)
+			err := r.Sync(ctx, reconcile.Request{NamespacedName: key})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			slurmQos := &slurmtypes.V0044Qos{}
+			err = tt.slurmClient.Get(ctx, slurmobject.ObjectKey(qos.Name), slurmQos)
+			require.Equal(t, tt.wantFound, err == nil)
+
+			checkQos := &slinkyv1beta1.QOS{}
+			if err := tt.client.Get(ctx, key, checkQos); err != nil {
+				return
+			}
+			if checkQos.DeletionTimestamp.IsZero() {
+				require.Contains(t, checkQos.Finalizers, slinkyv1beta1.FinalizerQOS)
+			}
+			if tt.wantReason != "" {
+				require.Len(t, checkQos.Status.Conditions, 1)
+				require.Equal(t, tt.wantReason, checkQos.Status.Conditions[0].Reason)
+			}
+			if tt.wantFound {
+				require.Equal(t, qos.Spec.Priority, checkQos.Status.Priority)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+)
+
+type SlurmControlInterface interface {
+	// GetQOS returns the Slurm QOS, or nil if it does not exist.
+	GetQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.QOS) (*slurmtypes.V0044Qos, error)
+	// SyncQOS creates and updates the Slurm QOS.
+	SyncQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.QOS) error
+	// DeleteQOS deletes the Slurm QOS.
+	DeleteQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.QOS) error
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+}
+
+// GetQOS implements SlurmControlInterface.
+func (r *realSlurmControl) GetQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.QOS) (*slurmtypes.V0044Qos, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for qos, cannot do GetQOS()")
+		return nil, nil
+	}
+
+	slurmQos := new(slurmtypes.V0044Qos)
+	key := slurmobject.ObjectKey(qos.Name)
+	if err := slurmClient.Get(ctx, key, slurmQos); err != nil {
+		if tolerateError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if slurmQos.Name == nil {
+		return nil, nil
+	}
+
+	return slurmQos, nil
+}
+
+// SyncQOS implements SlurmControlInterface.
+func (r *realSlurmControl) SyncQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.QOS) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for qos, cannot do SyncQOS()")
+		return nil
+	}
+
+	oldQos := new(slurmtypes.V0044Qos)
+	key := slurmobject.ObjectKey(qos.Name)
+	if err := slurmClient.Get(ctx, key, oldQos); !tolerateError(err) {
+		return err
+	}
+
+	if oldQos.Name == nil {
+		newQos := formatQos(qos, slinkyv1beta1.QOSLimits{})
+		if err := slurmClient.Create(ctx, &newQos, newQos.V0044Qos); !tolerateError(err) {
+			return fmt.Errorf("SyncQOS() failed to Create QOS=%s with error=%w", qos.Name, err)
+		}
+		return nil
+	}
+
+	if isQosMatch(*oldQos, qos) {
+		return nil
+	}
+
+	newQos := formatQos(qos, slurmdbutils.GetQosLimits(oldQos.V0044Qos))
+	if err := slurmClient.Update(ctx, &newQos, newQos.V0044Qos); !tolerateError(err) {
+		return fmt.Errorf("SyncQOS() failed to Update QOS=%s with error=%w", qos.Name, err)
+	}
+
+	return nil
+}
+
+// DeleteQOS implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteQOS(ctx context.Context, controller *slinkyv1beta1.Controller, qos *slinkyv1beta1.QOS) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for qos, cannot do DeleteQOS()")
+		return nil
+	}
+
+	slurmQos := new(slurmtypes.V0044Qos)
+	key := slurmobject.ObjectKey(qos.Name)
+	if err := slurmClient.Get(ctx, key, slurmQos); !tolerateError(err) {
+		return err
+	}
+
+	if slurmQos.Name == nil {
+		return nil
+	}
+
+	if err := slurmClient.Delete(ctx, slurmQos); !tolerateError(err) {
+		return err
+	}
+
+	return nil
+}
+
+// isQosMatch returns true when the Slurm QOS matches the QOS.
+func isQosMatch(slurmQos slurmtypes.V0044Qos, qos *slinkyv1beta1.QOS) bool {
+	if ptr.Deref(slurmQos.Description, "") != getDescription(qos) {
+		return false
+	}
+	// An unset priority is left to Slurm.
+	if qos.Spec.Priority != nil &&
+		ptr.Deref(slurmdbutils.Uint32NoValToInt32(slurmQos.Priority), 0) != *qos.Spec.Priority {
+		return false
+	}
+	if !set.New(ptr.Deref(slurmQos.Flags, nil)...).Equal(set.New(toQosFlags(qos.Spec.Flags)...)) {
+		return false
+	}
+	var preemptList []string
+	var preemptModes []slurmapi.V0044QosPreemptMode
+	if slurmQos.Preempt != nil {
+		preemptList = ptr.Deref(slurmQos.Preempt.List, nil)
+		preemptModes = ptr.Deref(slurmQos.Preempt.Mode, nil)
+	}
+	if !slurmdbutils.IsQosListMatch(preemptList, qos.Spec.Preempt) {
+		return false
+	}
+	// An unset preempt mode is left to Slurm.
+	if qos.Spec.PreemptMode != "" &&
+		!set.New(preemptModes...).Equal(set.New(slurmapi.V0044QosPreemptMode(qos.Spec.PreemptMode))) {
+		return false
+	}
+	return slurmdbutils.IsQosLimitsMatch(slurmQos.V0044Qos, qos.Spec.Limits)
+}
+
+func formatQos(qos *slinkyv1beta1.QOS, observedLimits slinkyv1beta1.QOSLimits) slurmtypes.V0044Qos {
+	flags := toQosFlags(qos.Spec.Flags)
+	slurmQos := slurmtypes.V0044Qos{
+		V0044Qos: slurmapi.V0044Qos{
+			Name:        ptr.To(qos.Name),
+			Description: ptr.To(getDescription(qos)),
+			Flags:       &flags,
+			Preempt: &slurmapi.V0044QosPreempt{
+				List: ptr.To(slurmapi.V0044QosPreemptList(set.New(qos.Spec.Preempt...).SortedList())),
+			},
+		},
+	}
+	if qos.Spec.Priority != nil {
+		slurmQos.Priority = slurmdbutils.Int32ToUint32NoVal(qos.Spec.Priority)
+	}
+	if qos.Spec.PreemptMode != "" {
+		slurmQos.Preempt.Mode = &[]slurmapi.V0044QosPreemptMode{
+			slurmapi.V0044QosPreemptMode(qos.Spec.PreemptMode),
+		}
+	}
+	slurmdbutils.SetQosLimits(&slurmQos.V0044Qos, qos.Spec.Limits, observedLimits)
+	return slurmQos
+}
+
+func toQosFlags(flags []string) []slurmapi.V0044QosFlags {
+	out := make([]slurmapi.V0044QosFlags, 0, len(flags))
+	for _, flag := range set.New(flags...).SortedList() {
+		out = append(out, slurmapi.V0044QosFlags(flag))
+	}
+	return out
+}
+
+func getDescription(qos *slinkyv1beta1.QOS) string {
+	if qos.Spec.Description == "" {
+		return qos.Name
+	}
+	return qos.Spec.Description
+}
+
+func (r *realSlurmControl) lookupClient(controller *slinkyv1beta1.Controller) slurmclient.Client {
+	if controller == nil {
+		return nil
+	}
+	return r.clientMap.Get(objectutils.NamespacedName(controller))
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clientMap *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clientMap,
+	}
+}
+
+func tolerateError(err error) bool {
+	if err == nil {
+		return true
+	}
+	errText := err.Error()
+	if errText == http.StatusText(http.StatusNoContent) ||
+		errText == http.StatusText(http.StatusNotFound) {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,371 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
+	"github.com/SlinkyProject/slurm-client/pkg/types"
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+// slurmUpdateFn mimics slurmdbd by applying the request onto the object.
+func slurmUpdateFn(_ context.Context, obj object.Object, req any, opts ...client.UpdateOption) error {
+	switch o := obj.(type) {
+	case *types.V0044Qos:
+		r, ok := req.(api.V0044Qos)
+		if !ok {
+			return errors.New("failed to cast request object")
+		}
+		o.V0044Qos = r
+	default:
+		return errors.New("failed to cast slurm object")
+	}
+	return nil
+}
+
+func newController(name string) *slinkyv1beta1.Controller {
+	return &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      name,
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			ClusterName: "slurm",
+		},
+	}
+}
+
+func newQOS(name string, limits slinkyv1beta1.QOSLimits) *slinkyv1beta1.QOS {
+	return &slinkyv1beta1.QOS{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      name,
+		},
+		Spec: slinkyv1beta1.QOSSpec{
+			SlurmdbRef: slinkyv1beta1.SlurmdbRef{
+				ControllerRef: &corev1.LocalObjectReference{
+					Name: "slurm",
+				},
+			},
+			Limits: limits,
+		},
+	}
+}
+
+func newSlurmQos(name string, limits slinkyv1beta1.QOSLimits) *types.V0044Qos {
+	qos := &types.V0044Qos{
+		V0044Qos: api.V0044Qos{
+			Name:        ptr.To(name),
+			Description: ptr.To(name),
+			Flags:       &[]api.V0044QosFlags{},
+			Preempt: &api.V0044QosPreempt{
+				List: &api.V0044QosPreemptList{},
+			},
+		},
+	}
+	slurmdbutils.SetQosLimits(&qos.V0044Qos, limits, slinkyv1beta1.QOSLimits{})
+	return qos
+}
+
+func newSlurmClientMap(controllerName string, client client.Client) *clientmap.ClientMap {
+	cm := clientmap.NewClientMap()
+	key := k8stypes.NamespacedName{
+		Namespace: corev1.NamespaceDefault,
+		Name:      controllerName,
+	}
+	cm.Add(key, client)
+	return cm
+}
+
+func Test_realSlurmControl_GetQOS(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     client.Client
+		controller *slinkyv1beta1.Controller
+		wantFound  bool
+		wantErr    bool
+	}{
+		{
+			name:       "No client",
+			client:     fake.NewFakeClient(newSlurmQos("normal", slinkyv1beta1.QOSLimits{})),
+			controller: newController("other"),
+			wantFound:  false,
+		},
+		{
+			name:       "Not found",
+			client:     fake.NewFakeClient(newSlurmQos("high", slinkyv1beta1.QOSLimits{})),
+			controller: newController("slurm"),
+			wantFound:  false,
+		},
+		{
+			name:       "Found",
+			client:     fake.NewFakeClient(newSlurmQos("normal", slinkyv1beta1.QOSLimits{})),
+			controller: newController("slurm"),
+			wantFound:  true,
+		},
+		{
+			name: "Get error",
+			client: fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(ctx context.Context, key object.ObjectKey, obj object.Object, opts ...client.GetOption) error {
+						return errors.New("failed to get")
+					},
+				}).
+				Build(),
+			controller: newController("slurm"),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qos := newQOS("normal", slinkyv1beta1.QOSLimits{})
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			got, err := r.GetQOS(context.Background(), tt.controller, qos)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetQOS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got != nil) != tt.wantFound {
+				t.Errorf("GetQOS() = %v, wantFound %v", got, tt.wantFound)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_SyncQOS(t *testing.T) {
+	limits := slinkyv1beta1.QOSLimits{
+		MaxJobsPerUser: ptr.To[int32](10),
+		MaxTRESPerUser: map[string]int64{"gres/gpu": 8},
+	}
+	tests := []struct {
+		name       string
+		client     client.Client
+		qos        *slinkyv1beta1.QOS
+		wantLimits slinkyv1beta1.QOSLimits
+		wantErr    bool
+	}{
+		{
+			name:       "Create",
+			client:     fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).Build(),
+			qos:        newQOS("normal", limits),
+			wantLimits: limits,
+		},
+		{
+			name: "Correct drift",
+			client: fake.NewClientBuilder().
+				WithUpdateFn(slurmUpdateFn).
+				WithObjects(newSlurmQos("normal", slinkyv1beta1.QOSLimits{
+					GrpJobs:        ptr.To[int32](5),
+					MaxTRESPerUser: map[string]int64{"cpu": 16},
+				})).
+				Build(),
+			qos:        newQOS("normal", limits),
+			wantLimits: limits,
+		},
+		{
+			name: "No change",
+			client: fake.NewClientBuilder().
+				WithObjects(newSlurmQos("normal", limits)).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Update: func(ctx context.Context, obj object.Object, req any, opts ...client.UpdateOption) error {
+						return errors.New("unexpected update")
+					},
+				}).
+				Build(),
+			qos:        newQOS("normal", limits),
+			wantLimits: limits,
+		},
+		{
+			name: "Create error",
+			client: fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, obj object.Object, req any, opts ...client.CreateOption) error {
+						return errors.New("failed to create")
+					},
+				}).
+				Build(),
+			qos:     newQOS("normal", limits),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := newController("slurm")
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			if err := r.SyncQOS(context.Background(), controller, tt.qos); (err != nil) != tt.wantErr {
+				t.Fatalf("SyncQOS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := r.GetQOS(context.Background(), controller, tt.qos)
+			if err != nil {
+				t.Fatalf("GetQOS() error = %v", err)
+			}
+			if got == nil {
+				t.Fatalf("SyncQOS() did not create qos")
+			}
+			if limits := slurmdbutils.GetQosLimits(got.V0044Qos); !apiequality.Semantic.DeepEqual(limits, tt.wantLimits) {
+				t.Errorf("SyncQOS() limits = %v, want %v", limits, tt.wantLimits)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_DeleteQOS(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  client.Client
+		wantErr bool
+	}{
+		{
+			name:   "Not found",
+			client: fake.NewFakeClient(),
+		},
+		{
+			name:   "Found",
+			client: fake.NewFakeClient(newSlurmQos("normal", slinkyv1beta1.QOSLimits{})),
+		},
+		{
+			name: "Delete error",
+			client: fake.NewClientBuilder().
+				WithObjects(newSlurmQos("normal", slinkyv1beta1.QOSLimits{})).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, obj object.Object, opts ...client.DeleteOption) error {
+						return errors.New("failed to delete")
+					},
+				}).
+				Build(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qos := newQOS("normal", slinkyv1beta1.QOSLimits{})
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			if err := r.DeleteQOS(context.Background(), newController("slurm"), qos); (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteQOS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := &types.V0044Qos{}
+			if err := tt.client.Get(context.Background(), object.ObjectKey("normal"), got); err == nil {
+				t.Errorf("DeleteQOS() did not delete qos = %v", got)
+			}
+		})
+	}
+}
+
+func Test_formatQos(t *testing.T) {
+	qos := newQOS("high", slinkyv1beta1.QOSLimits{})
+	qos.Spec.Description = "High priority"
+	qos.Spec.Priority = ptr.To[int32](100)
+	qos.Spec.Flags = []string{"DENY_LIMIT", "NO_DECAY", "DENY_LIMIT"}
+	qos.Spec.Preempt = []string{"scavenger", "normal"}
+	qos.Spec.PreemptMode = "REQUEUE"
+
+	got := formatQos(qos, slinkyv1beta1.QOSLimits{})
+	if desc := ptr.Deref(got.Description, ""); desc != "High priority" {
+		t.Errorf("formatQos() description = %v, want %v", desc, "High priority")
+	}
+	if priority := slurmdbutils.Uint32NoValToInt32(got.Priority); ptr.Deref(priority, 0) != 100 {
+		t.Errorf("formatQos() priority = %v, want %v", priority, 100)
+	}
+	wantFlags := []api.V0044QosFlags{"DENY_LIMIT", "NO_DECAY"}
+	if flags := ptr.Deref(got.Flags, nil); !apiequality.Semantic.DeepEqual(flags, wantFlags) {
+		t.Errorf("formatQos() flags = %v, want %v", flags, wantFlags)
+	}
+	wantPreempt := api.V0044QosPreemptList{"normal", "scavenger"}
+	if preempt := ptr.Deref(got.Preempt.List, nil); !apiequality.Semantic.DeepEqual(preempt, wantPreempt) {
+		t.Errorf("formatQos() preempt = %v, want %v", preempt, wantPreempt)
+	}
+	wantModes := []api.V0044QosPreemptMode{"REQUEUE"}
+	if modes := ptr.Deref(got.Preempt.Mode, nil); !apiequality.Semantic.DeepEqual(modes, wantModes) {
+		t.Errorf("formatQos() preempt mode = %v, want %v", modes, wantModes)
+	}
+}
+
+func Test_isQosMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		slurmQos *types.V0044Qos
+		qos      *slinkyv1beta1.QOS
+		want     bool
+	}{
+		{
+			name:     "Match",
+			slurmQos: newSlurmQos("normal", slinkyv1beta1.QOSLimits{}),
+			qos:      newQOS("normal", slinkyv1beta1.QOSLimits{}),
+			want:     true,
+		},
+		{
+			name:     "Description changed",
+			slurmQos: newSlurmQos("normal", slinkyv1beta1.QOSLimits{}),
+			qos: func() *slinkyv1beta1.QOS {
+				qos := newQOS("normal", slinkyv1beta1.QOSLimits{})
+				qos.Spec.Description = "Normal"
+				return qos
+			}(),
+			want: false,
+		},
+		{
+			name:     "Priority changed",
+			slurmQos: newSlurmQos("normal", slinkyv1beta1.QOSLimits{}),
+			qos: func() *slinkyv1beta1.QOS {
+				qos := newQOS("normal", slinkyv1beta1.QOSLimits{})
+				qos.Spec.Priority = ptr.To[int32](10)
+				return qos
+			}(),
+			want: false,
+		},
+		{
+			name:     "Flags changed",
+			slurmQos: newSlurmQos("normal", slinkyv1beta1.QOSLimits{}),
+			qos: func() *slinkyv1beta1.QOS {
+				qos := newQOS("normal", slinkyv1beta1.QOSLimits{})
+				qos.Spec.Flags = []string{"DENY_LIMIT"}
+				return qos
+			}(),
+			want: false,
+		},
+		{
+			name:     "Preempt changed",
+			slurmQos: newSlurmQos("normal", slinkyv1beta1.QOSLimits{}),
+			qos: func() *slinkyv1beta1.QOS {
+				qos := newQOS("normal", slinkyv1beta1.QOSLimits{})
+				qos.Spec.Preempt = []string{"scavenger"}
+				return qos
+			}(),
+			want: false,
+		},
+		{
+			name:     "Limits changed",
+			slurmQos: newSlurmQos("normal", slinkyv1beta1.QOSLimits{}),
+			qos:      newQOS("normal", slinkyv1beta1.QOSLimits{GrpJobs: ptr.To[int32](1)}),
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isQosMatch(*tt.slurmQos, tt.qos); got != tt.want {
+				t.Errorf("isQosMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
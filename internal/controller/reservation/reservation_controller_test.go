@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package reservation
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmfake "github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	testutils "github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("Reservation Controller", func() {
+	Context("When reconciling a Reservation", func() {
+		var name = testutils.GenerateResourceName(5)
+		var reservation *slinkyv1beta1.Reservation
+		var nodeset *slinkyv1beta1.NodeSet
+		var controller *slinkyv1beta1.Controller
+
+		BeforeEach(func() {
+			controller = testutils.NewController(name, corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset = testutils.NewNodeset(name, controller, 1)
+			reservation = testutils.NewReservation(name, controller, nodeset)
+			slurmClient := slurmfake.NewFakeClient(&slurmtypes.V0044Node{
+				V0044Node: slurmapi.V0044Node{
+					Name:     ptr.To(name + "-0"),
+					Features: &slurmapi.V0044CsvString{name},
+				},
+			})
+			clientMap.Add(types.NamespacedName{Namespace: corev1.NamespaceDefault, Name: controller.Name}, slurmClient)
+			Expect(k8sClient.Create(ctx, controller.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, nodeset.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, reservation.DeepCopy())).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, reservation)
+			_ = k8sClient.Delete(ctx, nodeset)
+			_ = k8sClient.Delete(ctx, controller)
+		})
+
+		It("Should create the Slurm reservation", func(ctx SpecContext) {
+			By("Expecting Reservation status NodeList")
+			reservationKey := client.ObjectKeyFromObject(reservation)
+			Eventually(func(g Gomega) {
+				checkReservation := &slinkyv1beta1.Reservation{}
+				g.Expect(k8sClient.Get(ctx, reservationKey, checkReservation)).To(Succeed())
+				g.Expect(checkReservation.Finalizers).To(ContainElement(slinkyv1beta1.FinalizerReservation))
+				g.Expect(checkReservation.Status.NodeList).To(Equal(name + "-0"))
+				g.Expect(checkReservation.Status.Active).To(BeFalse())
+			}, testutils.Timeout, testutils.Interval).Should(Succeed())
+		}, SpecTimeout(testutils.Timeout))
+	})
+})
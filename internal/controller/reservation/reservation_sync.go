@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package reservation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/puttsk/hostlist"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+// Sync implements control logic for synchronizing a Reservation.
+func (r *ReservationReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	reservation := &slinkyv1beta1.Reservation{}
+	if err := r.Get(ctx, req.NamespacedName, reservation); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Reservation has been deleted", "request", req)
+			return nil
+		}
+		return err
+	}
+	reservation = reservation.DeepCopy()
+	key := objectutils.KeyFunc(reservation)
+
+	if err := r.syncFinalizer(ctx, reservation); err != nil {
+		msg := fmt.Sprintf("Failed to sync finalizer: %v", err)
+		r.eventRecorder.Eventf(reservation, nil, corev1.EventTypeWarning, SyncFinalizerFailedReason, "SyncFinalizer", msg)
+		return err
+	}
+
+	if !reservation.DeletionTimestamp.IsZero() {
+		logger.Info("Reservation is being deleted, skipping sync", "request", req)
+		return nil
+	}
+
+	durationStore.Push(key, syncPeriod)
+	// Refresh the status promptly when the reservation starts or ends.
+	for _, t := range []time.Time{reservation.Spec.StartTime.Time, getEndTime(reservation)} {
+		if d := time.Until(t); d > 0 {
+			durationStore.Push(key, d+time.Second)
+		}
+	}
+
+	nodeList, err := r.getNodeList(ctx, reservation)
+	if err != nil {
+		return r.syncStatus(ctx, reservation, nodeList, err)
+	}
+	if nodeList == "" {
+		// Slurm cannot hold a reservation without nodes, delete it so that the
+		// nodes which are no longer selected are released.
+		logger.V(1).Info("Reservation has no nodes, deleting Slurm reservation", "reservation", klog.KObj(reservation))
+		if err := r.slurmControl.DeleteReservation(ctx, reservation); err != nil {
+			msg := fmt.Sprintf("Failed to delete Slurm reservation: %v", err)
+			r.eventRecorder.Eventf(reservation, nil, corev1.EventTypeWarning, SyncFailedReason, "DeleteReservation", msg)
+			return r.syncStatus(ctx, reservation, nodeList, err)
+		}
+		return r.syncStatus(ctx, reservation, nodeList)
+	}
+
+	if err := r.slurmControl.SyncReservation(ctx, reservation, nodeList); err != nil {
+		msg := fmt.Sprintf("Failed to sync Slurm reservation: %v", err)
+		r.eventRecorder.Eventf(reservation, nil, corev1.EventTypeWarning, SyncFailedReason, "SyncReservation", msg)
+		return r.syncStatus(ctx, reservation, nodeList, err)
+	}
+
+	return r.syncStatus(ctx, reservation, nodeList)
+}
+
+// getNodeList returns the hostlist of the Slurm nodes of the member NodeSets
+// and the explicit node list, as a union.
+func (r *ReservationReconciler) getNodeList(ctx context.Context, reservation *slinkyv1beta1.Reservation) (string, error) {
+	nodes := set.New[string]()
+
+	if reservation.Spec.NodeList != "" {
+		explicitNodes, err := hostlist.Expand(reservation.Spec.NodeList)
+		if err != nil {
+			return "", fmt.Errorf("failed to expand nodeList(%s): %w", reservation.Spec.NodeList, err)
+		}
+		nodes.Insert(explicitNodes...)
+	}
+
+	if len(reservation.Spec.NodeSets) > 0 {
+		nodesetList, err := r.refResolver.GetNodeSetsForReservation(ctx, reservation)
+		if err != nil {
+			return "", fmt.Errorf("failed to get NodeSets for Reservation(%s): %w", klog.KObj(reservation), err)
+		}
+		nodesetNodes, err := r.slurmControl.GetNodesForNodeSets(ctx, reservation, nodesetList)
+		if err != nil {
+			return "", err
+		}
+		nodes.Insert(nodesetNodes...)
+	}
+
+	if nodes.Len() == 0 {
+		return "", nil
+	}
+	return hostlist.Compress(nodes.SortedList())
+}
+
+// syncFinalizer ensures the Slurm reservation is deleted before the Reservation is.
+func (r *ReservationReconciler) syncFinalizer(ctx context.Context, reservation *slinkyv1beta1.Reservation) error {
+	if reservation.DeletionTimestamp.IsZero() {
+		return r.addFinalizerIfNeeded(ctx, reservation)
+	}
+
+	if !controllerutil.ContainsFinalizer(reservation, slinkyv1beta1.FinalizerReservation) {
+		return nil
+	}
+
+	// If the controller does not exist we cannot delete the Slurm reservation and must
+	// remove the finalizer in order to permit Reservation cleanup
+	controller := new(slinkyv1beta1.Controller)
+	key := client.ObjectKey{
+		Name:      reservation.Spec.ControllerRef.Name,
+		Namespace: reservation.Namespace,
+	}
+	if err := r.Get(ctx, key, controller); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return r.removeFinalizerIfNeeded(ctx, reservation)
+	}
+
+	if err := r.slurmControl.DeleteReservation(ctx, reservation); err != nil {
+		return err
+	}
+	return r.removeFinalizerIfNeeded(ctx, reservation)
+}
+
+func (r *ReservationReconciler) addFinalizerIfNeeded(ctx context.Context, reservation *slinkyv1beta1.Reservation) error {
+	if controllerutil.ContainsFinalizer(reservation, slinkyv1beta1.FinalizerReservation) {
+		return nil
+	}
+
+	finalizersToAdd := slices.Concat(reservation.Finalizers, []string{slinkyv1beta1.FinalizerReservation})
+	return r.updateFinalizers(ctx, reservation, finalizersToAdd)
+}
+
+func (r *ReservationReconciler) removeFinalizerIfNeeded(ctx context.Context, reservation *slinkyv1beta1.Reservation) error {
+	if !controllerutil.ContainsFinalizer(reservation, slinkyv1beta1.FinalizerReservation) {
+		return nil
+	}
+
+	currentFinalizers := set.New(reservation.Finalizers...)
+
+	finalizersToRemove := set.New(slinkyv1beta1.FinalizerReservation)
+	finalizersToKeep := currentFinalizers.Difference(finalizersToRemove).SortedList()
+
+	return r.updateFinalizers(ctx, reservation, finalizersToKeep)
+}
+
+func (r *ReservationReconciler) updateFinalizers(ctx context.Context, reservation *slinkyv1beta1.Reservation, newFinalizers []string) error {
+	logger := log.FromContext(ctx)
+
+	logger.V(1).Info("Pending Reservation Finalizer update", "newFinalizers", newFinalizers)
+
+	mutateFn := func(reservation *slinkyv1beta1.Reservation) error {
+		reservation.Finalizers = newFinalizers
+		return nil
+	}
+
+	if err := objectutils.PatchObject(r.Client, ctx, reservation, mutateFn); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getEndTime returns the end time of the Reservation spec.
+func getEndTime(reservation *slinkyv1beta1.Reservation) time.Time {
+	switch {
+	case reservation.Spec.EndTime != nil:
+		return reservation.Spec.EndTime.Time
+	case reservation.Spec.Duration != nil:
+		return reservation.Spec.StartTime.Add(reservation.Spec.Duration.Duration)
+	}
+	return reservation.Spec.StartTime.Time
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package reservation
+
+import (
+	"context"
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/reservationutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// syncStatus handles determining and updating the status.
+func (r *ReservationReconciler) syncStatus(
+	ctx context.Context,
+	reservation *slinkyv1beta1.Reservation,
+	nodeList string,
+	errors ...error,
+) error {
+	logger := log.FromContext(ctx)
+
+	reservationInfo, err := r.slurmControl.GetReservation(ctx, reservation)
+	if err != nil {
+		errors = append(errors, err)
+	}
+
+	newStatus := calculateStatus(reservation, reservationInfo, nodeList, utilerrors.NewAggregate(errors))
+
+	if apiequality.Semantic.DeepEqual(reservation.Status, newStatus) {
+		logger.V(2).Info("Reservation Status has not changed, skipping status update",
+			"reservation", klog.KObj(reservation), "status", reservation.Status)
+		return utilerrors.NewAggregate(errors)
+	}
+
+	if err := r.updateStatus(ctx, reservation, &newStatus); err != nil {
+		errors = append(errors, fmt.Errorf("error updating Reservation(%s) status: %w",
+			klog.KObj(reservation), err))
+	}
+
+	return utilerrors.NewAggregate(errors)
+}
+
+// calculateStatus returns the Reservation status given the Slurm reservation,
+// which is nil when it does not exist.
+func calculateStatus(
+	reservation *slinkyv1beta1.Reservation,
+	reservationInfo *slurmtypes.V0044ReservationInfo,
+	nodeList string,
+	syncErr error,
+) slinkyv1beta1.ReservationStatus {
+	newStatus := slinkyv1beta1.ReservationStatus{
+		NodeList:           nodeList,
+		ObservedGeneration: reservation.Generation,
+		Conditions:         []metav1.Condition{},
+	}
+	newStatus.Conditions = append(newStatus.Conditions, reservation.Status.Conditions...)
+
+	condition := metav1.Condition{
+		Type:               slurmconditions.ReservationConditionSynced,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: reservation.Generation,
+	}
+
+	if reservationInfo != nil {
+		newStatus.Active = reservationutils.IsActive(*reservationInfo)
+		newStatus.NodeList = ptr.Deref(reservationInfo.NodeList, nodeList)
+		if t := reservationutils.Uint64NoValToTime(reservationInfo.StartTime); !t.IsZero() {
+			newStatus.StartTime = ptr.To(metav1.NewTime(t))
+		}
+		if t := reservationutils.Uint64NoValToTime(reservationInfo.EndTime); !t.IsZero() {
+			newStatus.EndTime = ptr.To(metav1.NewTime(t))
+		}
+	}
+
+	switch {
+	case syncErr != nil:
+		condition.Reason = "SyncFailed"
+		condition.Message = syncErr.Error()
+	case reservationInfo != nil:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Synced"
+	case nodeList == "":
+		condition.Reason = "NoNodes"
+		condition.Message = "No Slurm nodes were resolved for the reservation."
+	case !getEndTime(reservation).After(metav1.Now().Time):
+		condition.Reason = "Ended"
+		condition.Message = "The reservation has ended."
+	default:
+		condition.Reason = "NotFound"
+		condition.Message = "The Slurm reservation does not exist."
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, condition)
+
+	return newStatus
+}
+
+func (r *ReservationReconciler) updateStatus(
+	ctx context.Context,
+	reservation *slinkyv1beta1.Reservation,
+	newStatus *slinkyv1beta1.ReservationStatus,
+) error {
+	logger := log.FromContext(ctx)
+
+	namespacedName := types.NamespacedName{
+		Namespace: reservation.GetNamespace(),
+		Name:      reservation.GetName(),
+	}
+
+	logger.V(1).Info("Pending Reservation Status update",
+		"reservation", klog.KObj(reservation), "newStatus", newStatus)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.Reservation{}
+		if err := r.Get(ctx, namespacedName, toUpdate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		toUpdate.Status = *newStatus
+		return r.Status().Update(ctx, toUpdate)
+	})
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package reservation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/reservationutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func Test_calculateStatus(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	now := time.Unix(time.Now().Unix(), 0)
+	activeReservationInfo := &slurmtypes.V0044ReservationInfo{
+		V0044ReservationInfo: slurmapi.V0044ReservationInfo{
+			Name:      ptr.To("maint"),
+			NodeList:  ptr.To("cpu-[0-1]"),
+			StartTime: ptr.To(reservationutils.TimeToUint64NoVal(now.Add(-time.Hour))),
+			EndTime:   ptr.To(reservationutils.TimeToUint64NoVal(now.Add(time.Hour))),
+		},
+	}
+	endedReservation := testutils.NewReservation("maint", controller)
+	endedReservation.Spec.StartTime = metav1.NewTime(now.Add(-2 * time.Hour))
+	type args struct {
+		reservation     *slinkyv1beta1.Reservation
+		reservationInfo *slurmtypes.V0044ReservationInfo
+		nodeList        string
+		syncErr         error
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantActive bool
+		wantNodes  string
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name: "active",
+			args: args{
+				reservation:     testutils.NewReservation("maint", controller),
+				reservationInfo: activeReservationInfo,
+				nodeList:        "cpu-0,cpu-1",
+			},
+			wantActive: true,
+			wantNodes:  "cpu-[0-1]",
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "Synced",
+		},
+		{
+			name: "sync failed",
+			args: args{
+				reservation: testutils.NewReservation("maint", controller),
+				nodeList:    "cpu-[0-1]",
+				syncErr:     errors.New("failed"),
+			},
+			wantNodes:  "cpu-[0-1]",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "SyncFailed",
+		},
+		{
+			name: "no nodes",
+			args: args{
+				reservation: testutils.NewReservation("maint", controller),
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "NoNodes",
+		},
+		{
+			name: "ended",
+			args: args{
+				reservation: endedReservation,
+				nodeList:    "cpu-[0-1]",
+			},
+			wantNodes:  "cpu-[0-1]",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "Ended",
+		},
+		{
+			name: "not found",
+			args: args{
+				reservation: testutils.NewReservation("maint", controller),
+				nodeList:    "cpu-[0-1]",
+			},
+			wantNodes:  "cpu-[0-1]",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "NotFound",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateStatus(tt.args.reservation, tt.args.reservationInfo, tt.args.nodeList, tt.args.syncErr)
+			require.Equal(t, tt.wantActive, got.Active)
+			require.Equal(t, tt.wantNodes, got.NodeList)
+			require.Len(t, got.Conditions, 1)
+			require.Equal(t, slurmconditions.ReservationConditionSynced, got.Conditions[0].Type)
+			require.Equal(t, tt.wantStatus, got.Conditions[0].Status)
+			require.Equal(t, tt.wantReason, got.Conditions[0].Reason)
+			if tt.args.reservationInfo != nil {
+				require.NotNil(t, got.StartTime)
+				require.NotNil(t, got.EndTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package reservation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmfake "github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newClientMap(controllerName string, client slurmclient.Client) *clientmap.ClientMap {
+	cm := clientmap.NewClientMap()
+	key := types.NamespacedName{
+		Namespace: corev1.NamespaceDefault,
+		Name:      controllerName,
+	}
+	cm.Add(key, client)
+	return cm
+}
+
+func newSlurmNode(name string, features ...string) *slurmtypes.V0044Node {
+	return &slurmtypes.V0044Node{
+		V0044Node: slurmapi.V0044Node{
+			Name:     ptr.To(name),
+			Features: ptr.To(slurmapi.V0044CsvString(features)),
+		},
+	}
+}
+
+func TestReservationReconciler_Sync(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	nodeset := testutils.NewNodeset("cpu", controller, 2)
+	reservation := testutils.NewReservation("maint", controller, nodeset)
+	reservationWithNodeList := reservation.DeepCopy()
+	reservationWithNodeList.Spec.NodeList = "extra-[0-1]"
+	reservationNoNodes := testutils.NewReservation("maint", controller)
+	deletedReservation := reservation.DeepCopy()
+	deletedReservation.Finalizers = []string{slinkyv1beta1.FinalizerReservation}
+	deletedReservation.DeletionTimestamp = ptr.To(metav1.Now())
+	tests := []struct {
+		name         string
+		client       client.Client
+		slurmClient  slurmclient.Client
+		wantFound    bool
+		wantNodeList string
+		wantReason   string
+		wantErr      bool
+	}{
+		{
+			name: "create from NodeSet",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(controller.DeepCopy(), nodeset.DeepCopy(), reservation.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.Reservation{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(
+				newSlurmNode("cpu-0", "cpu"),
+				newSlurmNode("cpu-1", "cpu"),
+				newSlurmNode("gpu-0", "gpu"),
+			),
+			wantFound:    true,
+			wantNodeList: "cpu-[0-1]",
+			wantReason:   "Synced",
+		},
+		{
+			name: "create from NodeSet and nodeList",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(controller.DeepCopy(), nodeset.DeepCopy(), reservationWithNodeList.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.Reservation{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(
+				newSlurmNode("cpu-0", "cpu"),
+			),
+			wantFound:    true,
+			wantNodeList: "cpu-0,extra-[0-1]",
+			wantReason:   "Synced",
+		},
+		{
+			name: "no nodes",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(controller.DeepCopy(), reservationNoNodes.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.Reservation{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(),
+			wantFound:   false,
+			wantReason:  "NoNodes",
+		},
+		{
+			name: "no nodes, existing reservation",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(controller.DeepCopy(), reservationNoNodes.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.Reservation{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(&slurmtypes.V0044ReservationInfo{
+				V0044ReservationInfo: slurmapi.V0044ReservationInfo{
+					Name:     ptr.To(reservation.Name),
+					NodeList: ptr.To("cpu-[0-1]"),
+				},
+			}),
+			wantFound:  false,
+			wantReason: "NoNodes",
+		},
+		{
+			name: "delete",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(controller.DeepCopy(), nodeset.DeepCopy(), deletedReservation.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.Reservation{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(&slurmtypes.V0044ReservationInfo{
+				V0044ReservationInfo: slurmapi.V0044ReservationInfo{
+					Name: ptr.To(reservation.Name),
+				},
+			}),
+			wantFound: false,
+		},
+		{
+			name: "not found",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(),
+			wantFound:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r := NewReconciler(tt.client, newClientMap(controller.Name, tt.slurmClient))
+			key := client.ObjectKeyFromObject(reservation)
+			err := r.Sync(ctx, reconcile.Request{NamespacedName: key})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			reservationInfo := &slurmtypes.V0044ReservationInfo{}
+			err = tt.slurmClient.Get(ctx, slurmobject.ObjectKey(reservation.Name), reservationInfo)
+			require.Equal(t, tt.wantFound, err == nil)
+			if tt.wantFound {
+				require.Equal(t, tt.wantNodeList, ptr.Deref(reservationInfo.NodeList, ""))
+			}
+
+			checkReservation := &slinkyv1beta1.Reservation{}
+			if err := tt.client.Get(ctx, key, checkReservation); err != nil {
+				return
+			}
+			if checkReservation.DeletionTimestamp.IsZero() {
+				require.Contains(t, checkReservation.Finalizers, slinkyv1beta1.FinalizerReservation)
+			}
+			if tt.wantReason != "" {
+				require.Len(t, checkReservation.Status.Conditions, 1)
+				require.Equal(t, tt.wantReason, checkReservation.Status.Conditions[0].Reason)
+				require.Equal(t, tt.wantNodeList, checkReservation.Status.NodeList)
+			}
+		})
+	}
+}
+
+func Test_getEndTime(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	reservation := testutils.NewReservation("maint", nil)
+	reservation.Spec.StartTime = metav1.NewTime(start)
+
+	reservation.Spec.Duration = &metav1.Duration{Duration: time.Hour}
+	require.Equal(t, start.Add(time.Hour), getEndTime(reservation))
+
+	reservation.Spec.Duration = nil
+	reservation.Spec.EndTime = ptr.To(metav1.NewTime(start.Add(2 * time.Hour)))
+	require.Equal(t, start.Add(2*time.Hour), getEndTime(reservation).UTC())
+}
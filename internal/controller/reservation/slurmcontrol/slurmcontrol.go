@@ -0,0 +1,343 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/reservationutils"
+)
+
+type SlurmControlInterface interface {
+	// GetNodesForNodeSets returns the Slurm nodes registered by the NodeSets.
+	GetNodesForNodeSets(ctx context.Context, reservation *slinkyv1beta1.Reservation, nodesetList *slinkyv1beta1.NodeSetList) ([]string, error)
+	// GetReservation returns the Slurm reservation, or nil if it does not exist.
+	GetReservation(ctx context.Context, reservation *slinkyv1beta1.Reservation) (*slurmtypes.V0044ReservationInfo, error)
+	// SyncReservation creates and updates the Slurm reservation over the nodes of the nodeList.
+	SyncReservation(ctx context.Context, reservation *slinkyv1beta1.Reservation, nodeList string) error
+	// DeleteReservation deletes the Slurm reservation.
+	DeleteReservation(ctx context.Context, reservation *slinkyv1beta1.Reservation) error
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+}
+
+// GetNodesForNodeSets implements SlurmControlInterface.
+func (r *realSlurmControl) GetNodesForNodeSets(ctx context.Context, reservation *slinkyv1beta1.Reservation, nodesetList *slinkyv1beta1.NodeSetList) ([]string, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(reservation)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for reservation, cannot do GetNodesForNodeSets()")
+		return nil, nil
+	}
+
+	if nodesetList == nil || len(nodesetList.Items) == 0 {
+		return nil, nil
+	}
+
+	// Slurm nodes registered by a NodeSet always have the NodeSet feature
+	featureSet := set.New[string]()
+	for _, nodeset := range nodesetList.Items {
+		featureSet.Insert(common.GetSlurmNodeSetName(&nodeset))
+	}
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil {
+		return nil, err
+	}
+
+	slurmNodeNames := []string{}
+	for _, node := range nodeList.Items {
+		features := set.New(ptr.Deref(node.Features, slurmapi.V0044CsvString{})...)
+		if featureSet.Intersection(features).Len() == 0 {
+			continue
+		}
+		slurmNodeNames = append(slurmNodeNames, ptr.Deref(node.Name, ""))
+	}
+	slices.Sort(slurmNodeNames)
+
+	return slurmNodeNames, nil
+}
+
+// GetReservation implements SlurmControlInterface.
+func (r *realSlurmControl) GetReservation(ctx context.Context, reservation *slinkyv1beta1.Reservation) (*slurmtypes.V0044ReservationInfo, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(reservation)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for reservation, cannot do GetReservation()")
+		return nil, nil
+	}
+
+	reservationInfo := new(slurmtypes.V0044ReservationInfo)
+	key := slurmobject.ObjectKey(reservation.Name)
+	if err := slurmClient.Get(ctx, key, reservationInfo); err != nil {
+		if tolerateError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if reservationInfo.Name == nil {
+		return nil, nil
+	}
+
+	return reservationInfo, nil
+}
+
+// DeleteReservation implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteReservation(ctx context.Context, reservation *slinkyv1beta1.Reservation) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(reservation)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for reservation, cannot do DeleteReservation()")
+		return nil
+	}
+
+	reservationInfo := new(slurmtypes.V0044ReservationInfo)
+	key := slurmobject.ObjectKey(reservation.Name)
+	if err := slurmClient.Get(ctx, key, reservationInfo); !tolerateError(err) {
+		return err
+	}
+
+	if reservationInfo.Name == nil {
+		return nil
+	}
+
+	if err := slurmClient.Delete(ctx, reservationInfo); !tolerateError(err) {
+		return err
+	}
+
+	return nil
+}
+
+// SyncReservation implements SlurmControlInterface.
+func (r *realSlurmControl) SyncReservation(ctx context.Context, reservation *slinkyv1beta1.Reservation, nodeList string) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(reservation)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for reservation, cannot do SyncReservation()")
+		return nil
+	}
+
+	reservationDesc, newReservationInfo := formatReservation(reservation, nodeList)
+
+	oldReservationInfo := new(slurmtypes.V0044ReservationInfo)
+	key := slurmobject.ObjectKey(reservation.Name)
+	if err := slurmClient.Get(ctx, key, oldReservationInfo); !tolerateError(err) {
+		return err
+	}
+
+	if oldReservationInfo.Name == nil {
+		// Slurm will not create a reservation which has already ended
+		endTime := reservationutils.Uint64NoValToTime(newReservationInfo.EndTime)
+		if !endTime.After(time.Now()) {
+			logger.V(1).Info("Reservation has already ended, skipping create", "endTime", endTime)
+			return nil
+		}
+		if err := slurmClient.Create(ctx, &newReservationInfo, reservationDesc); !tolerateError(err) {
+			return fmt.Errorf("SyncReservation() failed to Create Reservation=%s with error=%w", reservation.Name, err)
+		}
+		return nil
+	}
+
+	// We should honor existing start times for reoccuring reservations, Slurm updates them for us.
+	// See SyncReservationForNodeSet() of the NodeSet slurmcontrol for details.
+	oldStartTime := reservationutils.Uint64NoValToTime(oldReservationInfo.StartTime)
+	newStartTime := reservationutils.Uint64NoValToTime(newReservationInfo.StartTime)
+	if oldStartTime.After(newStartTime) &&
+		reservationutils.HasFlags(*oldReservationInfo, reservationutils.ReoccuringInfoFlags, true) &&
+		reservationutils.HasFlags(newReservationInfo, reservationutils.ReoccuringInfoFlags, true) {
+		duration := reservationutils.Uint64NoValToTime(newReservationInfo.EndTime).Sub(newStartTime)
+		newReservationInfo.StartTime = ptr.To(reservationutils.TimeToUint64NoVal(oldStartTime))
+		newReservationInfo.EndTime = ptr.To(reservationutils.TimeToUint64NoVal(oldStartTime.Add(duration)))
+		reservationDesc.StartTime = newReservationInfo.StartTime
+		if reservationDesc.EndTime != nil {
+			reservationDesc.EndTime = newReservationInfo.EndTime
+		}
+	}
+
+	// Slurm will not allow most field updates for active reservations, but
+	// adding and removing nodes is permitted.
+	if reservationutils.IsActive(*oldReservationInfo) {
+		if reservationutils.IsNodeListMatch(*oldReservationInfo, newReservationInfo) {
+			return nil
+		}
+		if err := updateReservationNodes(ctx, slurmClient, oldReservationInfo, reservationDesc); !tolerateError(err) {
+			return fmt.Errorf("SyncReservation() failed to Update Reservation=%s with error=%w", reservation.Name, err)
+		}
+		return nil
+	}
+
+	if isReservationMatch(*oldReservationInfo, newReservationInfo) {
+		return nil
+	}
+
+	if err := slurmClient.Update(ctx, &newReservationInfo, reservationDesc); !tolerateError(err) {
+		return fmt.Errorf("SyncReservation() failed to Update Reservation=%s with error=%w", reservation.Name, err)
+	}
+
+	return nil
+}
+
+// isReservationMatch returns true when the key values of the reservations match.
+func isReservationMatch(old, new slurmtypes.V0044ReservationInfo) bool {
+	if !reservationutils.IsNodeListMatch(old, new) {
+		return false
+	}
+	if !reservationutils.Uint64NoValToTime(old.StartTime).Equal(reservationutils.Uint64NoValToTime(new.StartTime)) ||
+		!reservationutils.Uint64NoValToTime(old.EndTime).Equal(reservationutils.Uint64NoValToTime(new.EndTime)) {
+		return false
+	}
+	if !isCsvMatch(ptr.Deref(old.Users, ""), ptr.Deref(new.Users, "")) ||
+		!isCsvMatch(ptr.Deref(old.Accounts, ""), ptr.Deref(new.Accounts, "")) {
+		return false
+	}
+	// SPEC_NODES is an output-only flag, set by Slurm when nodes are requested by name.
+	oldFlags := set.New(ptr.Deref(old.Flags, nil)...)
+	oldFlags.Delete(slurmapi.V0044ReservationInfoFlagsSPECNODES)
+	newFlags := set.New(ptr.Deref(new.Flags, nil)...)
+	return oldFlags.Equal(newFlags)
+}
+
+func isCsvMatch(old, new string) bool {
+	split := func(s string) set.Set[string] {
+		out := set.New[string]()
+		for item := range strings.SplitSeq(s, ",") {
+			if item != "" {
+				out.Insert(item)
+			}
+		}
+		return out
+	}
+	return split(old).Equal(split(new))
+}
+
+func updateReservationNodes(ctx context.Context, slurmClient slurmclient.Client, reservation *slurmtypes.V0044ReservationInfo, reservationDesc slurmapi.V0044ReservationDescMsg) error {
+	var flags []slurmapi.V0044ReservationDescMsgFlags
+	if reservation.Flags != nil {
+		for _, f := range *reservation.Flags {
+			if f == slurmapi.V0044ReservationInfoFlagsSPECNODES {
+				continue
+			}
+			flags = append(flags, slurmapi.V0044ReservationDescMsgFlags(f))
+		}
+	}
+
+	oldReservation := slurmapi.V0044ReservationDescMsg{
+		Name:      reservation.Name,
+		StartTime: reservation.StartTime,
+		EndTime:   reservation.EndTime,
+		Flags:     &flags,
+		Users:     reservationDesc.Users,
+		Accounts:  reservationDesc.Accounts,
+		NodeList:  reservationDesc.NodeList,
+	}
+
+	return slurmClient.Update(ctx, reservation, oldReservation)
+}
+
+func formatReservation(reservation *slinkyv1beta1.Reservation, nodeList string) (slurmapi.V0044ReservationDescMsg, slurmtypes.V0044ReservationInfo) {
+	name := reservation.Name
+	spec := reservation.Spec
+
+	start := spec.StartTime.In(time.UTC)
+	var end time.Time
+	switch {
+	case spec.EndTime != nil:
+		end = spec.EndTime.In(time.UTC)
+	case spec.Duration != nil:
+		end = start.Add(spec.Duration.Duration)
+	}
+	startTime := reservationutils.TimeToUint64NoVal(start)
+	endTime := reservationutils.TimeToUint64NoVal(end)
+
+	descFlags := reservationutils.ParseFlags[slurmapi.V0044ReservationDescMsgFlags](spec.Flags, nil)
+	infoFlags := reservationutils.ParseFlags[slurmapi.V0044ReservationInfoFlags](spec.Flags, nil)
+
+	users := set.New(spec.Users...).SortedList()
+	accounts := set.New(spec.Accounts...).SortedList()
+
+	reservationInfo := slurmtypes.V0044ReservationInfo{
+		V0044ReservationInfo: slurmapi.V0044ReservationInfo{
+			Name:      &name,
+			StartTime: &startTime,
+			EndTime:   &endTime,
+			Flags:     &infoFlags,
+			NodeList:  ptr.To(nodeList),
+		},
+	}
+	reservationDesc := slurmapi.V0044ReservationDescMsg{
+		Name:      &name,
+		StartTime: &startTime,
+		Flags:     &descFlags,
+		NodeList:  &slurmapi.V0044HostlistString{nodeList},
+	}
+	if spec.Duration != nil {
+		duration := reservationutils.DurationToUint32NoVal(spec.Duration.Duration)
+		reservationDesc.Duration = &duration
+	} else {
+		reservationDesc.EndTime = &endTime
+	}
+	if len(users) > 0 {
+		reservationInfo.Users = ptr.To(strings.Join(users, ","))
+		reservationDesc.Users = ptr.To(slurmapi.V0044CsvString(users))
+	}
+	if len(accounts) > 0 {
+		reservationInfo.Accounts = ptr.To(strings.Join(accounts, ","))
+		reservationDesc.Accounts = ptr.To(slurmapi.V0044CsvString(accounts))
+	}
+
+	return reservationDesc, reservationInfo
+}
+
+func (r *realSlurmControl) lookupClient(reservation *slinkyv1beta1.Reservation) slurmclient.Client {
+	key := ktypes.NamespacedName{
+		Namespace: reservation.Namespace,
+		Name:      reservation.Spec.ControllerRef.Name,
+	}
+	return r.clientMap.Get(key)
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clientMap *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clientMap,
+	}
+}
+
+func tolerateError(err error) bool {
+	if err == nil {
+		return true
+	}
+	errText := err.Error()
+	if errText == http.StatusText(http.StatusNoContent) ||
+		errText == http.StatusText(http.StatusNotFound) {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,459 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
+	"github.com/SlinkyProject/slurm-client/pkg/types"
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/reservationutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+// slurmUpdateFn mimics slurmrestd by applying the reservation request onto the reservation.
+func slurmUpdateFn(_ context.Context, obj object.Object, req any, opts ...client.UpdateOption) error {
+	switch o := obj.(type) {
+	case *types.V0044ReservationInfo:
+		r, ok := req.(api.V0044ReservationDescMsg)
+		if !ok {
+			return errors.New("failed to cast request object")
+		}
+		if r.NodeList != nil {
+			o.NodeList = ptr.To(strings.Join(*r.NodeList, ","))
+		}
+		if r.Users != nil {
+			o.Users = ptr.To(strings.Join(*r.Users, ","))
+		}
+		if r.StartTime != nil {
+			o.StartTime = r.StartTime
+		}
+		if r.EndTime != nil {
+			o.EndTime = r.EndTime
+		}
+	default:
+		return errors.New("failed to cast slurm object")
+	}
+	return nil
+}
+
+func newReservation(name, controllerName string, start time.Time, duration time.Duration) *slinkyv1beta1.Reservation {
+	return &slinkyv1beta1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      name,
+		},
+		Spec: slinkyv1beta1.ReservationSpec{
+			ControllerRef: corev1.LocalObjectReference{
+				Name: controllerName,
+			},
+			StartTime: metav1.NewTime(start),
+			Duration:  &metav1.Duration{Duration: duration},
+			Users:     []string{"root"},
+			Flags:     []string{"maint"},
+		},
+	}
+}
+
+func newReservationInfo(name string, start, end time.Time, nodeList string) *types.V0044ReservationInfo {
+	return &types.V0044ReservationInfo{
+		V0044ReservationInfo: api.V0044ReservationInfo{
+			Name:      ptr.To(name),
+			StartTime: ptr.To(reservationutils.TimeToUint64NoVal(start)),
+			EndTime:   ptr.To(reservationutils.TimeToUint64NoVal(end)),
+			NodeList:  ptr.To(nodeList),
+			Users:     ptr.To("root"),
+			Flags: &[]api.V0044ReservationInfoFlags{
+				api.V0044ReservationInfoFlagsMAINT,
+				api.V0044ReservationInfoFlagsSPECNODES,
+			},
+		},
+	}
+}
+
+func newSlurmClientMap(controllerName string, client client.Client) *clientmap.ClientMap {
+	cm := clientmap.NewClientMap()
+	key := k8stypes.NamespacedName{
+		Namespace: corev1.NamespaceDefault,
+		Name:      controllerName,
+	}
+	cm.Add(key, client)
+	return cm
+}
+
+func Test_realSlurmControl_GetNodesForNodeSets(t *testing.T) {
+	newNode := func(name string, features ...string) *types.V0044Node {
+		return &types.V0044Node{
+			V0044Node: api.V0044Node{
+				Name:     ptr.To(name),
+				Features: ptr.To(api.V0044CsvString(features)),
+			},
+		}
+	}
+	newNodeSet := func(name string) slinkyv1beta1.NodeSet {
+		return slinkyv1beta1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: corev1.NamespaceDefault,
+				Name:      name,
+			},
+		}
+	}
+	reservation := newReservation("foo", "slurm", time.Now(), time.Hour)
+	tests := []struct {
+		name        string
+		client      client.Client
+		nodesetList *slinkyv1beta1.NodeSetList
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:   "No NodeSets",
+			client: fake.NewFakeClient(newNode("cpu-0", "cpu")),
+			want:   nil,
+		},
+		{
+			name: "Match by feature",
+			client: fake.NewFakeClient(
+				newNode("cpu-1", "cpu"),
+				newNode("cpu-0", "cpu", "fast"),
+				newNode("gpu-0", "gpu"),
+				newNode("login-0"),
+			),
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{newNodeSet("cpu")},
+			},
+			want: []string{"cpu-0", "cpu-1"},
+		},
+		{
+			name: "List error",
+			client: fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, list object.ObjectList, opts ...client.ListOption) error {
+						return errors.New("failed to list")
+					},
+				}).
+				Build(),
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{newNodeSet("cpu")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			got, err := r.GetNodesForNodeSets(context.Background(), reservation, tt.nodesetList)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetNodesForNodeSets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("GetNodesForNodeSets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_GetReservation(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		client      client.Client
+		reservation *slinkyv1beta1.Reservation
+		wantFound   bool
+		wantErr     bool
+	}{
+		{
+			name:        "No client",
+			client:      fake.NewFakeClient(),
+			reservation: newReservation("foo", "other", now, time.Hour),
+			wantFound:   false,
+		},
+		{
+			name:        "Not found",
+			client:      fake.NewFakeClient(),
+			reservation: newReservation("foo", "slurm", now, time.Hour),
+			wantFound:   false,
+		},
+		{
+			name:        "Found",
+			client:      fake.NewFakeClient(newReservationInfo("foo", now, now.Add(time.Hour), "node-0")),
+			reservation: newReservation("foo", "slurm", now, time.Hour),
+			wantFound:   true,
+		},
+		{
+			name: "Get error",
+			client: fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(ctx context.Context, key object.ObjectKey, obj object.Object, opts ...client.GetOption) error {
+						return errors.New("failed to get")
+					},
+				}).
+				Build(),
+			reservation: newReservation("foo", "slurm", now, time.Hour),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			got, err := r.GetReservation(context.Background(), tt.reservation)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetReservation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got != nil) != tt.wantFound {
+				t.Errorf("GetReservation() = %v, wantFound %v", got, tt.wantFound)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_DeleteReservation(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		client  client.Client
+		wantErr bool
+	}{
+		{
+			name:   "Not found",
+			client: fake.NewFakeClient(),
+		},
+		{
+			name:   "Found",
+			client: fake.NewFakeClient(newReservationInfo("foo", now, now.Add(time.Hour), "node-0")),
+		},
+		{
+			name: "Delete error",
+			client: fake.NewClientBuilder().
+				WithObjects(newReservationInfo("foo", now, now.Add(time.Hour), "node-0")).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, obj object.Object, opts ...client.DeleteOption) error {
+						return errors.New("failed to delete")
+					},
+				}).
+				Build(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservation := newReservation("foo", "slurm", now, time.Hour)
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			if err := r.DeleteReservation(context.Background(), reservation); (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteReservation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := r.GetReservation(context.Background(), reservation)
+			if err != nil {
+				t.Fatalf("GetReservation() error = %v", err)
+			}
+			if got != nil {
+				t.Errorf("DeleteReservation() did not delete reservation = %v", got)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_SyncReservation(t *testing.T) {
+	now := time.Unix(time.Now().Unix(), 0)
+	tests := []struct {
+		name         string
+		client       client.Client
+		reservation  *slinkyv1beta1.Reservation
+		nodeList     string
+		wantFound    bool
+		wantNodeList string
+		wantStart    time.Time
+		wantErr      bool
+	}{
+		{
+			name:         "Create",
+			client:       fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).Build(),
+			reservation:  newReservation("foo", "slurm", now.Add(time.Hour), time.Hour),
+			nodeList:     "node-[0-1]",
+			wantFound:    true,
+			wantNodeList: "node-[0-1]",
+			wantStart:    now.Add(time.Hour),
+		},
+		{
+			name:        "Skip create, already ended",
+			client:      fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).Build(),
+			reservation: newReservation("foo", "slurm", now.Add(-2*time.Hour), time.Hour),
+			nodeList:    "node-[0-1]",
+			wantFound:   false,
+		},
+		{
+			name: "Update inactive",
+			client: fake.NewClientBuilder().
+				WithUpdateFn(slurmUpdateFn).
+				WithObjects(newReservationInfo("foo", now.Add(time.Hour), now.Add(2*time.Hour), "node-0")).
+				Build(),
+			reservation:  newReservation("foo", "slurm", now.Add(2*time.Hour), time.Hour),
+			nodeList:     "node-[0-1]",
+			wantFound:    true,
+			wantNodeList: "node-[0-1]",
+			wantStart:    now.Add(2 * time.Hour),
+		},
+		{
+			name: "Update active, only nodes",
+			client: fake.NewClientBuilder().
+				WithUpdateFn(slurmUpdateFn).
+				WithObjects(newReservationInfo("foo", now.Add(-time.Hour), now.Add(time.Hour), "node-0")).
+				Build(),
+			reservation:  newReservation("foo", "slurm", now.Add(time.Hour), time.Hour),
+			nodeList:     "node-[0-1]",
+			wantFound:    true,
+			wantNodeList: "node-[0-1]",
+			wantStart:    now.Add(-time.Hour),
+		},
+		{
+			name: "No change",
+			client: fake.NewClientBuilder().
+				WithObjects(newReservationInfo("foo", now.Add(time.Hour), now.Add(2*time.Hour), "node-0,node-1")).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Update: func(ctx context.Context, obj object.Object, req any, opts ...client.UpdateOption) error {
+						return errors.New("unexpected update")
+					},
+				}).
+				Build(),
+			reservation:  newReservation("foo", "slurm", now.Add(time.Hour), time.Hour),
+			nodeList:     "node-[0-1]",
+			wantFound:    true,
+			wantNodeList: "node-0,node-1",
+			wantStart:    now.Add(time.Hour),
+		},
+		{
+			name: "Update error",
+			client: fake.NewClientBuilder().
+				WithObjects(newReservationInfo("foo", now.Add(time.Hour), now.Add(2*time.Hour), "node-0")).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Update: func(ctx context.Context, obj object.Object, req any, opts ...client.UpdateOption) error {
+						return errors.New("failed to update")
+					},
+				}).
+				Build(),
+			reservation: newReservation("foo", "slurm", now.Add(time.Hour), time.Hour),
+			nodeList:    "node-[0-1]",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			if err := r.SyncReservation(context.Background(), tt.reservation, tt.nodeList); (err != nil) != tt.wantErr {
+				t.Fatalf("SyncReservation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := r.GetReservation(context.Background(), tt.reservation)
+			if err != nil {
+				t.Fatalf("GetReservation() error = %v", err)
+			}
+			if (got != nil) != tt.wantFound {
+				t.Fatalf("SyncReservation() found = %v, wantFound %v", got != nil, tt.wantFound)
+			}
+			if got == nil {
+				return
+			}
+			if nodeList := ptr.Deref(got.NodeList, ""); nodeList != tt.wantNodeList {
+				t.Errorf("SyncReservation() NodeList = %v, want %v", nodeList, tt.wantNodeList)
+			}
+			if start := reservationutils.Uint64NoValToTime(got.StartTime); !start.Equal(tt.wantStart) {
+				t.Errorf("SyncReservation() StartTime = %v, want %v", start, tt.wantStart)
+			}
+		})
+	}
+}
+
+func Test_formatReservation(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	reservation := newReservation("foo", "slurm", start, time.Hour)
+	reservation.Spec.Accounts = []string{"physics", "chemistry"}
+	desc, info := formatReservation(reservation, "node-[0-1]")
+	if desc.Duration == nil || ptr.Deref(desc.Duration.Number, 0) != 60 {
+		t.Errorf("formatReservation() Duration = %v, want 60", desc.Duration)
+	}
+	if desc.EndTime != nil {
+		t.Errorf("formatReservation() EndTime = %v, want nil", desc.EndTime)
+	}
+	if got := reservationutils.Uint64NoValToTime(info.EndTime); !got.Equal(start.Add(time.Hour)) {
+		t.Errorf("formatReservation() info EndTime = %v, want %v", got, start.Add(time.Hour))
+	}
+	if got := ptr.Deref(info.Accounts, ""); got != "chemistry,physics" {
+		t.Errorf("formatReservation() Accounts = %v, want %v", got, "chemistry,physics")
+	}
+	if got := *desc.Flags; len(got) != 1 || got[0] != api.V0044ReservationDescMsgFlagsMAINT {
+		t.Errorf("formatReservation() Flags = %v", got)
+	}
+
+	reservation.Spec.Duration = nil
+	reservation.Spec.EndTime = ptr.To(metav1.NewTime(end))
+	reservation.Spec.Users = nil
+	desc, info = formatReservation(reservation, "node-0")
+	if desc.Duration != nil {
+		t.Errorf("formatReservation() Duration = %v, want nil", desc.Duration)
+	}
+	if got := reservationutils.Uint64NoValToTime(desc.EndTime); !got.Equal(end) {
+		t.Errorf("formatReservation() EndTime = %v, want %v", got, end)
+	}
+	if desc.Users != nil || info.Users != nil {
+		t.Errorf("formatReservation() Users = %v, want nil", desc.Users)
+	}
+}
+
+func Test_isReservationMatch(t *testing.T) {
+	now := time.Unix(time.Now().Unix(), 0)
+	base := newReservationInfo("foo", now, now.Add(time.Hour), "node-[0-1]")
+	withoutSpecNodes := newReservationInfo("foo", now, now.Add(time.Hour), "node-0,node-1")
+	withoutSpecNodes.Flags = &[]api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsMAINT}
+	otherUsers := newReservationInfo("foo", now, now.Add(time.Hour), "node-[0-1]")
+	otherUsers.Users = ptr.To("root,slurm")
+	otherTime := newReservationInfo("foo", now, now.Add(2*time.Hour), "node-[0-1]")
+	tests := []struct {
+		name string
+		new  *types.V0044ReservationInfo
+		want bool
+	}{
+		{
+			name: "Match, ignoring SPEC_NODES and hostlist format",
+			new:  withoutSpecNodes,
+			want: true,
+		},
+		{
+			name: "Users differ",
+			new:  otherUsers,
+			want: false,
+		},
+		{
+			name: "Times differ",
+			new:  otherTime,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isReservationMatch(*base, *tt.new); got != tt.want {
+				t.Errorf("isReservationMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+)
+
+type SlurmControlInterface interface {
+	// GetUser returns the Slurm user, or nil if it does not exist.
+	GetUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.User) (*slurmtypes.V0044User, error)
+	// GetUserAssociations returns the Slurm associations of the user on the cluster of the Controller.
+	GetUserAssociations(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.User) ([]slurmtypes.V0044Assoc, error)
+	// SyncUser creates and updates the Slurm user and its associations on the
+	// cluster of the Controller, and deletes associations which are not desired.
+	SyncUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.User) error
+	// DeleteUser deletes the Slurm user associations on the cluster of the Controller.
+	// The Slurm user is left in slurmdbd, it may be associated with other clusters.
+	DeleteUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.User) error
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+}
+
+// GetUser implements SlurmControlInterface.
+func (r *realSlurmControl) GetUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.User) (*slurmtypes.V0044User, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for user, cannot do GetUser()")
+		return nil, nil
+	}
+
+	slurmUser := new(slurmtypes.V0044User)
+	key := slurmobject.ObjectKey(user.Name)
+	if err := slurmClient.Get(ctx, key, slurmUser); err != nil {
+		if tolerateError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if slurmUser.Name == "" {
+		return nil, nil
+	}
+
+	return slurmUser, nil
+}
+
+// GetUserAssociations implements SlurmControlInterface.
+func (r *realSlurmControl) GetUserAssociations(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.User) ([]slurmtypes.V0044Assoc, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for user, cannot do GetUserAssociations()")
+		return nil, nil
+	}
+
+	return getUserAssociations(ctx, slurmClient, controller.ClusterName(), user.Name)
+}
+
+// SyncUser implements SlurmControlInterface.
+func (r *realSlurmControl) SyncUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.User) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for user, cannot do SyncUser()")
+		return nil
+	}
+
+	newUser := formatUser(user)
+	oldUser := new(slurmtypes.V0044User)
+	key := slurmobject.ObjectKey(user.Name)
+	if err := slurmClient.Get(ctx, key, oldUser); !tolerateError(err) {
+		return err
+	}
+
+	switch {
+	case oldUser.Name == "":
+		if err := slurmClient.Create(ctx, &newUser, newUser.V0044User); !tolerateError(err) {
+			return fmt.Errorf("SyncUser() failed to Create User=%s with error=%w", user.Name, err)
+		}
+	case !isUserMatch(*oldUser, newUser):
+		if err := slurmClient.Update(ctx, &newUser, newUser.V0044User); !tolerateError(err) {
+			return fmt.Errorf("SyncUser() failed to Update User=%s with error=%w", user.Name, err)
+		}
+	}
+
+	clusterName := controller.ClusterName()
+	oldAssocs, err := getUserAssociations(ctx, slurmClient, clusterName, user.Name)
+	if err != nil {
+		return err
+	}
+	oldAssocMap := make(map[string]slurmtypes.V0044Assoc, len(oldAssocs))
+	for _, assoc := range oldAssocs {
+		oldAssocMap[associationKey(ptr.Deref(assoc.Account, ""), ptr.Deref(assoc.Partition, ""))] = assoc
+	}
+
+	for _, association := range user.Spec.Associations {
+		key := associationKey(association.Account, association.Partition)
+		oldAssoc, ok := oldAssocMap[key]
+		delete(oldAssocMap, key)
+
+		if !ok {
+			newAssoc := formatUserAssociation(user.Name, clusterName, association, slinkyv1beta1.AssociationLimits{})
+			if err := slurmClient.Create(ctx, &newAssoc, newAssoc.V0044Assoc); !tolerateError(err) {
+				return fmt.Errorf("SyncUser() failed to Create Association=%s for User=%s with error=%w", key, user.Name, err)
+			}
+			continue
+		}
+
+		if isUserAssociationMatch(oldAssoc, association) {
+			continue
+		}
+
+		observedLimits := slurmdbutils.GetAssociationLimits(oldAssoc.V0044Assoc)
+		newAssoc := formatUserAssociation(user.Name, clusterName, association, observedLimits)
+		if err := slurmClient.Update(ctx, &newAssoc, newAssoc.V0044Assoc); !tolerateError(err) {
+			return fmt.Errorf("SyncUser() failed to Update Association=%s for User=%s with error=%w", key, user.Name, err)
+		}
+	}
+
+	for key, oldAssoc := range oldAssocMap {
+		if err := slurmClient.Delete(ctx, &oldAssoc); !tolerateError(err) {
+			return fmt.Errorf("SyncUser() failed to Delete Association=%s for User=%s with error=%w", key, user.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteUser implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteUser(ctx context.Context, controller *slinkyv1beta1.Controller, user *slinkyv1beta1.User) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for user, cannot do DeleteUser()")
+		return nil
+	}
+
+	assocs, err := getUserAssociations(ctx, slurmClient, controller.ClusterName(), user.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, assoc := range assocs {
+		key := associationKey(ptr.Deref(assoc.Account, ""), ptr.Deref(assoc.Partition, ""))
+		if err := slurmClient.Delete(ctx, &assoc); !tolerateError(err) {
+			return fmt.Errorf("DeleteUser() failed to Delete Association=%s for User=%s with error=%w", key, user.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// getUserAssociations returns the associations of the user on the cluster.
+func getUserAssociations(ctx context.Context, slurmClient slurmclient.Client, clusterName, userName string) ([]slurmtypes.V0044Assoc, error) {
+	assocList := &slurmtypes.V0044AssocList{}
+	if err := slurmClient.List(ctx, assocList); err != nil {
+		if tolerateError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var assocs []slurmtypes.V0044Assoc
+	for _, assoc := range assocList.Items {
+		if ptr.Deref(assoc.Cluster, "") != clusterName || assoc.User != userName {
+			continue
+		}
+		assocs = append(assocs, assoc)
+	}
+
+	return assocs, nil
+}
+
+// associationKey returns a key which uniquely identifies a user association.
+func associationKey(account, partition string) string {
+	if partition == "" {
+		return account
+	}
+	return account + "/" + partition
+}
+
+// isUserMatch returns true when the Slurm user matches the desired Slurm user.
+func isUserMatch(oldUser, newUser slurmtypes.V0044User) bool {
+	var oldDefault, newDefault string
+	if oldUser.Default != nil {
+		oldDefault = ptr.Deref(oldUser.Default.Account, "")
+	}
+	if newUser.Default != nil {
+		newDefault = ptr.Deref(newUser.Default.Account, "")
+	}
+	if oldDefault != newDefault {
+		return false
+	}
+	return getAdminLevel(oldUser) == getAdminLevel(newUser)
+}
+
+// isUserAssociationMatch returns true when the Slurm association matches the user association.
+func isUserAssociationMatch(assoc slurmtypes.V0044Assoc, association slinkyv1beta1.UserAssociation) bool {
+	if association.DefaultQOS != "" {
+		if assoc.Default == nil || ptr.Deref(assoc.Default.Qos, "") != association.DefaultQOS {
+			return false
+		}
+	}
+	if len(association.QOS) > 0 {
+		if !slurmdbutils.IsQosListMatch(ptr.Deref(assoc.Qos, nil), association.QOS) {
+			return false
+		}
+	}
+	return slurmdbutils.IsAssociationLimitsMatch(assoc.V0044Assoc, association.Limits)
+}
+
+func formatUser(user *slinkyv1beta1.User) slurmtypes.V0044User {
+	adminLevel := user.Spec.AdminLevel
+	if adminLevel == "" {
+		adminLevel = slinkyv1beta1.AdminLevelNone
+	}
+	return slurmtypes.V0044User{
+		V0044User: slurmapi.V0044User{
+			Name: user.Name,
+			Default: &slurmapi.V0044UserDefault{
+				Account: ptr.To(getDefaultAccount(user)),
+			},
+			AdministratorLevel: &[]slurmapi.V0044UserAdministratorLevel{
+				slurmapi.V0044UserAdministratorLevel(adminLevel),
+			},
+		},
+	}
+}
+
+func formatUserAssociation(userName, clusterName string, association slinkyv1beta1.UserAssociation, observedLimits slinkyv1beta1.AssociationLimits) slurmtypes.V0044Assoc {
+	assoc := slurmtypes.V0044Assoc{
+		V0044Assoc: slurmapi.V0044Assoc{
+			Account: ptr.To(association.Account),
+			Cluster: ptr.To(clusterName),
+			User:    userName,
+		},
+	}
+	if association.Partition != "" {
+		assoc.Partition = ptr.To(association.Partition)
+	}
+	if association.DefaultQOS != "" {
+		assoc.Default = &slurmapi.V0044AssocDefault{
+			Qos: ptr.To(association.DefaultQOS),
+		}
+	}
+	if len(association.QOS) > 0 {
+		assoc.Qos = ptr.To(slurmapi.V0044QosStringIdList(association.QOS))
+	}
+	slurmdbutils.SetAssociationLimits(&assoc.V0044Assoc, association.Limits, observedLimits)
+	return assoc
+}
+
+// getDefaultAccount returns the default account of the user, which defaults
+// to the account of the first association.
+func getDefaultAccount(user *slinkyv1beta1.User) string {
+	if user.Spec.DefaultAccount != "" {
+		return user.Spec.DefaultAccount
+	}
+	if len(user.Spec.Associations) > 0 {
+		return user.Spec.Associations[0].Account
+	}
+	return ""
+}
+
+func getAdminLevel(user slurmtypes.V0044User) slurmapi.V0044UserAdministratorLevel {
+	levels := ptr.Deref(user.AdministratorLevel, nil)
+	if len(levels) == 0 {
+		return slurmapi.V0044UserAdministratorLevelNone
+	}
+	return levels[0]
+}
+
+func (r *realSlurmControl) lookupClient(controller *slinkyv1beta1.Controller) slurmclient.Client {
+	if controller == nil {
+		return nil
+	}
+	return r.clientMap.Get(objectutils.NamespacedName(controller))
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clientMap *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clientMap,
+	}
+}
+
+func tolerateError(err error) bool {
+	if err == nil {
+		return true
+	}
+	errText := err.Error()
+	if errText == http.StatusText(http.StatusNoContent) ||
+		errText == http.StatusText(http.StatusNotFound) {
+		return true
+	}
+	return false
+}
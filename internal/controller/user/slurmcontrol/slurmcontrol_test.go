@@ -0,0 +1,524 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
+	"github.com/SlinkyProject/slurm-client/pkg/types"
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+// slurmUpdateFn mimics slurmdbd by applying the request onto the object.
+func slurmUpdateFn(_ context.Context, obj object.Object, req any, opts ...client.UpdateOption) error {
+	switch o := obj.(type) {
+	case *types.V0044User:
+		r, ok := req.(api.V0044User)
+		if !ok {
+			return errors.New("failed to cast request object")
+		}
+		o.V0044User = r
+	case *types.V0044Assoc:
+		r, ok := req.(api.V0044Assoc)
+		if !ok {
+			return errors.New("failed to cast request object")
+		}
+		o.V0044Assoc = r
+	default:
+		return errors.New("failed to cast slurm object")
+	}
+	return nil
+}
+
+func newController(name string) *slinkyv1beta1.Controller {
+	return &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      name,
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			ClusterName: "slurm",
+		},
+	}
+}
+
+func newUser(name string, associations ...slinkyv1beta1.UserAssociation) *slinkyv1beta1.User {
+	return &slinkyv1beta1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      name,
+		},
+		Spec: slinkyv1beta1.UserSpec{
+			SlurmdbRef: slinkyv1beta1.SlurmdbRef{
+				ControllerRef: &corev1.LocalObjectReference{
+					Name: "slurm",
+				},
+			},
+			AdminLevel:   slinkyv1beta1.AdminLevelNone,
+			Associations: associations,
+		},
+	}
+}
+
+func newSlurmUser(name, defaultAccount string) *types.V0044User {
+	return &types.V0044User{
+		V0044User: api.V0044User{
+			Name: name,
+			Default: &api.V0044UserDefault{
+				Account: ptr.To(defaultAccount),
+			},
+			AdministratorLevel: &[]api.V0044UserAdministratorLevel{
+				api.V0044UserAdministratorLevelNone,
+			},
+		},
+	}
+}
+
+func newSlurmAssoc(user, account, partition string, limits slinkyv1beta1.AssociationLimits) *types.V0044Assoc {
+	assoc := &types.V0044Assoc{
+		V0044Assoc: api.V0044Assoc{
+			Account: ptr.To(account),
+			Cluster: ptr.To("slurm"),
+			User:    user,
+		},
+	}
+	if partition != "" {
+		assoc.Partition = ptr.To(partition)
+	}
+	slurmdbutils.SetAssociationLimits(&assoc.V0044Assoc, limits, slinkyv1beta1.AssociationLimits{})
+	return assoc
+}
+
+func newSlurmClientMap(controllerName string, client client.Client) *clientmap.ClientMap {
+	cm := clientmap.NewClientMap()
+	key := k8stypes.NamespacedName{
+		Namespace: corev1.NamespaceDefault,
+		Name:      controllerName,
+	}
+	cm.Add(key, client)
+	return cm
+}
+
+func Test_realSlurmControl_GetUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     client.Client
+		controller *slinkyv1beta1.Controller
+		wantFound  bool
+		wantErr    bool
+	}{
+		{
+			name:       "No client",
+			client:     fake.NewFakeClient(newSlurmUser("alice", "physics")),
+			controller: newController("other"),
+			wantFound:  false,
+		},
+		{
+			name:       "Not found",
+			client:     fake.NewFakeClient(newSlurmUser("bob", "physics")),
+			controller: newController("slurm"),
+			wantFound:  false,
+		},
+		{
+			name:       "Found",
+			client:     fake.NewFakeClient(newSlurmUser("alice", "physics")),
+			controller: newController("slurm"),
+			wantFound:  true,
+		},
+		{
+			name: "Get error",
+			client: fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(ctx context.Context, key object.ObjectKey, obj object.Object, opts ...client.GetOption) error {
+						return errors.New("failed to get")
+					},
+				}).
+				Build(),
+			controller: newController("slurm"),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := newUser("alice", slinkyv1beta1.UserAssociation{Account: "physics"})
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			got, err := r.GetUser(context.Background(), tt.controller, user)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got != nil) != tt.wantFound {
+				t.Errorf("GetUser() = %v, wantFound %v", got, tt.wantFound)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_GetUserAssociations(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     client.Client
+		controller *slinkyv1beta1.Controller
+		wantCount  int
+		wantErr    bool
+	}{
+		{
+			name:       "No client",
+			client:     fake.NewFakeClient(newSlurmAssoc("alice", "physics", "", slinkyv1beta1.AssociationLimits{})),
+			controller: newController("other"),
+			wantCount:  0,
+		},
+		{
+			name: "Found",
+			client: fake.NewFakeClient(
+				newSlurmAssoc("alice", "physics", "", slinkyv1beta1.AssociationLimits{}),
+				newSlurmAssoc("alice", "physics", "gpu", slinkyv1beta1.AssociationLimits{}),
+				newSlurmAssoc("bob", "physics", "", slinkyv1beta1.AssociationLimits{}),
+				newSlurmAssoc("", "physics", "", slinkyv1beta1.AssociationLimits{}),
+			),
+			controller: newController("slurm"),
+			wantCount:  2,
+		},
+		{
+			name: "List error",
+			client: fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, list object.ObjectList, opts ...client.ListOption) error {
+						return errors.New("failed to list")
+					},
+				}).
+				Build(),
+			controller: newController("slurm"),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := newUser("alice", slinkyv1beta1.UserAssociation{Account: "physics"})
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			got, err := r.GetUserAssociations(context.Background(), tt.controller, user)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetUserAssociations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.wantCount {
+				t.Errorf("GetUserAssociations() = %v, wantCount %v", got, tt.wantCount)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_SyncUser(t *testing.T) {
+	limits := slinkyv1beta1.AssociationLimits{
+		MaxJobs:        ptr.To[int32](10),
+		MaxTRESPerNode: map[string]int64{"gres/gpu": 4},
+	}
+	tests := []struct {
+		name        string
+		client      client.Client
+		user        *slinkyv1beta1.User
+		wantDefault string
+		wantLimits  map[string]slinkyv1beta1.AssociationLimits
+		wantErr     bool
+	}{
+		{
+			name:   "Create",
+			client: fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).Build(),
+			user: newUser("alice",
+				slinkyv1beta1.UserAssociation{Account: "physics", Limits: limits},
+				slinkyv1beta1.UserAssociation{Account: "physics", Partition: "gpu"},
+			),
+			wantDefault: "physics",
+			wantLimits: map[string]slinkyv1beta1.AssociationLimits{
+				"physics":     limits,
+				"physics/gpu": {},
+			},
+		},
+		{
+			name: "Correct drift",
+			client: fake.NewClientBuilder().
+				WithUpdateFn(slurmUpdateFn).
+				WithObjects(newSlurmUser("alice", "chemistry")).
+				WithObjects(newSlurmAssoc("alice", "physics", "", slinkyv1beta1.AssociationLimits{
+					MaxSubmitJobs: ptr.To[int32](5),
+				})).
+				WithObjects(newSlurmAssoc("alice", "chemistry", "", slinkyv1beta1.AssociationLimits{})).
+				Build(),
+			user: func() *slinkyv1beta1.User {
+				user := newUser("alice",
+					slinkyv1beta1.UserAssociation{Account: "chemistry"},
+					slinkyv1beta1.UserAssociation{Account: "physics", Limits: limits},
+				)
+				user.Spec.DefaultAccount = "physics"
+				return user
+			}(),
+			wantDefault: "physics",
+			wantLimits: map[string]slinkyv1beta1.AssociationLimits{
+				"chemistry": {},
+				"physics":   limits,
+			},
+		},
+		{
+			name: "Remove association",
+			client: fake.NewClientBuilder().
+				WithUpdateFn(slurmUpdateFn).
+				WithObjects(newSlurmUser("alice", "physics")).
+				WithObjects(newSlurmAssoc("alice", "physics", "", limits)).
+				WithObjects(newSlurmAssoc("alice", "chemistry", "", slinkyv1beta1.AssociationLimits{})).
+				Build(),
+			user:        newUser("alice", slinkyv1beta1.UserAssociation{Account: "physics", Limits: limits}),
+			wantDefault: "physics",
+			wantLimits: map[string]slinkyv1beta1.AssociationLimits{
+				"physics": limits,
+			},
+		},
+		{
+			name: "No change",
+			client: fake.NewClientBuilder().
+				WithObjects(newSlurmUser("alice", "physics")).
+				WithObjects(newSlurmAssoc("alice", "physics", "", limits)).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Update: func(ctx context.Context, obj object.Object, req any, opts ...client.UpdateOption) error {
+						return errors.New("unexpected update")
+					},
+				}).
+				Build(),
+			user:        newUser("alice", slinkyv1beta1.UserAssociation{Account: "physics", Limits: limits}),
+			wantDefault: "physics",
+			wantLimits: map[string]slinkyv1beta1.AssociationLimits{
+				"physics": limits,
+			},
+		},
+		{
+			name: "Create error",
+			client: fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, obj object.Object, req any, opts ...client.CreateOption) error {
+						return errors.New("failed to create")
+					},
+				}).
+				Build(),
+			user:    newUser("alice", slinkyv1beta1.UserAssociation{Account: "physics"}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := newController("slurm")
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			if err := r.SyncUser(context.Background(), controller, tt.user); (err != nil) != tt.wantErr {
+				t.Fatalf("SyncUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			user, err := r.GetUser(context.Background(), controller, tt.user)
+			if err != nil {
+				t.Fatalf("GetUser() error = %v", err)
+			}
+			if user == nil {
+				t.Fatalf("SyncUser() did not create user")
+			}
+			if got := ptr.Deref(user.Default.Account, ""); got != tt.wantDefault {
+				t.Errorf("SyncUser() default account = %v, want %v", got, tt.wantDefault)
+			}
+			assocs, err := r.GetUserAssociations(context.Background(), controller, tt.user)
+			if err != nil {
+				t.Fatalf("GetUserAssociations() error = %v", err)
+			}
+			gotLimits := make(map[string]slinkyv1beta1.AssociationLimits, len(assocs))
+			for _, assoc := range assocs {
+				key := associationKey(ptr.Deref(assoc.Account, ""), ptr.Deref(assoc.Partition, ""))
+				gotLimits[key] = slurmdbutils.GetAssociationLimits(assoc.V0044Assoc)
+			}
+			if !apiequality.Semantic.DeepEqual(gotLimits, tt.wantLimits) {
+				t.Errorf("SyncUser() limits = %v, want %v", gotLimits, tt.wantLimits)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_DeleteUser(t *testing.T) {
+	otherAssoc := newSlurmAssoc("alice", "physics", "", slinkyv1beta1.AssociationLimits{})
+	otherAssoc.Cluster = ptr.To("other")
+	tests := []struct {
+		name             string
+		client           client.Client
+		wantUser         bool
+		wantOtherCluster bool
+		wantErr          bool
+	}{
+		{
+			name:   "Not found",
+			client: fake.NewFakeClient(),
+		},
+		{
+			name: "Found",
+			client: fake.NewFakeClient(
+				newSlurmUser("alice", "physics"),
+				newSlurmAssoc("alice", "physics", "", slinkyv1beta1.AssociationLimits{}),
+				newSlurmAssoc("alice", "chemistry", "debug", slinkyv1beta1.AssociationLimits{}),
+				otherAssoc.DeepCopyObject(),
+			),
+			wantUser:         true,
+			wantOtherCluster: true,
+		},
+		{
+			name: "Delete error",
+			client: fake.NewClientBuilder().
+				WithObjects(
+					newSlurmUser("alice", "physics"),
+					newSlurmAssoc("alice", "physics", "", slinkyv1beta1.AssociationLimits{}),
+				).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, obj object.Object, opts ...client.DeleteOption) error {
+						return errors.New("failed to delete")
+					},
+				}).
+				Build(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			user := newUser("alice", slinkyv1beta1.UserAssociation{Account: "physics"})
+			r := NewSlurmControl(newSlurmClientMap("slurm", tt.client))
+			if err := r.DeleteUser(ctx, newController("slurm"), user); (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			assocs, err := getUserAssociations(ctx, tt.client, "slurm", "alice")
+			if err != nil {
+				t.Fatalf("getUserAssociations() error = %v", err)
+			}
+			if len(assocs) != 0 {
+				t.Errorf("DeleteUser() did not delete associations = %v", assocs)
+			}
+			// The user and its associations on other clusters are left to slurmdbd.
+			gotUser := tt.client.Get(ctx, object.ObjectKey("alice"), &types.V0044User{}) == nil
+			if gotUser != tt.wantUser {
+				t.Errorf("DeleteUser() user found = %v, want %v", gotUser, tt.wantUser)
+			}
+			otherAssocs, err := getUserAssociations(ctx, tt.client, "other", "alice")
+			if err != nil {
+				t.Fatalf("getUserAssociations() error = %v", err)
+			}
+			if gotOther := len(otherAssocs) > 0; gotOther != tt.wantOtherCluster {
+				t.Errorf("DeleteUser() other cluster associations found = %v, want %v", gotOther, tt.wantOtherCluster)
+			}
+		})
+	}
+}
+
+func Test_formatUser(t *testing.T) {
+	tests := []struct {
+		name string
+		user *slinkyv1beta1.User
+		want api.V0044User
+	}{
+		{
+			name: "Defaults",
+			user: newUser("alice",
+				slinkyv1beta1.UserAssociation{Account: "physics"},
+				slinkyv1beta1.UserAssociation{Account: "chemistry"},
+			),
+			want: api.V0044User{
+				Name: "alice",
+				Default: &api.V0044UserDefault{
+					Account: ptr.To("physics"),
+				},
+				AdministratorLevel: &[]api.V0044UserAdministratorLevel{
+					api.V0044UserAdministratorLevelNone,
+				},
+			},
+		},
+		{
+			name: "Default account and admin level",
+			user: func() *slinkyv1beta1.User {
+				user := newUser("alice",
+					slinkyv1beta1.UserAssociation{Account: "physics"},
+					slinkyv1beta1.UserAssociation{Account: "chemistry"},
+				)
+				user.Spec.DefaultAccount = "chemistry"
+				user.Spec.AdminLevel = slinkyv1beta1.AdminLevelOperator
+				return user
+			}(),
+			want: api.V0044User{
+				Name: "alice",
+				Default: &api.V0044UserDefault{
+					Account: ptr.To("chemistry"),
+				},
+				AdministratorLevel: &[]api.V0044UserAdministratorLevel{
+					api.V0044UserAdministratorLevelOperator,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatUser(tt.user); !apiequality.Semantic.DeepEqual(got.V0044User, tt.want) {
+				t.Errorf("formatUser() = %v, want %v", got.V0044User, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isUserAssociationMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		assoc       *types.V0044Assoc
+		association slinkyv1beta1.UserAssociation
+		want        bool
+	}{
+		{
+			name:        "Match",
+			assoc:       newSlurmAssoc("alice", "physics", "", slinkyv1beta1.AssociationLimits{}),
+			association: slinkyv1beta1.UserAssociation{Account: "physics"},
+			want:        true,
+		},
+		{
+			name:        "QOS changed",
+			assoc:       newSlurmAssoc("alice", "physics", "", slinkyv1beta1.AssociationLimits{}),
+			association: slinkyv1beta1.UserAssociation{Account: "physics", QOS: []string{"normal"}},
+			want:        false,
+		},
+		{
+			name:        "Default QOS changed",
+			assoc:       newSlurmAssoc("alice", "physics", "", slinkyv1beta1.AssociationLimits{}),
+			association: slinkyv1beta1.UserAssociation{Account: "physics", DefaultQOS: "normal"},
+			want:        false,
+		},
+		{
+			name:  "Limits changed",
+			assoc: newSlurmAssoc("alice", "physics", "", slinkyv1beta1.AssociationLimits{}),
+			association: slinkyv1beta1.UserAssociation{
+				Account: "physics",
+				Limits:  slinkyv1beta1.AssociationLimits{MaxJobs: ptr.To[int32](1)},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUserAssociationMatch(*tt.assoc, tt.association); got != tt.want {
+				t.Errorf("isUserAssociationMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
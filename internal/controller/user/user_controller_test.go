@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package user
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slurmfake "github.com/SlinkyProject/slurm-client/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	testutils "github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("User Controller", func() {
+	Context("When reconciling a User", func() {
+		var name = testutils.GenerateResourceName(5)
+		var user *slinkyv1beta1.User
+		var controller *slinkyv1beta1.Controller
+
+		BeforeEach(func() {
+			controller = testutils.NewController(name, corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewAccount(name, controller)
+			user = testutils.NewUser(name, controller, account)
+			slurmClient := slurmfake.NewFakeClient()
+			clientMap.Add(types.NamespacedName{Namespace: corev1.NamespaceDefault, Name: controller.Name}, slurmClient)
+			Expect(k8sClient.Create(ctx, controller.DeepCopy())).To(Succeed())
+			Expect(k8sClient.Create(ctx, user.DeepCopy())).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, user)
+			_ = k8sClient.Delete(ctx, controller)
+		})
+
+		It("Should create the Slurm user", func(ctx SpecContext) {
+			By("Expecting User status default account")
+			userKey := client.ObjectKeyFromObject(user)
+			Eventually(func(g Gomega) {
+				checkUser := &slinkyv1beta1.User{}
+				g.Expect(k8sClient.Get(ctx, userKey, checkUser)).To(Succeed())
+				g.Expect(checkUser.Finalizers).To(ContainElement(slinkyv1beta1.FinalizerUser))
+				g.Expect(checkUser.Status.DefaultAccount).To(Equal(name))
+				g.Expect(checkUser.Status.Associations).To(HaveLen(1))
+			}, testutils.Timeout, testutils.Interval).Should(Succeed())
+		}, SpecTimeout(testutils.Timeout))
+	})
+})
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package user
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/slurmdbsync"
+)
+
+// Sync implements control logic for synchronizing a User.
+func (r *UserReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	user := &slinkyv1beta1.User{}
+	if err := r.Get(ctx, req.NamespacedName, user); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("User has been deleted", "request", req)
+			return nil
+		}
+		return err
+	}
+	user = user.DeepCopy()
+
+	syncer := &slurmdbsync.Syncer[*slinkyv1beta1.User]{
+		Client:        r.Client,
+		RefResolver:   r.refResolver,
+		EventRecorder: r.eventRecorder,
+		DurationStore: durationStore,
+		SyncPeriod:    syncPeriod,
+		Kind:          "User",
+		Finalizer:     slinkyv1beta1.FinalizerUser,
+		SlurmdbRefFn: func(user *slinkyv1beta1.User) slinkyv1beta1.SlurmdbRef {
+			return user.Spec.SlurmdbRef
+		},
+		SyncFn:   r.slurmControl.SyncUser,
+		DeleteFn: r.slurmControl.DeleteUser,
+		StatusFn: r.syncStatus,
+	}
+	return syncer.Sync(ctx, user)
+}
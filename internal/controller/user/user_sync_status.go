@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package user
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// syncStatus handles determining and updating the status.
+func (r *UserReconciler) syncStatus(
+	ctx context.Context,
+	user *slinkyv1beta1.User,
+	controller *slinkyv1beta1.Controller,
+	errors ...error,
+) error {
+	logger := log.FromContext(ctx)
+
+	var slurmUser *slurmtypes.V0044User
+	var assocs []slurmtypes.V0044Assoc
+	if controller != nil {
+		var err error
+		slurmUser, err = r.slurmControl.GetUser(ctx, controller, user)
+		if err != nil {
+			errors = append(errors, err)
+		}
+		assocs, err = r.slurmControl.GetUserAssociations(ctx, controller, user)
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	newStatus := calculateStatus(user, slurmUser, assocs, utilerrors.NewAggregate(errors))
+
+	if apiequality.Semantic.DeepEqual(user.Status, newStatus) {
+		logger.V(2).Info("User Status has not changed, skipping status update",
+			"user", klog.KObj(user), "status", user.Status)
+		return utilerrors.NewAggregate(errors)
+	}
+
+	if err := r.updateStatus(ctx, user, &newStatus); err != nil {
+		errors = append(errors, fmt.Errorf("error updating User(%s) status: %w",
+			klog.KObj(user), err))
+	}
+
+	return utilerrors.NewAggregate(errors)
+}
+
+// calculateStatus returns the User status given the Slurm user, which is nil
+// when it does not exist, and its associations.
+func calculateStatus(
+	user *slinkyv1beta1.User,
+	slurmUser *slurmtypes.V0044User,
+	assocs []slurmtypes.V0044Assoc,
+	syncErr error,
+) slinkyv1beta1.UserStatus {
+	newStatus := slinkyv1beta1.UserStatus{
+		ObservedGeneration: user.Generation,
+		Conditions:         []metav1.Condition{},
+	}
+	newStatus.Conditions = append(newStatus.Conditions, user.Status.Conditions...)
+
+	condition := metav1.Condition{
+		Type:               slurmconditions.SlurmdbConditionSynced,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: user.Generation,
+	}
+
+	if slurmUser != nil && slurmUser.Default != nil {
+		newStatus.DefaultAccount = ptr.Deref(slurmUser.Default.Account, "")
+	}
+	for _, assoc := range assocs {
+		newStatus.Associations = append(newStatus.Associations, slinkyv1beta1.UserAssociationStatus{
+			Account:   ptr.Deref(assoc.Account, ""),
+			Partition: ptr.Deref(assoc.Partition, ""),
+			Limits:    slurmdbutils.GetAssociationLimits(assoc.V0044Assoc),
+		})
+	}
+	slices.SortFunc(newStatus.Associations, func(a, b slinkyv1beta1.UserAssociationStatus) int {
+		return cmp.Or(
+			cmp.Compare(a.Account, b.Account),
+			cmp.Compare(a.Partition, b.Partition),
+		)
+	})
+
+	switch {
+	case syncErr != nil:
+		condition.Reason = "SyncFailed"
+		condition.Message = syncErr.Error()
+	case slurmUser != nil:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Synced"
+	default:
+		condition.Reason = "NotFound"
+		condition.Message = "The Slurm user does not exist."
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, condition)
+
+	return newStatus
+}
+
+func (r *UserReconciler) updateStatus(
+	ctx context.Context,
+	user *slinkyv1beta1.User,
+	newStatus *slinkyv1beta1.UserStatus,
+) error {
+	logger := log.FromContext(ctx)
+
+	namespacedName := types.NamespacedName{
+		Namespace: user.GetNamespace(),
+		Name:      user.GetName(),
+	}
+
+	logger.V(1).Info("Pending User Status update",
+		"user", klog.KObj(user), "newStatus", newStatus)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.User{}
+		if err := r.Get(ctx, namespacedName, toUpdate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		toUpdate.Status = *newStatus
+		return r.Status().Update(ctx, toUpdate)
+	})
+}
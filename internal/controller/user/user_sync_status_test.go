@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package user
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func Test_calculateStatus(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	user := testutils.NewUser("alice", controller, testutils.NewAccount("physics", controller))
+	limits := slinkyv1beta1.AssociationLimits{
+		MaxJobs: ptr.To[int32](10),
+	}
+	slurmUser := &slurmtypes.V0044User{
+		V0044User: slurmapi.V0044User{
+			Name: "alice",
+			Default: &slurmapi.V0044UserDefault{
+				Account: ptr.To("physics"),
+			},
+		},
+	}
+	assocs := []slurmtypes.V0044Assoc{
+		{
+			V0044Assoc: slurmapi.V0044Assoc{
+				Account:   ptr.To("physics"),
+				Cluster:   ptr.To("slurm"),
+				Partition: ptr.To("gpu"),
+				User:      "alice",
+			},
+		},
+		{
+			V0044Assoc: slurmapi.V0044Assoc{
+				Account: ptr.To("physics"),
+				Cluster: ptr.To("slurm"),
+				User:    "alice",
+			},
+		},
+	}
+	slurmdbutils.SetAssociationLimits(&assocs[1].V0044Assoc, limits, slinkyv1beta1.AssociationLimits{})
+	wantAssociations := []slinkyv1beta1.UserAssociationStatus{
+		{Account: "physics", Limits: limits},
+		{Account: "physics", Partition: "gpu"},
+	}
+	type args struct {
+		user      *slinkyv1beta1.User
+		slurmUser *slurmtypes.V0044User
+		assocs    []slurmtypes.V0044Assoc
+		syncErr   error
+	}
+	tests := []struct {
+		name               string
+		args               args
+		wantDefaultAccount string
+		wantAssociations   []slinkyv1beta1.UserAssociationStatus
+		wantStatus         metav1.ConditionStatus
+		wantReason         string
+	}{
+		{
+			name: "synced",
+			args: args{
+				user:      user,
+				slurmUser: slurmUser,
+				assocs:    assocs,
+			},
+			wantDefaultAccount: "physics",
+			wantAssociations:   wantAssociations,
+			wantStatus:         metav1.ConditionTrue,
+			wantReason:         "Synced",
+		},
+		{
+			name: "not found",
+			args: args{
+				user: user,
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "NotFound",
+		},
+		{
+			name: "sync failed",
+			args: args{
+				user:      user,
+				slurmUser: slurmUser,
+				assocs:    assocs,
+				syncErr:   errors.New("failed"),
+			},
+			wantDefaultAccount: "physics",
+			wantAssociations:   wantAssociations,
+			wantStatus:         metav1.ConditionFalse,
+			wantReason:         "SyncFailed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateStatus(tt.args.user, tt.args.slurmUser, tt.args.assocs, tt.args.syncErr)
+			require.Equal(t, tt.wantDefaultAccount, got.DefaultAccount)
+			require.Equal(t, tt.wantAssociations, got.Associations)
+			require.Len(t, got.Conditions, 1)
+			require.Equal(t, slurmconditions.SlurmdbConditionSynced, got.Conditions[0].Type)
+			require.Equal(t, tt.wantStatus, got.Conditions[0].Status)
+			require.Equal(t, tt.wantReason, got.Conditions[0].Reason)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package user
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmfake "github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newClientMap(controllerName string, client slurmclient.Client) *clientmap.ClientMap {
+	cm := clientmap.NewClientMap()
+	key := types.NamespacedName{
+		Namespace: corev1.NamespaceDefault,
+		Name:      controllerName,
+	}
+	cm.Add(key, client)
+	return cm
+}
+
+func TestUserReconciler_Sync(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	account := testutils.NewAccount("physics", controller)
+	user := testutils.NewUser("alice", controller, account)
+	userNoController := testutils.NewUser("alice", testutils.NewController("other", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil), account)
+	deletedUser := user.DeepCopy()
+	deletedUser.Finalizers = []string{slinkyv1beta1.FinalizerUser}
+	deletedUser.DeletionTimestamp = ptr.To(metav1.Now())
+	tests := []struct {
+		name        string
+		client      client.Client
+		slurmClient slurmclient.Client
+		wantFound   bool
+		wantAssoc   bool
+		wantReason  string
+		wantErr     bool
+	}{
+		{
+			name: "create",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(controller.DeepCopy(), user.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.User{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(),
+			wantFound:   true,
+			wantAssoc:   true,
+			wantReason:  "Synced",
+		},
+		{
+			name: "controller not found",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(userNoController.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.User{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(),
+			wantFound:   false,
+			wantReason:  "SyncFailed",
+			wantErr:     true,
+		},
+		{
+			name: "delete",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(controller.DeepCopy(), deletedUser.DeepCopy()).
+				WithStatusSubresource(&slinkyv1beta1.User{}).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(
+				&slurmtypes.V0044User{
+					V0044User: slurmapi.V0044User{
+						Name: user.Name,
+					},
+				},
+				&slurmtypes.V0044Assoc{
+					V0044Assoc: slurmapi.V0044Assoc{
+						Account: ptr.To(account.Name),
+						Cluster: ptr.To(controller.ClusterName()),
+						User:    user.Name,
+					},
+				},
+			),
+			// The Slurm user is left, only its associations are deleted.
+			wantFound: true,
+		},
+		{
+			name: "not found",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				Build(),
+			slurmClient: slurmfake.NewFakeClient(),
+			wantFound:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			r := NewReconciler(tt.client, newClientMap(controller.Name, tt.slurmClient))
+			key := client.ObjectKeyFromObject(user)
+			err := r.Sync(ctx, reconcile.Request{NamespacedName: key})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			slurmUser := &slurmtypes.V0044User{}
+			err = tt.slurmClient.Get(ctx, slurmobject.ObjectKey(user.Name), slurmUser)
+			require.Equal(t, tt.wantFound, err == nil)
+			assocList := &slurmtypes.V0044AssocList{}
+			require.NoError(t, tt.slurmClient.List(ctx, assocList))
+			gotAssoc := slices.ContainsFunc(assocList.Items, func(assoc slurmtypes.V0044Assoc) bool {
+				return assoc.User == user.Name
+			})
+			require.Equal(t, tt.wantAssoc, gotAssoc)
+
+			checkUser := &slinkyv1beta1.User{}
+			if err := tt.client.Get(ctx, key, checkUser); err != nil {
+				return
+			}
+			if checkUser.DeletionTimestamp.IsZero() {
+				require.Contains(t, checkUser.Finalizers, slinkyv1beta1.FinalizerUser)
+			}
+			if tt.wantReason != "" {
+				require.Len(t, checkUser.Status.Conditions, 1)
+				require.Equal(t, tt.wantReason, checkUser.Status.Conditions[0].Reason)
+			}
+			if tt.wantFound {
+				require.Equal(t, account.Name, checkUser.Status.DefaultAccount)
+				require.Len(t, checkUser.Status.Associations, 1)
+			}
+		})
+	}
+}
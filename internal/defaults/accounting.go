@@ -4,6 +4,9 @@
 package defaults
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 )
 
@@ -11,6 +14,11 @@ import (
 const (
 	DefaultAccountingStoragePort int    = 3306
 	DefaultAccountingStorageDB   string = "slurm_acct_db"
+
+	DefaultAccountingManagedDatabaseUser    string = "slurm"
+	DefaultAccountingManagedDatabaseStorage string = "16Gi"
+
+	DefaultAccountingPreflightEnabled bool = true
 )
 
 func SetAccountingDefaults(accounting *slinkyv1beta1.Accounting) {
@@ -25,4 +33,21 @@ func SetAccountingDefaults(accounting *slinkyv1beta1.Accounting) {
 	if s.StorageConfig.Database == "" {
 		s.StorageConfig.Database = DefaultAccountingStorageDB
 	}
+
+	if s.Preflight.Enabled == nil {
+		s.Preflight.Enabled = ptr.To(DefaultAccountingPreflightEnabled)
+	}
+
+	if m := s.ManagedDatabase; m != nil {
+		if m.Database == "" {
+			m.Database = DefaultAccountingStorageDB
+		}
+		if m.Username == "" {
+			m.Username = DefaultAccountingManagedDatabaseUser
+		}
+		if m.Storage == nil {
+			size := resource.MustParse(DefaultAccountingManagedDatabaseStorage)
+			m.Storage = &size
+		}
+	}
 }
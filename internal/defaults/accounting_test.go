@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 )
@@ -33,4 +34,29 @@ func TestSetAccountingDefaults(t *testing.T) {
 		require.Equal(t, 9999, a.Spec.StorageConfig.Port)
 		require.Equal(t, "mydb", a.Spec.StorageConfig.Database)
 	})
+
+	t.Run("managed database gets defaults", func(t *testing.T) {
+		a := &slinkyv1beta1.Accounting{}
+		a.Spec.ManagedDatabase = &slinkyv1beta1.ManagedDatabase{}
+		SetAccountingDefaults(a)
+
+		require.Equal(t, DefaultAccountingStorageDB, a.Spec.ManagedDatabase.Database)
+		require.Equal(t, DefaultAccountingManagedDatabaseUser, a.Spec.ManagedDatabase.Username)
+		require.Equal(t, resource.MustParse(DefaultAccountingManagedDatabaseStorage), *a.Spec.ManagedDatabase.Storage)
+	})
+
+	t.Run("managed database explicit values are not overridden", func(t *testing.T) {
+		size := resource.MustParse("1Gi")
+		a := &slinkyv1beta1.Accounting{}
+		a.Spec.ManagedDatabase = &slinkyv1beta1.ManagedDatabase{
+			Database: "mydb",
+			Username: "myuser",
+			Storage:  &size,
+		}
+		SetAccountingDefaults(a)
+
+		require.Equal(t, "mydb", a.Spec.ManagedDatabase.Database)
+		require.Equal(t, "myuser", a.Spec.ManagedDatabase.Username)
+		require.Equal(t, size, *a.Spec.ManagedDatabase.Storage)
+	})
 }
@@ -11,7 +11,9 @@ import (
 
 // Default values for Controller Spec fields when unspecified.
 const (
-	DefaultControllerPersistenceEnabled bool = true
+	DefaultControllerReplicas           int32 = 1
+	DefaultControllerPersistenceEnabled bool  = true
+	DefaultControllerBackupRetention    int32 = 7
 )
 
 func SetControllerDefaults(controller *slinkyv1beta1.Controller) {
@@ -20,7 +22,15 @@ func SetControllerDefaults(controller *slinkyv1beta1.Controller) {
 	}
 	s := &controller.Spec
 
+	if s.Replicas == nil {
+		s.Replicas = ptr.To(DefaultControllerReplicas)
+	}
+
 	if s.Persistence.Enabled == nil {
 		s.Persistence.Enabled = ptr.To(DefaultControllerPersistenceEnabled)
 	}
+
+	if s.Backup != nil && s.Backup.Retention == nil {
+		s.Backup.Retention = ptr.To(DefaultControllerBackupRetention)
+	}
 }
@@ -21,7 +21,16 @@ func TestSetControllerDefaults(t *testing.T) {
 		c := &slinkyv1beta1.Controller{}
 		SetControllerDefaults(c)
 
+		require.Equal(t, ptr.To(DefaultControllerReplicas), c.Spec.Replicas)
 		require.Equal(t, ptr.To(DefaultControllerPersistenceEnabled), c.Spec.Persistence.Enabled)
+		require.Nil(t, c.Spec.Backup)
+	})
+
+	t.Run("backup gets defaults", func(t *testing.T) {
+		c := &slinkyv1beta1.Controller{}
+		c.Spec.Backup = &slinkyv1beta1.ControllerBackup{}
+		SetControllerDefaults(c)
+		require.Equal(t, ptr.To(DefaultControllerBackupRetention), c.Spec.Backup.Retention)
 	})
 
 	t.Run("explicit values are not overridden", func(t *testing.T) {
@@ -33,5 +42,13 @@ func TestSetControllerDefaults(t *testing.T) {
 		c.Spec.Persistence.Enabled = ptr.To(false)
 		SetControllerDefaults(c)
 		require.Equal(t, ptr.To(false), c.Spec.Persistence.Enabled)
+
+		c.Spec.Replicas = ptr.To[int32](2)
+		SetControllerDefaults(c)
+		require.Equal(t, ptr.To[int32](2), c.Spec.Replicas)
+
+		c.Spec.Backup = &slinkyv1beta1.ControllerBackup{Retention: ptr.To[int32](3)}
+		SetControllerDefaults(c)
+		require.Equal(t, ptr.To[int32](3), c.Spec.Backup.Retention)
 	})
 }
@@ -4,6 +4,9 @@
 package defaults
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
@@ -22,6 +25,13 @@ const (
 // Default values for NodeSet Spec fields when unspecified.
 var (
 	DefaultNodeSetRollingUpdateMaxUnavailable intstr.IntOrString = intstr.FromString("25%")
+
+	DefaultNodeSetAutoscalingScaleUpStabilizationWindow   metav1.Duration = metav1.Duration{Duration: 0}
+	DefaultNodeSetAutoscalingScaleDownStabilizationWindow metav1.Duration = metav1.Duration{Duration: 5 * time.Minute}
+	DefaultNodeSetAutoscalingIdleTimeout                  metav1.Duration = metav1.Duration{Duration: 5 * time.Minute}
+
+	DefaultNodeSetPowerSaveSuspendTime   metav1.Duration = metav1.Duration{Duration: 5 * time.Minute}
+	DefaultNodeSetPowerSaveResumeTimeout metav1.Duration = metav1.Duration{Duration: 10 * time.Minute}
 )
 
 func SetNodeSetDefaults(nodeset *slinkyv1beta1.NodeSet) {
@@ -63,4 +73,24 @@ func SetNodeSetDefaults(nodeset *slinkyv1beta1.NodeSet) {
 	if s.PruneSlurmNodeRecords == "" {
 		s.PruneSlurmNodeRecords = DefaultNodeSetPruneSlurmNodeRecordType
 	}
+
+	if s.Autoscaling.ScaleUpStabilizationWindow == nil {
+		s.Autoscaling.ScaleUpStabilizationWindow = ptr.To(DefaultNodeSetAutoscalingScaleUpStabilizationWindow)
+	}
+
+	if s.Autoscaling.ScaleDownStabilizationWindow == nil {
+		s.Autoscaling.ScaleDownStabilizationWindow = ptr.To(DefaultNodeSetAutoscalingScaleDownStabilizationWindow)
+	}
+
+	if s.Autoscaling.IdleTimeout == nil {
+		s.Autoscaling.IdleTimeout = ptr.To(DefaultNodeSetAutoscalingIdleTimeout)
+	}
+
+	if s.PowerSave.SuspendTime == nil {
+		s.PowerSave.SuspendTime = ptr.To(DefaultNodeSetPowerSaveSuspendTime)
+	}
+
+	if s.PowerSave.ResumeTimeout == nil {
+		s.PowerSave.ResumeTimeout = ptr.To(DefaultNodeSetPowerSaveResumeTimeout)
+	}
 }
@@ -5,8 +5,10 @@ package defaults
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
@@ -30,6 +32,11 @@ func TestSetNodeSetDefaults(t *testing.T) {
 		require.Equal(t, slinkyv1beta1.RetainPersistentVolumeClaimRetentionPolicyType, ns.Spec.PersistentVolumeClaimRetentionPolicy.WhenDeleted)
 		require.Equal(t, slinkyv1beta1.RetainPersistentVolumeClaimRetentionPolicyType, ns.Spec.PersistentVolumeClaimRetentionPolicy.WhenScaled)
 		require.Equal(t, DefaultNodeSetPruneSlurmNodeRecordType, ns.Spec.PruneSlurmNodeRecords)
+		require.Equal(t, ptr.To(DefaultNodeSetAutoscalingScaleUpStabilizationWindow), ns.Spec.Autoscaling.ScaleUpStabilizationWindow)
+		require.Equal(t, ptr.To(DefaultNodeSetAutoscalingScaleDownStabilizationWindow), ns.Spec.Autoscaling.ScaleDownStabilizationWindow)
+		require.Equal(t, ptr.To(DefaultNodeSetAutoscalingIdleTimeout), ns.Spec.Autoscaling.IdleTimeout)
+		require.Equal(t, ptr.To(DefaultNodeSetPowerSaveSuspendTime), ns.Spec.PowerSave.SuspendTime)
+		require.Equal(t, ptr.To(DefaultNodeSetPowerSaveResumeTimeout), ns.Spec.PowerSave.ResumeTimeout)
 	})
 
 	t.Run("explicit values are not overridden", func(t *testing.T) {
@@ -42,6 +49,10 @@ func TestSetNodeSetDefaults(t *testing.T) {
 		ns.Spec.PersistentVolumeClaimRetentionPolicy.WhenDeleted = slinkyv1beta1.DeletePersistentVolumeClaimRetentionPolicyType
 		ns.Spec.PersistentVolumeClaimRetentionPolicy.WhenScaled = slinkyv1beta1.DeletePersistentVolumeClaimRetentionPolicyType
 		ns.Spec.PruneSlurmNodeRecords = slinkyv1beta1.NodeSetPruneNodeRecordTypeNodeNotFound
+		idleTimeout := metav1.Duration{Duration: 90 * time.Second}
+		ns.Spec.Autoscaling.IdleTimeout = ptr.To(idleTimeout)
+		suspendTime := metav1.Duration{Duration: time.Hour}
+		ns.Spec.PowerSave.SuspendTime = ptr.To(suspendTime)
 		SetNodeSetDefaults(ns)
 
 		require.Equal(t, ptr.To(int32(3)), ns.Spec.Replicas)
@@ -51,5 +62,7 @@ func TestSetNodeSetDefaults(t *testing.T) {
 		require.Equal(t, slinkyv1beta1.DeletePersistentVolumeClaimRetentionPolicyType, ns.Spec.PersistentVolumeClaimRetentionPolicy.WhenDeleted)
 		require.Equal(t, slinkyv1beta1.DeletePersistentVolumeClaimRetentionPolicyType, ns.Spec.PersistentVolumeClaimRetentionPolicy.WhenScaled)
 		require.Equal(t, slinkyv1beta1.NodeSetPruneNodeRecordTypeNodeNotFound, ns.Spec.PruneSlurmNodeRecords)
+		require.Equal(t, ptr.To(idleTimeout), ns.Spec.Autoscaling.IdleTimeout)
+		require.Equal(t, ptr.To(suspendTime), ns.Spec.PowerSave.SuspendTime)
 	})
 }
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdbsync
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+// Reasons for events
+const (
+	// SyncFailedReason is added to an event when the Slurm object cannot be synchronized.
+	SyncFailedReason = "SyncFailed"
+	// SyncFinalizerFailedReason is added to an event when a sync finalizer sub-step fails.
+	SyncFinalizerFailedReason = "SyncFinalizerFailed"
+)
+
+// Syncer synchronizes an object with the Slurm accounting object it manages
+// through the slurmrestd of the Controller of its SlurmdbRef.
+type Syncer[T client.Object] struct {
+	Client        client.Client
+	RefResolver   *refresolver.RefResolver
+	EventRecorder events.EventRecorder
+	DurationStore *durationstore.DurationStore
+	// SyncPeriod is how often the Slurm object is synchronized, to correct drift.
+	SyncPeriod time.Duration
+
+	// Kind is the kind of the object (e.g. Account), used in messages.
+	Kind string
+	// Finalizer ensures the Slurm object is deleted before the object is.
+	Finalizer string
+
+	// SlurmdbRefFn returns the SlurmdbRef of the object.
+	SlurmdbRefFn func(obj T) slinkyv1beta1.SlurmdbRef
+	// SyncFn creates and updates the Slurm object.
+	SyncFn func(ctx context.Context, controller *slinkyv1beta1.Controller, obj T) error
+	// DeleteFn deletes the Slurm object.
+	DeleteFn func(ctx context.Context, controller *slinkyv1beta1.Controller, obj T) error
+	// StatusFn updates the object status. The controller is nil when it could not be resolved.
+	StatusFn func(ctx context.Context, obj T, controller *slinkyv1beta1.Controller, errors ...error) error
+}
+
+// Sync implements control logic for synchronizing the object.
+func (s *Syncer[T]) Sync(ctx context.Context, obj T) error {
+	logger := log.FromContext(ctx)
+
+	if err := s.syncFinalizer(ctx, obj); err != nil {
+		msg := fmt.Sprintf("Failed to sync finalizer: %v", err)
+		s.EventRecorder.Eventf(obj, nil, corev1.EventTypeWarning, SyncFinalizerFailedReason, "SyncFinalizer", msg)
+		return err
+	}
+
+	if !obj.GetDeletionTimestamp().IsZero() {
+		logger.Info(s.Kind+" is being deleted, skipping sync", "object", klog.KObj(obj))
+		return nil
+	}
+
+	s.DurationStore.Push(objectutils.KeyFunc(obj), s.SyncPeriod)
+
+	controller, err := s.RefResolver.GetControllerForSlurmdbRef(ctx, s.SlurmdbRefFn(obj), obj.GetNamespace())
+	if err != nil {
+		err = fmt.Errorf("failed to get Controller for %s(%s): %w", s.Kind, klog.KObj(obj), err)
+		return s.StatusFn(ctx, obj, nil, err)
+	}
+
+	if err := s.SyncFn(ctx, controller, obj); err != nil {
+		msg := fmt.Sprintf("Failed to sync Slurm %s: %v", s.Kind, err)
+		s.EventRecorder.Eventf(obj, nil, corev1.EventTypeWarning, SyncFailedReason, "Sync"+s.Kind, msg)
+		return s.StatusFn(ctx, obj, controller, err)
+	}
+
+	return s.StatusFn(ctx, obj, controller)
+}
+
+// syncFinalizer ensures the Slurm object is deleted before the object is.
+func (s *Syncer[T]) syncFinalizer(ctx context.Context, obj T) error {
+	if obj.GetDeletionTimestamp().IsZero() {
+		return s.addFinalizerIfNeeded(ctx, obj)
+	}
+
+	if !controllerutil.ContainsFinalizer(obj, s.Finalizer) {
+		return nil
+	}
+
+	// If the controller does not exist we cannot delete the Slurm object and must
+	// remove the finalizer in order to permit object cleanup
+	controller, err := s.RefResolver.GetControllerForSlurmdbRef(ctx, s.SlurmdbRefFn(obj), obj.GetNamespace())
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return s.removeFinalizerIfNeeded(ctx, obj)
+	}
+
+	if err := s.DeleteFn(ctx, controller, obj); err != nil {
+		return err
+	}
+	return s.removeFinalizerIfNeeded(ctx, obj)
+}
+
+func (s *Syncer[T]) addFinalizerIfNeeded(ctx context.Context, obj T) error {
+	if controllerutil.ContainsFinalizer(obj, s.Finalizer) {
+		return nil
+	}
+
+	finalizersToAdd := slices.Concat(obj.GetFinalizers(), []string{s.Finalizer})
+	return s.updateFinalizers(ctx, obj, finalizersToAdd)
+}
+
+func (s *Syncer[T]) removeFinalizerIfNeeded(ctx context.Context, obj T) error {
+	if !controllerutil.ContainsFinalizer(obj, s.Finalizer) {
+		return nil
+	}
+
+	currentFinalizers := set.New(obj.GetFinalizers()...)
+
+	finalizersToRemove := set.New(s.Finalizer)
+	finalizersToKeep := currentFinalizers.Difference(finalizersToRemove).SortedList()
+
+	return s.updateFinalizers(ctx, obj, finalizersToKeep)
+}
+
+func (s *Syncer[T]) updateFinalizers(ctx context.Context, obj T, newFinalizers []string) error {
+	logger := log.FromContext(ctx)
+
+	logger.V(1).Info("Pending "+s.Kind+" Finalizer update", "newFinalizers", newFinalizers)
+
+	mutateFn := func(obj T) error {
+		obj.SetFinalizers(newFinalizers)
+		return nil
+	}
+
+	if err := objectutils.PatchObject(s.Client, ctx, obj, mutateFn); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
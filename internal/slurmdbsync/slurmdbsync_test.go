@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdbsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func TestSyncer_Sync(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(slinkyv1beta1.AddToScheme(scheme))
+
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	account := testutils.NewAccount("physics", controller)
+	accountNoController := testutils.NewAccount("physics", testutils.NewController("other", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil))
+	deletedAccount := account.DeepCopy()
+	deletedAccount.Finalizers = []string{slinkyv1beta1.FinalizerAccount}
+	deletedAccount.DeletionTimestamp = ptr.To(metav1.Now())
+
+	tests := []struct {
+		name          string
+		objects       []client.Object
+		account       *slinkyv1beta1.Account
+		syncErr       error
+		wantSynced    bool
+		wantDeleted   bool
+		wantStatus    bool
+		wantStatusErr bool
+		wantErr       bool
+	}{
+		{
+			name:       "sync",
+			objects:    []client.Object{controller.DeepCopy(), account.DeepCopy()},
+			account:    account,
+			wantSynced: true,
+			wantStatus: true,
+		},
+		{
+			name:          "sync error",
+			objects:       []client.Object{controller.DeepCopy(), account.DeepCopy()},
+			account:       account,
+			syncErr:       errors.New("failed to sync"),
+			wantSynced:    true,
+			wantStatus:    true,
+			wantStatusErr: true,
+			wantErr:       true,
+		},
+		{
+			name:          "controller not found",
+			objects:       []client.Object{accountNoController.DeepCopy()},
+			account:       accountNoController,
+			wantStatus:    true,
+			wantStatusErr: true,
+			wantErr:       true,
+		},
+		{
+			name:        "delete",
+			objects:     []client.Object{controller.DeepCopy(), deletedAccount.DeepCopy()},
+			account:     deletedAccount,
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.objects...).
+				Build()
+
+			var synced, deleted, status, statusErr bool
+			syncer := &Syncer[*slinkyv1beta1.Account]{
+				Client:        c,
+				RefResolver:   refresolver.New(c),
+				EventRecorder: events.NewFakeRecorder(10),
+				DurationStore: durationstore.NewDurationStore(durationstore.Less),
+				SyncPeriod:    time.Minute,
+				Kind:          "Account",
+				Finalizer:     slinkyv1beta1.FinalizerAccount,
+				SlurmdbRefFn: func(account *slinkyv1beta1.Account) slinkyv1beta1.SlurmdbRef {
+					return account.Spec.SlurmdbRef
+				},
+				SyncFn: func(context.Context, *slinkyv1beta1.Controller, *slinkyv1beta1.Account) error {
+					synced = true
+					return tt.syncErr
+				},
+				DeleteFn: func(context.Context, *slinkyv1beta1.Controller, *slinkyv1beta1.Account) error {
+					deleted = true
+					return nil
+				},
+				StatusFn: func(_ context.Context, _ *slinkyv1beta1.Account, _ *slinkyv1beta1.Controller, errs ...error) error {
+					status = true
+					statusErr = len(errs) > 0
+					return errors.Join(errs...)
+				},
+			}
+
+			err := syncer.Sync(ctx, tt.account.DeepCopy())
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.wantSynced, synced)
+			require.Equal(t, tt.wantDeleted, deleted)
+			require.Equal(t, tt.wantStatus, status)
+			require.Equal(t, tt.wantStatusErr, statusErr)
+
+			checkAccount := &slinkyv1beta1.Account{}
+			if err := c.Get(ctx, client.ObjectKeyFromObject(tt.account), checkAccount); err != nil {
+				return
+			}
+			if checkAccount.DeletionTimestamp.IsZero() {
+				require.Contains(t, checkAccount.Finalizers, slinkyv1beta1.FinalizerAccount)
+			} else {
+				require.NotContains(t, checkAccount.Finalizers, slinkyv1beta1.FinalizerAccount)
+			}
+		})
+	}
+}
@@ -70,3 +70,49 @@ func NewProperty(key string, val any) configProperty {
 func NewPropertyRaw(val any) configProperty {
 	return configProperty{val: val, raw: true}
 }
+
+// Key returns the key of the property, empty if it is raw.
+func (p configProperty) Key() string {
+	return p.key
+}
+
+// Value returns the value of the property.
+func (p configProperty) Value() string {
+	return fmt.Sprintf("%v", p.val)
+}
+
+// IsRaw returns true if the property is not a `key=value` pair.
+func (p configProperty) IsRaw() bool {
+	return p.raw
+}
+
+// ParseLines returns the lines of the config data, stripped of comments and
+// surrounding whitespace. Blank lines are skipped.
+func ParseLines(data string) []string {
+	lines := []string{}
+	for line := range strings.Lines(data) {
+		line, _, _ = strings.Cut(line, "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// ParseProperties returns the properties of the config data, one per line,
+// using the default separator. Lines without a key are returned raw.
+func ParseProperties(data string) []configProperty {
+	props := []configProperty{}
+	for _, line := range ParseLines(data) {
+		key, val, ok := strings.Cut(line, DefaultSeparator)
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			props = append(props, NewPropertyRaw(line))
+			continue
+		}
+		props = append(props, NewProperty(key, strings.TrimSpace(val)))
+	}
+	return props
+}
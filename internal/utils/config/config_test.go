@@ -44,3 +44,56 @@ func Test_configBuilder_Build(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLines(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "empty",
+			data: "",
+			want: []string{},
+		},
+		{
+			name: "comments and blank lines",
+			data: "# comment\n\nName=gpu File=/dev/nvidia0 # inline\n  AutoDetect=nvml  \n",
+			want: []string{"Name=gpu File=/dev/nvidia0", "AutoDetect=nvml"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ParseLines(tt.data))
+		})
+	}
+}
+
+func TestParseProperties(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []configProperty
+	}{
+		{
+			name: "empty",
+			data: "",
+			want: []configProperty{},
+		},
+		{
+			name: "properties",
+			data: "### cgroup ###\nConstrainDevices = yes\nAllowedRAMSpace=95\nNotAProperty\n=foo\n",
+			want: []configProperty{
+				NewProperty("ConstrainDevices", "yes"),
+				NewProperty("AllowedRAMSpace", "95"),
+				NewPropertyRaw("NotAProperty"),
+				NewPropertyRaw("=foo"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ParseProperties(tt.data))
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SlurmConfParam is a `Key=Value` parameter of a slurm.conf line.
+type SlurmConfParam struct {
+	Key   string
+	Value string
+}
+
+// SlurmConfLine is a logical line of slurm.conf, with continuations joined.
+type SlurmConfLine struct {
+	// Number is the number of the first physical line.
+	Number int
+	// Include is the file of an `Include` directive, empty otherwise.
+	Include string
+	// Params are the `Key=Value` parameters of the line.
+	Params []SlurmConfParam
+}
+
+// ParseSlurmConf parses slurm.conf formatted data into logical lines.
+// Comments are stripped and lines ending in a backslash are joined with the
+// next line. Malformed lines are skipped and reported as errors.
+//
+// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_DESCRIPTION
+func ParseSlurmConf(data string) ([]SlurmConfLine, []error) {
+	var lines []SlurmConfLine
+	var errs []error
+
+	var b strings.Builder
+	number := 0
+	parse := func() {
+		text := strings.TrimSpace(b.String())
+		b.Reset()
+		if text == "" {
+			return
+		}
+		line, err := parseSlurmConfLine(number, text)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", number, err))
+			return
+		}
+		lines = append(lines, line)
+	}
+
+	for i, text := range strings.Split(data, "\n") {
+		if b.Len() == 0 {
+			number = i + 1
+		}
+		text = strings.TrimSuffix(text, "\r")
+		text, _, _ = strings.Cut(text, "#")
+		text = strings.TrimRight(text, " \t")
+		if before, ok := strings.CutSuffix(text, `\`); ok {
+			b.WriteString(before)
+			continue
+		}
+		b.WriteString(text)
+		parse()
+	}
+	if b.Len() > 0 {
+		parse()
+	}
+
+	return lines, errs
+}
+
+func parseSlurmConfLine(number int, text string) (SlurmConfLine, error) {
+	line := SlurmConfLine{Number: number}
+
+	if fields := strings.Fields(text); len(fields) > 1 && strings.EqualFold(fields[0], "Include") {
+		line.Include = strings.TrimSpace(text[len(fields[0]):])
+		return line, nil
+	}
+
+	tokens, err := splitSlurmConfTokens(text)
+	if err != nil {
+		return line, err
+	}
+	for _, token := range tokens {
+		key, val, ok := strings.Cut(token, "=")
+		switch {
+		case !ok:
+			return line, fmt.Errorf("expected Key=Value, got %q", token)
+		case key == "":
+			return line, fmt.Errorf("missing key in %q", token)
+		}
+		line.Params = append(line.Params, SlurmConfParam{
+			Key:   key,
+			Value: strings.Trim(val, `"`),
+		})
+	}
+
+	return line, nil
+}
+
+// splitSlurmConfTokens splits the text on whitespace, except within double quotes.
+func splitSlurmConfTokens(text string) ([]string, error) {
+	var tokens []string
+	var b strings.Builder
+	quoted := false
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			b.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t'):
+			if b.Len() > 0 {
+				tokens = append(tokens, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, errors.New("unterminated quote")
+	}
+	if b.Len() > 0 {
+		tokens = append(tokens, b.String())
+	}
+	return tokens, nil
+}
+
+// CanonicalSlurmNodeKey returns the Slurm spelling of the node option key
+// (e.g. `RealMemory` for `realmemory`), or the key if it is unknown.
+func CanonicalSlurmNodeKey(key string) string {
+	return slurmConfBlockKeys["nodename"].canonical(key)
+}
+
+// LintSlurmConf validates slurm.conf formatted data. Malformed lines and
+// reserved options are returned as errors; unknown, deprecated, and duplicate
+// options are returned as warnings, as newer Slurm versions may know them.
+func LintSlurmConf(data string, reserved ...string) ([]string, []error) {
+	var warns []string
+	lines, errs := ParseSlurmConf(data)
+
+	reservedKeys := newKeySet(reserved...)
+	seen := map[string]int{}
+	for _, line := range lines {
+		if line.Include != "" || len(line.Params) == 0 {
+			continue
+		}
+		first := line.Params[0].Key
+		if known, ok := slurmConfBlockKeys[strings.ToLower(first)]; ok {
+			if reservedKeys.has(first) {
+				errs = append(errs, fmt.Errorf("line %d: option %q is managed by slurm-operator", line.Number, first))
+			}
+			for _, param := range line.Params[1:] {
+				if !known.has(param.Key) {
+					warns = append(warns, fmt.Sprintf("line %d: unknown %s option %q", line.Number, known.canonical(first), param.Key))
+				}
+			}
+			continue
+		}
+		for _, param := range line.Params {
+			key := strings.ToLower(param.Key)
+			switch {
+			case reservedKeys.has(key):
+				errs = append(errs, fmt.Errorf("line %d: option %q is managed by slurm-operator", line.Number, param.Key))
+			case slurmConfDeprecatedKeys[key] != "":
+				warns = append(warns, fmt.Sprintf("line %d: option %q is deprecated: %s", line.Number, param.Key, slurmConfDeprecatedKeys[key]))
+			case !slurmConfKeys.has(key):
+				warns = append(warns, fmt.Sprintf("line %d: unknown option %q", line.Number, param.Key))
+			}
+			if prev, ok := seen[key]; ok {
+				warns = append(warns, fmt.Sprintf("line %d: option %q duplicates line %d, the last value takes precedence", line.Number, param.Key, prev))
+			}
+			seen[key] = line.Number
+		}
+	}
+
+	return warns, errs
+}
+
+// LintSlurmNodeConf validates the parameters of a single slurm.conf NodeName
+// line (e.g. `Weight=5 Features=foo`), without the NodeName parameter itself.
+// Malformed items and reserved options are returned as errors; unknown options
+// are returned as warnings.
+//
+// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_NODE-CONFIGURATION
+func LintSlurmNodeConf(data string, reserved ...string) ([]string, []error) {
+	if strings.ContainsAny(data, "\r\n") {
+		return nil, []error{errors.New("must be a single line")}
+	}
+	var warns []string
+	lines, errs := ParseSlurmConf(data)
+
+	reservedKeys := newKeySet(append(reserved, "NodeName")...)
+	known := slurmConfBlockKeys["nodename"]
+	for _, line := range lines {
+		if line.Include != "" {
+			errs = append(errs, errors.New("unexpected Include directive"))
+			continue
+		}
+		for _, param := range line.Params {
+			switch {
+			case reservedKeys.has(param.Key):
+				errs = append(errs, fmt.Errorf("option %q is managed by slurm-operator", param.Key))
+			case !known.has(param.Key):
+				warns = append(warns, fmt.Sprintf("unknown NodeName option %q", param.Key))
+			}
+		}
+	}
+
+	return warns, errs
+}
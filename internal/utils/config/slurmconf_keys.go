@@ -0,0 +1,371 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"strings"
+)
+
+// keySet is a set of case-insensitive option keys.
+type keySet map[string]string
+
+func newKeySet(keys ...string) keySet {
+	s := make(keySet, len(keys))
+	for _, key := range keys {
+		s[strings.ToLower(key)] = key
+	}
+	return s
+}
+
+func (s keySet) has(key string) bool {
+	_, ok := s[strings.ToLower(key)]
+	return ok
+}
+
+func (s keySet) canonical(key string) string {
+	if canonical, ok := s[strings.ToLower(key)]; ok {
+		return canonical
+	}
+	return key
+}
+
+// slurmConfKeys are the global options of slurm.conf.
+// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_PARAMETERS
+var slurmConfKeys = newKeySet(
+	"AccountingStorageBackupHost",
+	"AccountingStorageEnforce",
+	"AccountingStorageExternalHost",
+	"AccountingStorageHost",
+	"AccountingStorageParameters",
+	"AccountingStoragePass",
+	"AccountingStoragePort",
+	"AccountingStorageTRES",
+	"AccountingStorageType",
+	"AccountingStorageUser",
+	"AccountingStoreFlags",
+	"AcctGatherEnergyType",
+	"AcctGatherFilesystemType",
+	"AcctGatherInterconnectType",
+	"AcctGatherNodeFreq",
+	"AcctGatherProfileType",
+	"AllowSpecResourcesUsage",
+	"AuthAltParameters",
+	"AuthAltTypes",
+	"AuthInfo",
+	"AuthType",
+	"BatchStartTimeout",
+	"BcastExclude",
+	"BcastParameters",
+	"BurstBufferType",
+	"CertgenParameters",
+	"CertgenType",
+	"CertmgrParameters",
+	"CertmgrType",
+	"CliFilterParameters",
+	"CliFilterPlugins",
+	"ClusterName",
+	"CommunicationParameters",
+	"CompleteWait",
+	"CpuFreqDef",
+	"CpuFreqGovernors",
+	"CredType",
+	"DataParserParameters",
+	"DebugFlags",
+	"DefCpuPerGPU",
+	"DefMemPerCPU",
+	"DefMemPerGPU",
+	"DefMemPerNode",
+	"DependencyParameters",
+	"DisableRootJobs",
+	"EioTimeout",
+	"EnforcePartLimits",
+	"Epilog",
+	"EpilogMsgTime",
+	"EpilogSlurmctld",
+	"EpilogTimeout",
+	"FairShareDampeningFactor",
+	"FederationParameters",
+	"FirstJobId",
+	"GpuFreqDef",
+	"GresTypes",
+	"GroupUpdateForce",
+	"GroupUpdateTime",
+	"HashPlugin",
+	"HealthCheckInterval",
+	"HealthCheckNodeState",
+	"HealthCheckProgram",
+	"HttpParserType",
+	"InactiveLimit",
+	"InteractiveStepOptions",
+	"JobAcctGatherFrequency",
+	"JobAcctGatherParams",
+	"JobAcctGatherType",
+	"JobCompHost",
+	"JobCompLoc",
+	"JobCompParams",
+	"JobCompPass",
+	"JobCompPassScript",
+	"JobCompPort",
+	"JobCompType",
+	"JobCompUser",
+	"JobContainerType",
+	"JobDefaults",
+	"JobFileAppend",
+	"JobRequeue",
+	"JobSubmitPlugins",
+	"KillOnBadExit",
+	"KillWait",
+	"LaunchParameters",
+	"Licenses",
+	"LogTimeFormat",
+	"MailDomain",
+	"MailProg",
+	"MaxArraySize",
+	"MaxBatchRequeue",
+	"MaxDBDMsgs",
+	"MaxJobCount",
+	"MaxJobId",
+	"MaxMemPerCPU",
+	"MaxMemPerNode",
+	"MaxNodeCount",
+	"MaxStepCount",
+	"MaxTasksPerNode",
+	"MCSParameters",
+	"MCSPlugin",
+	"MessageTimeout",
+	"MetricsType",
+	"MinJobAge",
+	"MpiDefault",
+	"MpiParams",
+	"NamespaceType",
+	"NodeFeaturesPlugins",
+	"OverTimeLimit",
+	"PluginDir",
+	"PlugStackConfig",
+	"PreemptExemptTime",
+	"PreemptMode",
+	"PreemptParameters",
+	"PreemptType",
+	"PrEpParameters",
+	"PrEpPlugins",
+	"PriorityCalcPeriod",
+	"PriorityDecayHalfLife",
+	"PriorityFavorSmall",
+	"PriorityFlags",
+	"PriorityMaxAge",
+	"PriorityParameters",
+	"PrioritySiteFactorParameters",
+	"PrioritySiteFactorPlugin",
+	"PriorityType",
+	"PriorityUsageResetPeriod",
+	"PriorityWeightAge",
+	"PriorityWeightAssoc",
+	"PriorityWeightFairshare",
+	"PriorityWeightJobSize",
+	"PriorityWeightPartition",
+	"PriorityWeightQOS",
+	"PriorityWeightTRES",
+	"PrivateData",
+	"ProctrackType",
+	"Prolog",
+	"PrologEpilogTimeout",
+	"PrologFlags",
+	"PrologSlurmctld",
+	"PrologTimeout",
+	"PropagatePrioProcess",
+	"PropagateResourceLimits",
+	"PropagateResourceLimitsExcept",
+	"RebootProgram",
+	"ReconfigFlags",
+	"RequeueExit",
+	"RequeueExitHold",
+	"ResumeFailProgram",
+	"ResumeProgram",
+	"ResumeRate",
+	"ResumeTimeout",
+	"ResvEpilog",
+	"ResvOverRun",
+	"ResvProlog",
+	"ReturnToService",
+	"SchedulerParameters",
+	"SchedulerTimeSlice",
+	"SchedulerType",
+	"ScronParameters",
+	"SelectType",
+	"SelectTypeParameters",
+	"SlurmctldAddr",
+	"SlurmctldDebug",
+	"SlurmctldHost",
+	"SlurmctldLogFile",
+	"SlurmctldParameters",
+	"SlurmctldPidFile",
+	"SlurmctldPort",
+	"SlurmctldPrimaryOffProg",
+	"SlurmctldPrimaryOnProg",
+	"SlurmctldSyslogDebug",
+	"SlurmctldTimeout",
+	"SlurmdDebug",
+	"SlurmdLogFile",
+	"SlurmdParameters",
+	"SlurmdPidFile",
+	"SlurmdPort",
+	"SlurmdSpoolDir",
+	"SlurmdSyslogDebug",
+	"SlurmdTimeout",
+	"SlurmdUser",
+	"SlurmSchedLogFile",
+	"SlurmSchedLogLevel",
+	"SlurmUser",
+	"SrunEpilog",
+	"SrunPortRange",
+	"SrunProlog",
+	"StateSaveLocation",
+	"SuspendExcNodes",
+	"SuspendExcParts",
+	"SuspendExcStates",
+	"SuspendProgram",
+	"SuspendRate",
+	"SuspendTime",
+	"SuspendTimeout",
+	"SwitchParameters",
+	"SwitchType",
+	"TaskEpilog",
+	"TaskPlugin",
+	"TaskPluginParam",
+	"TaskProlog",
+	"TCPTimeout",
+	"TLSParameters",
+	"TLSType",
+	"TmpFS",
+	"TopologyParam",
+	"TopologyPlugin",
+	"TrackWCKey",
+	"TreeWidth",
+	"UnkillableStepProgram",
+	"UnkillableStepTimeout",
+	"UrlParserType",
+	"UsePAM",
+	"VSizeFactor",
+	"WaitTime",
+	"X11Parameters",
+)
+
+// slurmConfDeprecatedKeys are the removed or replaced options of slurm.conf,
+// keyed by lowercase option.
+var slurmConfDeprecatedKeys = map[string]string{
+	"accountingstorejobcomment":      "use AccountingStoreFlags=job_comment instead",
+	"backupaddr":                     "use SlurmctldHost instead",
+	"backupcontroller":               "use SlurmctldHost instead",
+	"checkpointtype":                 "checkpointing was removed",
+	"controladdr":                    "use SlurmctldHost instead",
+	"controlmachine":                 "use SlurmctldHost instead",
+	"cryptotype":                     "use CredType instead",
+	"fastschedule":                   "it is no longer used",
+	"getenvtimeout":                  "it is no longer used",
+	"jobcheckpointdir":               "checkpointing was removed",
+	"jobcredentialprivatekey":        "use CredType instead",
+	"jobcredentialpubliccertificate": "use CredType instead",
+	"keepalivetime":                  "use CommunicationParameters=keepalivetime instead",
+	"memlimitenforce":                "use JobAcctGatherParams=OverMemoryKill instead",
+	"msgaggregationparams":           "message aggregation was removed",
+	"powerparameters":                "power management was removed",
+	"powerplugin":                    "power management was removed",
+	"sallocdefaultcommand":           "use LaunchParameters=use_interactive_step instead",
+	"schedulerport":                  "it is no longer used",
+	"schedulerrootfilter":            "it is no longer used",
+}
+
+// slurmConfBlockKeys are the options of slurm.conf lines that define an
+// entity, keyed by the lowercase option that starts the line.
+var slurmConfBlockKeys = map[string]keySet{
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_NODE-CONFIGURATION
+	"nodename": newKeySet(
+		"NodeName",
+		"BcastAddr",
+		"Boards",
+		"CoreSpecCount",
+		"CoresPerSocket",
+		"CpuBind",
+		"CPUs",
+		"CpuSpecList",
+		"Feature",
+		"Features",
+		"Gres",
+		"MemSpecLimit",
+		"NodeAddr",
+		"NodeHostname",
+		"Port",
+		"Procs",
+		"RealMemory",
+		"Reason",
+		"RestrictedCoresPerGPU",
+		"Sockets",
+		"SocketsPerBoard",
+		"State",
+		"ThreadsPerCore",
+		"TmpDisk",
+		"Topology",
+		"Weight",
+	),
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_DOWN-NODE-CONFIGURATION
+	"downnodes": newKeySet(
+		"DownNodes",
+		"Reason",
+		"State",
+	),
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_NODESET-CONFIGURATION
+	"nodeset": newKeySet(
+		"NodeSet",
+		"Feature",
+		"Nodes",
+	),
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_PARTITION-CONFIGURATION
+	"partitionname": newKeySet(
+		"PartitionName",
+		"AllocNodes",
+		"AllowAccounts",
+		"AllowGroups",
+		"AllowQos",
+		"Alternate",
+		"CpuBind",
+		"Default",
+		"DefaultTime",
+		"DefCpuPerGPU",
+		"DefMemPerCPU",
+		"DefMemPerGPU",
+		"DefMemPerNode",
+		"DenyAccounts",
+		"DenyQos",
+		"DisableRootJobs",
+		"ExclusiveTopo",
+		"ExclusiveUser",
+		"GraceTime",
+		"Hidden",
+		"LLN",
+		"MaxCPUsPerNode",
+		"MaxCPUsPerSocket",
+		"MaxMemPerCPU",
+		"MaxMemPerNode",
+		"MaxNodes",
+		"MaxTime",
+		"MinNodes",
+		"Nodes",
+		"OverSubscribe",
+		"OverTimeLimit",
+		"PowerDownOnIdle",
+		"PreemptMode",
+		"PriorityJobFactor",
+		"PriorityTier",
+		"QOS",
+		"ReqResv",
+		"ResumeTimeout",
+		"RootOnly",
+		"SelectTypeParameters",
+		"State",
+		"SuspendTime",
+		"SuspendTimeout",
+		"Topology",
+		"TRESBillingWeights",
+	),
+}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSlurmConf(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		want     []SlurmConfLine
+		wantErrs int
+	}{
+		{
+			name: "empty",
+			data: "",
+			want: nil,
+		},
+		{
+			name: "lines",
+			data: `# comment
+ClusterName=slurm # comment
+
+NodeName=foo-[0-1] CPUs=4 Reason="under maintenance"
+Include /etc/slurm/extra.conf`,
+			want: []SlurmConfLine{
+				{Number: 2, Params: []SlurmConfParam{{Key: "ClusterName", Value: "slurm"}}},
+				{Number: 4, Params: []SlurmConfParam{
+					{Key: "NodeName", Value: "foo-[0-1]"},
+					{Key: "CPUs", Value: "4"},
+					{Key: "Reason", Value: "under maintenance"},
+				}},
+				{Number: 5, Include: "/etc/slurm/extra.conf"},
+			},
+		},
+		{
+			name: "continuation",
+			data: `SchedulerParameters=bf_continue,\
+bf_interval=60
+MinJobAge=2`,
+			want: []SlurmConfLine{
+				{Number: 1, Params: []SlurmConfParam{{Key: "SchedulerParameters", Value: "bf_continue,bf_interval=60"}}},
+				{Number: 3, Params: []SlurmConfParam{{Key: "MinJobAge", Value: "2"}}},
+			},
+		},
+		{
+			name: "empty value",
+			data: `DebugFlags=`,
+			want: []SlurmConfLine{
+				{Number: 1, Params: []SlurmConfParam{{Key: "DebugFlags", Value: ""}}},
+			},
+		},
+		{
+			name: "malformed",
+			data: `MinJobAge 2
+=foo
+Reason="unterminated
+MaxJobCount=1000`,
+			want: []SlurmConfLine{
+				{Number: 4, Params: []SlurmConfParam{{Key: "MaxJobCount", Value: "1000"}}},
+			},
+			wantErrs: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := ParseSlurmConf(tt.data)
+			require.Equal(t, tt.want, got)
+			require.Len(t, errs, tt.wantErrs, errs)
+		})
+	}
+}
+
+func TestLintSlurmConf(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		reserved  []string
+		wantWarns []string
+		wantErrs  []string
+	}{
+		{
+			name: "empty",
+			data: "",
+		},
+		{
+			name: "valid",
+			data: `slurmctlddebug=debug2
+SchedulerParameters=bf_continue
+PartitionName=all Nodes=ALL Default=YES MaxTime=UNLIMITED State=UP
+NodeName=foo CPUs=4 State=CLOUD
+Include extra.conf`,
+			reserved: []string{"ClusterName"},
+		},
+		{
+			name: "unknown",
+			data: `SlurmctldDebugg=debug2
+PartitionName=all Nodes=ALL Defualt=YES`,
+			wantWarns: []string{
+				`line 1: unknown option "SlurmctldDebugg"`,
+				`line 2: unknown PartitionName option "Defualt"`,
+			},
+		},
+		{
+			name:     "reserved",
+			data:     `clustername=foo`,
+			reserved: []string{"ClusterName"},
+			wantErrs: []string{
+				`line 1: option "clustername" is managed by slurm-operator`,
+			},
+		},
+		{
+			name: "deprecated and duplicate",
+			data: `ControlMachine=foo
+MinJobAge=2
+MinJobAge=4`,
+			wantWarns: []string{
+				`line 1: option "ControlMachine" is deprecated: use SlurmctldHost instead`,
+				`line 3: option "MinJobAge" duplicates line 2, the last value takes precedence`,
+			},
+		},
+		{
+			name: "malformed",
+			data: `MinJobAge 2`,
+			wantErrs: []string{
+				`line 1: expected Key=Value, got "MinJobAge"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warns, errs := LintSlurmConf(tt.data, tt.reserved...)
+			require.Equal(t, tt.wantWarns, warns)
+			gotErrs := []string{}
+			for _, err := range errs {
+				gotErrs = append(gotErrs, err.Error())
+			}
+			require.ElementsMatch(t, tt.wantErrs, gotErrs)
+		})
+	}
+}
+
+func TestLintSlurmNodeConf(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		reserved  []string
+		wantWarns int
+		wantErrs  int
+	}{
+		{
+			name: "empty",
+			data: "",
+		},
+		{
+			name: "valid",
+			data: "Weight=5  features=foo,bar RealMemory=4096",
+		},
+		{
+			name:      "unknown",
+			data:      "Weight=5 Foo=bar",
+			wantWarns: 1,
+		},
+		{
+			name:     "reserved",
+			data:     "NodeName=foo Port=6818",
+			reserved: []string{"Port"},
+			wantErrs: 2,
+		},
+		{
+			name:     "malformed",
+			data:     "Weight=5 Features",
+			wantErrs: 1,
+		},
+		{
+			name:     "multiple lines",
+			data:     "Weight=5\nFeatures=foo",
+			wantErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warns, errs := LintSlurmNodeConf(tt.data, tt.reserved...)
+			require.Len(t, warns, tt.wantWarns, warns)
+			require.Len(t, errs, tt.wantErrs, errs)
+		})
+	}
+}
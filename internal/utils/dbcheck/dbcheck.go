@@ -0,0 +1,280 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dbcheck checks that slurmdbd can use its MySQL/MariaDB database.
+package dbcheck
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// DefaultTimeout bounds the duration of a check.
+	DefaultTimeout = 10 * time.Second
+
+	// MySQL server error codes.
+	// Ref: https://mariadb.com/kb/en/mariadb-error-code-reference/
+	errDBAccessDenied = 1044
+	errAccessDenied   = 1045
+	errBadDB          = 1049
+	errNoSuchTable    = 1146
+)
+
+var (
+	// ErrUnreachable is returned when the database server cannot be connected to.
+	ErrUnreachable = errors.New("database is unreachable")
+	// ErrAccessDenied is returned when the database server rejects the credentials.
+	ErrAccessDenied = errors.New("database access denied")
+	// ErrInsufficientPrivileges is returned when the user cannot manage the database.
+	ErrInsufficientPrivileges = errors.New("insufficient database privileges")
+)
+
+// requiredPrivileges are the privileges slurmdbd needs on its database to
+// create and upgrade its schema.
+// Ref: https://slurm.schedmd.com/accounting.html#slurm-accounting-configuration-before-build
+var requiredPrivileges = []string{
+	"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "INDEX", "ALTER",
+}
+
+// Config is the database access of slurmdbd.
+type Config struct {
+	Host     string
+	Port     int
+	Database string
+	Username string
+	Password string
+}
+
+// Result is the state of the slurmdbd database.
+type Result struct {
+	// SchemaVersion is the version of the slurmdbd schema, or nil if slurmdbd
+	// has not created its schema yet.
+	SchemaVersion *int
+	// Clusters are the clusters registered in slurmdbd.
+	Clusters []string
+	// Warnings are the checks which could not be verified (e.g. privileges
+	// which may be granted by a role), but do not prevent the use of the database.
+	Warnings []string
+}
+
+type Checker interface {
+	// Check connects to the database, and verifies the privileges of the user.
+	Check(ctx context.Context, config Config) (*Result, error)
+}
+
+type checker struct {
+	open func(config Config) (*sql.DB, error)
+}
+
+func NewChecker() Checker {
+	return &checker{
+		open: openMySQL,
+	}
+}
+
+func openMySQL(config Config) (*sql.DB, error) {
+	cfg := mysql.NewConfig()
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(config.Host, strconv.Itoa(config.Port))
+	cfg.User = config.Username
+	cfg.Passwd = config.Password
+	cfg.Timeout = DefaultTimeout
+	cfg.ReadTimeout = DefaultTimeout
+	cfg.WriteTimeout = DefaultTimeout
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(connector), nil
+}
+
+// Check implements Checker.
+func (c *checker) Check(ctx context.Context, config Config) (*Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	db, err := c.open(config)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnreachable, err)
+	}
+	defer db.Close()
+
+	if err := db.PingContext(ctx); err != nil {
+		if isMySQLError(err, errAccessDenied, errDBAccessDenied) {
+			return nil, fmt.Errorf("%w: %w", ErrAccessDenied, err)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrUnreachable, err)
+	}
+
+	grants, err := queryStrings(ctx, db, "SHOW GRANTS FOR CURRENT_USER()")
+	if err != nil {
+		return nil, fmt.Errorf("failed to show grants: %w", err)
+	}
+	result := &Result{}
+	if missing := missingPrivileges(grants, config.Database); len(missing) > 0 {
+		msg := fmt.Sprintf("user %q is missing %s on database %q",
+			config.Username, strings.Join(missing, ", "), config.Database)
+		// Privileges of roles are not listed, hence they may still be granted.
+		if !hasRoleGrants(grants) {
+			return nil, fmt.Errorf("%w: %s", ErrInsufficientPrivileges, msg)
+		}
+		result.Warnings = append(result.Warnings, msg+", unless granted by a role")
+	}
+
+	database := quoteIdentifier(config.Database)
+
+	// Ref: https://github.com/SchedMD/slurm/blob/master/src/plugins/accounting_storage/mysql/as_mysql_convert.c
+	versions, err := queryStrings(ctx, db, fmt.Sprintf("SELECT version FROM %s.convert_version_table", database))
+	switch {
+	case isMySQLError(err, errBadDB, errNoSuchTable):
+		return result, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get schema version: %w", err)
+	case len(versions) > 0:
+		version, err := strconv.Atoi(versions[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse schema version %q: %w", versions[0], err)
+		}
+		result.SchemaVersion = &version
+	}
+
+	clusters, err := queryStrings(ctx, db, fmt.Sprintf("SELECT name FROM %s.cluster_table WHERE deleted = 0 ORDER BY name", database))
+	switch {
+	case isMySQLError(err, errBadDB, errNoSuchTable):
+	case err != nil:
+		return nil, fmt.Errorf("failed to get clusters: %w", err)
+	default:
+		result.Clusters = clusters
+	}
+
+	return result, nil
+}
+
+// queryStrings returns the values of a single column query.
+func queryStrings(ctx context.Context, db *sql.DB, query string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		out = append(out, value)
+	}
+	return out, rows.Err()
+}
+
+func isMySQLError(err error, numbers ...uint16) bool {
+	mysqlErr := &mysql.MySQLError{}
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	for _, number := range numbers {
+		if mysqlErr.Number == number {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// grantRegex matches the privileges and target of a `SHOW GRANTS` line.
+// (e.g. "GRANT ALL PRIVILEGES ON `slurm_acct_db`.* TO `slurm`@`%`")
+var grantRegex = regexp.MustCompile("^GRANT (.+) ON (\\S+) TO ")
+
+// hasRoleGrants returns true if the grants include a grant which is not a
+// privilege grant (e.g. "GRANT `slurm_role` TO `slurm`@`%`").
+func hasRoleGrants(grants []string) bool {
+	for _, grant := range grants {
+		if strings.HasPrefix(grant, "GRANT ") && !grantRegex.MatchString(grant) {
+			return true
+		}
+	}
+	return false
+}
+
+// missingPrivileges returns the required privileges which the grants do not
+// give on the database.
+func missingPrivileges(grants []string, database string) []string {
+	granted := sets.New[string]()
+	for _, grant := range grants {
+		match := grantRegex.FindStringSubmatch(grant)
+		if match == nil || !grantsDatabase(match[2], database) {
+			continue
+		}
+		for privilege := range strings.SplitSeq(match[1], ",") {
+			privilege = strings.ToUpper(strings.TrimSpace(privilege))
+			if privilege == "ALL" || privilege == "ALL PRIVILEGES" {
+				return nil
+			}
+			granted.Insert(privilege)
+		}
+	}
+
+	var missing []string
+	for _, privilege := range requiredPrivileges {
+		if !granted.Has(privilege) {
+			missing = append(missing, privilege)
+		}
+	}
+	return missing
+}
+
+// grantsDatabase returns true if the grant target applies to all tables of the
+// database.
+func grantsDatabase(target, database string) bool {
+	if target == "*.*" {
+		return true
+	}
+	name, ok := strings.CutSuffix(target, ".*")
+	if !ok {
+		return false
+	}
+	name = strings.Trim(name, "`")
+	return likeRegex(name).MatchString(database)
+}
+
+// likeRegex returns the regex of a database name pattern of a grant, where
+// `%` and `_` are wildcards unless escaped (e.g. "slurm%", "slurm\_acct\_db").
+// Ref: https://mariadb.com/kb/en/grant/#database-privileges
+func likeRegex(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			expr.WriteString(".*")
+		case r == '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package dbcheck
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestChecker_Check(t *testing.T) {
+	config := Config{
+		Host:     "mariadb",
+		Port:     3306,
+		Database: "slurm_acct_db",
+		Username: "slurm",
+		Password: "password",
+	}
+	grantAll := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"Grants for slurm@%"}).
+			AddRow("GRANT USAGE ON *.* TO `slurm`@`%`").
+			AddRow("GRANT ALL PRIVILEGES ON `slurm_acct_db`.* TO `slurm`@`%`")
+	}
+	showGrants := regexp.QuoteMeta("SHOW GRANTS FOR CURRENT_USER()")
+	selectVersion := regexp.QuoteMeta("SELECT version FROM `slurm_acct_db`.convert_version_table")
+	selectClusters := regexp.QuoteMeta("SELECT name FROM `slurm_acct_db`.cluster_table WHERE deleted = 0 ORDER BY name")
+
+	tests := []struct {
+		name    string
+		expect  func(mock sqlmock.Sqlmock)
+		want    *Result
+		wantErr error
+	}{
+		{
+			name: "unreachable",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPing().WillReturnError(errors.New("dial tcp: lookup mariadb: no such host"))
+			},
+			wantErr: ErrUnreachable,
+		},
+		{
+			name: "access denied",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPing().WillReturnError(&mysql.MySQLError{Number: errAccessDenied, Message: "Access denied for user 'slurm'@'10.0.0.1'"})
+			},
+			wantErr: ErrAccessDenied,
+		},
+		{
+			name: "insufficient privileges",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPing()
+				mock.ExpectQuery(showGrants).WillReturnRows(sqlmock.NewRows([]string{"Grants for slurm@%"}).
+					AddRow("GRANT USAGE ON *.* TO `slurm`@`%`").
+					AddRow("GRANT SELECT, INSERT ON `slurm_acct_db`.* TO `slurm`@`%`"))
+			},
+			wantErr: ErrInsufficientPrivileges,
+		},
+		{
+			name: "role grant",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPing()
+				mock.ExpectQuery(showGrants).WillReturnRows(sqlmock.NewRows([]string{"Grants for slurm@%"}).
+					AddRow("GRANT `slurm_role` TO `slurm`@`%`").
+					AddRow("GRANT USAGE ON *.* TO `slurm`@`%`").
+					AddRow("SET DEFAULT ROLE `slurm_role` FOR `slurm`@`%`"))
+				mock.ExpectQuery(selectVersion).WillReturnError(&mysql.MySQLError{Number: errBadDB})
+			},
+			want: &Result{
+				Warnings: []string{`user "slurm" is missing SELECT, INSERT, UPDATE, DELETE, CREATE, DROP, INDEX, ALTER on database "slurm_acct_db", unless granted by a role`},
+			},
+		},
+		{
+			name: "new database",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPing()
+				mock.ExpectQuery(showGrants).WillReturnRows(grantAll())
+				mock.ExpectQuery(selectVersion).WillReturnError(&mysql.MySQLError{Number: errBadDB})
+			},
+			want: &Result{},
+		},
+		{
+			name: "initialized",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPing()
+				mock.ExpectQuery(showGrants).WillReturnRows(grantAll())
+				mock.ExpectQuery(selectVersion).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("15"))
+				mock.ExpectQuery(selectClusters).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("bar").AddRow("foo"))
+			},
+			want: &Result{
+				SchemaVersion: ptr.To(15),
+				Clusters:      []string{"bar", "foo"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			require.NoError(t, err)
+			tt.expect(mock)
+			c := &checker{
+				open: func(got Config) (*sql.DB, error) {
+					require.Equal(t, config, got)
+					return db, nil
+				},
+			}
+
+			got, err := c.Check(context.TODO(), config)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_missingPrivileges(t *testing.T) {
+	tests := []struct {
+		name     string
+		grants   []string
+		database string
+		want     []string
+	}{
+		{
+			name: "all on database",
+			grants: []string{
+				"GRANT USAGE ON *.* TO `slurm`@`%`",
+				"GRANT ALL PRIVILEGES ON `slurm_acct_db`.* TO `slurm`@`%`",
+			},
+			database: "slurm_acct_db",
+		},
+		{
+			name: "all on escaped database",
+			grants: []string{
+				"GRANT ALL PRIVILEGES ON `slurm\\_acct\\_db`.* TO 'slurm'@'%' IDENTIFIED BY PASSWORD '*ABC'",
+			},
+			database: "slurm_acct_db",
+		},
+		{
+			name: "all on everything",
+			grants: []string{
+				"GRANT ALL PRIVILEGES ON *.* TO `root`@`localhost` WITH GRANT OPTION",
+			},
+			database: "slurm_acct_db",
+		},
+		{
+			name: "explicit privileges",
+			grants: []string{
+				"GRANT SELECT, INSERT, UPDATE, DELETE ON *.* TO `slurm`@`%`",
+				"GRANT CREATE, DROP, INDEX, ALTER, LOCK TABLES ON `slurm_acct_db`.* TO `slurm`@`%`",
+			},
+			database: "slurm_acct_db",
+		},
+		{
+			name: "other database",
+			grants: []string{
+				"GRANT USAGE ON *.* TO `slurm`@`%`",
+				"GRANT ALL PRIVILEGES ON `other_db`.* TO `slurm`@`%`",
+			},
+			database: "slurm_acct_db",
+			want:     []string{"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "INDEX", "ALTER"},
+		},
+		{
+			name: "all on wildcard database",
+			grants: []string{
+				"GRANT ALL PRIVILEGES ON `slurm%`.* TO `slurm`@`%`",
+			},
+			database: "slurm_acct_db",
+		},
+		{
+			name: "all on other wildcard database",
+			grants: []string{
+				"GRANT ALL PRIVILEGES ON `other%`.* TO `slurm`@`%`",
+				"GRANT ALL PRIVILEGES ON `slurm\\_acct`.* TO `slurm`@`%`",
+			},
+			database: "slurm_acct_db",
+			want:     []string{"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "INDEX", "ALTER"},
+		},
+		{
+			name: "table only",
+			grants: []string{
+				"GRANT ALL PRIVILEGES ON `slurm_acct_db`.`job_table` TO `slurm`@`%`",
+				"GRANT SELECT, INSERT, UPDATE, DELETE ON `slurm_acct_db`.* TO `slurm`@`%`",
+			},
+			database: "slurm_acct_db",
+			want:     []string{"CREATE", "DROP", "INDEX", "ALTER"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, missingPrivileges(tt.grants, tt.database))
+		})
+	}
+}
+
+func Test_hasRoleGrants(t *testing.T) {
+	tests := []struct {
+		name   string
+		grants []string
+		want   bool
+	}{
+		{
+			name: "privileges",
+			grants: []string{
+				"GRANT USAGE ON *.* TO `slurm`@`%`",
+				"GRANT ALL PRIVILEGES ON `slurm_acct_db`.* TO `slurm`@`%`",
+			},
+		},
+		{
+			name: "role",
+			grants: []string{
+				"GRANT USAGE ON *.* TO `slurm`@`%`",
+				"GRANT `slurm_role`@`%` TO `slurm`@`%`",
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, hasRoleGrants(tt.grants))
+		})
+	}
+}
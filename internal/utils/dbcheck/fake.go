@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package dbcheck
+
+import (
+	"context"
+)
+
+// FakeChecker is a Checker which returns a fixed outcome, for tests.
+type FakeChecker struct {
+	Result *Result
+	Err    error
+
+	// Configs are the configurations which were checked.
+	Configs []Config
+}
+
+func NewFakeChecker(result *Result, err error) *FakeChecker {
+	return &FakeChecker{
+		Result: result,
+		Err:    err,
+	}
+}
+
+// Check implements Checker.
+func (c *FakeChecker) Check(ctx context.Context, config Config) (*Result, error) {
+	c.Configs = append(c.Configs, config)
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	return c.Result, nil
+}
@@ -10,9 +10,12 @@ import (
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -29,7 +32,7 @@ func DeleteObject(c client.Client, ctx context.Context, eventRecorder events.Eve
 	logger := log.FromContext(ctx)
 
 	var oldObj client.Object
-	switch newObj.(type) {
+	switch o := newObj.(type) {
 	case *corev1.ConfigMap:
 		oldObj = &corev1.ConfigMap{}
 	case *corev1.Secret:
@@ -54,6 +57,12 @@ func DeleteObject(c client.Client, ctx context.Context, eventRecorder events.Eve
 		oldObj = &policyv1.PodDisruptionBudget{}
 	case *monitoringv1.ServiceMonitor:
 		oldObj = &monitoringv1.ServiceMonitor{}
+	case *batchv1.Job:
+		oldObj = &batchv1.Job{}
+	case *unstructured.Unstructured:
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(o.GroupVersionKind())
+		oldObj = obj
 	default:
 		return errors.New("unhandled object, this is a bug")
 	}
@@ -72,7 +81,12 @@ func DeleteObject(c client.Client, ctx context.Context, eventRecorder events.Eve
 		return nil
 	}
 
-	if err := c.Delete(ctx, oldObj); err != nil {
+	var opts []client.DeleteOption
+	if _, ok := oldObj.(*batchv1.Job); ok {
+		// Jobs orphan their pods by default.
+		opts = append(opts, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	}
+	if err := c.Delete(ctx, oldObj, opts...); err != nil {
 		if eventRecorder != nil {
 			eventRecorder.Eventf(eventObj, oldObj, corev1.EventTypeWarning, ReasonDeleteFailed, "Delete", "Error deleting: %T %s: %v", oldObj, key, err)
 		}
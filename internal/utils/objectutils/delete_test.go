@@ -10,6 +10,7 @@ import (
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -147,6 +148,22 @@ func TestDeleteObject(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Job",
+			args: args{
+				c: fake.NewFakeClient(&batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+				}),
+				ctx: context.TODO(),
+				newObj: &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -8,12 +8,15 @@ import (
 	"errors"
 	"fmt"
 
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,13 +36,15 @@ func SyncObject(c client.Client, ctx context.Context, eventRecorder events.Event
 	logger := log.FromContext(ctx)
 
 	var oldObj client.Object
-	switch newObj.(type) {
+	switch o := newObj.(type) {
 	case *corev1.ConfigMap:
 		oldObj = &corev1.ConfigMap{}
 	case *corev1.Secret:
 		oldObj = &corev1.Secret{}
 	case *corev1.Service:
 		oldObj = &corev1.Service{}
+	case *corev1.PersistentVolumeClaim:
+		oldObj = &corev1.PersistentVolumeClaim{}
 	case *appsv1.Deployment:
 		oldObj = &appsv1.Deployment{}
 	case *appsv1.StatefulSet:
@@ -58,6 +63,20 @@ func SyncObject(c client.Client, ctx context.Context, eventRecorder events.Event
 		oldObj = &policyv1.PodDisruptionBudget{}
 	case *monitoringv1.ServiceMonitor:
 		oldObj = &monitoringv1.ServiceMonitor{}
+	case *batchv1.Job:
+		oldObj = &batchv1.Job{}
+	case *mariadbv1alpha1.MariaDB:
+		oldObj = &mariadbv1alpha1.MariaDB{}
+	case *mariadbv1alpha1.Database:
+		oldObj = &mariadbv1alpha1.Database{}
+	case *mariadbv1alpha1.User:
+		oldObj = &mariadbv1alpha1.User{}
+	case *mariadbv1alpha1.Grant:
+		oldObj = &mariadbv1alpha1.Grant{}
+	case *unstructured.Unstructured:
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(o.GroupVersionKind())
+		oldObj = obj
 	default:
 		return errors.New("unhandled object, this is a bug")
 	}
@@ -141,6 +160,18 @@ func SyncObject(c client.Client, ctx context.Context, eventRecorder events.Event
 			obj.Spec = o.Spec
 			return nil
 		})
+	case *corev1.PersistentVolumeClaim:
+		obj := oldObj.(*corev1.PersistentVolumeClaim)
+		patchErr = PatchObject(c, ctx, obj, func(obj *corev1.PersistentVolumeClaim) error {
+			obj.Annotations = structutils.MergeMaps(obj.Annotations, o.Annotations)
+			obj.Labels = structutils.MergeMaps(obj.Labels, o.Labels)
+			if !equality.Semantic.DeepEqual(obj.OwnerReferences, o.OwnerReferences) {
+				obj.OwnerReferences = o.OwnerReferences
+			}
+			// Only storage requests are mutable, for volume expansion.
+			obj.Spec.Resources.Requests = o.Spec.Resources.Requests
+			return nil
+		})
 	case *appsv1.Deployment:
 		obj := oldObj.(*appsv1.Deployment)
 		patchErr = PatchObject(c, ctx, obj, func(obj *appsv1.Deployment) error {
@@ -274,6 +305,21 @@ func SyncObject(c client.Client, ctx context.Context, eventRecorder events.Event
 			obj.Spec.ServiceDiscoveryRole = o.Spec.ServiceDiscoveryRole
 			return nil
 		})
+	case *mariadbv1alpha1.MariaDB:
+		obj := oldObj.(*mariadbv1alpha1.MariaDB)
+		patchErr = PatchObject(c, ctx, obj, func(obj *mariadbv1alpha1.MariaDB) error {
+			obj.Annotations = structutils.MergeMaps(obj.Annotations, o.Annotations)
+			obj.Labels = structutils.MergeMaps(obj.Labels, o.Labels)
+			if !equality.Semantic.DeepEqual(obj.OwnerReferences, o.OwnerReferences) {
+				obj.OwnerReferences = o.OwnerReferences
+			}
+			obj.Spec.Image = o.Spec.Image
+			obj.Spec.MyCnf = o.Spec.MyCnf
+			obj.Spec.Resources = o.Spec.Resources
+			// Only the storage size is mutable, for volume expansion.
+			obj.Spec.Storage.Size = o.Spec.Storage.Size
+			return nil
+		})
 	default:
 		return errors.New("unhandled patch object, this is a bug")
 	}
@@ -9,12 +9,15 @@ import (
 	"testing"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	mariadbv1alpha1 "github.com/mariadb-operator/mariadb-operator/api/v1alpha1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -26,6 +29,7 @@ import (
 func init() {
 	utilruntime.Must(slinkyv1beta1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(monitoringv1.AddToScheme(scheme.Scheme))
+	utilruntime.Must(mariadbv1alpha1.AddToScheme(scheme.Scheme))
 }
 
 func TestSyncObject(t *testing.T) {
@@ -196,6 +200,64 @@ func TestSyncObject(t *testing.T) {
 				shouldUpdate: true,
 			},
 		},
+		{
+			name: "Create PersistentVolumeClaim",
+			args: args{
+				c:   fake.NewFakeClient(),
+				ctx: context.TODO(),
+				newObj: &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+				},
+				shouldUpdate: true,
+			},
+		},
+		{
+			name: "Create Job",
+			args: args{
+				c:   fake.NewFakeClient(),
+				ctx: context.TODO(),
+				newObj: &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+				},
+			},
+		},
+		{
+			name: "Create Unstructured",
+			args: args{
+				c:   fake.NewFakeClient(),
+				ctx: context.TODO(),
+				newObj: func() *unstructured.Unstructured {
+					obj := &unstructured.Unstructured{}
+					obj.SetAPIVersion("snapshot.storage.k8s.io/v1")
+					obj.SetKind("VolumeSnapshot")
+					obj.SetName("foo")
+					return obj
+				}(),
+			},
+		},
+		{
+			name: "Update PersistentVolumeClaim",
+			args: args{
+				c: fake.NewClientBuilder().WithObjects(
+					&corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "foo",
+						},
+					},
+				).Build(),
+				ctx: context.TODO(),
+				newObj: &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+				},
+				shouldUpdate: true,
+			},
+		},
 		{
 			name: "Create Deployment",
 			args: args{
@@ -484,6 +546,77 @@ func TestSyncObject(t *testing.T) {
 				shouldUpdate: true,
 			},
 		},
+		{
+			name: "Create MariaDB",
+			args: args{
+				c:   fake.NewFakeClient(),
+				ctx: context.TODO(),
+				newObj: &mariadbv1alpha1.MariaDB{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+				},
+				shouldUpdate: true,
+			},
+		},
+		{
+			name: "Update MariaDB",
+			args: args{
+				c: fake.NewClientBuilder().WithObjects(
+					&mariadbv1alpha1.MariaDB{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "foo",
+						},
+					},
+				).Build(),
+				ctx: context.TODO(),
+				newObj: &mariadbv1alpha1.MariaDB{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+				},
+				shouldUpdate: true,
+			},
+		},
+		{
+			name: "Create Database",
+			args: args{
+				c:   fake.NewFakeClient(),
+				ctx: context.TODO(),
+				newObj: &mariadbv1alpha1.Database{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+				},
+				shouldUpdate: false,
+			},
+		},
+		{
+			name: "Create User",
+			args: args{
+				c:   fake.NewFakeClient(),
+				ctx: context.TODO(),
+				newObj: &mariadbv1alpha1.User{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+				},
+				shouldUpdate: false,
+			},
+		},
+		{
+			name: "Create Grant",
+			args: args{
+				c:   fake.NewFakeClient(),
+				ctx: context.TODO(),
+				newObj: &mariadbv1alpha1.Grant{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+				},
+				shouldUpdate: false,
+			},
+		},
 		{
 			name: "Update ConfigMap add OwnerReferences",
 			args: args{
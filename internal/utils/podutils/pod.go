@@ -16,6 +16,11 @@ func IsPodCordon(pod *corev1.Pod) bool {
 	return pod.GetAnnotations()[slinkyv1beta1.AnnotationPodCordon] == "true"
 }
 
+// IsPodSurge returns true if and only if the surge label is set to true.
+func IsPodSurge(pod *corev1.Pod) bool {
+	return pod.GetLabels()[slinkyv1beta1.LabelNodeSetPodSurge] == "true"
+}
+
 // isRunningAndReady returns true if pod is in the PodRunning Phase, if it has a condition of PodReady.
 func IsRunningAndReady(pod *corev1.Pod) bool {
 	return IsRunning(pod) && podutil.IsPodReady(pod)
@@ -10,8 +10,45 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 )
 
+func TestIsPodSurge(t *testing.T) {
+	var podA, podB corev1.Pod
+	podA.Labels = map[string]string{
+		slinkyv1beta1.LabelNodeSetPodSurge: "true",
+	}
+	type args struct {
+		pod *corev1.Pod
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "podA should be a surge pod",
+			args: args{
+				pod: &podA,
+			},
+			want: true,
+		},
+		{
+			name: "podB should not be a surge pod",
+			args: args{
+				pod: &podB,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsPodSurge(tt.args.pod))
+		})
+	}
+}
+
 func TestIsRunningAndReady(t *testing.T) {
 	var podA, podB corev1.Pod
 	podA.Status.Phase = corev1.PodRunning
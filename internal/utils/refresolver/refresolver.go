@@ -5,9 +5,15 @@ package refresolver
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -121,6 +127,74 @@ func (r *RefResolver) GetRestapisForController(ctx context.Context, controller *
 	return out, nil
 }
 
+func (r *RefResolver) GetPartitionsForController(ctx context.Context, controller *slinkyv1beta1.Controller) (*slinkyv1beta1.PartitionList, error) {
+	if controller == nil {
+		return &slinkyv1beta1.PartitionList{}, nil
+	}
+
+	list := &slinkyv1beta1.PartitionList{}
+	if err := r.reader.List(ctx, list, client.InNamespace(controller.Namespace)); err != nil {
+		return nil, err
+	}
+
+	out := &slinkyv1beta1.PartitionList{}
+	for _, item := range list.Items {
+		refKey := types.NamespacedName{
+			Namespace: item.Namespace,
+			Name:      item.Spec.ControllerRef.Name,
+		}
+		if IsKeyMatch(refKey, objectutils.NamespacedName(controller)) {
+			out.Items = append(out.Items, item)
+		}
+	}
+
+	return out, nil
+}
+
+func (r *RefResolver) GetNodeSetsForPartition(ctx context.Context, partition *slinkyv1beta1.Partition) (*slinkyv1beta1.NodeSetList, error) {
+	if partition == nil {
+		return &slinkyv1beta1.NodeSetList{}, nil
+	}
+
+	list := &slinkyv1beta1.NodeSetList{}
+	if err := r.reader.List(ctx, list, client.InNamespace(partition.Namespace)); err != nil {
+		return nil, err
+	}
+
+	out := &slinkyv1beta1.NodeSetList{}
+	for _, item := range list.Items {
+		ok, err := IsPartitionMember(partition, &item)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out.Items = append(out.Items, item)
+		}
+	}
+
+	return out, nil
+}
+
+func (r *RefResolver) GetNodeSetsForReservation(ctx context.Context, reservation *slinkyv1beta1.Reservation) (*slinkyv1beta1.NodeSetList, error) {
+	if reservation == nil {
+		return &slinkyv1beta1.NodeSetList{}, nil
+	}
+
+	list := &slinkyv1beta1.NodeSetList{}
+	if err := r.reader.List(ctx, list, client.InNamespace(reservation.Namespace)); err != nil {
+		return nil, err
+	}
+
+	out := &slinkyv1beta1.NodeSetList{}
+	for _, item := range list.Items {
+		if IsReservationMember(reservation, &item) {
+			out.Items = append(out.Items, item)
+		}
+	}
+
+	return out, nil
+}
+
 func (r *RefResolver) GetControllersForAccounting(ctx context.Context, accounting *slinkyv1beta1.Accounting) (*slinkyv1beta1.ControllerList, error) {
 	if accounting == nil {
 		return &slinkyv1beta1.ControllerList{}, nil
@@ -148,6 +222,35 @@ func (r *RefResolver) GetControllersForAccounting(ctx context.Context, accountin
 	return out, nil
 }
 
+// GetControllerForSlurmdbRef returns the Controller through which Slurm accounting
+// records are managed. When an Accounting is referenced, the first Controller (by
+// name) referencing the Accounting is returned.
+func (r *RefResolver) GetControllerForSlurmdbRef(ctx context.Context, ref slinkyv1beta1.SlurmdbRef, namespace string) (*slinkyv1beta1.Controller, error) {
+	switch {
+	case ref.ControllerRef != nil:
+		return r.GetController(ctx, *ref.ControllerRef, namespace)
+	case ref.AccountingRef != nil:
+		accounting, err := r.GetAccounting(ctx, *ref.AccountingRef, namespace)
+		if err != nil {
+			return nil, err
+		}
+		controllerList, err := r.GetControllersForAccounting(ctx, accounting)
+		if err != nil {
+			return nil, err
+		}
+		if len(controllerList.Items) == 0 {
+			gr := slinkyv1beta1.GroupVersion.WithResource("controllers").GroupResource()
+			return nil, apierrors.NewNotFound(gr, fmt.Sprintf("referencing Accounting %s", accounting.Name))
+		}
+		slices.SortFunc(controllerList.Items, func(a, b slinkyv1beta1.Controller) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		return &controllerList.Items[0], nil
+	default:
+		return nil, errors.New("one of controllerRef or accountingRef is required")
+	}
+}
+
 func (r *RefResolver) GetSecretKeyRef(ctx context.Context, selector corev1.SecretKeySelector, namespace string) ([]byte, error) {
 	secret := &corev1.Secret{}
 	key := types.NamespacedName{
@@ -172,3 +275,60 @@ func IsKeyMatch(key1, key2 types.NamespacedName) bool {
 	}
 	return false
 }
+
+// IsPartitionMember returns true if the NodeSet is selected by the Partition,
+// either by name or by label selector, and both reference the same Controller.
+func IsPartitionMember(partition *slinkyv1beta1.Partition, nodeset *slinkyv1beta1.NodeSet) (bool, error) {
+	if partition == nil || nodeset == nil {
+		return false, nil
+	}
+
+	partitionRefKey := types.NamespacedName{
+		Namespace: partition.Namespace,
+		Name:      partition.Spec.ControllerRef.Name,
+	}
+	nodesetRefKey := types.NamespacedName{
+		Namespace: nodeset.Namespace,
+		Name:      nodeset.Spec.ControllerRef.Name,
+	}
+	if !IsKeyMatch(partitionRefKey, nodesetRefKey) {
+		return false, nil
+	}
+
+	if slices.Contains(partition.Spec.NodeSets, nodeset.Name) {
+		return true, nil
+	}
+
+	if partition.Spec.NodeSetSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(partition.Spec.NodeSetSelector)
+	if err != nil {
+		return false, err
+	}
+	if selector.Empty() {
+		return false, nil
+	}
+	return selector.Matches(labels.Set(nodeset.Labels)), nil
+}
+
+// IsReservationMember returns true if the NodeSet is selected by the Reservation.
+func IsReservationMember(reservation *slinkyv1beta1.Reservation, nodeset *slinkyv1beta1.NodeSet) bool {
+	if reservation == nil || nodeset == nil {
+		return false
+	}
+
+	reservationRefKey := types.NamespacedName{
+		Namespace: reservation.Namespace,
+		Name:      reservation.Spec.ControllerRef.Name,
+	}
+	nodesetRefKey := types.NamespacedName{
+		Namespace: nodeset.Namespace,
+		Name:      nodeset.Spec.ControllerRef.Name,
+	}
+	if !IsKeyMatch(reservationRefKey, nodesetRefKey) {
+		return false
+	}
+
+	return slices.Contains(reservation.Spec.NodeSets, nodeset.Name)
+}
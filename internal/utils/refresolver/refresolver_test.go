@@ -454,6 +454,303 @@ func TestRefResolver_GetRestapisForController(t *testing.T) {
 	}
 }
 
+func TestRefResolver_GetPartitionsForController(t *testing.T) {
+	type fields struct {
+		reader client.Reader
+	}
+	type args struct {
+		ctx        context.Context
+		controller *slinkyv1beta1.Controller
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "slurm",
+						Namespace: metav1.NamespaceDefault,
+					},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "found",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(&slinkyv1beta1.Partition{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "slurm-foo",
+							Namespace: metav1.NamespaceDefault,
+						},
+						Spec: slinkyv1beta1.PartitionSpec{
+							ControllerRef: corev1.LocalObjectReference{
+								Name: "slurm",
+							},
+						},
+					}).
+					WithObjects(&slinkyv1beta1.Partition{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "slurm1",
+							Namespace: metav1.NamespaceDefault,
+						},
+						Spec: slinkyv1beta1.PartitionSpec{
+							ControllerRef: corev1.LocalObjectReference{
+								Name: "slurm1",
+							},
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "slurm",
+						Namespace: metav1.NamespaceDefault,
+					},
+				},
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.fields.reader)
+			got, err := r.GetPartitionsForController(tt.args.ctx, tt.args.controller)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, got.Items, tt.want)
+		})
+	}
+}
+
+func TestRefResolver_GetNodeSetsForPartition(t *testing.T) {
+	newNodeSet := func(name, controllerName string, labels map[string]string) *slinkyv1beta1.NodeSet {
+		return &slinkyv1beta1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+				Labels:    labels,
+			},
+			Spec: slinkyv1beta1.NodeSetSpec{
+				ControllerRef: corev1.LocalObjectReference{
+					Name: controllerName,
+				},
+			},
+		}
+	}
+	type fields struct {
+		reader client.Reader
+	}
+	type args struct {
+		ctx       context.Context
+		partition *slinkyv1beta1.Partition
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "nil",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			want: []string{},
+		},
+		{
+			name: "by name and selector",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(
+						newNodeSet("cpu", "slurm", nil),
+						newNodeSet("gpu", "slurm", map[string]string{"tier": "gpu"}),
+						newNodeSet("other", "slurm", map[string]string{"tier": "cpu"}),
+						newNodeSet("foreign", "slurm1", map[string]string{"tier": "gpu"}),
+					).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				partition: &slinkyv1beta1.Partition{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "all",
+						Namespace: metav1.NamespaceDefault,
+					},
+					Spec: slinkyv1beta1.PartitionSpec{
+						ControllerRef: corev1.LocalObjectReference{
+							Name: "slurm",
+						},
+						NodeSets: []string{"cpu"},
+						NodeSetSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"tier": "gpu"},
+						},
+					},
+				},
+			},
+			want: []string{"cpu", "gpu"},
+		},
+		{
+			name: "bad selector",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(newNodeSet("cpu", "slurm", nil)).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				partition: &slinkyv1beta1.Partition{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "all",
+						Namespace: metav1.NamespaceDefault,
+					},
+					Spec: slinkyv1beta1.PartitionSpec{
+						ControllerRef: corev1.LocalObjectReference{
+							Name: "slurm",
+						},
+						NodeSetSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "tier", Operator: "Bogus"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.fields.reader)
+			got, err := r.GetNodeSetsForPartition(tt.args.ctx, tt.args.partition)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			names := []string{}
+			for _, item := range got.Items {
+				names = append(names, item.Name)
+			}
+			require.ElementsMatch(t, tt.want, names)
+		})
+	}
+}
+
+func TestRefResolver_GetNodeSetsForReservation(t *testing.T) {
+	newNodeSet := func(name, controllerName string) *slinkyv1beta1.NodeSet {
+		return &slinkyv1beta1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: slinkyv1beta1.NodeSetSpec{
+				ControllerRef: corev1.LocalObjectReference{
+					Name: controllerName,
+				},
+			},
+		}
+	}
+	type fields struct {
+		reader client.Reader
+	}
+	type args struct {
+		ctx         context.Context
+		reservation *slinkyv1beta1.Reservation
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   []string
+	}{
+		{
+			name: "nil",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+			},
+			want: []string{},
+		},
+		{
+			name: "by name",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(
+						newNodeSet("cpu", "slurm"),
+						newNodeSet("gpu", "slurm"),
+						newNodeSet("foreign", "slurm1"),
+					).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				reservation: &slinkyv1beta1.Reservation{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "maint",
+						Namespace: metav1.NamespaceDefault,
+					},
+					Spec: slinkyv1beta1.ReservationSpec{
+						ControllerRef: corev1.LocalObjectReference{
+							Name: "slurm",
+						},
+						NodeSets: []string{"gpu", "foreign"},
+					},
+				},
+			},
+			want: []string{"gpu"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.fields.reader)
+			got, err := r.GetNodeSetsForReservation(tt.args.ctx, tt.args.reservation)
+			require.NoError(t, err)
+			names := []string{}
+			for _, item := range got.Items {
+				names = append(names, item.Name)
+			}
+			require.ElementsMatch(t, tt.want, names)
+		})
+	}
+}
+
 func TestRefResolver_GetControllersForAccounting(t *testing.T) {
 	type fields struct {
 		reader client.Reader
@@ -544,6 +841,136 @@ func TestRefResolver_GetControllersForAccounting(t *testing.T) {
 	}
 }
 
+func TestRefResolver_GetControllerForSlurmdbRef(t *testing.T) {
+	newController := func(name, accountingName string) *slinkyv1beta1.Controller {
+		controller := &slinkyv1beta1.Controller{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+			},
+		}
+		if accountingName != "" {
+			controller.Spec.AccountingRef = &corev1.LocalObjectReference{Name: accountingName}
+		}
+		return controller
+	}
+	accounting := &slinkyv1beta1.Accounting{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "slurm",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	type fields struct {
+		reader client.Reader
+	}
+	type args struct {
+		ctx       context.Context
+		ref       slinkyv1beta1.SlurmdbRef
+		namespace string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "empty ref",
+			fields: fields{
+				reader: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			},
+			args: args{
+				ctx:       context.TODO(),
+				namespace: metav1.NamespaceDefault,
+			},
+			wantErr: true,
+		},
+		{
+			name: "controllerRef",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(newController("slurm", "")).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				ref: slinkyv1beta1.SlurmdbRef{
+					ControllerRef: &corev1.LocalObjectReference{Name: "slurm"},
+				},
+				namespace: metav1.NamespaceDefault,
+			},
+			want: "slurm",
+		},
+		{
+			name: "controllerRef not found",
+			fields: fields{
+				reader: fake.NewClientBuilder().WithScheme(scheme).Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				ref: slinkyv1beta1.SlurmdbRef{
+					ControllerRef: &corev1.LocalObjectReference{Name: "slurm"},
+				},
+				namespace: metav1.NamespaceDefault,
+			},
+			wantErr: true,
+		},
+		{
+			name: "accountingRef",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(accounting.DeepCopy()).
+					WithObjects(newController("slurm-b", "slurm")).
+					WithObjects(newController("slurm-a", "slurm")).
+					WithObjects(newController("other", "")).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				ref: slinkyv1beta1.SlurmdbRef{
+					AccountingRef: &corev1.LocalObjectReference{Name: "slurm"},
+				},
+				namespace: metav1.NamespaceDefault,
+			},
+			want: "slurm-a",
+		},
+		{
+			name: "accountingRef without controller",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(accounting.DeepCopy()).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				ref: slinkyv1beta1.SlurmdbRef{
+					AccountingRef: &corev1.LocalObjectReference{Name: "slurm"},
+				},
+				namespace: metav1.NamespaceDefault,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.fields.reader)
+			got, err := r.GetControllerForSlurmdbRef(tt.args.ctx, tt.args.ref, tt.args.namespace)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.Name)
+		})
+	}
+}
+
 func TestRefResolver_GetSecretKeyRef(t *testing.T) {
 	type fields struct {
 		reader client.Reader
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package reservationutils
+
+import (
+	"strings"
+	"time"
+
+	"github.com/puttsk/hostlist"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+)
+
+// ReoccuringInfoFlags are the flags which make a Slurm reservation reoccur.
+var ReoccuringInfoFlags = []slurmapi.V0044ReservationInfoFlags{
+	slurmapi.V0044ReservationInfoFlagsHOURLY,
+	slurmapi.V0044ReservationInfoFlagsDAILY,
+	slurmapi.V0044ReservationInfoFlagsWEEKLY,
+	slurmapi.V0044ReservationInfoFlagsWEEKEND,
+	slurmapi.V0044ReservationInfoFlagsWEEKDAY,
+}
+
+// IsActive() returns a boolean value based on whether the reservation
+// that it is passed is currently running. Once the Slurm RestAPI provides a
+// slurmapi.V0044ReservationInfoStatus field, this helper function should be
+// deleted in favor of using that field directly.
+func IsActive(reservation slurmtypes.V0044ReservationInfo) bool {
+	if reservation.StartTime == nil || reservation.EndTime == nil {
+		return false
+	}
+	start := time.Unix(ptr.Deref(reservation.StartTime.Number, 0), 0)
+	end := time.Unix(ptr.Deref(reservation.EndTime.Number, 0), 0)
+
+	now := time.Now().In(time.UTC)
+	if start.Before(now) && end.After(now) {
+		return true
+	}
+
+	return false
+}
+
+// IsNodeListMatch() returns true if both reservations expand to the same nodes.
+func IsNodeListMatch(old slurmtypes.V0044ReservationInfo, new slurmtypes.V0044ReservationInfo) bool {
+	if old.NodeList != nil {
+		newNodeList, _ := hostlist.Expand(ptr.Deref(new.NodeList, ""))
+		oldNodeList, _ := hostlist.Expand(*old.NodeList)
+		if apiequality.Semantic.DeepEqual(newNodeList, oldNodeList) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasFlags() returns true if the reservation has any (or all) of the flags.
+func HasFlags(reservation slurmtypes.V0044ReservationInfo, flags []slurmapi.V0044ReservationInfoFlags, anyFlags bool) bool {
+	if reservation.Flags == nil && len(flags) > 0 {
+		return false
+	}
+
+	flagSet := set.New(flags...)
+	resFlags := set.New(ptr.Deref(reservation.Flags, nil)...)
+	intersection := flagSet.Intersection(resFlags)
+
+	if anyFlags {
+		return intersection.Len() > 0
+	} else {
+		return intersection.Len() == len(flags)
+	}
+}
+
+// ParseFlags() returns the sorted union of the required flags and the user flags.
+func ParseFlags[T slurmapi.V0044ReservationInfoFlags | slurmapi.V0044ReservationDescMsgFlags](flags []string, reqFlags []T) []T {
+	// Required flags
+	flagSet := set.New(reqFlags...)
+
+	// User flags
+	for _, flag := range flags {
+		f := T(strings.ToUpper(flag))
+		flagSet.Insert(f)
+	}
+
+	return flagSet.SortedList()
+}
+
+// TimeToUint64NoVal() converts a time.Time into a Slurm timestamp.
+func TimeToUint64NoVal(t time.Time) slurmapi.V0044Uint64NoValStruct {
+	return slurmapi.V0044Uint64NoValStruct{
+		Infinite: new(false),
+		Number:   ptr.To(t.Unix()),
+		Set:      new(true),
+	}
+}
+
+// DurationToUint32NoVal() converts a time.Duration into Slurm minutes.
+func DurationToUint32NoVal(d time.Duration) slurmapi.V0044Uint32NoValStruct {
+	return slurmapi.V0044Uint32NoValStruct{
+		Infinite: new(false),
+		Number:   ptr.To(int32(d.Minutes())),
+		Set:      new(true),
+	}
+}
+
+// Uint64NoValToTime() converts a Slurm timestamp into a time.Time.
+// The zero time is returned when the value is not set.
+func Uint64NoValToTime(v *slurmapi.V0044Uint64NoValStruct) time.Time {
+	if v == nil || v.Number == nil || ptr.Deref(v.Infinite, false) {
+		return time.Time{}
+	}
+	return time.Unix(*v.Number, 0)
+}
@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package reservationutils
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+)
+
+func newReservationInfo(start, end time.Time, nodeList string, flags ...slurmapi.V0044ReservationInfoFlags) slurmtypes.V0044ReservationInfo {
+	startTime := TimeToUint64NoVal(start)
+	endTime := TimeToUint64NoVal(end)
+	return slurmtypes.V0044ReservationInfo{
+		V0044ReservationInfo: slurmapi.V0044ReservationInfo{
+			Name:      ptr.To("foo"),
+			StartTime: &startTime,
+			EndTime:   &endTime,
+			NodeList:  ptr.To(nodeList),
+			Flags:     &flags,
+		},
+	}
+}
+
+func TestIsActive(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		reservation slurmtypes.V0044ReservationInfo
+		want        bool
+	}{
+		{
+			name:        "Active",
+			reservation: newReservationInfo(now.Add(-time.Hour), now.Add(time.Hour), ""),
+			want:        true,
+		},
+		{
+			name:        "Future",
+			reservation: newReservationInfo(now.Add(time.Hour), now.Add(2*time.Hour), ""),
+			want:        false,
+		},
+		{
+			name:        "Past",
+			reservation: newReservationInfo(now.Add(-2*time.Hour), now.Add(-time.Hour), ""),
+			want:        false,
+		},
+		{
+			name:        "Empty",
+			reservation: slurmtypes.V0044ReservationInfo{},
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsActive(tt.reservation); got != tt.want {
+				t.Errorf("IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNodeListMatch(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		old  slurmtypes.V0044ReservationInfo
+		new  slurmtypes.V0044ReservationInfo
+		want bool
+	}{
+		{
+			name: "Same hostlist",
+			old:  newReservationInfo(now, now, "node-[0-2]"),
+			new:  newReservationInfo(now, now, "node-0,node-1,node-2"),
+			want: true,
+		},
+		{
+			name: "Different hostlist",
+			old:  newReservationInfo(now, now, "node-[0-2]"),
+			new:  newReservationInfo(now, now, "node-[0-3]"),
+			want: false,
+		},
+		{
+			name: "No old hostlist",
+			old:  slurmtypes.V0044ReservationInfo{},
+			new:  newReservationInfo(now, now, "node-0"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNodeListMatch(tt.old, tt.new); got != tt.want {
+				t.Errorf("IsNodeListMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasFlags(t *testing.T) {
+	now := time.Now()
+	reservation := newReservationInfo(now, now, "",
+		slurmapi.V0044ReservationInfoFlagsMAINT, slurmapi.V0044ReservationInfoFlagsDAILY)
+	type args struct {
+		flags    []slurmapi.V0044ReservationInfoFlags
+		anyFlags bool
+	}
+	tests := []struct {
+		name        string
+		reservation slurmtypes.V0044ReservationInfo
+		args        args
+		want        bool
+	}{
+		{
+			name:        "Any, match",
+			reservation: reservation,
+			args: args{
+				flags:    ReoccuringInfoFlags,
+				anyFlags: true,
+			},
+			want: true,
+		},
+		{
+			name:        "All, no match",
+			reservation: reservation,
+			args: args{
+				flags:    ReoccuringInfoFlags,
+				anyFlags: false,
+			},
+			want: false,
+		},
+		{
+			name:        "All, match",
+			reservation: reservation,
+			args: args{
+				flags:    []slurmapi.V0044ReservationInfoFlags{slurmapi.V0044ReservationInfoFlagsMAINT, slurmapi.V0044ReservationInfoFlagsDAILY},
+				anyFlags: false,
+			},
+			want: true,
+		},
+		{
+			name:        "No flags",
+			reservation: slurmtypes.V0044ReservationInfo{},
+			args: args{
+				flags:    ReoccuringInfoFlags,
+				anyFlags: true,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasFlags(tt.reservation, tt.args.flags, tt.args.anyFlags); got != tt.want {
+				t.Errorf("HasFlags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	got := ParseFlags([]string{"daily", "MAINT"}, []slurmapi.V0044ReservationDescMsgFlags{slurmapi.V0044ReservationDescMsgFlagsMAINT})
+	want := []slurmapi.V0044ReservationDescMsgFlags{"DAILY", slurmapi.V0044ReservationDescMsgFlagsMAINT}
+	if len(got) != len(want) {
+		t.Fatalf("ParseFlags() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ParseFlags() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestUint64NoValToTime(t *testing.T) {
+	now := time.Unix(time.Now().Unix(), 0)
+	tests := []struct {
+		name string
+		v    *slurmapi.V0044Uint64NoValStruct
+		want time.Time
+	}{
+		{
+			name: "Set",
+			v:    ptr.To(TimeToUint64NoVal(now)),
+			want: now,
+		},
+		{
+			name: "Nil",
+			v:    nil,
+			want: time.Time{},
+		},
+		{
+			name: "Infinite",
+			v: &slurmapi.V0044Uint64NoValStruct{
+				Infinite: new(true),
+				Number:   ptr.To[int64](0),
+			},
+			want: time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Uint64NoValToTime(tt.v); !got.Equal(tt.want) {
+				t.Errorf("Uint64NoValToTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
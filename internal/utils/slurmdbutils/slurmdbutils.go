@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdbutils
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// clearedTresCount is the TRES count which clears a TRES limit in Slurm.
+const clearedTresCount = -1
+
+// Int32ToUint32NoVal() converts a limit to its Slurm representation, where
+// an unset limit is infinite (i.e. cleared).
+func Int32ToUint32NoVal(limit *int32) *slurmapi.V0044Uint32NoValStruct {
+	if limit == nil {
+		return &slurmapi.V0044Uint32NoValStruct{
+			Infinite: ptr.To(true),
+			Set:      ptr.To(true),
+		}
+	}
+	return &slurmapi.V0044Uint32NoValStruct{
+		Number: ptr.To(*limit),
+		Set:    ptr.To(true),
+	}
+}
+
+// Uint32NoValToInt32() converts a Slurm limit, returning nil when the limit is
+// not set or infinite.
+func Uint32NoValToInt32(limit *slurmapi.V0044Uint32NoValStruct) *int32 {
+	if limit == nil || limit.Number == nil ||
+		!ptr.Deref(limit.Set, false) || ptr.Deref(limit.Infinite, false) {
+		return nil
+	}
+	return ptr.To(*limit.Number)
+}
+
+// DurationToMinutes() converts a time limit to its Slurm representation in
+// minutes, where an unset time limit is infinite (i.e. cleared).
+func DurationToMinutes(duration *metav1.Duration) *slurmapi.V0044Uint32NoValStruct {
+	if duration == nil {
+		return Int32ToUint32NoVal(nil)
+	}
+	return Int32ToUint32NoVal(ptr.To(int32(duration.Duration / time.Minute)))
+}
+
+// MinutesToDuration() converts a Slurm time limit in minutes, returning nil
+// when the time limit is not set or infinite.
+func MinutesToDuration(limit *slurmapi.V0044Uint32NoValStruct) *metav1.Duration {
+	minutes := Uint32NoValToInt32(limit)
+	if minutes == nil {
+		return nil
+	}
+	return &metav1.Duration{Duration: time.Duration(*minutes) * time.Minute}
+}
+
+// TresMapToList() converts TRES limits to their Slurm representation, sorted
+// by TRES. The TRES which are only in the observed limits are cleared.
+func TresMapToList(tres, observed map[string]int64) *slurmapi.V0044TresList {
+	keys := slices.Collect(maps.Keys(tres))
+	for key := range observed {
+		if _, ok := tres[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	list := make(slurmapi.V0044TresList, 0, len(keys))
+	for _, key := range keys {
+		count, ok := tres[key]
+		if !ok {
+			count = clearedTresCount
+		}
+		tresType, tresName, _ := strings.Cut(key, "/")
+		item := slurmapi.V0044Tres{
+			Type:  tresType,
+			Count: ptr.To(count),
+		}
+		if tresName != "" {
+			item.Name = ptr.To(tresName)
+		}
+		list = append(list, item)
+	}
+	return &list
+}
+
+// TresListToMap() converts Slurm TRES limits, keyed by TRES (e.g. "cpu",
+// "gres/gpu"). Cleared TRES are omitted and nil is returned when empty.
+func TresListToMap(list *slurmapi.V0044TresList) map[string]int64 {
+	if list == nil {
+		return nil
+	}
+	var out map[string]int64
+	for _, item := range *list {
+		count := ptr.Deref(item.Count, clearedTresCount)
+		if count < 0 {
+			continue
+		}
+		key := item.Type
+		if name := ptr.Deref(item.Name, ""); name != "" {
+			key += "/" + name
+		}
+		if out == nil {
+			out = make(map[string]int64)
+		}
+		out[key] = count
+	}
+	return out
+}
+
+// SetAssociationLimits() sets the limits on the Slurm association. Limits
+// which are only in the observed limits are cleared.
+func SetAssociationLimits(assoc *slurmapi.V0044Assoc, limits, observed slinkyv1beta1.AssociationLimits) {
+	assoc.SharesRaw = limits.Fairshare
+	assoc.Priority = Int32ToUint32NoVal(limits.Priority)
+	assoc.Max = &slurmapi.V0044AssocMax{
+		Jobs: &slurmapi.V0044AssocMaxJobs{
+			Active: Int32ToUint32NoVal(limits.GrpJobs),
+			Total:  Int32ToUint32NoVal(limits.GrpSubmitJobs),
+			Per: &slurmapi.V0044AssocMaxJobsPer{
+				Count:     Int32ToUint32NoVal(limits.MaxJobs),
+				Submitted: Int32ToUint32NoVal(limits.MaxSubmitJobs),
+				WallClock: DurationToMinutes(limits.MaxWallDurationPerJob),
+			},
+		},
+		Tres: &slurmapi.V0044AssocMaxTres{
+			Total: TresMapToList(limits.GrpTRES, observed.GrpTRES),
+			Per: &slurmapi.V0044AssocMaxTresPer{
+				Job:  TresMapToList(limits.MaxTRESPerJob, observed.MaxTRESPerJob),
+				Node: TresMapToList(limits.MaxTRESPerNode, observed.MaxTRESPerNode),
+			},
+		},
+	}
+}
+
+// GetAssociationLimits() returns the limits of the Slurm association.
+func GetAssociationLimits(assoc slurmapi.V0044Assoc) slinkyv1beta1.AssociationLimits {
+	limits := slinkyv1beta1.AssociationLimits{
+		Fairshare: assoc.SharesRaw,
+		Priority:  Uint32NoValToInt32(assoc.Priority),
+	}
+	if assoc.Max == nil {
+		return limits
+	}
+	if jobs := assoc.Max.Jobs; jobs != nil {
+		limits.GrpJobs = Uint32NoValToInt32(jobs.Active)
+		limits.GrpSubmitJobs = Uint32NoValToInt32(jobs.Total)
+		if per := jobs.Per; per != nil {
+			limits.MaxJobs = Uint32NoValToInt32(per.Count)
+			limits.MaxSubmitJobs = Uint32NoValToInt32(per.Submitted)
+			limits.MaxWallDurationPerJob = MinutesToDuration(per.WallClock)
+		}
+	}
+	if tres := assoc.Max.Tres; tres != nil {
+		limits.GrpTRES = TresListToMap(tres.Total)
+		if per := tres.Per; per != nil {
+			limits.MaxTRESPerJob = TresListToMap(per.Job)
+			limits.MaxTRESPerNode = TresListToMap(per.Node)
+		}
+	}
+	return limits
+}
+
+// IsAssociationLimitsMatch() returns true if the Slurm association has the
+// limits, as Slurm would represent them.
+func IsAssociationLimitsMatch(assoc slurmapi.V0044Assoc, limits slinkyv1beta1.AssociationLimits) bool {
+	desired := slurmapi.V0044Assoc{}
+	SetAssociationLimits(&desired, limits, slinkyv1beta1.AssociationLimits{})
+	observed := GetAssociationLimits(assoc)
+	// An unset fairshare is left to Slurm.
+	if limits.Fairshare == nil {
+		observed.Fairshare = nil
+	}
+	return apiequality.Semantic.DeepEqual(observed, GetAssociationLimits(desired))
+}
+
+// IsQosListMatch() returns true if the QOS lists contain the same QOS.
+func IsQosListMatch(old, new []string) bool {
+	return slices.Equal(sortedUnique(old), sortedUnique(new))
+}
+
+func sortedUnique(list []string) []string {
+	out := slices.Clone(list)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// SetQosLimits() sets the limits on the Slurm QOS. Limits which are only in
+// the observed limits are cleared.
+func SetQosLimits(qos *slurmapi.V0044Qos, limits, observed slinkyv1beta1.QOSLimits) {
+	qos.Limits = &slurmapi.V0044QosLimits{
+		Max: &slurmapi.V0044QosLimitsMax{
+			ActiveJobs: &slurmapi.V0044QosLimitsMaxActiveJobs{
+				Count: Int32ToUint32NoVal(limits.GrpJobs),
+			},
+			Jobs: &slurmapi.V0044QosLimitsMaxJobs{
+				ActiveJobs: &slurmapi.V0044QosLimitsMaxJobsActiveJobs{
+					Per: &slurmapi.V0044QosLimitsMaxJobsPer{
+						Account: Int32ToUint32NoVal(limits.MaxJobsPerAccount),
+						User:    Int32ToUint32NoVal(limits.MaxJobsPerUser),
+					},
+				},
+				Per: &slurmapi.V0044QosLimitsMaxJobsPer{
+					Account: Int32ToUint32NoVal(limits.MaxSubmitJobsPerAccount),
+					User:    Int32ToUint32NoVal(limits.MaxSubmitJobsPerUser),
+				},
+			},
+			Tres: &slurmapi.V0044QosLimitsMaxTres{
+				Total: TresMapToList(limits.GrpTRES, observed.GrpTRES),
+				Per: &slurmapi.V0044QosLimitsMaxTresPer{
+					Job:  TresMapToList(limits.MaxTRESPerJob, observed.MaxTRESPerJob),
+					Node: TresMapToList(limits.MaxTRESPerNode, observed.MaxTRESPerNode),
+					User: TresMapToList(limits.MaxTRESPerUser, observed.MaxTRESPerUser),
+				},
+			},
+			WallClock: &slurmapi.V0044QosLimitsMaxWallClock{
+				Per: &slurmapi.V0044QosLimitsMaxWallClockPer{
+					Job: DurationToMinutes(limits.MaxWallDurationPerJob),
+				},
+			},
+		},
+	}
+}
+
+// GetQosLimits() returns the limits of the Slurm QOS.
+func GetQosLimits(qos slurmapi.V0044Qos) slinkyv1beta1.QOSLimits {
+	limits := slinkyv1beta1.QOSLimits{}
+	if qos.Limits == nil || qos.Limits.Max == nil {
+		return limits
+	}
+	maxLimits := qos.Limits.Max
+	if maxLimits.ActiveJobs != nil {
+		limits.GrpJobs = Uint32NoValToInt32(maxLimits.ActiveJobs.Count)
+	}
+	if jobs := maxLimits.Jobs; jobs != nil {
+		if jobs.ActiveJobs != nil && jobs.ActiveJobs.Per != nil {
+			limits.MaxJobsPerAccount = Uint32NoValToInt32(jobs.ActiveJobs.Per.Account)
+			limits.MaxJobsPerUser = Uint32NoValToInt32(jobs.ActiveJobs.Per.User)
+		}
+		if jobs.Per != nil {
+			limits.MaxSubmitJobsPerAccount = Uint32NoValToInt32(jobs.Per.Account)
+			limits.MaxSubmitJobsPerUser = Uint32NoValToInt32(jobs.Per.User)
+		}
+	}
+	if tres := maxLimits.Tres; tres != nil {
+		limits.GrpTRES = TresListToMap(tres.Total)
+		if per := tres.Per; per != nil {
+			limits.MaxTRESPerJob = TresListToMap(per.Job)
+			limits.MaxTRESPerNode = TresListToMap(per.Node)
+			limits.MaxTRESPerUser = TresListToMap(per.User)
+		}
+	}
+	if maxLimits.WallClock != nil && maxLimits.WallClock.Per != nil {
+		limits.MaxWallDurationPerJob = MinutesToDuration(maxLimits.WallClock.Per.Job)
+	}
+	return limits
+}
+
+// IsQosLimitsMatch() returns true if the Slurm QOS has the limits, as Slurm
+// would represent them.
+func IsQosLimitsMatch(qos slurmapi.V0044Qos, limits slinkyv1beta1.QOSLimits) bool {
+	desired := slurmapi.V0044Qos{}
+	SetQosLimits(&desired, limits, slinkyv1beta1.QOSLimits{})
+	return apiequality.Semantic.DeepEqual(GetQosLimits(qos), GetQosLimits(desired))
+}
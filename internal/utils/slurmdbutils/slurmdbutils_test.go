@@ -0,0 +1,407 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdbutils
+
+import (
+	"testing"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func TestInt32ToUint32NoVal(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit *int32
+		want  *int32
+	}{
+		{
+			name:  "Unset",
+			limit: nil,
+			want:  nil,
+		},
+		{
+			name:  "Zero",
+			limit: ptr.To[int32](0),
+			want:  ptr.To[int32](0),
+		},
+		{
+			name:  "Set",
+			limit: ptr.To[int32](10),
+			want:  ptr.To[int32](10),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noVal := Int32ToUint32NoVal(tt.limit)
+			if !ptr.Deref(noVal.Set, false) {
+				t.Errorf("Int32ToUint32NoVal() = %v, want set", noVal)
+			}
+			if got := Uint32NoValToInt32(noVal); !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("Uint32NoValToInt32() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUint32NoValToInt32(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit *slurmapi.V0044Uint32NoValStruct
+		want  *int32
+	}{
+		{
+			name:  "Nil",
+			limit: nil,
+			want:  nil,
+		},
+		{
+			name:  "Not set",
+			limit: &slurmapi.V0044Uint32NoValStruct{Number: ptr.To[int32](1), Set: ptr.To(false)},
+			want:  nil,
+		},
+		{
+			name:  "Infinite",
+			limit: &slurmapi.V0044Uint32NoValStruct{Infinite: ptr.To(true), Set: ptr.To(true)},
+			want:  nil,
+		},
+		{
+			name:  "Number",
+			limit: &slurmapi.V0044Uint32NoValStruct{Number: ptr.To[int32](1), Set: ptr.To(true)},
+			want:  ptr.To[int32](1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Uint32NoValToInt32(tt.limit); !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("Uint32NoValToInt32() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationToMinutes(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration *metav1.Duration
+		want     *metav1.Duration
+	}{
+		{
+			name:     "Unset",
+			duration: nil,
+			want:     nil,
+		},
+		{
+			name:     "Minutes",
+			duration: &metav1.Duration{Duration: 90 * time.Minute},
+			want:     &metav1.Duration{Duration: 90 * time.Minute},
+		},
+		{
+			name:     "Truncated",
+			duration: &metav1.Duration{Duration: 90 * time.Second},
+			want:     &metav1.Duration{Duration: time.Minute},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MinutesToDuration(DurationToMinutes(tt.duration)); !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("MinutesToDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTresMapToList(t *testing.T) {
+	tests := []struct {
+		name     string
+		tres     map[string]int64
+		observed map[string]int64
+		want     slurmapi.V0044TresList
+	}{
+		{
+			name: "Empty",
+			want: slurmapi.V0044TresList{},
+		},
+		{
+			name: "Sorted",
+			tres: map[string]int64{"mem": 1024, "cpu": 8, "gres/gpu": 2},
+			want: slurmapi.V0044TresList{
+				{Type: "cpu", Count: ptr.To[int64](8)},
+				{Type: "gres", Name: ptr.To("gpu"), Count: ptr.To[int64](2)},
+				{Type: "mem", Count: ptr.To[int64](1024)},
+			},
+		},
+		{
+			name:     "Cleared",
+			tres:     map[string]int64{"cpu": 8},
+			observed: map[string]int64{"cpu": 4, "gres/gpu": 2},
+			want: slurmapi.V0044TresList{
+				{Type: "cpu", Count: ptr.To[int64](8)},
+				{Type: "gres", Name: ptr.To("gpu"), Count: ptr.To[int64](-1)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TresMapToList(tt.tres, tt.observed)
+			if !apiequality.Semantic.DeepEqual(*got, tt.want) {
+				t.Errorf("TresMapToList() = %v, want %v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTresListToMap(t *testing.T) {
+	tests := []struct {
+		name string
+		list *slurmapi.V0044TresList
+		want map[string]int64
+	}{
+		{
+			name: "Nil",
+			list: nil,
+			want: nil,
+		},
+		{
+			name: "Cleared",
+			list: &slurmapi.V0044TresList{
+				{Type: "cpu", Count: ptr.To[int64](-1)},
+			},
+			want: nil,
+		},
+		{
+			name: "TRES",
+			list: &slurmapi.V0044TresList{
+				{Type: "cpu", Count: ptr.To[int64](8)},
+				{Type: "gres", Name: ptr.To("gpu"), Count: ptr.To[int64](2)},
+				{Type: "mem", Count: ptr.To[int64](-1)},
+			},
+			want: map[string]int64{"cpu": 8, "gres/gpu": 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TresListToMap(tt.list); !apiequality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("TresListToMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAssociationLimits(t *testing.T) {
+	limits := slinkyv1beta1.AssociationLimits{
+		Fairshare:             ptr.To[int32](10),
+		Priority:              ptr.To[int32](100),
+		GrpJobs:               ptr.To[int32](20),
+		GrpSubmitJobs:         ptr.To[int32](40),
+		GrpTRES:               map[string]int64{"cpu": 128},
+		MaxJobs:               ptr.To[int32](5),
+		MaxSubmitJobs:         ptr.To[int32](10),
+		MaxTRESPerJob:         map[string]int64{"gres/gpu": 4},
+		MaxTRESPerNode:        map[string]int64{"mem": 1024},
+		MaxWallDurationPerJob: &metav1.Duration{Duration: time.Hour},
+	}
+	tests := []struct {
+		name   string
+		limits slinkyv1beta1.AssociationLimits
+	}{
+		{
+			name:   "Empty",
+			limits: slinkyv1beta1.AssociationLimits{},
+		},
+		{
+			name:   "Limits",
+			limits: limits,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assoc := slurmapi.V0044Assoc{}
+			SetAssociationLimits(&assoc, tt.limits, slinkyv1beta1.AssociationLimits{})
+			if got := GetAssociationLimits(assoc); !apiequality.Semantic.DeepEqual(got, tt.limits) {
+				t.Errorf("GetAssociationLimits() = %v, want %v", got, tt.limits)
+			}
+		})
+	}
+}
+
+func TestIsAssociationLimitsMatch(t *testing.T) {
+	newAssoc := func(limits slinkyv1beta1.AssociationLimits) slurmapi.V0044Assoc {
+		assoc := slurmapi.V0044Assoc{}
+		SetAssociationLimits(&assoc, limits, slinkyv1beta1.AssociationLimits{})
+		return assoc
+	}
+	tests := []struct {
+		name   string
+		assoc  slurmapi.V0044Assoc
+		limits slinkyv1beta1.AssociationLimits
+		want   bool
+	}{
+		{
+			name:   "Empty",
+			assoc:  slurmapi.V0044Assoc{},
+			limits: slinkyv1beta1.AssociationLimits{},
+			want:   true,
+		},
+		{
+			name:   "Match",
+			assoc:  newAssoc(slinkyv1beta1.AssociationLimits{MaxJobs: ptr.To[int32](5)}),
+			limits: slinkyv1beta1.AssociationLimits{MaxJobs: ptr.To[int32](5)},
+			want:   true,
+		},
+		{
+			name:   "Fairshare left to Slurm",
+			assoc:  newAssoc(slinkyv1beta1.AssociationLimits{Fairshare: ptr.To[int32](1)}),
+			limits: slinkyv1beta1.AssociationLimits{},
+			want:   true,
+		},
+		{
+			name:   "Wall clock in minutes",
+			assoc:  newAssoc(slinkyv1beta1.AssociationLimits{MaxWallDurationPerJob: &metav1.Duration{Duration: time.Minute}}),
+			limits: slinkyv1beta1.AssociationLimits{MaxWallDurationPerJob: &metav1.Duration{Duration: 90 * time.Second}},
+			want:   true,
+		},
+		{
+			name:   "Limit changed",
+			assoc:  newAssoc(slinkyv1beta1.AssociationLimits{MaxJobs: ptr.To[int32](5)}),
+			limits: slinkyv1beta1.AssociationLimits{MaxJobs: ptr.To[int32](10)},
+			want:   false,
+		},
+		{
+			name:   "Limit removed",
+			assoc:  newAssoc(slinkyv1beta1.AssociationLimits{GrpTRES: map[string]int64{"cpu": 8}}),
+			limits: slinkyv1beta1.AssociationLimits{},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAssociationLimitsMatch(tt.assoc, tt.limits); got != tt.want {
+				t.Errorf("IsAssociationLimitsMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsQosListMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		old  []string
+		new  []string
+		want bool
+	}{
+		{
+			name: "Empty",
+			want: true,
+		},
+		{
+			name: "Unordered",
+			old:  []string{"normal", "high"},
+			new:  []string{"high", "normal"},
+			want: true,
+		},
+		{
+			name: "Different",
+			old:  []string{"normal"},
+			new:  []string{"normal", "high"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsQosListMatch(tt.old, tt.new); got != tt.want {
+				t.Errorf("IsQosListMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetQosLimits(t *testing.T) {
+	limits := slinkyv1beta1.QOSLimits{
+		GrpJobs:                 ptr.To[int32](100),
+		GrpTRES:                 map[string]int64{"cpu": 1024},
+		MaxJobsPerAccount:       ptr.To[int32](50),
+		MaxJobsPerUser:          ptr.To[int32](10),
+		MaxSubmitJobsPerAccount: ptr.To[int32](500),
+		MaxSubmitJobsPerUser:    ptr.To[int32](100),
+		MaxTRESPerJob:           map[string]int64{"gres/gpu": 8},
+		MaxTRESPerNode:          map[string]int64{"mem": 4096},
+		MaxTRESPerUser:          map[string]int64{"cpu": 256},
+		MaxWallDurationPerJob:   &metav1.Duration{Duration: 24 * time.Hour},
+	}
+	tests := []struct {
+		name   string
+		limits slinkyv1beta1.QOSLimits
+	}{
+		{
+			name:   "Empty",
+			limits: slinkyv1beta1.QOSLimits{},
+		},
+		{
+			name:   "Limits",
+			limits: limits,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qos := slurmapi.V0044Qos{}
+			SetQosLimits(&qos, tt.limits, slinkyv1beta1.QOSLimits{})
+			if got := GetQosLimits(qos); !apiequality.Semantic.DeepEqual(got, tt.limits) {
+				t.Errorf("GetQosLimits() = %v, want %v", got, tt.limits)
+			}
+		})
+	}
+}
+
+func TestIsQosLimitsMatch(t *testing.T) {
+	newQos := func(limits slinkyv1beta1.QOSLimits) slurmapi.V0044Qos {
+		qos := slurmapi.V0044Qos{}
+		SetQosLimits(&qos, limits, slinkyv1beta1.QOSLimits{})
+		return qos
+	}
+	tests := []struct {
+		name   string
+		qos    slurmapi.V0044Qos
+		limits slinkyv1beta1.QOSLimits
+		want   bool
+	}{
+		{
+			name:   "Empty",
+			qos:    slurmapi.V0044Qos{},
+			limits: slinkyv1beta1.QOSLimits{},
+			want:   true,
+		},
+		{
+			name:   "Match",
+			qos:    newQos(slinkyv1beta1.QOSLimits{MaxJobsPerUser: ptr.To[int32](5)}),
+			limits: slinkyv1beta1.QOSLimits{MaxJobsPerUser: ptr.To[int32](5)},
+			want:   true,
+		},
+		{
+			name:   "Limit changed",
+			qos:    newQos(slinkyv1beta1.QOSLimits{MaxJobsPerUser: ptr.To[int32](5)}),
+			limits: slinkyv1beta1.QOSLimits{MaxJobsPerUser: ptr.To[int32](10)},
+			want:   false,
+		},
+		{
+			name:   "Limit removed",
+			qos:    newQos(slinkyv1beta1.QOSLimits{MaxTRESPerUser: map[string]int64{"cpu": 8}}),
+			limits: slinkyv1beta1.QOSLimits{},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsQosLimitsMatch(tt.qos, tt.limits); got != tt.want {
+				t.Errorf("IsQosLimitsMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
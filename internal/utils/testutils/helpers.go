@@ -257,6 +257,130 @@ func NewRestapi(name string, controller *slinkyv1beta1.Controller) *slinkyv1beta
 	}
 }
 
+func NewPartition(name string, controller *slinkyv1beta1.Controller, nodesets ...*slinkyv1beta1.NodeSet) *slinkyv1beta1.Partition {
+	var controllerRef corev1.LocalObjectReference
+	if controller != nil {
+		controllerRef = corev1.LocalObjectReference{
+			Name: controller.Name,
+		}
+	}
+	nodesetNames := make([]string, 0, len(nodesets))
+	for _, nodeset := range nodesets {
+		nodesetNames = append(nodesetNames, nodeset.Name)
+	}
+	return &slinkyv1beta1.Partition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.PartitionAPIVersion,
+			Kind:       slinkyv1beta1.PartitionKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.PartitionSpec{
+			ControllerRef: controllerRef,
+			NodeSets:      nodesetNames,
+		},
+	}
+}
+
+func NewReservation(name string, controller *slinkyv1beta1.Controller, nodesets ...*slinkyv1beta1.NodeSet) *slinkyv1beta1.Reservation {
+	var controllerRef corev1.LocalObjectReference
+	if controller != nil {
+		controllerRef = corev1.LocalObjectReference{
+			Name: controller.Name,
+		}
+	}
+	nodesetNames := make([]string, 0, len(nodesets))
+	for _, nodeset := range nodesets {
+		nodesetNames = append(nodesetNames, nodeset.Name)
+	}
+	return &slinkyv1beta1.Reservation{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.ReservationAPIVersion,
+			Kind:       slinkyv1beta1.ReservationKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.ReservationSpec{
+			ControllerRef: controllerRef,
+			StartTime:     metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second)),
+			Duration:      &metav1.Duration{Duration: time.Hour},
+			Users:         []string{"root"},
+			NodeSets:      nodesetNames,
+		},
+	}
+}
+
+func newSlurmdbRef(controller *slinkyv1beta1.Controller) slinkyv1beta1.SlurmdbRef {
+	ref := slinkyv1beta1.SlurmdbRef{}
+	if controller != nil {
+		ref.ControllerRef = &corev1.LocalObjectReference{
+			Name: controller.Name,
+		}
+	}
+	return ref
+}
+
+func NewAccount(name string, controller *slinkyv1beta1.Controller) *slinkyv1beta1.Account {
+	return &slinkyv1beta1.Account{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.AccountAPIVersion,
+			Kind:       slinkyv1beta1.AccountKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.AccountSpec{
+			SlurmdbRef:    newSlurmdbRef(controller),
+			ParentAccount: "root",
+		},
+	}
+}
+
+func NewUser(name string, controller *slinkyv1beta1.Controller, accounts ...*slinkyv1beta1.Account) *slinkyv1beta1.User {
+	associations := make([]slinkyv1beta1.UserAssociation, 0, len(accounts))
+	for _, account := range accounts {
+		associations = append(associations, slinkyv1beta1.UserAssociation{
+			Account: account.Name,
+		})
+	}
+	return &slinkyv1beta1.User{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.UserAPIVersion,
+			Kind:       slinkyv1beta1.UserKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.UserSpec{
+			SlurmdbRef:   newSlurmdbRef(controller),
+			AdminLevel:   slinkyv1beta1.AdminLevelNone,
+			Associations: associations,
+		},
+	}
+}
+
+func NewQOS(name string, controller *slinkyv1beta1.Controller) *slinkyv1beta1.QOS {
+	return &slinkyv1beta1.QOS{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.QOSAPIVersion,
+			Kind:       slinkyv1beta1.QOSKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.QOSSpec{
+			SlurmdbRef: newSlurmdbRef(controller),
+		},
+	}
+}
+
 func NewToken(name string, jwtKeySecret *corev1.Secret) *slinkyv1beta1.Token {
 	return &slinkyv1beta1.Token{
 		TypeMeta: metav1.TypeMeta{
@@ -362,3 +362,155 @@ func TestNewRestapi(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPartition(t *testing.T) {
+	type args struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		nodesets   []*slinkyv1beta1.NodeSet
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "smoke",
+			args: args{
+				name:       "foo",
+				controller: NewController("foo", NewSlurmKeyRef("foo"), NewJwtKeyRef("foo"), nil),
+				nodesets: []*slinkyv1beta1.NodeSet{
+					NewNodeset("foo", nil, 1),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPartition(tt.args.name, tt.args.controller, tt.args.nodesets...)
+
+			require.NotNil(t, got)
+			require.Contains(t, got.Name, tt.args.name)
+			require.Len(t, got.Spec.NodeSets, len(tt.args.nodesets))
+		})
+	}
+}
+
+func TestNewReservation(t *testing.T) {
+	type args struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		nodesets   []*slinkyv1beta1.NodeSet
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "smoke",
+			args: args{
+				name:       "foo",
+				controller: NewController("foo", NewSlurmKeyRef("foo"), NewJwtKeyRef("foo"), nil),
+				nodesets: []*slinkyv1beta1.NodeSet{
+					NewNodeset("foo", nil, 1),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewReservation(tt.args.name, tt.args.controller, tt.args.nodesets...)
+
+			require.NotNil(t, got)
+			require.Contains(t, got.Name, tt.args.name)
+			require.Len(t, got.Spec.NodeSets, len(tt.args.nodesets))
+		})
+	}
+}
+
+func TestNewAccount(t *testing.T) {
+	type args struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "smoke",
+			args: args{
+				name:       "foo",
+				controller: NewController("foo", NewSlurmKeyRef("foo"), NewJwtKeyRef("foo"), nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewAccount(tt.args.name, tt.args.controller)
+
+			require.NotNil(t, got)
+			require.Contains(t, got.Name, tt.args.name)
+			require.Equal(t, tt.args.controller.Name, got.Spec.ControllerRef.Name)
+		})
+	}
+}
+
+func TestNewUser(t *testing.T) {
+	type args struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		accounts   []*slinkyv1beta1.Account
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "smoke",
+			args: args{
+				name:       "foo",
+				controller: NewController("foo", NewSlurmKeyRef("foo"), NewJwtKeyRef("foo"), nil),
+				accounts: []*slinkyv1beta1.Account{
+					NewAccount("foo", nil),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewUser(tt.args.name, tt.args.controller, tt.args.accounts...)
+
+			require.NotNil(t, got)
+			require.Contains(t, got.Name, tt.args.name)
+			require.Len(t, got.Spec.Associations, len(tt.args.accounts))
+		})
+	}
+}
+
+func TestNewQOS(t *testing.T) {
+	type args struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "smoke",
+			args: args{
+				name:       "foo",
+				controller: NewController("foo", NewSlurmKeyRef("foo"), NewJwtKeyRef("foo"), nil),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewQOS(tt.args.name, tt.args.controller)
+
+			require.NotNil(t, got)
+			require.Contains(t, got.Name, tt.args.name)
+			require.Equal(t, tt.args.controller.Name, got.Spec.ControllerRef.Name)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=accounts,verbs=delete;create;update
+
+type AccountWebhook struct{}
+
+// log is for logging in this package.
+var accountlog = logf.Log.WithName("account-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *AccountWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &slinkyv1beta1.Account{}).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-account,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=accounts,verbs=create;update,versions=v1beta1,name=account-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ admission.Validator[*slinkyv1beta1.Account] = &AccountWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *AccountWebhook) ValidateCreate(ctx context.Context, account *slinkyv1beta1.Account) (admission.Warnings, error) {
+	accountlog.Info("validate create", "account", klog.KObj(account))
+
+	warns, errs := r.validateAccount(account)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *AccountWebhook) ValidateUpdate(ctx context.Context, oldAccount, newAccount *slinkyv1beta1.Account) (admission.Warnings, error) {
+	accountlog.Info("validate update", "newAccount", klog.KObj(newAccount))
+
+	warns, errs := r.validateAccount(newAccount)
+
+	if !apiequality.Semantic.DeepEqual(newAccount.Spec.SlurmdbRef, oldAccount.Spec.SlurmdbRef) {
+		errs = append(errs, errors.New("cannot change controllerRef or accountingRef after deployment"))
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *AccountWebhook) ValidateDelete(ctx context.Context, account *slinkyv1beta1.Account) (admission.Warnings, error) {
+	accountlog.Info("validate delete", "account", klog.KObj(account))
+
+	return nil, nil
+}
+
+func (r *AccountWebhook) validateAccount(account *slinkyv1beta1.Account) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	spec := account.Spec
+
+	errs = append(errs, validateSlurmdbRef(spec.SlurmdbRef)...)
+
+	if spec.ParentAccount == account.Name {
+		errs = append(errs, errors.New("parentAccount cannot be the account itself"))
+	}
+
+	errs = append(errs, validateQosList(spec.DefaultQOS, spec.QOS)...)
+	errs = append(errs, validateAssociationLimits("limits", spec.Limits)...)
+
+	return warns, errs
+}
+
+// validateSlurmdbRef returns errors unless exactly one of the references is set.
+func validateSlurmdbRef(ref slinkyv1beta1.SlurmdbRef) []error {
+	var errs []error
+
+	switch {
+	case ref.ControllerRef != nil && ref.AccountingRef != nil:
+		errs = append(errs, errors.New("controllerRef and accountingRef are mutually exclusive"))
+	case ref.ControllerRef != nil:
+		if ref.ControllerRef.Name == "" {
+			errs = append(errs, errors.New("controllerRef.name must not be empty"))
+		}
+	case ref.AccountingRef != nil:
+		if ref.AccountingRef.Name == "" {
+			errs = append(errs, errors.New("accountingRef.name must not be empty"))
+		}
+	default:
+		errs = append(errs, errors.New("one of controllerRef or accountingRef must be set"))
+	}
+
+	return errs
+}
+
+// validateQosList returns errors if the default QOS is not among the QOS.
+func validateQosList(defaultQos string, qosList []string) []error {
+	var errs []error
+
+	for _, qos := range qosList {
+		if qos == "" || strings.ContainsAny(qos, ", \t\r\n") {
+			errs = append(errs, fmt.Errorf("qos contains an invalid QOS name %q", qos))
+		}
+	}
+	if defaultQos != "" && len(qosList) > 0 && !slices.Contains(qosList, defaultQos) {
+		errs = append(errs, fmt.Errorf("defaultQOS %q must be one of qos", defaultQos))
+	}
+
+	return errs
+}
+
+func validateAssociationLimits(path string, limits slinkyv1beta1.AssociationLimits) []error {
+	var errs []error
+
+	errs = append(errs, validateTres(path+".grpTRES", limits.GrpTRES)...)
+	errs = append(errs, validateTres(path+".maxTRESPerJob", limits.MaxTRESPerJob)...)
+	errs = append(errs, validateTres(path+".maxTRESPerNode", limits.MaxTRESPerNode)...)
+	if limits.MaxWallDurationPerJob != nil && limits.MaxWallDurationPerJob.Minutes() < 1 {
+		errs = append(errs, fmt.Errorf("%s.maxWallDurationPerJob must be at least 1 minute", path))
+	}
+
+	return errs
+}
+
+// validateTres returns errors for invalid TRES (e.g. "cpu", "gres/gpu") or counts.
+func validateTres(path string, tres map[string]int64) []error {
+	var errs []error
+
+	for key, count := range tres {
+		tresType, tresName, found := strings.Cut(key, "/")
+		if tresType == "" || (found && tresName == "") {
+			errs = append(errs, fmt.Errorf("%s contains an invalid TRES %q", path, key))
+		}
+		if count < 0 {
+			errs = append(errs, fmt.Errorf("%s[%s] must not be negative", path, key))
+		}
+	}
+
+	return errs
+}
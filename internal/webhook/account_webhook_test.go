@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("Account Webhook", func() {
+	Context("When Creating an Account with Validating Webhook", func() {
+		It("Should deny if neither controllerRef nor accountingRef are set", func(ctx SpecContext) {
+			account := testutils.NewAccount("physics", nil)
+
+			_, err := accountWebhook.ValidateCreate(ctx, account)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if both controllerRef and accountingRef are set", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewAccount("physics", controller)
+			account.Spec.AccountingRef = &corev1.LocalObjectReference{Name: "some-accounting"}
+
+			_, err := accountWebhook.ValidateCreate(ctx, account)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if the account is its own parent", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewAccount("physics", controller)
+			account.Spec.ParentAccount = "physics"
+
+			_, err := accountWebhook.ValidateCreate(ctx, account)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if defaultQOS is not one of qos", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewAccount("physics", controller)
+			account.Spec.QOS = []string{"normal"}
+			account.Spec.DefaultQOS = "high"
+
+			_, err := accountWebhook.ValidateCreate(ctx, account)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if a TRES limit is invalid", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewAccount("physics", controller)
+			account.Spec.Limits.GrpTRES = map[string]int64{"gres/": 1, "cpu": -1}
+
+			_, err := accountWebhook.ValidateCreate(ctx, account)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if all required fields are provided", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewAccount("physics", controller)
+			account.Spec.QOS = []string{"normal", "high"}
+			account.Spec.DefaultQOS = "normal"
+			account.Spec.Limits.MaxJobs = ptr.To[int32](10)
+			account.Spec.Limits.GrpTRES = map[string]int64{"cpu": 64, "gres/gpu": 8}
+
+			warns, err := accountWebhook.ValidateCreate(ctx, account)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(BeEmpty())
+		})
+	})
+
+	Context("When Updating an Account with Validating Webhook", func() {
+		It("Should reject changes to controllerRef", func(ctx SpecContext) {
+			oldController := testutils.NewController("old-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			newController := testutils.NewController("new-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldAccount := testutils.NewAccount("physics", oldController)
+			newAccount := testutils.NewAccount("physics", newController)
+
+			_, err := accountWebhook.ValidateUpdate(ctx, oldAccount, newAccount)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit changes to the limits", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldAccount := testutils.NewAccount("physics", controller)
+			newAccount := oldAccount.DeepCopy()
+			newAccount.Spec.Limits.MaxJobs = ptr.To[int32](10)
+
+			_, err := accountWebhook.ValidateUpdate(ctx, oldAccount, newAccount)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -6,6 +6,10 @@ package webhook
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
 
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -55,6 +59,17 @@ func (r *AccountingWebhook) ValidateUpdate(ctx context.Context, oldAccounting, n
 		errs = append(errs, errors.New("the value of JwtKeyRef or JwtHs256KeyRef cannot be modified after deployment"))
 	}
 
+	// The mariadb-operator does not rename the database and user.
+	oldManaged, newManaged := oldAccounting.Spec.ManagedDatabase, newAccounting.Spec.ManagedDatabase
+	if oldManaged != nil && newManaged != nil {
+		if oldManaged.Database != newManaged.Database {
+			errs = append(errs, errors.New("cannot change managedDatabase.database after deployment"))
+		}
+		if oldManaged.Username != newManaged.Username {
+			errs = append(errs, errors.New("cannot change managedDatabase.username after deployment"))
+		}
+	}
+
 	return warns, utilerrors.NewAggregate(errs)
 }
 
@@ -74,5 +89,84 @@ func (r *AccountingWebhook) validateAccounting(accounting *slinkyv1beta1.Account
 		warns = append(warns, "ExternalIPs may not be set for accounting service")
 	}
 
+	if accounting.Spec.ManagedDatabase != nil && accounting.Spec.StorageConfig.Host != "" {
+		warns = append(warns, "storageConfig is ignored when managedDatabase is set")
+	}
+
+	retentionWarns, retentionErrs := validateRetention(accounting.Spec.Retention)
+	warns = append(warns, retentionWarns...)
+	errs = append(errs, retentionErrs...)
+
+	return warns, errs
+}
+
+// validateRetention validates the archive and purge policy of accounting records.
+func validateRetention(retention *slinkyv1beta1.AccountingRetention) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if retention == nil {
+		return nil, nil
+	}
+
+	records := []struct {
+		purgeField   string
+		purgeAfter   string
+		archiveField string
+		archive      bool
+	}{
+		{"purgeEventAfter", retention.PurgeEventAfter, "archiveEvents", retention.ArchiveEvents},
+		{"purgeJobAfter", retention.PurgeJobAfter, "archiveJobs", retention.ArchiveJobs},
+		{"purgeReservationAfter", retention.PurgeReservationAfter, "archiveReservations", retention.ArchiveReservations},
+		{"purgeStepAfter", retention.PurgeStepAfter, "archiveSteps", retention.ArchiveSteps},
+		{"purgeSuspendAfter", retention.PurgeSuspendAfter, "archiveSuspend", retention.ArchiveSuspend},
+		{"purgeTXNAfter", retention.PurgeTXNAfter, "archiveTXN", retention.ArchiveTXN},
+		{"purgeUsageAfter", retention.PurgeUsageAfter, "archiveUsage", retention.ArchiveUsage},
+	}
+	archives := false
+	for _, record := range records {
+		if record.purgeAfter != "" {
+			if err := validatePurgeAfter(record.purgeAfter); err != nil {
+				errs = append(errs, fmt.Errorf("retention.%s is invalid (%s): %w", record.purgeField, record.purgeAfter, err))
+			}
+		}
+		if !record.archive {
+			continue
+		}
+		archives = true
+		if record.purgeAfter == "" {
+			warns = append(warns, fmt.Sprintf("retention.%s has no effect without retention.%s", record.archiveField, record.purgeField))
+		}
+	}
+
+	if archives && retention.ArchiveVolume == nil {
+		errs = append(errs, errors.New("retention.archiveVolume is required to archive records"))
+	}
+
 	return warns, errs
 }
+
+// validatePurgeAfter validates a slurmdbd purge duration, which is a number
+// of months, or of the unit that the suffix abbreviates (e.g. "12", "12months",
+// "30days", "720hours").
+// Ref: https://slurm.schedmd.com/slurmdbd.conf.html#OPT_PurgeJobAfter
+func validatePurgeAfter(purgeAfter string) error {
+	suffix := strings.TrimLeftFunc(purgeAfter, unicode.IsDigit)
+	number, unit := strings.TrimSuffix(purgeAfter, suffix), strings.ToLower(suffix)
+	if number == "" {
+		return errors.New("must start with a number")
+	}
+	if n, err := strconv.ParseUint(number, 10, 32); err != nil || n == 0 {
+		return errors.New("must be a positive number")
+	}
+	if unit == "" {
+		return nil
+	}
+	for _, valid := range []string{"hours", "days", "months"} {
+		// slurmdbd accepts abbreviations of at least two characters.
+		if len(unit) >= 2 && strings.HasPrefix(valid, unit) {
+			return nil
+		}
+	}
+	return fmt.Errorf("unit %q must be one of hours, days, or months", suffix)
+}
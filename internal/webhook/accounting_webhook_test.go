@@ -4,11 +4,16 @@
 package webhook
 
 import (
+	"testing"
+
 	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 )
 
 var _ = Describe("Accounting Webhook", func() {
@@ -33,6 +38,29 @@ var _ = Describe("Accounting Webhook", func() {
 			_, err := accountingWebhook.ValidateUpdate(ctx, newAccounting, newAccounting)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("Should deny an Update if the managed database name has changed", func() {
+			By("Returning an error")
+			oldAccounting := testutils.NewAccounting("test-accounting", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, corev1.SecretKeySelector{})
+			oldAccounting.Spec.ManagedDatabase = &slinkyv1beta1.ManagedDatabase{Database: "slurm_acct_db", Username: "slurm"}
+
+			newAccounting := oldAccounting.DeepCopy()
+			newAccounting.Spec.ManagedDatabase.Database = "other_db"
+
+			_, err := accountingWebhook.ValidateUpdate(ctx, oldAccounting, newAccounting)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit an Update if the managed database is enabled", func() {
+			By("Not returning an error")
+			oldAccounting := testutils.NewAccounting("test-accounting", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, corev1.SecretKeySelector{})
+
+			newAccounting := oldAccounting.DeepCopy()
+			newAccounting.Spec.ManagedDatabase = &slinkyv1beta1.ManagedDatabase{Database: "slurm_acct_db", Username: "slurm"}
+
+			_, err := accountingWebhook.ValidateUpdate(ctx, oldAccounting, newAccounting)
+			Expect(err).NotTo(HaveOccurred())
+		})
 	})
 
 	Context("When creating Accounting with Validating Webhook", func() {
@@ -52,6 +80,24 @@ var _ = Describe("Accounting Webhook", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(warnings).To(ContainElement("ExternalIPs may not be set for accounting service"))
 		})
+
+		It("Should warn if storageConfig is set with managedDatabase", func() {
+			newAccounting := testutils.NewAccounting("test-accounting", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, corev1.SecretKeySelector{})
+			newAccounting.Spec.ManagedDatabase = &slinkyv1beta1.ManagedDatabase{}
+
+			warnings, err := accountingWebhook.ValidateCreate(ctx, newAccounting)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ContainElement("storageConfig is ignored when managedDatabase is set"))
+		})
+
+		It("Should deny a Create if a purge duration is invalid", func() {
+			By("Returning an error")
+			newAccounting := testutils.NewAccounting("test-accounting", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, corev1.SecretKeySelector{})
+			newAccounting.Spec.Retention = &slinkyv1beta1.AccountingRetention{PurgeJobAfter: "1y"}
+
+			_, err := accountingWebhook.ValidateCreate(ctx, newAccounting)
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Context("When deleting Accounting with Validating Webhook", func() {
@@ -64,3 +110,103 @@ var _ = Describe("Accounting Webhook", func() {
 		})
 	})
 })
+
+func Test_validateRetention(t *testing.T) {
+	archiveVolume := &corev1.PersistentVolumeClaimVolumeSource{
+		ClaimName: "archive",
+	}
+	tests := []struct {
+		name      string
+		retention *slinkyv1beta1.AccountingRetention
+		wantWarns int
+		wantErrs  int
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "purge",
+			retention: &slinkyv1beta1.AccountingRetention{
+				PurgeEventAfter:       "1month",
+				PurgeJobAfter:         "12",
+				PurgeReservationAfter: "30days",
+				PurgeStepAfter:        "720hours",
+				PurgeSuspendAfter:     "6MONTHS",
+				PurgeTXNAfter:         "90da",
+				PurgeUsageAfter:       "24mo",
+			},
+		},
+		{
+			name: "invalid durations",
+			retention: &slinkyv1beta1.AccountingRetention{
+				PurgeJobAfter:  "1y",
+				PurgeStepAfter: "0days",
+				PurgeTXNAfter:  "days",
+			},
+			wantErrs: 3,
+		},
+		{
+			name: "archive",
+			retention: &slinkyv1beta1.AccountingRetention{
+				PurgeJobAfter: "12months",
+				ArchiveJobs:   true,
+				ArchiveVolume: archiveVolume,
+			},
+		},
+		{
+			name: "archive without volume",
+			retention: &slinkyv1beta1.AccountingRetention{
+				PurgeJobAfter: "12months",
+				ArchiveJobs:   true,
+			},
+			wantErrs: 1,
+		},
+		{
+			name: "archive without purge",
+			retention: &slinkyv1beta1.AccountingRetention{
+				ArchiveSteps:  true,
+				ArchiveVolume: archiveVolume,
+			},
+			wantWarns: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warns, errs := validateRetention(tt.retention)
+			require.Len(t, warns, tt.wantWarns)
+			require.Len(t, errs, tt.wantErrs)
+		})
+	}
+}
+
+func Test_validatePurgeAfter(t *testing.T) {
+	tests := []struct {
+		purgeAfter string
+		wantErr    bool
+	}{
+		{purgeAfter: "12"},
+		{purgeAfter: "12months"},
+		{purgeAfter: "12month"},
+		{purgeAfter: "30days"},
+		{purgeAfter: "30Day"},
+		{purgeAfter: "720hours"},
+		{purgeAfter: "720ho"},
+		{purgeAfter: "", wantErr: true},
+		{purgeAfter: "0", wantErr: true},
+		{purgeAfter: "-1", wantErr: true},
+		{purgeAfter: "1y", wantErr: true},
+		{purgeAfter: "1h", wantErr: true},
+		{purgeAfter: "1 days", wantErr: true},
+		{purgeAfter: "99999999999", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.purgeAfter, func(t *testing.T) {
+			err := validatePurgeAfter(tt.purgeAfter)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -7,23 +7,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"slices"
+	"strings"
 
+	"github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/controllerbuilder"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
 )
 
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=delete;create;update
 
 type ControllerWebhook struct {
@@ -47,7 +56,7 @@ var _ admission.Validator[*slinkyv1beta1.Controller] = &ControllerWebhook{}
 func (r *ControllerWebhook) ValidateCreate(ctx context.Context, controller *slinkyv1beta1.Controller) (admission.Warnings, error) {
 	controllerlog.Info("validate create", "controller", klog.KObj(controller))
 
-	warns, errs := r.validateController(ctx, controller)
+	warns, errs := r.validateController(ctx, nil, controller)
 
 	// https://slurm.schedmd.com/slurm.conf.html#OPT_ClusterName
 	controllerName := controller.ClusterName()
@@ -63,7 +72,7 @@ func (r *ControllerWebhook) ValidateCreate(ctx context.Context, controller *slin
 func (r *ControllerWebhook) ValidateUpdate(ctx context.Context, oldController, newController *slinkyv1beta1.Controller) (admission.Warnings, error) {
 	controllerlog.Info("validate update", "newController", klog.KObj(newController))
 
-	warns, errs := r.validateController(ctx, newController)
+	warns, errs := r.validateController(ctx, oldController, newController)
 
 	if newController.ClusterName() != oldController.ClusterName() {
 		errs = append(errs, errors.New("cannot change ClusterName after deployment"))
@@ -80,6 +89,17 @@ func (r *ControllerWebhook) ValidateUpdate(ctx context.Context, oldController, n
 	if !apiequality.Semantic.DeepEqual(newController.Spec.Persistence.Enabled, oldController.Spec.Persistence.Enabled) {
 		errs = append(errs, errors.New("cannot change persistence.enabled after deployment"))
 	}
+	// With backups, a shared savestate PVC is used instead of volumeClaimTemplates.
+	persistence := newController.Spec.Persistence
+	oldBackups := ptr.Deref(oldController.Spec.Replicas, 1) > 1
+	newBackups := ptr.Deref(newController.Spec.Replicas, 1) > 1
+	if oldBackups != newBackups && ptr.Deref(persistence.Enabled, true) && persistence.ExistingClaim == "" {
+		errs = append(errs, errors.New("cannot change replicas between 1 and more than 1 after deployment, unless persistence.existingClaim is used"))
+	}
+	// The save-state is only restored when it is first created.
+	if !apiequality.Semantic.DeepEqual(newController.Spec.RestoreFrom, oldController.Spec.RestoreFrom) {
+		errs = append(errs, errors.New("cannot change restoreFrom after deployment"))
+	}
 
 	return warns, utilerrors.NewAggregate(errs)
 }
@@ -91,7 +111,10 @@ func (r *ControllerWebhook) ValidateDelete(ctx context.Context, controller *slin
 	return nil, nil
 }
 
-func (r *ControllerWebhook) validateController(ctx context.Context, controller *slinkyv1beta1.Controller) (admission.Warnings, []error) {
+// validateController validates the Controller. The oldController is nil on
+// create; on update, ExtraConf is only linted when it changes, hence existing
+// Controllers are not blocked by a newer lint.
+func (r *ControllerWebhook) validateController(ctx context.Context, oldController, controller *slinkyv1beta1.Controller) (admission.Warnings, []error) {
 	var warns admission.Warnings
 	var errs []error
 
@@ -117,6 +140,9 @@ func (r *ControllerWebhook) validateController(ctx context.Context, controller *
 		"topology.yaml",
 	}
 
+	topologies := controller.Spec.Topology.Topologies
+
+	configFiles := map[string]string{}
 	refs := controller.Spec.ConfigFileRefs
 	for _, ref := range refs {
 		configMap := &corev1.ConfigMap{}
@@ -128,17 +154,40 @@ func (r *ControllerWebhook) validateController(ctx context.Context, controller *
 			errs = append(errs, err)
 			continue
 		}
-		configFiles := structutils.Keys(configMap.Data)
-		controllerlog.V(1).Info("configMap files", "files", configFiles)
-		for _, file := range configFiles {
+		files := structutils.Keys(configMap.Data)
+		controllerlog.V(1).Info("configMap files", "files", files)
+		for _, file := range files {
+			configFiles[file] = configMap.Data[file]
 			if slices.Contains(denyConfigFiles, file) {
 				errs = append(errs, fmt.Errorf("the configFile is reserved for slurm-operator use: %s", file))
+			} else if len(topologies) > 0 && (file == "topology.conf" || file == "topology.yaml") {
+				errs = append(errs, fmt.Errorf("the configFile conflicts with topology.topologies: %s", file))
 			} else if !slices.Contains(knownConfigFiles, file) {
 				warns = append(warns, fmt.Sprintf("the configFile is unknown to Slurm, make sure to include it in another config file otherwise it is ignored: %s", file))
 			}
 		}
 	}
 
+	if oldController == nil || controller.Spec.ExtraConf != oldController.Spec.ExtraConf {
+		extraConfWarns, extraConfErrs := validateExtraConf(controller.Spec.ExtraConf, configFiles)
+		warns = append(warns, extraConfWarns...)
+		errs = append(errs, extraConfErrs...)
+	}
+
+	replicasWarns, replicasErrs := r.validateReplicas(ctx, controller)
+	warns = append(warns, replicasWarns...)
+	errs = append(errs, replicasErrs...)
+
+	backupWarns, backupErrs := r.validateBackup(ctx, controller)
+	warns = append(warns, backupWarns...)
+	errs = append(errs, backupErrs...)
+
+	errs = append(errs, validateTopologies(topologies)...)
+
+	schedulingWarns, schedulingErrs := validateScheduling(controller.Spec.Scheduling, controller.Spec.ExtraConf)
+	warns = append(warns, schedulingWarns...)
+	errs = append(errs, schedulingErrs...)
+
 	// Prevent MitM via CVE-2020-8554
 	if controller.Spec.Service.ServiceSpecWrapper.ExternalIPs != nil {
 		warns = append(warns, "ExternalIPs may not be set for controller service")
@@ -146,3 +195,289 @@ func (r *ControllerWebhook) validateController(ctx context.Context, controller *
 
 	return warns, errs
 }
+
+// validateExtraConf lints the ExtraConf appended to `slurm.conf`, and the
+// config files it includes from the ConfigFileRefs.
+// Ref: https://slurm.schedmd.com/slurm.conf.html
+func validateExtraConf(extraConf string, configFiles map[string]string) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	lint := func(name, data string) {
+		lintWarns, lintErrs := config.LintSlurmConf(data, controllerbuilder.ReservedSlurmConfKeys...)
+		for _, warn := range lintWarns {
+			warns = append(warns, fmt.Sprintf("%s: %s", name, warn))
+		}
+		for _, err := range lintErrs {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	lint("extraConf", extraConf)
+
+	lines, _ := config.ParseSlurmConf(extraConf)
+	for _, line := range lines {
+		if line.Include == "" {
+			continue
+		}
+		file := path.Base(line.Include)
+		if data, ok := configFiles[file]; ok {
+			lint(file, data)
+		}
+	}
+
+	return warns, errs
+}
+
+// validateReplicas validates that slurmctld backups share StateSaveLocation
+// through ReadWriteMany storage.
+// Ref: https://slurm.schedmd.com/quickstart_admin.html#HA
+func (r *ControllerWebhook) validateReplicas(ctx context.Context, controller *slinkyv1beta1.Controller) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if controller.Spec.External || ptr.Deref(controller.Spec.Replicas, 1) <= 1 {
+		return nil, nil
+	}
+
+	persistence := controller.Spec.Persistence
+	switch {
+	case !ptr.Deref(persistence.Enabled, true):
+		errs = append(errs, errors.New("replicas greater than 1 requires persistence.enabled, so that slurmctld instances share StateSaveLocation"))
+	case persistence.ExistingClaim != "":
+		claim := &corev1.PersistentVolumeClaim{}
+		claimKey := types.NamespacedName{
+			Name:      persistence.ExistingClaim,
+			Namespace: controller.Namespace,
+		}
+		if err := r.Get(ctx, claimKey, claim); err != nil {
+			if !apierrors.IsNotFound(err) {
+				errs = append(errs, err)
+				break
+			}
+			warns = append(warns, fmt.Sprintf("persistence.existingClaim %q was not found, it must have ReadWriteMany access when created", persistence.ExistingClaim))
+			break
+		}
+		if !slices.Contains(claim.Spec.AccessModes, corev1.ReadWriteMany) {
+			errs = append(errs, fmt.Errorf("replicas greater than 1 requires persistence.existingClaim %q to have ReadWriteMany access", persistence.ExistingClaim))
+		}
+	case len(persistence.AccessModes) > 0 && !slices.Contains(persistence.AccessModes, corev1.ReadWriteMany):
+		errs = append(errs, errors.New("replicas greater than 1 requires persistence.accessModes to contain ReadWriteMany"))
+	}
+
+	return warns, errs
+}
+
+// validateBackup validates the backup and restore of the save-state, which
+// require it to be persisted.
+func (r *ControllerWebhook) validateBackup(ctx context.Context, controller *slinkyv1beta1.Controller) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	backup := controller.Spec.Backup
+	restore := controller.Spec.RestoreFrom
+	if backup == nil && restore == nil {
+		return nil, nil
+	}
+
+	persistence := controller.Spec.Persistence
+	switch {
+	case controller.Spec.External:
+		errs = append(errs, errors.New("backup and restoreFrom are not supported with an external slurmctld"))
+		return warns, errs
+	case !ptr.Deref(persistence.Enabled, true):
+		errs = append(errs, errors.New("backup and restoreFrom require persistence.enabled"))
+		return warns, errs
+	}
+
+	checkClaim := func(field, name string) {
+		claim := &corev1.PersistentVolumeClaim{}
+		claimKey := types.NamespacedName{
+			Name:      name,
+			Namespace: controller.Namespace,
+		}
+		if err := r.Get(ctx, claimKey, claim); err != nil {
+			if !apierrors.IsNotFound(err) {
+				errs = append(errs, err)
+				return
+			}
+			warns = append(warns, fmt.Sprintf("%s %q was not found", field, name))
+		}
+	}
+
+	if backup != nil {
+		if _, err := cron.ParseStandard(backup.Schedule); err != nil {
+			errs = append(errs, fmt.Errorf("backup.schedule is invalid (%s): %w", backup.Schedule, err))
+		}
+		if backup.PersistentVolumeClaim != nil {
+			checkClaim("backup.persistentVolumeClaim.claimName", backup.PersistentVolumeClaim.ClaimName)
+		}
+	}
+
+	if restore != nil {
+		if restore.VolumeSnapshotName != "" && persistence.ExistingClaim != "" {
+			errs = append(errs, errors.New("restoreFrom.volumeSnapshotName cannot be used with persistence.existingClaim, restore the snapshot into the existing claim instead"))
+		}
+		if restore.PersistentVolumeClaim != nil {
+			checkClaim("restoreFrom.persistentVolumeClaim.claimName", restore.PersistentVolumeClaim.ClaimName)
+		}
+	}
+
+	return warns, errs
+}
+
+// validateTopologies validates the topologies rendered into `topology.yaml`.
+// Ref: https://slurm.schedmd.com/topology.yaml.html
+func validateTopologies(topologies []slinkyv1beta1.Topology) []error {
+	var errs []error
+
+	clusterDefaults := 0
+	for _, topology := range topologies {
+		if topology.ClusterDefault {
+			clusterDefaults++
+		}
+
+		kinds := 0
+		if topology.Tree != nil {
+			kinds++
+			if len(topology.Tree.Switches) == 0 {
+				errs = append(errs, fmt.Errorf("topology %q tree must have at least one switch", topology.Name))
+			}
+		}
+		if topology.Block != nil {
+			kinds++
+			if len(topology.Block.Blocks) == 0 {
+				errs = append(errs, fmt.Errorf("topology %q block must have at least one block", topology.Name))
+			}
+			for _, size := range topology.Block.BlockSizes {
+				if size <= 0 {
+					errs = append(errs, fmt.Errorf("topology %q block size must be positive: %d", topology.Name, size))
+				}
+			}
+		}
+		if topology.Flat {
+			kinds++
+		}
+		if kinds != 1 {
+			errs = append(errs, fmt.Errorf("topology %q must set exactly one of tree, block, or flat", topology.Name))
+		}
+	}
+	if clusterDefaults > 1 {
+		errs = append(errs, fmt.Errorf("at most one topology may be the cluster default, found %d", clusterDefaults))
+	}
+
+	return errs
+}
+
+// validateScheduling validates the scheduling options rendered into `slurm.conf`.
+// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_SCHEDULING
+func validateScheduling(scheduling slinkyv1beta1.ControllerScheduling, extraConf string) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SchedulerParameters
+	if scheduling.SchedulerType == "sched/builtin" {
+		for _, param := range scheduling.SchedulerParameters {
+			if strings.HasPrefix(strings.ToLower(param), "bf_") {
+				warns = append(warns, fmt.Sprintf("schedulerParameters option is ignored without sched/backfill: %s", param))
+			}
+		}
+	}
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SelectTypeParameters
+	consumables := []string{}
+	for _, param := range scheduling.SelectTypeParameters {
+		param = strings.ToUpper(param)
+		if !strings.HasPrefix(param, "CR_") {
+			continue
+		}
+		switch param {
+		case "CR_CPU", "CR_CPU_MEMORY", "CR_CORE", "CR_CORE_MEMORY", "CR_SOCKET", "CR_SOCKET_MEMORY":
+			consumables = append(consumables, param)
+			if scheduling.SelectType == "select/linear" {
+				errs = append(errs, fmt.Errorf("selectTypeParameters option requires select/cons_tres: %s", param))
+			}
+		case "CR_MEMORY":
+			consumables = append(consumables, param)
+		}
+	}
+	if len(consumables) > 1 {
+		errs = append(errs, fmt.Errorf("selectTypeParameters must contain at most one consumable resource type, found: %s",
+			strings.Join(consumables, ",")))
+	}
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityType
+	priority := scheduling.Priority
+	if priority.Type == "priority/basic" {
+		if len(priority.Flags) > 0 || priority.DecayHalfLife != "" || priority.MaxAge != "" ||
+			!apiequality.Semantic.DeepEqual(priority.Weights, slinkyv1beta1.PriorityWeights{}) {
+			errs = append(errs, errors.New("priority flags, decayHalfLife, maxAge, and weights require priority/multifactor"))
+		}
+	}
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PreemptMode
+	preempt := scheduling.Preempt
+	modes := set.New[string]()
+	for _, mode := range preempt.Mode {
+		modes.Insert(strings.ToUpper(mode))
+	}
+	if modes.Has("OFF") && modes.Len() > 1 {
+		errs = append(errs, errors.New("preempt mode OFF cannot be combined with other modes"))
+	}
+	if (preempt.Type == "" || preempt.Type == "preempt/none") && modes.Len() > 0 && !modes.Equal(set.New("OFF")) {
+		errs = append(errs, errors.New("preempt mode requires preempt/partition_prio or preempt/qos"))
+	}
+	if preempt.Type == "preempt/partition_prio" && modes.Has("SUSPEND") && !modes.Has("GANG") {
+		errs = append(errs, errors.New("preempt mode SUSPEND requires GANG with preempt/partition_prio"))
+	}
+
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_DefMemPerCPU
+	limits := scheduling.Limits
+	if limits.DefMemPerCPU != nil && limits.DefMemPerNode != nil {
+		errs = append(errs, errors.New("limits defMemPerCPU and defMemPerNode are mutually exclusive"))
+	}
+	if limits.MaxMemPerCPU != nil && limits.MaxMemPerNode != nil {
+		errs = append(errs, errors.New("limits maxMemPerCPU and maxMemPerNode are mutually exclusive"))
+	}
+	if limits.DefMemPerCPU != nil && limits.MaxMemPerCPU != nil && *limits.MaxMemPerCPU > 0 && *limits.DefMemPerCPU > *limits.MaxMemPerCPU {
+		errs = append(errs, fmt.Errorf("limits defMemPerCPU (%d) exceeds maxMemPerCPU (%d)", *limits.DefMemPerCPU, *limits.MaxMemPerCPU))
+	}
+	if limits.DefMemPerNode != nil && limits.MaxMemPerNode != nil && *limits.MaxMemPerNode > 0 && *limits.DefMemPerNode > *limits.MaxMemPerNode {
+		errs = append(errs, fmt.Errorf("limits defMemPerNode (%d) exceeds maxMemPerNode (%d)", *limits.DefMemPerNode, *limits.MaxMemPerNode))
+	}
+
+	// Scalar options are not merged, so ExtraConf silently takes precedence.
+	scalarOptions := map[string]bool{
+		"SchedulerType":           scheduling.SchedulerType != "",
+		"SelectType":              scheduling.SelectType != "",
+		"PriorityType":            priority.Type != "",
+		"PriorityDecayHalfLife":   priority.DecayHalfLife != "",
+		"PriorityMaxAge":          priority.MaxAge != "",
+		"PriorityWeightAge":       priority.Weights.Age != nil,
+		"PriorityWeightAssoc":     priority.Weights.Assoc != nil,
+		"PriorityWeightFairshare": priority.Weights.Fairshare != nil,
+		"PriorityWeightJobSize":   priority.Weights.JobSize != nil,
+		"PriorityWeightPartition": priority.Weights.Partition != nil,
+		"PriorityWeightQOS":       priority.Weights.QOS != nil,
+		"PriorityWeightTRES":      len(priority.Weights.TRES) > 0,
+		"PreemptType":             preempt.Type != "",
+		"PreemptExemptTime":       preempt.ExemptTime != "",
+		"MaxJobCount":             limits.MaxJobCount != nil,
+		"MaxArraySize":            limits.MaxArraySize != nil,
+		"MaxStepCount":            limits.MaxStepCount != nil,
+		"MaxTasksPerNode":         limits.MaxTasksPerNode != nil,
+		"DefMemPerCPU":            limits.DefMemPerCPU != nil,
+		"DefMemPerNode":           limits.DefMemPerNode != nil,
+		"MaxMemPerCPU":            limits.MaxMemPerCPU != nil,
+		"MaxMemPerNode":           limits.MaxMemPerNode != nil,
+	}
+	for _, prop := range config.ParseProperties(extraConf) {
+		for option, isSet := range scalarOptions {
+			if isSet && strings.EqualFold(prop.Key(), option) {
+				warns = append(warns, fmt.Sprintf("extraConf overrides the scheduling option: %s", option))
+			}
+		}
+	}
+
+	return warns, errs
+}
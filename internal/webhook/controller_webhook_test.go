@@ -4,12 +4,20 @@
 package webhook
 
 import (
+	"context"
+	"testing"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
@@ -37,6 +45,79 @@ var _ = Describe("Controller Webhook", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(warnings).To(ContainElement("ExternalIPs may not be set for controller service"))
 		})
+
+		It("Should admit valid topologies", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.Topology.Topologies = []slinkyv1beta1.Topology{
+				{
+					Name:           "topo-switch",
+					ClusterDefault: true,
+					Tree: &slinkyv1beta1.TopologyTree{
+						Switches: []slinkyv1beta1.TopologySwitch{{Name: "s1", Nodes: "node[1-2]"}},
+					},
+				},
+				{
+					Name: "topo-block",
+					Block: &slinkyv1beta1.TopologyBlock{
+						BlockSizes: []int32{2, 4},
+						Blocks:     []slinkyv1beta1.TopologyBlockEntry{{Name: "b1", Nodes: "node[1-2]"}},
+					},
+				},
+				{
+					Name: "topo-flat",
+					Flat: true,
+				},
+			}
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a topology without exactly one of tree, block, or flat", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.Topology.Topologies = []slinkyv1beta1.Topology{
+				{
+					Name: "topo",
+					Flat: true,
+					Block: &slinkyv1beta1.TopologyBlock{
+						Blocks: []slinkyv1beta1.TopologyBlockEntry{{Name: "b1", Nodes: "node[1-2]"}},
+					},
+				},
+				{
+					Name: "topo-empty",
+				},
+			}
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny multiple cluster default topologies", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.Topology.Topologies = []slinkyv1beta1.Topology{
+				{Name: "topo-1", ClusterDefault: true, Flat: true},
+				{Name: "topo-2", ClusterDefault: true, Flat: true},
+			}
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny non-positive block sizes", func(ctx SpecContext) {
+			controller := testutils.NewController("clustername", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			controller.Spec.Topology.Topologies = []slinkyv1beta1.Topology{
+				{
+					Name: "topo-block",
+					Block: &slinkyv1beta1.TopologyBlock{
+						BlockSizes: []int32{0},
+						Blocks:     []slinkyv1beta1.TopologyBlockEntry{{Name: "b1", Nodes: "node[1-2]"}},
+					},
+				},
+			}
+
+			_, err := controllerWebhook.ValidateCreate(ctx, controller)
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Context("When Updating a Controller with Validating Webhook", func() {
@@ -80,6 +161,37 @@ var _ = Describe("Controller Webhook", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("Should reject changes to controller.restoreFrom", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+
+			newController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			newController.Spec.RestoreFrom = &slinkyv1beta1.ControllerRestore{
+				VolumeSnapshotName: "snapshot",
+			}
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should reject changes to extraConf with reserved options", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			newController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			newController.Spec.ExtraConf = "ClusterName=foo"
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if extraConf is unchanged", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldController.Spec.ExtraConf = "ClusterName=foo"
+			newController := oldController.DeepCopy()
+			newController.Spec.Replicas = ptr.To[int32](1)
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("Should admit if changes pass validation", func(ctx SpecContext) {
 			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
 			newController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
@@ -100,3 +212,361 @@ var _ = Describe("Controller Webhook", func() {
 		})
 	})
 })
+
+func Test_validateScheduling(t *testing.T) {
+	tests := []struct {
+		name       string
+		scheduling slinkyv1beta1.ControllerScheduling
+		extraConf  string
+		wantWarns  int
+		wantErrs   int
+	}{
+		{
+			name:       "empty",
+			scheduling: slinkyv1beta1.ControllerScheduling{},
+		},
+		{
+			name: "valid",
+			scheduling: slinkyv1beta1.ControllerScheduling{
+				SchedulerType:        "sched/backfill",
+				SchedulerParameters:  []string{"bf_continue"},
+				SelectType:           "select/cons_tres",
+				SelectTypeParameters: []string{"CR_Core_Memory", "CR_ONE_TASK_PER_CORE"},
+				Priority: slinkyv1beta1.SchedulingPriority{
+					Type:  "priority/multifactor",
+					Flags: []string{"FAIR_TREE"},
+					Weights: slinkyv1beta1.PriorityWeights{
+						Fairshare: ptr.To[int32](10000),
+					},
+				},
+				Preempt: slinkyv1beta1.SchedulingPreempt{
+					Type: "preempt/partition_prio",
+					Mode: []string{"GANG", "SUSPEND"},
+				},
+				Limits: slinkyv1beta1.SchedulingLimits{
+					DefMemPerCPU: ptr.To[int64](1024),
+					MaxMemPerCPU: ptr.To[int64](4096),
+				},
+			},
+			extraConf: "SchedulerParameters=bf_interval=60\nMinJobAge=2",
+		},
+		{
+			name: "backfill parameters with builtin",
+			scheduling: slinkyv1beta1.ControllerScheduling{
+				SchedulerType:       "sched/builtin",
+				SchedulerParameters: []string{"bf_continue", "defer"},
+			},
+			wantWarns: 1,
+		},
+		{
+			name: "consumable resources",
+			scheduling: slinkyv1beta1.ControllerScheduling{
+				SelectType:           "select/linear",
+				SelectTypeParameters: []string{"CR_Core", "CR_Memory"},
+			},
+			wantErrs: 2,
+		},
+		{
+			name: "weights with basic priority",
+			scheduling: slinkyv1beta1.ControllerScheduling{
+				Priority: slinkyv1beta1.SchedulingPriority{
+					Type: "priority/basic",
+					Weights: slinkyv1beta1.PriorityWeights{
+						Age: ptr.To[int32](1000),
+					},
+				},
+			},
+			wantErrs: 1,
+		},
+		{
+			name: "preempt mode without preempt type",
+			scheduling: slinkyv1beta1.ControllerScheduling{
+				Preempt: slinkyv1beta1.SchedulingPreempt{
+					Mode: []string{"OFF", "REQUEUE"},
+				},
+			},
+			wantErrs: 2,
+		},
+		{
+			name: "suspend without gang",
+			scheduling: slinkyv1beta1.ControllerScheduling{
+				Preempt: slinkyv1beta1.SchedulingPreempt{
+					Type: "preempt/partition_prio",
+					Mode: []string{"SUSPEND"},
+				},
+			},
+			wantErrs: 1,
+		},
+		{
+			name: "memory limits",
+			scheduling: slinkyv1beta1.ControllerScheduling{
+				Limits: slinkyv1beta1.SchedulingLimits{
+					DefMemPerCPU:  ptr.To[int64](4096),
+					DefMemPerNode: ptr.To[int64](4096),
+					MaxMemPerCPU:  ptr.To[int64](1024),
+				},
+			},
+			wantErrs: 2,
+		},
+		{
+			name: "extraConf override",
+			scheduling: slinkyv1beta1.ControllerScheduling{
+				SelectType: "select/cons_tres",
+				Limits: slinkyv1beta1.SchedulingLimits{
+					MaxJobCount: ptr.To[int32](10000),
+				},
+			},
+			extraConf: "selecttype=select/linear\nMaxJobCount=20000\nMaxArraySize=1001",
+			wantWarns: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warns, errs := validateScheduling(tt.scheduling, tt.extraConf)
+			require.Len(t, warns, tt.wantWarns, warns)
+			require.Len(t, errs, tt.wantErrs, errs)
+		})
+	}
+}
+
+func Test_validateExtraConf(t *testing.T) {
+	tests := []struct {
+		name        string
+		extraConf   string
+		configFiles map[string]string
+		wantWarns   int
+		wantErrs    int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "valid",
+			extraConf: `SlurmctldDebug=debug2
+PartitionName=all Nodes=ALL Default=YES MaxTime=UNLIMITED State=UP`,
+		},
+		{
+			name: "invalid",
+			extraConf: `SlurmctldHost=foo
+SlurmctldDebugg=debug2
+MinJobAge 2
+ControlMachine=foo`,
+			wantWarns: 2,
+			wantErrs:  2,
+		},
+		{
+			name:      "included config file",
+			extraConf: `Include /etc/slurm/extra.conf`,
+			configFiles: map[string]string{
+				"extra.conf": "AuthType=auth/munge",
+			},
+			wantErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warns, errs := validateExtraConf(tt.extraConf, tt.configFiles)
+			require.Len(t, warns, tt.wantWarns, warns)
+			require.Len(t, errs, tt.wantErrs, errs)
+		})
+	}
+}
+
+func TestControllerWebhook_validateReplicas(t *testing.T) {
+	newClaim := func(name string, mode corev1.PersistentVolumeAccessMode) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: name},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{mode},
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		replicas    int32
+		persistence slinkyv1beta1.ControllerPersistence
+		objects     []client.Object
+		wantWarns   int
+		wantErrs    int
+	}{
+		{
+			name:     "single",
+			replicas: 1,
+			persistence: slinkyv1beta1.ControllerPersistence{
+				Enabled: ptr.To(false),
+			},
+		},
+		{
+			name:     "backups",
+			replicas: 2,
+		},
+		{
+			name:     "backups without persistence",
+			replicas: 2,
+			persistence: slinkyv1beta1.ControllerPersistence{
+				Enabled: ptr.To(false),
+			},
+			wantErrs: 1,
+		},
+		{
+			name:     "backups with ReadWriteOnce",
+			replicas: 2,
+			persistence: slinkyv1beta1.ControllerPersistence{
+				PersistentVolumeClaimSpec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				},
+			},
+			wantErrs: 1,
+		},
+		{
+			name:     "backups with existing ReadWriteMany claim",
+			replicas: 2,
+			persistence: slinkyv1beta1.ControllerPersistence{
+				ExistingClaim: "statesave",
+			},
+			objects: []client.Object{newClaim("statesave", corev1.ReadWriteMany)},
+		},
+		{
+			name:     "backups with existing ReadWriteOnce claim",
+			replicas: 2,
+			persistence: slinkyv1beta1.ControllerPersistence{
+				ExistingClaim: "statesave",
+			},
+			objects:  []client.Object{newClaim("statesave", corev1.ReadWriteOnce)},
+			wantErrs: 1,
+		},
+		{
+			name:     "backups with missing claim",
+			replicas: 2,
+			persistence: slinkyv1beta1.ControllerPersistence{
+				ExistingClaim: "statesave",
+			},
+			wantWarns: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "slurm"},
+				Spec: slinkyv1beta1.ControllerSpec{
+					Replicas:    ptr.To(tt.replicas),
+					Persistence: tt.persistence,
+				},
+			}
+			r := &ControllerWebhook{
+				Client: fake.NewClientBuilder().WithObjects(tt.objects...).Build(),
+			}
+			warns, errs := r.validateReplicas(context.TODO(), controller)
+			require.Len(t, warns, tt.wantWarns, warns)
+			require.Len(t, errs, tt.wantErrs, errs)
+		})
+	}
+}
+
+func TestControllerWebhook_validateBackup(t *testing.T) {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "backup"},
+	}
+	claimBackup := func(schedule, claimName string) *slinkyv1beta1.ControllerBackup {
+		return &slinkyv1beta1.ControllerBackup{
+			Schedule:              schedule,
+			PersistentVolumeClaim: &slinkyv1beta1.ControllerBackupVolume{ClaimName: claimName},
+		}
+	}
+	tests := []struct {
+		name        string
+		external    bool
+		persistence slinkyv1beta1.ControllerPersistence
+		backup      *slinkyv1beta1.ControllerBackup
+		restore     *slinkyv1beta1.ControllerRestore
+		objects     []client.Object
+		wantWarns   int
+		wantErrs    int
+	}{
+		{
+			name: "none",
+			persistence: slinkyv1beta1.ControllerPersistence{
+				Enabled: ptr.To(false),
+			},
+		},
+		{
+			name:    "backup",
+			backup:  claimBackup("@daily", "backup"),
+			objects: []client.Object{claim},
+		},
+		{
+			name: "snapshot backup",
+			backup: &slinkyv1beta1.ControllerBackup{
+				Schedule:       "0 */6 * * *",
+				VolumeSnapshot: &slinkyv1beta1.ControllerBackupVolumeSnapshot{},
+			},
+		},
+		{
+			name:     "invalid schedule",
+			backup:   claimBackup("every day", "backup"),
+			objects:  []client.Object{claim},
+			wantErrs: 1,
+		},
+		{
+			name:      "missing backup claim",
+			backup:    claimBackup("@daily", "backup"),
+			wantWarns: 1,
+		},
+		{
+			name:   "without persistence",
+			backup: claimBackup("@daily", "backup"),
+			persistence: slinkyv1beta1.ControllerPersistence{
+				Enabled: ptr.To(false),
+			},
+			wantErrs: 1,
+		},
+		{
+			name:     "external",
+			external: true,
+			backup:   claimBackup("@daily", "backup"),
+			wantErrs: 1,
+		},
+		{
+			name: "restore snapshot",
+			restore: &slinkyv1beta1.ControllerRestore{
+				VolumeSnapshotName: "snapshot",
+			},
+		},
+		{
+			name: "restore snapshot into existing claim",
+			persistence: slinkyv1beta1.ControllerPersistence{
+				ExistingClaim: "statesave",
+			},
+			restore: &slinkyv1beta1.ControllerRestore{
+				VolumeSnapshotName: "snapshot",
+			},
+			wantErrs: 1,
+		},
+		{
+			name: "restore missing claim",
+			restore: &slinkyv1beta1.ControllerRestore{
+				PersistentVolumeClaim: &slinkyv1beta1.ControllerRestoreVolume{ClaimName: "backup"},
+			},
+			wantWarns: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{Namespace: corev1.NamespaceDefault, Name: "slurm"},
+				Spec: slinkyv1beta1.ControllerSpec{
+					External:    tt.external,
+					Persistence: tt.persistence,
+					Backup:      tt.backup,
+					RestoreFrom: tt.restore,
+				},
+			}
+			r := &ControllerWebhook{
+				Client: fake.NewClientBuilder().WithObjects(tt.objects...).Build(),
+			}
+			warns, errs := r.validateBackup(context.TODO(), controller)
+			require.Len(t, warns, tt.wantWarns, warns)
+			require.Len(t, errs, tt.wantErrs, errs)
+		})
+	}
+}
@@ -7,24 +7,42 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/controllerbuilder"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
 )
 
-// +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets,verbs=delete;create;update
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=partitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets,verbs=get;list;watch;delete;create;update
 
-type NodeSetWebhook struct{}
+type NodeSetWebhook struct {
+	client.Client
+}
 
 // log is for logging in this package.
 var nodesetlog = logf.Log.WithName("nodeset-resource")
@@ -45,6 +63,20 @@ func (r *NodeSetWebhook) ValidateCreate(ctx context.Context, nodeset *slinkyv1be
 
 	warns, errs := r.validateNodeSet(nodeset)
 
+	extraConfWarns, extraConfErrs := validateNodeSetExtraConf(nodeset)
+	warns = append(warns, extraConfWarns...)
+	errs = append(errs, extraConfErrs...)
+	configWarns, configErrs := r.validateConfigFiles(ctx, nodeset)
+	warns = append(warns, configWarns...)
+	errs = append(errs, configErrs...)
+	prologWarns, prologErrs := r.validateScriptRefs(ctx, nodeset, "prologScriptRefs", nodeset.Spec.PrologScriptRefs)
+	warns = append(warns, prologWarns...)
+	errs = append(errs, prologErrs...)
+	epilogWarns, epilogErrs := r.validateScriptRefs(ctx, nodeset, "epilogScriptRefs", nodeset.Spec.EpilogScriptRefs)
+	warns = append(warns, epilogWarns...)
+	errs = append(errs, epilogErrs...)
+	errs = append(errs, r.validatePartitionConflicts(ctx, nodeset)...)
+
 	return warns, utilerrors.NewAggregate(errs)
 }
 
@@ -54,12 +86,46 @@ func (r *NodeSetWebhook) ValidateUpdate(ctx context.Context, oldNodeSet, newNode
 
 	warns, errs := r.validateNodeSet(newNodeSet)
 
+	// ExtraConf is only linted when it changes, hence existing NodeSets are not
+	// blocked by a newer lint. The reserved keys depend on resourceSpec.
+	if newNodeSet.Spec.ExtraConf != oldNodeSet.Spec.ExtraConf ||
+		newNodeSet.Spec.ResourceSpec.Enabled != oldNodeSet.Spec.ResourceSpec.Enabled {
+		extraConfWarns, extraConfErrs := validateNodeSetExtraConf(newNodeSet)
+		warns = append(warns, extraConfWarns...)
+		errs = append(errs, extraConfErrs...)
+	}
+	// The referenced ConfigMaps are only validated when the references change,
+	// hence changes to them do not block unrelated updates, like finalizers.
+	isDeleting := !newNodeSet.DeletionTimestamp.IsZero()
+	if !isDeleting && !apiequality.Semantic.DeepEqual(newNodeSet.Spec.ConfigFileRefs, oldNodeSet.Spec.ConfigFileRefs) {
+		configWarns, configErrs := r.validateConfigFiles(ctx, newNodeSet)
+		warns = append(warns, configWarns...)
+		errs = append(errs, configErrs...)
+	}
+	if !isDeleting && !apiequality.Semantic.DeepEqual(newNodeSet.Spec.PrologScriptRefs, oldNodeSet.Spec.PrologScriptRefs) {
+		prologWarns, prologErrs := r.validateScriptRefs(ctx, newNodeSet, "prologScriptRefs", newNodeSet.Spec.PrologScriptRefs)
+		warns = append(warns, prologWarns...)
+		errs = append(errs, prologErrs...)
+	}
+	if !isDeleting && !apiequality.Semantic.DeepEqual(newNodeSet.Spec.EpilogScriptRefs, oldNodeSet.Spec.EpilogScriptRefs) {
+		epilogWarns, epilogErrs := r.validateScriptRefs(ctx, newNodeSet, "epilogScriptRefs", newNodeSet.Spec.EpilogScriptRefs)
+		warns = append(warns, epilogWarns...)
+		errs = append(errs, epilogErrs...)
+	}
+	if newNodeSet.Spec.Partition.Enabled != oldNodeSet.Spec.Partition.Enabled ||
+		common.GetSlurmNodeSetName(newNodeSet) != common.GetSlurmNodeSetName(oldNodeSet) {
+		errs = append(errs, r.validatePartitionConflicts(ctx, newNodeSet)...)
+	}
+
 	if !apiequality.Semantic.DeepEqual(newNodeSet.Spec.ControllerRef, oldNodeSet.Spec.ControllerRef) {
 		errs = append(errs, errors.New("cannot change controllerRef after deployment"))
 	}
 	if !apiequality.Semantic.DeepEqual(newNodeSet.Spec.VolumeClaimTemplates, oldNodeSet.Spec.VolumeClaimTemplates) {
 		errs = append(errs, errors.New("cannot change volumeClaimTemplates after deployment"))
 	}
+	if !isResourceClaimTemplatesEqual(oldNodeSet.Spec.ResourceClaimTemplates, newNodeSet.Spec.ResourceClaimTemplates) {
+		errs = append(errs, errors.New("cannot change resourceClaimTemplates names or specs after deployment"))
+	}
 
 	return warns, utilerrors.NewAggregate(errs)
 }
@@ -92,6 +158,26 @@ func (r *NodeSetWebhook) validateNodeSet(nodeset *slinkyv1beta1.NodeSet) (admiss
 		}
 	}
 
+	if ptr.Deref(nodeset.Spec.UpdateStrategy.RollingUpdate.Partition, 0) > 0 &&
+		nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+		errs = append(errs, fmt.Errorf("partition requires scalingMode=%s", slinkyv1beta1.ScalingModeStatefulset))
+	}
+
+	if ms := nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge; ms != nil {
+		if ms.Type == intstr.Int && ms.IntVal < 0 {
+			errs = append(errs, fmt.Errorf("maxSurge must be >= 0, got %d", ms.IntVal))
+		}
+		isZero := (ms.Type == intstr.Int && ms.IntVal == 0) || (ms.Type == intstr.String && ms.StrVal == "0%")
+		if !isZero {
+			if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+				errs = append(errs, fmt.Errorf("maxSurge requires scalingMode=%s", slinkyv1beta1.ScalingModeStatefulset))
+			}
+			if nodeset.Spec.PowerSave.Enabled {
+				errs = append(errs, errors.New("maxSurge is not supported with powerSave.enabled=true"))
+			}
+		}
+	}
+
 	zeroDuration := metav1.Duration{}
 	if duration := nodeset.Spec.UpdateStrategy.ScheduledUpdate.Duration; duration != zeroDuration {
 		if duration.Duration < time.Minute {
@@ -99,6 +185,52 @@ func (r *NodeSetWebhook) validateNodeSet(nodeset *slinkyv1beta1.NodeSet) (admiss
 		}
 	}
 
+	for _, topologyLabel := range nodeset.Spec.TopologyLabels {
+		if strings.ContainsAny(topologyLabel.Topology, ",: \t\n") {
+			errs = append(errs, fmt.Errorf("topologyLabels topology %q must not contain ',', ':', or whitespace", topologyLabel.Topology))
+		}
+	}
+
+	resourceClaims := make(map[string]bool, len(nodeset.Spec.ResourceClaimTemplates))
+	for _, template := range nodeset.Spec.ResourceClaimTemplates {
+		resourceClaims[template.Name] = true
+		for _, feature := range template.Features {
+			if feature == "" || strings.ContainsAny(feature, ", \t\n") {
+				errs = append(errs, fmt.Errorf("resourceClaimTemplates %q feature %q must not be empty or contain ',' or whitespace", template.Name, feature))
+			}
+		}
+	}
+
+	gresKeys := make(map[string]bool, len(nodeset.Spec.Gres))
+	for _, gres := range nodeset.Spec.Gres {
+		if strings.ContainsAny(gres.Name, ",: \t\n") || strings.ContainsAny(gres.Type, ",: \t\n") {
+			errs = append(errs, fmt.Errorf("gres %q name and type must not contain ',', ':', or whitespace", gres.Name))
+		}
+		key := gres.Name + ":" + gres.Type
+		if gresKeys[key] {
+			errs = append(errs, fmt.Errorf("gres %q duplicates type %q", gres.Name, gres.Type))
+		}
+		gresKeys[key] = true
+		switch {
+		case (gres.ResourceName == "") == (gres.ResourceClaim == ""):
+			errs = append(errs, fmt.Errorf("gres %q requires exactly one of resourceName or resourceClaim", gres.Name))
+		case gres.ResourceClaim != "" && !resourceClaims[gres.ResourceClaim]:
+			errs = append(errs, fmt.Errorf("gres %q resourceClaim %q does not match a resourceClaimTemplate", gres.Name, gres.ResourceClaim))
+		case gres.ResourceClaim != "":
+			if count := common.GetSlurmNodeGresCount(nodeset, gres); count <= 0 {
+				errs = append(errs, fmt.Errorf("gres %q requires a resourceClaim with an exact device count, got %d", gres.Name, count))
+			}
+		default:
+			if count := common.GetSlurmNodeGresCount(nodeset, gres); count <= 0 {
+				errs = append(errs, fmt.Errorf("gres %q requires a positive slurmd resource limit for %q, got %d", gres.Name, gres.ResourceName, count))
+			}
+		}
+	}
+
+	if timeout := nodeset.Spec.DrainPolicy.Timeout; timeout != nil && timeout.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("drainPolicy.timeout must be positive, got %s", timeout.Duration))
+	}
+
 	if nodeset.Spec.Ssh.Enabled && nodeset.Spec.Ssh.SssdConfRef.Name == "" {
 		errs = append(errs, errors.New("ssh.sssdConfRef.name must not be empty when ssh is enabled"))
 	}
@@ -110,5 +242,258 @@ func (r *NodeSetWebhook) validateNodeSet(nodeset *slinkyv1beta1.NodeSet) (admiss
 		}
 	}
 
+	if autoscaling := nodeset.Spec.Autoscaling; autoscaling.Enabled {
+		if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+			errs = append(errs, fmt.Errorf("autoscaling.enabled requires scalingMode=%s", slinkyv1beta1.ScalingModeStatefulset))
+		}
+		if !nodeset.Spec.Partition.Enabled {
+			errs = append(errs, errors.New("autoscaling.enabled requires partition.enabled=true"))
+		}
+		if autoscaling.MaxReplicas < 1 {
+			errs = append(errs, fmt.Errorf("autoscaling.maxReplicas must be > 0, got %d", autoscaling.MaxReplicas))
+		}
+		if autoscaling.MaxReplicas < autoscaling.MinReplicas {
+			errs = append(errs, fmt.Errorf("autoscaling.maxReplicas (%d) must not be less than autoscaling.minReplicas (%d)",
+				autoscaling.MaxReplicas, autoscaling.MinReplicas))
+		}
+	}
+
+	if nodeset.Spec.PowerSave.Enabled {
+		if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+			errs = append(errs, fmt.Errorf("powerSave.enabled requires scalingMode=%s", slinkyv1beta1.ScalingModeStatefulset))
+		}
+		if !nodeset.Spec.Partition.Enabled {
+			errs = append(errs, errors.New("powerSave.enabled requires partition.enabled=true"))
+		}
+		if nodeset.Spec.Autoscaling.Enabled {
+			errs = append(errs, errors.New("powerSave.enabled and autoscaling.enabled are mutually exclusive"))
+		}
+		if nodeset.Spec.Template.PodSpecWrapper.HostNetwork {
+			errs = append(errs, errors.New("powerSave.enabled is not supported with hostNetwork=true"))
+		}
+		if nodeset.Spec.ResourceSpec.Enabled {
+			errs = append(errs, errors.New("powerSave.enabled and resourceSpec.enabled are mutually exclusive"))
+		}
+	}
+
+	if resourceSpec := nodeset.Spec.ResourceSpec; resourceSpec.Enabled {
+		if resourceSpec.ReservedMemory.Sign() < 0 {
+			errs = append(errs, fmt.Errorf("resourceSpec.reservedMemory must not be negative, got %s", resourceSpec.ReservedMemory.String()))
+		}
+		cpuLimit := nodeset.Spec.Slurmd.Resources.Limits.Cpu()
+		if podResources := nodeset.Spec.Template.PodSpecWrapper.Resources; cpuLimit.IsZero() && podResources != nil {
+			cpuLimit = podResources.Limits.Cpu()
+		}
+		if cpuLimit.IsZero() || cpuLimit.MilliValue()%1000 != 0 {
+			warns = append(warns, "resourceSpec.enabled expects an integer CPU limit for slurmd, as allocated by the static CPU manager")
+		}
+	}
+
+	return warns, errs
+}
+
+// validateNodeSetExtraConf lints the ExtraConf of the Slurm nodes of the NodeSet.
+// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_NODE-CONFIGURATION
+func validateNodeSetExtraConf(nodeset *slinkyv1beta1.NodeSet) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	reservedNodeConfKeys := common.ReservedSlurmNodeConfKeys
+	if nodeset.Spec.ResourceSpec.Enabled {
+		reservedNodeConfKeys = append(slices.Clone(reservedNodeConfKeys), common.ResourceSpecSlurmNodeConfKeys...)
+	}
+	lintWarns, lintErrs := config.LintSlurmNodeConf(nodeset.Spec.ExtraConf, reservedNodeConfKeys...)
+	for _, warn := range lintWarns {
+		warns = append(warns, fmt.Sprintf("extraConf: %s", warn))
+	}
+	for _, err := range lintErrs {
+		errs = append(errs, fmt.Errorf("extraConf: %w", err))
+	}
+
+	return warns, errs
+}
+
+// validateConfigFiles validates the node-scoped config files of the NodeSet.
+// They are included by the config files of the Controller,
+// hence they must not be overridden by the Controller.
+func (r *NodeSetWebhook) validateConfigFiles(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if len(nodeset.Spec.ConfigFileRefs) == 0 {
+		return warns, errs
+	}
+
+	configFiles := make(map[string]string)
+	for _, ref := range nodeset.Spec.ConfigFileRefs {
+		configMap := &corev1.ConfigMap{}
+		configMapKey := types.NamespacedName{
+			Name:      ref.Name,
+			Namespace: nodeset.Namespace,
+		}
+		if err := r.Get(ctx, configMapKey, configMap); err != nil {
+			if apierrors.IsNotFound(err) {
+				warns = append(warns, fmt.Sprintf("the configFileRefs ConfigMap is not found: %s", ref.Name))
+			} else {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		files := structutils.Keys(configMap.Data)
+		sort.Strings(files)
+		for _, file := range files {
+			if !controllerbuilder.IsNodeSetConfigFile(file) {
+				errs = append(errs, fmt.Errorf("the configFile is not a node-scoped config file: %s", file))
+				continue
+			}
+			if _, ok := configFiles[file]; ok {
+				errs = append(errs, fmt.Errorf("the configFile is referenced more than once: %s", file))
+				continue
+			}
+			configFiles[file] = configMap.Data[file]
+			if !slices.Contains(controllerbuilder.NodeSetPropertyConfigFiles, file) {
+				continue
+			}
+			for _, prop := range config.ParseProperties(configMap.Data[file]) {
+				if prop.IsRaw() {
+					warns = append(warns, fmt.Sprintf("the configFile %s line is not a `key=value` property and is ignored: %s", file, prop.Value()))
+				}
+			}
+		}
+	}
+
+	controller := &slinkyv1beta1.Controller{}
+	controllerKey := types.NamespacedName{
+		Name:      nodeset.Spec.ControllerRef.Name,
+		Namespace: nodeset.Namespace,
+	}
+	if err := r.Get(ctx, controllerKey, controller); err != nil {
+		if !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+		return warns, errs
+	}
+	for _, ref := range controller.Spec.ConfigFileRefs {
+		configMap := &corev1.ConfigMap{}
+		configMapKey := types.NamespacedName{
+			Name:      ref.Name,
+			Namespace: controller.Namespace,
+		}
+		if err := r.Get(ctx, configMapKey, configMap); err != nil {
+			continue
+		}
+		for _, file := range structutils.Keys(configMap.Data) {
+			if _, ok := configFiles[file]; ok {
+				errs = append(errs, fmt.Errorf("the configFile conflicts with the configFileRefs of Controller %s: %s", controller.Name, file))
+			}
+		}
+	}
+
+	return warns, errs
+}
+
+// validatePartitionConflicts validates the partition of the NodeSet against the
+// other partitions of its Controller, which are declared by Partitions, other
+// NodeSets, or the Controller extraConf. Slurm rejects a slurm.conf with
+// duplicate partition names.
+func (r *NodeSetWebhook) validatePartitionConflicts(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) []error {
+	var errs []error
+
+	controllerName := nodeset.Spec.ControllerRef.Name
+	if !nodeset.Spec.Partition.Enabled || controllerName == "" {
+		return nil
+	}
+	name := common.GetSlurmNodeSetName(nodeset)
+
+	partitionList := &slinkyv1beta1.PartitionList{}
+	if err := r.List(ctx, partitionList, client.InNamespace(nodeset.Namespace)); err != nil {
+		return append(errs, err)
+	}
+	for _, partition := range partitionList.Items {
+		if partition.Spec.ControllerRef.Name == controllerName && partition.Name == name {
+			errs = append(errs, fmt.Errorf("partition name conflicts with Partition %s: %s", partition.Name, name))
+		}
+	}
+
+	nodesetList := &slinkyv1beta1.NodeSetList{}
+	if err := r.List(ctx, nodesetList, client.InNamespace(nodeset.Namespace)); err != nil {
+		return append(errs, err)
+	}
+	for _, other := range nodesetList.Items {
+		if other.Name == nodeset.Name || other.Spec.ControllerRef.Name != controllerName || !other.Spec.Partition.Enabled {
+			continue
+		}
+		if common.GetSlurmNodeSetName(&other) == name {
+			errs = append(errs, fmt.Errorf("partition name conflicts with the partition of NodeSet %s: %s", other.Name, name))
+		}
+	}
+
+	controller := &slinkyv1beta1.Controller{}
+	controllerKey := types.NamespacedName{
+		Name:      controllerName,
+		Namespace: nodeset.Namespace,
+	}
+	if err := r.Get(ctx, controllerKey, controller); err != nil {
+		if !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+		return errs
+	}
+	lines, _ := config.ParseSlurmConf(controller.Spec.ExtraConf)
+	for _, line := range lines {
+		if len(line.Params) == 0 || !strings.EqualFold(line.Params[0].Key, "PartitionName") {
+			continue
+		}
+		if line.Params[0].Value == name {
+			errs = append(errs, fmt.Errorf("partition name conflicts with the extraConf of Controller %s: %s", controller.Name, name))
+		}
+	}
+
+	return errs
+}
+
+// validateScriptRefs validates the prolog or epilog scripts of the NodeSet.
+// The scripts of all refs are mounted into the same directory, hence their
+// filenames must be unique.
+func (r *NodeSetWebhook) validateScriptRefs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, field string, refs []corev1.LocalObjectReference) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	scripts := set.New[string]()
+	for _, ref := range refs {
+		configMap := &corev1.ConfigMap{}
+		configMapKey := types.NamespacedName{
+			Name:      ref.Name,
+			Namespace: nodeset.Namespace,
+		}
+		if err := r.Get(ctx, configMapKey, configMap); err != nil {
+			if apierrors.IsNotFound(err) {
+				warns = append(warns, fmt.Sprintf("the %s ConfigMap is not found: %s", field, ref.Name))
+			} else {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		for _, script := range structutils.Keys(configMap.Data) {
+			if scripts.Has(script) {
+				errs = append(errs, fmt.Errorf("%s script is referenced more than once: %s", field, script))
+			}
+			scripts.Insert(script)
+		}
+	}
+
 	return warns, errs
 }
+
+func isResourceClaimTemplatesEqual(oldTemplates, newTemplates []slinkyv1beta1.NodeSetResourceClaimTemplate) bool {
+	if len(oldTemplates) != len(newTemplates) {
+		return false
+	}
+	for i := range oldTemplates {
+		if oldTemplates[i].Name != newTemplates[i].Name ||
+			!apiequality.Semantic.DeepEqual(oldTemplates[i].Spec, newTemplates[i].Spec) {
+			return false
+		}
+	}
+	return true
+}
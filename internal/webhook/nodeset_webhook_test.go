@@ -4,14 +4,27 @@
 package webhook
 
 import (
+	"context"
+	"testing"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
@@ -42,6 +55,97 @@ var _ = Describe("NodeSet Webhook", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("Should deny if maxSurge is negative", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(-1))
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if maxSurge is set with scalingMode=DaemonSet", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+			nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(1))
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if partition is set with scalingMode=DaemonSet", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+			nodeset.Spec.UpdateStrategy.RollingUpdate.Partition = ptr.To[int32](1)
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if maxSurge is set with powerSave", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.PowerSave.Enabled = true
+			nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromString("10%"))
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if maxSurge is configured", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromString("25%"))
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if topologyLabels topology is malformed", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.TopologyLabels = []slinkyv1beta1.NodeSetTopologyLabel{
+				{Topology: "topo-block:b0", Key: "example.com/rack"},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if topologyLabels is configured", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.TopologyLabels = []slinkyv1beta1.NodeSetTopologyLabel{
+				{Topology: "topo-block", Key: "example.com/rack"},
+				{Topology: "topo-switch", Key: "topology.kubernetes.io/zone"},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if drainPolicy.timeout is not positive", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.DrainPolicy.Timeout = &metav1.Duration{}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if drainPolicy is configured", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.DrainPolicy.Timeout = &metav1.Duration{Duration: time.Hour}
+			nodeset.Spec.DrainPolicy.TimeoutAction = slinkyv1beta1.DrainTimeoutActionCancel
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("Should deny if SSH is enabled without sssdConfRef", func(ctx SpecContext) {
 			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
 			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
@@ -69,6 +173,226 @@ var _ = Describe("NodeSet Webhook", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("Should deny if autoscaling is enabled without a partition", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = false
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MaxReplicas = 4
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if autoscaling maxReplicas is less than minReplicas", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MinReplicas = 4
+			nodeset.Spec.Autoscaling.MaxReplicas = 2
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if autoscaling is enabled with scalingMode=DaemonSet", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MaxReplicas = 4
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if autoscaling is configured", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MinReplicas = 1
+			nodeset.Spec.Autoscaling.MaxReplicas = 4
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if powerSave is enabled without a partition", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = false
+			nodeset.Spec.PowerSave.Enabled = true
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if powerSave and autoscaling are both enabled", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.PowerSave.Enabled = true
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MaxReplicas = 4
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if powerSave is enabled with hostNetwork", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.PowerSave.Enabled = true
+			nodeset.Spec.Template.PodSpecWrapper.HostNetwork = true
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if powerSave is enabled with resourceSpec", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.PowerSave.Enabled = true
+			nodeset.Spec.ResourceSpec.Enabled = true
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should warn if resourceSpec is enabled without a CPU limit", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ResourceSpec.Enabled = true
+
+			warns, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).NotTo(BeEmpty())
+		})
+
+		It("Should admit if resourceSpec is configured", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Slurmd.Resources.Limits = corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("8"),
+			}
+			nodeset.Spec.ResourceSpec = slinkyv1beta1.NodeSetResourceSpec{
+				Enabled:        true,
+				ReservedCpus:   1,
+				ReservedMemory: resource.MustParse("1Gi"),
+			}
+
+			warns, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(BeEmpty())
+		})
+
+		It("Should admit if powerSave is configured", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 4)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.PowerSave.Enabled = true
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if gres has no slurmd resource limit", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Gres = []slinkyv1beta1.NodeSetGres{
+				{ResourceName: "nvidia.com/gpu", Name: "gpu"},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if gres name contains ':'", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Slurmd.Resources.Limits = corev1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("4"),
+			}
+			nodeset.Spec.Gres = []slinkyv1beta1.NodeSetGres{
+				{ResourceName: "nvidia.com/gpu", Name: "gpu:a100"},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if gres is configured", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Slurmd.Resources.Limits = corev1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("4"),
+			}
+			nodeset.Spec.Gres = []slinkyv1beta1.NodeSetGres{
+				{ResourceName: "nvidia.com/gpu", Name: "gpu", Type: "a100", AutoDetect: "nvml"},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny if gres resourceClaim has no resourceClaimTemplate", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Gres = []slinkyv1beta1.NodeSetGres{
+				{ResourceClaim: "gpu", Name: "gpu"},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if gres sets both resourceName and resourceClaim", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Slurmd.Resources.Limits = corev1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("4"),
+			}
+			nodeset.Spec.ResourceClaimTemplates = []slinkyv1beta1.NodeSetResourceClaimTemplate{
+				{Name: "gpu", Spec: newResourceClaimSpec(4)},
+			}
+			nodeset.Spec.Gres = []slinkyv1beta1.NodeSetGres{
+				{ResourceName: "nvidia.com/gpu", ResourceClaim: "gpu", Name: "gpu"},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if resourceClaimTemplates feature contains ','", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ResourceClaimTemplates = []slinkyv1beta1.NodeSetResourceClaimTemplate{
+				{Name: "gpu", Spec: newResourceClaimSpec(4), Features: []string{"gpu,a100"}},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if gres is backed by a resourceClaim", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ResourceClaimTemplates = []slinkyv1beta1.NodeSetResourceClaimTemplate{
+				{Name: "gpu", Spec: newResourceClaimSpec(4), Features: []string{"a100"}},
+			}
+			nodeset.Spec.Gres = []slinkyv1beta1.NodeSetGres{
+				{ResourceClaim: "gpu", Name: "gpu", AutoDetect: "nvml"},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("Should admit if all required fields are provided", func(ctx SpecContext) {
 			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
 			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
@@ -103,6 +427,34 @@ var _ = Describe("NodeSet Webhook", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("Should reject changes to resourceClaimTemplates", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldNodeSet := testutils.NewNodeset("test-nodeset", controller, 1)
+			oldNodeSet.Spec.ResourceClaimTemplates = []slinkyv1beta1.NodeSetResourceClaimTemplate{
+				{Name: "gpu", Spec: newResourceClaimSpec(4)},
+			}
+
+			newNodeSet := oldNodeSet.DeepCopy()
+			newNodeSet.Spec.ResourceClaimTemplates[0].Spec = newResourceClaimSpec(8)
+
+			_, err := nodeSetWebhook.ValidateUpdate(ctx, oldNodeSet, newNodeSet)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit changes to resourceClaimTemplates features", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldNodeSet := testutils.NewNodeset("test-nodeset", controller, 1)
+			oldNodeSet.Spec.ResourceClaimTemplates = []slinkyv1beta1.NodeSetResourceClaimTemplate{
+				{Name: "gpu", Spec: newResourceClaimSpec(4)},
+			}
+
+			newNodeSet := oldNodeSet.DeepCopy()
+			newNodeSet.Spec.ResourceClaimTemplates[0].Features = []string{"a100"}
+
+			_, err := nodeSetWebhook.ValidateUpdate(ctx, oldNodeSet, newNodeSet)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("Should admit if no immutable fields change", func(ctx SpecContext) {
 			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
 			oldNodeSet := testutils.NewNodeset("test-nodeset", controller, 1)
@@ -122,3 +474,443 @@ var _ = Describe("NodeSet Webhook", func() {
 		})
 	})
 })
+
+func newResourceClaimSpec(count int64) resourcev1.ResourceClaimSpec {
+	return resourcev1.ResourceClaimSpec{
+		Devices: resourcev1.DeviceClaim{
+			Requests: []resourcev1.DeviceRequest{
+				{
+					Name: "gpu",
+					Exactly: &resourcev1.ExactDeviceRequest{
+						DeviceClassName: "gpu.nvidia.com",
+						Count:           count,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestNodeSetWebhook_validateConfigFiles(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(slinkyv1beta1.AddToScheme(scheme))
+
+	newConfigMap := func(name string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: corev1.NamespaceDefault,
+			},
+			Data: data,
+		}
+	}
+	newNodeSet := func(name string, configFileRefs ...string) *slinkyv1beta1.NodeSet {
+		nodeset := &slinkyv1beta1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: corev1.NamespaceDefault,
+			},
+			Spec: slinkyv1beta1.NodeSetSpec{
+				ControllerRef: corev1.LocalObjectReference{Name: "slurm"},
+			},
+		}
+		for _, ref := range configFileRefs {
+			nodeset.Spec.ConfigFileRefs = append(nodeset.Spec.ConfigFileRefs, corev1.LocalObjectReference{Name: ref})
+		}
+		return nodeset
+	}
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "slurm",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			ConfigFileRefs: []corev1.LocalObjectReference{{Name: "slurm-config"}},
+		},
+	}
+	controllerConfig := newConfigMap("slurm-config", map[string]string{
+		"acct_gather.conf": "ProfileHDF5Dir=/tmp",
+	})
+	gpuConfig := newConfigMap("gpu-config", map[string]string{
+		"cgroup.conf": "ConstrainDevices=yes\nAllowedRAMSpace=95",
+		"gres.conf":   "NodeName=gpu-[0-3] AutoDetect=nvml",
+	})
+
+	tests := []struct {
+		name      string
+		objects   []client.Object
+		nodeset   *slinkyv1beta1.NodeSet
+		wantWarns int
+		wantErrs  int
+	}{
+		{
+			name:    "no config files",
+			nodeset: newNodeSet("cpu"),
+		},
+		{
+			name:      "missing configMap",
+			nodeset:   newNodeSet("cpu", "cpu-config"),
+			wantWarns: 1,
+		},
+		{
+			name:    "node-scoped config files",
+			objects: []client.Object{controller, controllerConfig, gpuConfig},
+			nodeset: newNodeSet("gpu", "gpu-config"),
+		},
+		{
+			name: "not node-scoped config file",
+			objects: []client.Object{
+				newConfigMap("cpu-config", map[string]string{"slurm.conf": "", "topology.conf": ""}),
+			},
+			nodeset:  newNodeSet("cpu", "cpu-config"),
+			wantErrs: 2,
+		},
+		{
+			name: "referenced more than once",
+			objects: []client.Object{
+				gpuConfig,
+				newConfigMap("gpu-config-2", map[string]string{"gres.conf": ""}),
+			},
+			nodeset:  newNodeSet("gpu", "gpu-config", "gpu-config-2"),
+			wantErrs: 1,
+		},
+		{
+			name: "not a property",
+			objects: []client.Object{
+				newConfigMap("cpu-config", map[string]string{"cgroup.conf": "ConstrainCores yes"}),
+			},
+			nodeset:   newNodeSet("cpu", "cpu-config"),
+			wantWarns: 1,
+		},
+		{
+			name: "conflicts with controller",
+			objects: []client.Object{
+				controller, controllerConfig,
+				newConfigMap("cpu-config", map[string]string{"acct_gather.conf": "ProfileHDF5Dir=/tmp"}),
+			},
+			nodeset:  newNodeSet("cpu", "cpu-config"),
+			wantErrs: 1,
+		},
+		{
+			name: "differs from other nodeset",
+			objects: []client.Object{
+				controller, controllerConfig, gpuConfig,
+				newNodeSet("gpu", "gpu-config"),
+				newConfigMap("cpu-config", map[string]string{"cgroup.conf": "allowedramspace=90\nConstrainDevices=yes"}),
+			},
+			nodeset: newNodeSet("cpu", "cpu-config"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &NodeSetWebhook{
+				Client: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(tt.objects...).
+					Build(),
+			}
+			warns, errs := r.validateConfigFiles(context.TODO(), tt.nodeset)
+			require.Len(t, warns, tt.wantWarns)
+			require.Len(t, errs, tt.wantErrs, errs)
+		})
+	}
+}
+
+func TestNodeSetWebhook_ValidateUpdate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(slinkyv1beta1.AddToScheme(scheme))
+
+	newConfigMap := func(name string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: corev1.NamespaceDefault,
+			},
+			Data: data,
+		}
+	}
+	objects := []client.Object{
+		newConfigMap("cpu-config", map[string]string{"slurm.conf": ""}),
+		newConfigMap("cpu-prolog", map[string]string{"00-cpu.sh": ""}),
+		newConfigMap("cpu-epilog", map[string]string{"00-cpu.sh": ""}),
+	}
+	oldNodeSet := &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cpu",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.NodeSetSpec{
+			ControllerRef:    corev1.LocalObjectReference{Name: "slurm"},
+			ExtraConf:        "NodeName=foo",
+			ConfigFileRefs:   []corev1.LocalObjectReference{{Name: "cpu-config"}},
+			PrologScriptRefs: []corev1.LocalObjectReference{{Name: "cpu-prolog"}, {Name: "cpu-prolog"}},
+			EpilogScriptRefs: []corev1.LocalObjectReference{{Name: "cpu-epilog"}, {Name: "cpu-epilog"}},
+		},
+	}
+	newNodeSet := func(fn func(nodeset *slinkyv1beta1.NodeSet)) *slinkyv1beta1.NodeSet {
+		nodeset := oldNodeSet.DeepCopy()
+		fn(nodeset)
+		return nodeset
+	}
+
+	tests := []struct {
+		name       string
+		newNodeSet *slinkyv1beta1.NodeSet
+		wantErr    bool
+	}{
+		{
+			name:       "unchanged refs",
+			newNodeSet: newNodeSet(func(nodeset *slinkyv1beta1.NodeSet) { nodeset.Spec.Replicas = ptr.To[int32](2) }),
+		},
+		{
+			name:       "changed extraConf",
+			newNodeSet: newNodeSet(func(nodeset *slinkyv1beta1.NodeSet) { nodeset.Spec.ExtraConf = "NodeName=bar" }),
+			wantErr:    true,
+		},
+		{
+			name: "changed configFileRefs",
+			newNodeSet: newNodeSet(func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.ConfigFileRefs = append(nodeset.Spec.ConfigFileRefs, corev1.LocalObjectReference{Name: "gpu-config"})
+			}),
+			wantErr: true,
+		},
+		{
+			name: "changed prologScriptRefs",
+			newNodeSet: newNodeSet(func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.PrologScriptRefs = append(nodeset.Spec.PrologScriptRefs, corev1.LocalObjectReference{Name: "gpu-prolog"})
+			}),
+			wantErr: true,
+		},
+		{
+			name: "changed epilogScriptRefs",
+			newNodeSet: newNodeSet(func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.EpilogScriptRefs = append(nodeset.Spec.EpilogScriptRefs, corev1.LocalObjectReference{Name: "gpu-epilog"})
+			}),
+			wantErr: true,
+		},
+		{
+			name: "changed refs, deleting",
+			newNodeSet: newNodeSet(func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.DeletionTimestamp = ptr.To(metav1.Now())
+				nodeset.Spec.ConfigFileRefs = append(nodeset.Spec.ConfigFileRefs, corev1.LocalObjectReference{Name: "gpu-config"})
+				nodeset.Spec.PrologScriptRefs = append(nodeset.Spec.PrologScriptRefs, corev1.LocalObjectReference{Name: "gpu-prolog"})
+				nodeset.Spec.EpilogScriptRefs = append(nodeset.Spec.EpilogScriptRefs, corev1.LocalObjectReference{Name: "gpu-epilog"})
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &NodeSetWebhook{
+				Client: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(objects...).
+					Build(),
+			}
+			_, err := r.ValidateUpdate(context.TODO(), oldNodeSet, tt.newNodeSet)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNodeSetWebhook_validateScriptRefs(t *testing.T) {
+	newConfigMap := func(name string, scripts ...string) *corev1.ConfigMap {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: corev1.NamespaceDefault,
+			},
+			Data: map[string]string{},
+		}
+		for _, script := range scripts {
+			configMap.Data[script] = "#!/bin/sh\nexit 0"
+		}
+		return configMap
+	}
+	nodeset := &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gpu",
+			Namespace: corev1.NamespaceDefault,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		objects   []client.Object
+		refs      []corev1.LocalObjectReference
+		wantWarns int
+		wantErrs  int
+	}{
+		{
+			name: "no scripts",
+		},
+		{
+			name:      "missing configMap",
+			refs:      []corev1.LocalObjectReference{{Name: "gpu-prolog"}},
+			wantWarns: 1,
+		},
+		{
+			name: "unique scripts",
+			objects: []client.Object{
+				newConfigMap("gpu-prolog", "00-gpu.sh"),
+				newConfigMap("dcgm-prolog", "90-dcgm.sh"),
+			},
+			refs:     []corev1.LocalObjectReference{{Name: "gpu-prolog"}, {Name: "dcgm-prolog"}},
+			wantErrs: 0,
+		},
+		{
+			name: "duplicate scripts",
+			objects: []client.Object{
+				newConfigMap("gpu-prolog", "00-gpu.sh"),
+				newConfigMap("dcgm-prolog", "00-gpu.sh", "90-dcgm.sh"),
+			},
+			refs:     []corev1.LocalObjectReference{{Name: "gpu-prolog"}, {Name: "dcgm-prolog"}},
+			wantErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &NodeSetWebhook{
+				Client: fake.NewClientBuilder().WithObjects(tt.objects...).Build(),
+			}
+			warns, errs := r.validateScriptRefs(context.TODO(), nodeset, "prologScriptRefs", tt.refs)
+			require.Len(t, warns, tt.wantWarns)
+			require.Len(t, errs, tt.wantErrs, errs)
+		})
+	}
+}
+
+func TestNodeSetWebhook_validatePartitionConflicts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(slinkyv1beta1.AddToScheme(scheme))
+
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	otherController := testutils.NewController("other", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	newNodeSet := func(name string, controller *slinkyv1beta1.Controller, enabled bool) *slinkyv1beta1.NodeSet {
+		nodeset := testutils.NewNodeset(name, controller, 1)
+		nodeset.Spec.Partition.Enabled = enabled
+		return nodeset
+	}
+	newController := func(extraConf string) *slinkyv1beta1.Controller {
+		controller := controller.DeepCopy()
+		controller.Spec.ExtraConf = extraConf
+		return controller
+	}
+
+	tests := []struct {
+		name     string
+		objects  []client.Object
+		nodeset  *slinkyv1beta1.NodeSet
+		wantErrs int
+	}{
+		{
+			name: "no conflicts",
+			objects: []client.Object{
+				newController("PartitionName=debug Nodes=ALL"),
+				newNodeSet("gpu", controller, true),
+				testutils.NewPartition("batch", controller),
+			},
+			nodeset: newNodeSet("cpu", controller, true),
+		},
+		{
+			name:    "partition disabled",
+			objects: []client.Object{testutils.NewPartition("cpu", controller)},
+			nodeset: newNodeSet("cpu", controller, false),
+		},
+		{
+			name:     "partition name",
+			objects:  []client.Object{testutils.NewPartition("cpu", controller)},
+			nodeset:  newNodeSet("cpu", controller, true),
+			wantErrs: 1,
+		},
+		{
+			name:    "partition of other controller",
+			objects: []client.Object{testutils.NewPartition("cpu", otherController)},
+			nodeset: newNodeSet("cpu", controller, true),
+		},
+		{
+			name: "nodeset partition name",
+			objects: []client.Object{
+				func() client.Object {
+					nodeset := newNodeSet("cpu-2", controller, true)
+					nodeset.Spec.Template.PodSpecWrapper.Hostname = "cpu-"
+					return nodeset
+				}(),
+			},
+			nodeset:  newNodeSet("cpu", controller, true),
+			wantErrs: 1,
+		},
+		{
+			name:    "update of same nodeset",
+			objects: []client.Object{newNodeSet("cpu", controller, true)},
+			nodeset: newNodeSet("cpu", controller, true),
+		},
+		{
+			name:     "controller extraConf partition",
+			objects:  []client.Object{newController("partitionname=cpu Nodes=ALL")},
+			nodeset:  newNodeSet("cpu", controller, true),
+			wantErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &NodeSetWebhook{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+			}
+			errs := r.validatePartitionConflicts(context.TODO(), tt.nodeset)
+			require.Len(t, errs, tt.wantErrs, errs)
+		})
+	}
+}
+
+func Test_validateNodeSetExtraConf(t *testing.T) {
+	tests := []struct {
+		name         string
+		extraConf    string
+		resourceSpec bool
+		wantWarns    int
+		wantErrs     int
+	}{
+		{
+			name:      "valid",
+			extraConf: "Weight=5 Features=foo CPUs=8",
+		},
+		{
+			name:      "malformed",
+			extraConf: "Weight=5 Features",
+			wantErrs:  1,
+		},
+		{
+			name:      "unknown and reserved",
+			extraConf: "Wieght=5 NodeName=foo",
+			wantWarns: 1,
+			wantErrs:  1,
+		},
+		{
+			name:         "resourceSpec",
+			extraConf:    "CPUs=8 RealMemory=4096",
+			resourceSpec: true,
+			wantErrs:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					ControllerRef: corev1.LocalObjectReference{Name: "slurm"},
+					ExtraConf:     tt.extraConf,
+				},
+			}
+			nodeset.Spec.ResourceSpec.Enabled = tt.resourceSpec
+			warns, errs := validateNodeSetExtraConf(nodeset)
+			require.Len(t, warns, tt.wantWarns, warns)
+			require.Len(t, errs, tt.wantErrs, errs)
+		})
+	}
+}
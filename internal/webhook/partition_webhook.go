@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+)
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=partitions,verbs=get;list;watch;delete;create;update
+
+type PartitionWebhook struct {
+	client.Client
+}
+
+// log is for logging in this package.
+var partitionlog = logf.Log.WithName("partition-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *PartitionWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &slinkyv1beta1.Partition{}).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-partition,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=partitions,verbs=create;update,versions=v1beta1,name=partition-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ admission.Validator[*slinkyv1beta1.Partition] = &PartitionWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *PartitionWebhook) ValidateCreate(ctx context.Context, partition *slinkyv1beta1.Partition) (admission.Warnings, error) {
+	partitionlog.Info("validate create", "partition", klog.KObj(partition))
+
+	warns, errs := r.validatePartition(ctx, partition)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *PartitionWebhook) ValidateUpdate(ctx context.Context, oldPartition, newPartition *slinkyv1beta1.Partition) (admission.Warnings, error) {
+	partitionlog.Info("validate update", "newPartition", klog.KObj(newPartition))
+
+	warns, errs := r.validatePartition(ctx, newPartition)
+
+	if !apiequality.Semantic.DeepEqual(newPartition.Spec.ControllerRef, oldPartition.Spec.ControllerRef) {
+		errs = append(errs, errors.New("cannot change controllerRef after deployment"))
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *PartitionWebhook) ValidateDelete(ctx context.Context, partition *slinkyv1beta1.Partition) (admission.Warnings, error) {
+	partitionlog.Info("validate delete", "partition", klog.KObj(partition))
+
+	return nil, nil
+}
+
+func (r *PartitionWebhook) validatePartition(ctx context.Context, partition *slinkyv1beta1.Partition) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	if partition.Spec.ControllerRef.Name == "" {
+		errs = append(errs, errors.New("controllerRef.name must not be empty"))
+	}
+
+	if selector := partition.Spec.NodeSetSelector; selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+			errs = append(errs, fmt.Errorf("nodeSetSelector is invalid: %w", err))
+		}
+	}
+
+	if len(partition.Spec.NodeSets) == 0 && partition.Spec.NodeSetSelector == nil {
+		warns = append(warns, "partition has no nodeSets nor nodeSetSelector, it will not contain any nodes")
+	}
+
+	if strings.ContainsAny(partition.Spec.MaxTime, " \t\r\n") {
+		errs = append(errs, errors.New("maxTime must not contain whitespace"))
+	}
+
+	for _, account := range partition.Spec.AllowAccounts {
+		if account == "" || strings.ContainsAny(account, ", \t\r\n") {
+			errs = append(errs, fmt.Errorf("allowAccounts contains an invalid account name %q", account))
+		}
+	}
+
+	if strings.ContainsAny(partition.Spec.ExtraConf, "\r\n") {
+		errs = append(errs, errors.New("extraConf must not contain newlines"))
+	}
+
+	errs = append(errs, r.validatePartitionConflicts(ctx, partition)...)
+
+	return warns, errs
+}
+
+// validatePartitionConflicts validates the Partition against the other
+// partitions of its Controller. Slurm rejects a slurm.conf with duplicate
+// partition names, and silently picks one of several default partitions.
+func (r *PartitionWebhook) validatePartitionConflicts(ctx context.Context, partition *slinkyv1beta1.Partition) []error {
+	var errs []error
+
+	controllerName := partition.Spec.ControllerRef.Name
+	if controllerName == "" {
+		return nil
+	}
+
+	nodesetList := &slinkyv1beta1.NodeSetList{}
+	if err := r.List(ctx, nodesetList, client.InNamespace(partition.Namespace)); err != nil {
+		return append(errs, err)
+	}
+	for _, nodeset := range nodesetList.Items {
+		if nodeset.Spec.ControllerRef.Name != controllerName || !nodeset.Spec.Partition.Enabled {
+			continue
+		}
+		if common.GetSlurmNodeSetName(&nodeset) == partition.Name {
+			errs = append(errs, fmt.Errorf("partition name conflicts with the partition of NodeSet %s: %s", nodeset.Name, partition.Name))
+		}
+	}
+
+	if !partition.Spec.Default {
+		return errs
+	}
+	partitionList := &slinkyv1beta1.PartitionList{}
+	if err := r.List(ctx, partitionList, client.InNamespace(partition.Namespace)); err != nil {
+		return append(errs, err)
+	}
+	for _, other := range partitionList.Items {
+		if other.Name == partition.Name || other.Spec.ControllerRef.Name != controllerName {
+			continue
+		}
+		if other.Spec.Default {
+			errs = append(errs, fmt.Errorf("default must not be set, Partition %s is already the default partition", other.Name))
+		}
+	}
+
+	return errs
+}
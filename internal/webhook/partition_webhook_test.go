@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("Partition Webhook", func() {
+	Context("When Creating a Partition with Validating Webhook", func() {
+		It("Should deny if controllerRef.name is empty", func(ctx SpecContext) {
+			partition := testutils.NewPartition("test-partition", nil)
+
+			_, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if nodeSetSelector is invalid", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition := testutils.NewPartition("test-partition", controller)
+			partition.Spec.NodeSetSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "tier", Operator: "Bogus"},
+				},
+			}
+
+			_, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if allowAccounts contains a comma", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition := testutils.NewPartition("test-partition", controller)
+			partition.Spec.AllowAccounts = []string{"physics,chemistry"}
+
+			_, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if extraConf contains a newline", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition := testutils.NewPartition("test-partition", controller)
+			partition.Spec.ExtraConf = "Hidden=YES\nSchedulerParameters=malicious"
+
+			_, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should warn if no NodeSets are selected", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			partition := testutils.NewPartition("test-partition", controller)
+
+			warns, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).NotTo(BeEmpty())
+		})
+
+		It("Should admit if all required fields are provided", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			partition := testutils.NewPartition("test-partition", controller, nodeset)
+			partition.Spec.MaxTime = "1-00:00:00"
+			partition.Spec.AllowAccounts = []string{"physics"}
+
+			warns, err := partitionWebhook.ValidateCreate(ctx, partition)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(BeEmpty())
+		})
+	})
+
+	Context("When Updating a Partition with Validating Webhook", func() {
+		It("Should reject changes to controllerRef", func(ctx SpecContext) {
+			oldController := testutils.NewController("old-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldPartition := testutils.NewPartition("test-partition", oldController)
+
+			newController := testutils.NewController("new-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			newPartition := testutils.NewPartition("test-partition", newController)
+
+			_, err := partitionWebhook.ValidateUpdate(ctx, oldPartition, newPartition)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if no immutable fields change", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldPartition := testutils.NewPartition("test-partition", controller)
+			newPartition := testutils.NewPartition("test-partition", controller, testutils.NewNodeset("test-nodeset", controller, 1))
+
+			_, err := partitionWebhook.ValidateUpdate(ctx, oldPartition, newPartition)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When Deleting a Partition with Validating Webhook", func() {
+		It("Should admit a Delete", func(ctx SpecContext) {
+			partition := testutils.NewPartition("test-partition", nil)
+
+			_, err := partitionWebhook.ValidateDelete(ctx, partition)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
+
+func TestPartitionWebhook_validatePartitionConflicts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(slinkyv1beta1.AddToScheme(scheme))
+
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	otherController := testutils.NewController("other", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	newNodeSet := func(name string, controller *slinkyv1beta1.Controller, enabled bool) *slinkyv1beta1.NodeSet {
+		nodeset := testutils.NewNodeset(name, controller, 1)
+		nodeset.Spec.Partition.Enabled = enabled
+		return nodeset
+	}
+	newPartition := func(name string, controller *slinkyv1beta1.Controller, isDefault bool) *slinkyv1beta1.Partition {
+		partition := testutils.NewPartition(name, controller)
+		partition.Spec.Default = isDefault
+		return partition
+	}
+
+	tests := []struct {
+		name      string
+		objects   []client.Object
+		partition *slinkyv1beta1.Partition
+		wantErrs  int
+	}{
+		{
+			name:      "no conflicts",
+			objects:   []client.Object{newNodeSet("gpu", controller, true)},
+			partition: newPartition("batch", controller, true),
+		},
+		{
+			name:      "nodeset partition name",
+			objects:   []client.Object{newNodeSet("batch", controller, true)},
+			partition: newPartition("batch", controller, false),
+			wantErrs:  1,
+		},
+		{
+			name:      "nodeset partition disabled",
+			objects:   []client.Object{newNodeSet("batch", controller, false)},
+			partition: newPartition("batch", controller, false),
+		},
+		{
+			name:      "nodeset of other controller",
+			objects:   []client.Object{newNodeSet("batch", otherController, true)},
+			partition: newPartition("batch", controller, false),
+		},
+		{
+			name:      "second default",
+			objects:   []client.Object{newPartition("debug", controller, true)},
+			partition: newPartition("batch", controller, true),
+			wantErrs:  1,
+		},
+		{
+			name:      "default of other controller",
+			objects:   []client.Object{newPartition("debug", otherController, true)},
+			partition: newPartition("batch", controller, true),
+		},
+		{
+			name:      "update of default",
+			objects:   []client.Object{newPartition("batch", controller, true)},
+			partition: newPartition("batch", controller, true),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PartitionWebhook{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+			}
+			errs := r.validatePartitionConflicts(context.TODO(), tt.partition)
+			require.Len(t, errs, tt.wantErrs)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -17,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
 )
@@ -37,6 +39,7 @@ func (r *PodBindingWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;update;patch;watch
 // +kubebuilder:rbac:groups="",resources=pods/binding,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets,verbs=get;list;watch
 // +kubebuilder:webhook:path=/mutate--v1-binding,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups="",resources=pods/binding,verbs=create,versions=v1,name=podsbinding-v1.kb.io,admissionReviewVersions=v1
 
 var _ admission.Defaulter[*corev1.Binding] = &PodBindingWebhook{}
@@ -66,9 +69,21 @@ func (r *PodBindingWebhook) Default(ctx context.Context, binding *corev1.Binding
 		return err
 	}
 
-	topologySpec := node.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec]
+	nodeset := &slinkyv1beta1.NodeSet{}
+	if ref := metav1.GetControllerOf(pod); ref != nil && ref.Kind == slinkyv1beta1.NodeSetKind {
+		nodesetKey := types.NamespacedName{Namespace: pod.Namespace, Name: ref.Name}
+		if err := r.Get(ctx, nodesetKey, nodeset); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	topologySpec := common.GetSlurmNodeTopologySpec(nodeset, node)
 	mutateFn := func(pod *corev1.Pod) error {
 		pod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec] = topologySpec
+		if nodeset.Spec.ResourceSpec.Enabled {
+			// The slurmd container reads the resource spec on start.
+			pod.Annotations[slinkyv1beta1.AnnotationPodResourceSpec] = common.GetSlurmNodeResourceSpec(nodeset, pod, node)
+		}
 		return nil
 	}
 	if err := objectutils.PatchObject(r.Client, ctx, pod, mutateFn); err != nil {
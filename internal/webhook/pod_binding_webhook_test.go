@@ -10,7 +10,11 @@ import (
 
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
@@ -58,6 +62,72 @@ func TestPodBindingWebhook_Default(t *testing.T) {
 		},
 	}
 
+	nodeset := &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.NodeSetSpec{
+			TopologyLabels: []slinkyv1beta1.NodeSetTopologyLabel{
+				{Topology: "topo-switch", Key: "topology.kubernetes.io/zone"},
+				{Topology: "topo-block", Key: "example.com/rack"},
+			},
+		},
+	}
+
+	nodesetPod := workerPod.DeepCopy()
+	nodesetPod.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(nodeset, slinkyv1beta1.NodeSetGVK),
+	}
+
+	nodeWithLabels := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-3",
+			Annotations: map[string]string{
+				slinkyv1beta1.AnnotationNodeTopologySpec: "topo-block:b3",
+			},
+			Labels: map[string]string{
+				"topology.kubernetes.io/zone": "zone-a",
+				"example.com/rack":            "rack-3",
+			},
+		},
+	}
+
+	resourceSpecNodeSet := nodeset.DeepCopy()
+	resourceSpecNodeSet.Name = "worker-rs"
+	resourceSpecNodeSet.Spec.ResourceSpec = slinkyv1beta1.NodeSetResourceSpec{
+		Enabled:      true,
+		ReservedCpus: 2,
+	}
+	resourceSpecPod := workerPod.DeepCopy()
+	resourceSpecPod.Name = "worker-rs-0"
+	resourceSpecPod.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(resourceSpecNodeSet, slinkyv1beta1.NodeSetGVK),
+	}
+	resourceSpecPod.Spec.Containers = []corev1.Container{
+		{
+			Name: labels.WorkerApp,
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("8"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+				},
+			},
+		},
+	}
+	nodeWithHardware := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-4",
+			Annotations: map[string]string{
+				slinkyv1beta1.AnnotationNodeHardware: "Sockets=2 CoresPerSocket=32 ThreadsPerCore=2",
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(slinkyv1beta1.AddToScheme(scheme))
+
 	type args struct {
 		ctx     context.Context
 		binding *corev1.Binding
@@ -69,6 +139,7 @@ func TestPodBindingWebhook_Default(t *testing.T) {
 		wantErr       bool
 		wantTopology  string
 		checkTopology bool
+		wantResources string
 	}{
 		{
 			name:   "Worker pod gets topology annotation from node",
@@ -104,6 +175,47 @@ func TestPodBindingWebhook_Default(t *testing.T) {
 			wantTopology:  "",
 			checkTopology: true,
 		},
+		{
+			name: "Worker pod gets topology from NodeSet topology labels",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(nodeset.DeepCopy(), nodesetPod.DeepCopy(), nodeWithLabels.DeepCopy()).
+				Build(),
+			args: args{
+				ctx: context.TODO(),
+				binding: &corev1.Binding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      nodesetPod.Name,
+						Namespace: nodesetPod.Namespace,
+					},
+					Target: corev1.ObjectReference{Name: nodeWithLabels.Name},
+				},
+			},
+			wantErr:       false,
+			wantTopology:  "topo-block:b3,topo-switch:zone-a",
+			checkTopology: true,
+		},
+		{
+			name: "Worker pod gets resource spec from node hardware",
+			client: fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(resourceSpecNodeSet.DeepCopy(), resourceSpecPod.DeepCopy(), nodeWithHardware.DeepCopy()).
+				Build(),
+			args: args{
+				ctx: context.TODO(),
+				binding: &corev1.Binding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceSpecPod.Name,
+						Namespace: resourceSpecPod.Namespace,
+					},
+					Target: corev1.ObjectReference{Name: nodeWithHardware.Name},
+				},
+			},
+			wantErr:       false,
+			wantTopology:  "",
+			checkTopology: true,
+			wantResources: "Sockets=1 CoresPerSocket=4 ThreadsPerCore=2 RealMemory=16384 CpuSpecList=0-1",
+		},
 		{
 			name:   "Non-worker pod is skipped",
 			client: fake.NewFakeClient(nonWorkerPod.DeepCopy()),
@@ -201,6 +313,7 @@ func TestPodBindingWebhook_Default(t *testing.T) {
 				podKey := client.ObjectKeyFromObject(tt.args.binding)
 				require.NoError(t, tt.client.Get(tt.args.ctx, podKey, gotPod))
 				require.Equal(t, tt.wantTopology, gotPod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec])
+				require.Equal(t, tt.wantResources, gotPod.Annotations[slinkyv1beta1.AnnotationPodResourceSpec])
 			}
 		})
 	}
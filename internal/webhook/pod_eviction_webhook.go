@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+const (
+	// evictionRetryAfterSeconds is the suggested delay before an eviction is retried.
+	evictionRetryAfterSeconds = 10
+)
+
+type PodEvictionWebhook struct {
+	client.Client
+}
+
+// log is for logging in this package.
+var evictionlog = logf.Log.WithName("eviction-resource")
+
+func (r *PodEvictionWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &policyv1.Eviction{}).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;update;patch;watch
+// +kubebuilder:webhook:path=/validate-policy-v1-eviction,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=NoneOnDryRun,groups="",resources=pods/eviction,verbs=create,versions=v1,name=podseviction-v1.kb.io,admissionReviewVersions=v1
+
+var _ admission.Validator[*policyv1.Eviction] = &PodEvictionWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *PodEvictionWebhook) ValidateCreate(ctx context.Context, eviction *policyv1.Eviction) (admission.Warnings, error) {
+	evictionlog.V(1).Info("validate create", "eviction", klog.KObj(eviction))
+
+	pod := &corev1.Pod{}
+	podKey := client.ObjectKeyFromObject(eviction)
+	if req, err := admission.RequestFromContext(ctx); err == nil && podKey.Namespace == "" {
+		// The eviction body may omit the namespace of the pod.
+		podKey.Namespace = req.Namespace
+	}
+	if err := r.Get(ctx, podKey, pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not fetch pod for eviction: %w", err)
+	}
+
+	podLabels := pod.GetLabels()
+	if len(podLabels) == 0 || podLabels[labels.AppLabel] != labels.WorkerApp {
+		evictionlog.V(1).Info("ignoring pod", "pod", klog.KObj(pod))
+		return nil, nil
+	}
+
+	// Only a running pod can host a Slurm node with running jobs.
+	if !podutils.IsRunning(pod) || podutils.IsTerminating(pod) {
+		return nil, nil
+	}
+
+	switch {
+	case !hasSlurmNodeConditions(pod):
+		evictionlog.Info("allow eviction of pod without a Slurm node", "pod", klog.KObj(pod))
+		return nil, nil
+	case slurmconditions.IsNodeDrained(&pod.Status):
+		evictionlog.Info("allow eviction of drained pod", "pod", klog.KObj(pod))
+		return nil, nil
+	case slurmconditions.IsConditionTrue(&pod.Status, slurmconditions.PodConditionDown) &&
+		!slurmconditions.IsNodeBusy(&pod.Status):
+		// The NodeSet controller does not drain an unresponsive Slurm node.
+		evictionlog.Info("allow eviction of down pod", "pod", klog.KObj(pod))
+		return nil, nil
+	}
+
+	if !podutils.IsPodCordon(pod) && !isDryRun(ctx) {
+		// The NodeSet controller drains the Slurm node of a cordoned pod.
+		mutateFn := func(pod *corev1.Pod) error {
+			if pod.Annotations == nil {
+				pod.Annotations = make(map[string]string)
+			}
+			pod.Annotations[slinkyv1beta1.AnnotationPodCordon] = "true"
+			return nil
+		}
+		if err := objectutils.PatchObject(r.Client, ctx, pod, mutateFn); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			evictionlog.Error(err, "failed to cordon pod", "pod", klog.KObj(pod))
+			return nil, err
+		}
+		evictionlog.Info("cordoned pod for eviction", "pod", klog.KObj(pod))
+	}
+
+	msg := fmt.Sprintf("pod %s is draining its Slurm node, eviction is allowed once the Slurm node is drained",
+		klog.KObj(pod))
+	return nil, apierrors.NewTooManyRequests(msg, evictionRetryAfterSeconds)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *PodEvictionWebhook) ValidateUpdate(ctx context.Context, oldEviction, newEviction *policyv1.Eviction) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *PodEvictionWebhook) ValidateDelete(ctx context.Context, eviction *policyv1.Eviction) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// isDryRun returns true if the admission request does not persist its side effects.
+func isDryRun(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return false
+	}
+	return ptr.Deref(req.DryRun, false)
+}
+
+// hasSlurmNodeConditions returns true if the pod reports the state of its Slurm node.
+func hasSlurmNodeConditions(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if strings.HasPrefix(string(cond.Type), slurmconditions.PodStatePrefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func TestPodEvictionWebhook_ValidateCreate(t *testing.T) {
+	newWorkerPod := func(conditions ...corev1.PodConditionType) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "worker-0",
+				Namespace: corev1.NamespaceDefault,
+				Labels: map[string]string{
+					labels.AppLabel: labels.WorkerApp,
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+			},
+		}
+		for _, condType := range conditions {
+			pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+				Type:   condType,
+				Status: corev1.ConditionTrue,
+			})
+		}
+		return pod
+	}
+
+	nonWorkerPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-pod",
+			Namespace: corev1.NamespaceDefault,
+			Labels: map[string]string{
+				labels.AppLabel: "nginx",
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+
+	newEviction := func(pod *corev1.Pod) *policyv1.Eviction {
+		return &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+			},
+		}
+	}
+
+	busyPod := newWorkerPod(slurmconditions.PodConditionAllocated)
+	drainingPod := newWorkerPod(slurmconditions.PodConditionAllocated, slurmconditions.PodConditionDrain)
+	drainingPod.Annotations = map[string]string{slinkyv1beta1.AnnotationPodCordon: "true"}
+	drainedPod := newWorkerPod(slurmconditions.PodConditionIdle, slurmconditions.PodConditionDrain)
+	downPod := newWorkerPod(slurmconditions.PodConditionDown, slurmconditions.PodConditionNotResponding)
+	unregisteredPod := newWorkerPod()
+	pendingPod := newWorkerPod(slurmconditions.PodConditionAllocated)
+	pendingPod.Status.Phase = corev1.PodPending
+
+	dryRunCtx := admission.NewContextWithRequest(context.TODO(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			DryRun: ptr.To(true),
+		},
+	})
+
+	type args struct {
+		ctx      context.Context
+		eviction *policyv1.Eviction
+	}
+	tests := []struct {
+		name            string
+		client          client.Client
+		args            args
+		wantErr         bool
+		wantTooMany     bool
+		checkCordon     bool
+		wantCordonedPod bool
+	}{
+		{
+			name:   "Non-worker pod is allowed",
+			client: fake.NewFakeClient(nonWorkerPod.DeepCopy()),
+			args: args{
+				ctx:      context.TODO(),
+				eviction: newEviction(nonWorkerPod),
+			},
+		},
+		{
+			name:   "Pod not found is allowed",
+			client: fake.NewFakeClient(),
+			args: args{
+				ctx:      context.TODO(),
+				eviction: newEviction(busyPod),
+			},
+		},
+		{
+			name:   "Pending pod is allowed",
+			client: fake.NewFakeClient(pendingPod.DeepCopy()),
+			args: args{
+				ctx:      context.TODO(),
+				eviction: newEviction(pendingPod),
+			},
+		},
+		{
+			name:   "Pod without Slurm node is allowed",
+			client: fake.NewFakeClient(unregisteredPod.DeepCopy()),
+			args: args{
+				ctx:      context.TODO(),
+				eviction: newEviction(unregisteredPod),
+			},
+		},
+		{
+			name:   "Drained pod is allowed",
+			client: fake.NewFakeClient(drainedPod.DeepCopy()),
+			args: args{
+				ctx:      context.TODO(),
+				eviction: newEviction(drainedPod),
+			},
+		},
+		{
+			name:   "Down pod is allowed",
+			client: fake.NewFakeClient(downPod.DeepCopy()),
+			args: args{
+				ctx:      context.TODO(),
+				eviction: newEviction(downPod),
+			},
+		},
+		{
+			name:   "Busy pod is cordoned and rejected",
+			client: fake.NewFakeClient(busyPod.DeepCopy()),
+			args: args{
+				ctx:      context.TODO(),
+				eviction: newEviction(busyPod),
+			},
+			wantErr:         true,
+			wantTooMany:     true,
+			checkCordon:     true,
+			wantCordonedPod: true,
+		},
+		{
+			name:   "Draining pod is rejected",
+			client: fake.NewFakeClient(drainingPod.DeepCopy()),
+			args: args{
+				ctx:      context.TODO(),
+				eviction: newEviction(drainingPod),
+			},
+			wantErr:         true,
+			wantTooMany:     true,
+			checkCordon:     true,
+			wantCordonedPod: true,
+		},
+		{
+			name:   "Busy pod is not cordoned on dry run",
+			client: fake.NewFakeClient(busyPod.DeepCopy()),
+			args: args{
+				ctx:      dryRunCtx,
+				eviction: newEviction(busyPod),
+			},
+			wantErr:         true,
+			wantTooMany:     true,
+			checkCordon:     true,
+			wantCordonedPod: false,
+		},
+		{
+			name: "Patch failure returns error",
+			client: fake.NewClientBuilder().
+				WithRuntimeObjects(busyPod.DeepCopy()).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, client client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						return http.ErrAbortHandler
+					},
+				}).
+				Build(),
+			args: args{
+				ctx:      context.TODO(),
+				eviction: newEviction(busyPod),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PodEvictionWebhook{Client: tt.client}
+			_, err := r.ValidateCreate(tt.args.ctx, tt.args.eviction)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantTooMany, apierrors.IsTooManyRequests(err))
+			if tt.checkCordon {
+				gotPod := &corev1.Pod{}
+				podKey := client.ObjectKeyFromObject(tt.args.eviction)
+				require.NoError(t, tt.client.Get(tt.args.ctx, podKey, gotPod))
+				_, gotCordonedPod := gotPod.Annotations[slinkyv1beta1.AnnotationPodCordon]
+				require.Equal(t, tt.wantCordonedPod, gotCordonedPod)
+			}
+		})
+	}
+}
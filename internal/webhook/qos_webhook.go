@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=qoses,verbs=delete;create;update
+
+type QOSWebhook struct{}
+
+// log is for logging in this package.
+var qoslog = logf.Log.WithName("qos-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *QOSWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &slinkyv1beta1.QOS{}).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-qos,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=qoses,verbs=create;update,versions=v1beta1,name=qos-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ admission.Validator[*slinkyv1beta1.QOS] = &QOSWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *QOSWebhook) ValidateCreate(ctx context.Context, qos *slinkyv1beta1.QOS) (admission.Warnings, error) {
+	qoslog.Info("validate create", "qos", klog.KObj(qos))
+
+	warns, errs := r.validateQOS(qos)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *QOSWebhook) ValidateUpdate(ctx context.Context, oldQos, newQos *slinkyv1beta1.QOS) (admission.Warnings, error) {
+	qoslog.Info("validate update", "newQos", klog.KObj(newQos))
+
+	warns, errs := r.validateQOS(newQos)
+
+	if !apiequality.Semantic.DeepEqual(newQos.Spec.SlurmdbRef, oldQos.Spec.SlurmdbRef) {
+		errs = append(errs, errors.New("cannot change controllerRef or accountingRef after deployment"))
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *QOSWebhook) ValidateDelete(ctx context.Context, qos *slinkyv1beta1.QOS) (admission.Warnings, error) {
+	qoslog.Info("validate delete", "qos", klog.KObj(qos))
+
+	return nil, nil
+}
+
+func (r *QOSWebhook) validateQOS(qos *slinkyv1beta1.QOS) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	spec := qos.Spec
+
+	errs = append(errs, validateSlurmdbRef(spec.SlurmdbRef)...)
+
+	if spec.Priority != nil && *spec.Priority < 0 {
+		errs = append(errs, errors.New("priority must not be negative"))
+	}
+	for _, flag := range spec.Flags {
+		if flag == "" || strings.ContainsAny(flag, ", \t\r\n") {
+			errs = append(errs, fmt.Errorf("flags contains an invalid flag %q", flag))
+		}
+	}
+	for _, preempt := range spec.Preempt {
+		if preempt == "" || strings.ContainsAny(preempt, ", \t\r\n") {
+			errs = append(errs, fmt.Errorf("preempt contains an invalid QOS name %q", preempt))
+		}
+	}
+	if slices.Contains(spec.Preempt, qos.Name) {
+		errs = append(errs, errors.New("preempt cannot contain the QOS itself"))
+	}
+
+	limits := spec.Limits
+	errs = append(errs, validateTres("limits.grpTRES", limits.GrpTRES)...)
+	errs = append(errs, validateTres("limits.maxTRESPerJob", limits.MaxTRESPerJob)...)
+	errs = append(errs, validateTres("limits.maxTRESPerNode", limits.MaxTRESPerNode)...)
+	errs = append(errs, validateTres("limits.maxTRESPerUser", limits.MaxTRESPerUser)...)
+	if limits.MaxWallDurationPerJob != nil && limits.MaxWallDurationPerJob.Minutes() < 1 {
+		errs = append(errs, errors.New("limits.maxWallDurationPerJob must be at least 1 minute"))
+	}
+
+	return warns, errs
+}
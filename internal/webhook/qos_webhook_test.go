@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("QOS Webhook", func() {
+	Context("When Creating a QOS with Validating Webhook", func() {
+		It("Should deny if neither controllerRef nor accountingRef are set", func(ctx SpecContext) {
+			qos := testutils.NewQOS("high", nil)
+
+			_, err := qosWebhook.ValidateCreate(ctx, qos)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if the QOS preempts itself", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			qos := testutils.NewQOS("high", controller)
+			qos.Spec.Preempt = []string{"high"}
+
+			_, err := qosWebhook.ValidateCreate(ctx, qos)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if maxWallDurationPerJob is less than 1 minute", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			qos := testutils.NewQOS("high", controller)
+			qos.Spec.Limits.MaxWallDurationPerJob = &metav1.Duration{Duration: 30 * time.Second}
+
+			_, err := qosWebhook.ValidateCreate(ctx, qos)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if a TRES limit is negative", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			qos := testutils.NewQOS("high", controller)
+			qos.Spec.Limits.MaxTRESPerUser = map[string]int64{"gres/gpu": -1}
+
+			_, err := qosWebhook.ValidateCreate(ctx, qos)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if all required fields are provided", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			qos := testutils.NewQOS("high", controller)
+			qos.Spec.Priority = ptr.To[int32](100)
+			qos.Spec.Flags = []string{"DENY_LIMIT"}
+			qos.Spec.Preempt = []string{"scavenger"}
+			qos.Spec.PreemptMode = "REQUEUE"
+			qos.Spec.Limits.MaxTRESPerUser = map[string]int64{"gres/gpu": 8}
+
+			warns, err := qosWebhook.ValidateCreate(ctx, qos)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(BeEmpty())
+		})
+	})
+
+	Context("When Updating a QOS with Validating Webhook", func() {
+		It("Should reject changes to controllerRef", func(ctx SpecContext) {
+			oldController := testutils.NewController("old-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			newController := testutils.NewController("new-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldQos := testutils.NewQOS("high", oldController)
+			newQos := testutils.NewQOS("high", newController)
+
+			_, err := qosWebhook.ValidateUpdate(ctx, oldQos, newQos)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit changes to the priority", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			oldQos := testutils.NewQOS("high", controller)
+			newQos := oldQos.DeepCopy()
+			newQos.Spec.Priority = ptr.To[int32](200)
+
+			_, err := qosWebhook.ValidateUpdate(ctx, oldQos, newQos)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
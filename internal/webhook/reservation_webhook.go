@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/puttsk/hostlist"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=reservations,verbs=delete;create;update
+
+type ReservationWebhook struct{}
+
+// log is for logging in this package.
+var reservationlog = logf.Log.WithName("reservation-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *ReservationWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &slinkyv1beta1.Reservation{}).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-reservation,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=reservations,verbs=create;update,versions=v1beta1,name=reservation-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ admission.Validator[*slinkyv1beta1.Reservation] = &ReservationWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *ReservationWebhook) ValidateCreate(ctx context.Context, reservation *slinkyv1beta1.Reservation) (admission.Warnings, error) {
+	reservationlog.Info("validate create", "reservation", klog.KObj(reservation))
+
+	warns, errs := r.validateReservation(reservation)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ReservationWebhook) ValidateUpdate(ctx context.Context, oldReservation, newReservation *slinkyv1beta1.Reservation) (admission.Warnings, error) {
+	reservationlog.Info("validate update", "newReservation", klog.KObj(newReservation))
+
+	warns, errs := r.validateReservation(newReservation)
+
+	if !apiequality.Semantic.DeepEqual(newReservation.Spec.ControllerRef, oldReservation.Spec.ControllerRef) {
+		errs = append(errs, errors.New("cannot change controllerRef after deployment"))
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ReservationWebhook) ValidateDelete(ctx context.Context, reservation *slinkyv1beta1.Reservation) (admission.Warnings, error) {
+	reservationlog.Info("validate delete", "reservation", klog.KObj(reservation))
+
+	return nil, nil
+}
+
+func (r *ReservationWebhook) validateReservation(reservation *slinkyv1beta1.Reservation) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	spec := reservation.Spec
+
+	if spec.ControllerRef.Name == "" {
+		errs = append(errs, errors.New("controllerRef.name must not be empty"))
+	}
+
+	if spec.StartTime.IsZero() {
+		errs = append(errs, errors.New("startTime must be set"))
+	}
+
+	switch {
+	case spec.Duration != nil && spec.EndTime != nil:
+		errs = append(errs, errors.New("duration and endTime are mutually exclusive"))
+	case spec.Duration != nil:
+		if spec.Duration.Duration < time.Minute {
+			errs = append(errs, errors.New("duration must be at least 1 minute"))
+		}
+	case spec.EndTime != nil:
+		if !spec.EndTime.After(spec.StartTime.Time) {
+			errs = append(errs, errors.New("endTime must be after startTime"))
+		}
+	default:
+		errs = append(errs, errors.New("one of duration or endTime must be set"))
+	}
+
+	var endTime time.Time
+	if spec.EndTime != nil {
+		endTime = spec.EndTime.Time
+	} else if spec.Duration != nil {
+		endTime = spec.StartTime.Add(spec.Duration.Duration)
+	}
+	if !endTime.IsZero() && endTime.Before(time.Now()) {
+		warns = append(warns, "reservation has already ended, it will not be created in Slurm")
+	}
+
+	if len(spec.Users) == 0 && len(spec.Accounts) == 0 {
+		errs = append(errs, errors.New("at least one of users or accounts must be set"))
+	}
+	for _, user := range spec.Users {
+		if user == "" || strings.ContainsAny(user, ", \t\r\n") {
+			errs = append(errs, fmt.Errorf("users contains an invalid user name %q", user))
+		}
+	}
+	for _, account := range spec.Accounts {
+		if account == "" || strings.ContainsAny(account, ", \t\r\n") {
+			errs = append(errs, fmt.Errorf("accounts contains an invalid account name %q", account))
+		}
+	}
+	for _, flag := range spec.Flags {
+		if flag == "" || strings.ContainsAny(flag, ", \t\r\n") {
+			errs = append(errs, fmt.Errorf("flags contains an invalid flag %q", flag))
+		}
+	}
+
+	if len(spec.NodeSets) == 0 && spec.NodeList == "" {
+		errs = append(errs, errors.New("at least one of nodeSets or nodeList must be set"))
+	}
+	if spec.NodeList != "" {
+		if _, err := hostlist.Expand(spec.NodeList); err != nil {
+			errs = append(errs, fmt.Errorf("nodeList is not a valid hostlist: %w", err))
+		}
+	}
+
+	return warns, errs
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("Reservation Webhook", func() {
+	Context("When Creating a Reservation with Validating Webhook", func() {
+		It("Should deny if controllerRef.name is empty", func(ctx SpecContext) {
+			nodeset := testutils.NewNodeset("test-nodeset", nil, 1)
+			reservation := testutils.NewReservation("test-reservation", nil, nodeset)
+
+			_, err := reservationWebhook.ValidateCreate(ctx, reservation)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if both duration and endTime are set", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			reservation := testutils.NewReservation("test-reservation", controller, nodeset)
+			reservation.Spec.EndTime = &metav1.Time{Time: reservation.Spec.StartTime.Add(time.Hour)}
+
+			_, err := reservationWebhook.ValidateCreate(ctx, reservation)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if endTime is before startTime", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			reservation := testutils.NewReservation("test-reservation", controller, nodeset)
+			reservation.Spec.Duration = nil
+			reservation.Spec.EndTime = &metav1.Time{Time: reservation.Spec.StartTime.Add(-time.Hour)}
+
+			_, err := reservationWebhook.ValidateCreate(ctx, reservation)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if duration is less than 1 minute", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			reservation := testutils.NewReservation("test-reservation", controller, nodeset)
+			reservation.Spec.Duration = &metav1.Duration{Duration: 30 * time.Second}
+
+			_, err := reservationWebhook.ValidateCreate(ctx, reservation)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if neither users nor accounts are set", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			reservation := testutils.NewReservation("test-reservation", controller, nodeset)
+			reservation.Spec.Users = nil
+
+			_, err := reservationWebhook.ValidateCreate(ctx, reservation)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if neither nodeSets nor nodeList are set", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			reservation := testutils.NewReservation("test-reservation", controller)
+
+			_, err := reservationWebhook.ValidateCreate(ctx, reservation)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if nodeList is not a valid hostlist", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			reservation := testutils.NewReservation("test-reservation", controller)
+			reservation.Spec.NodeList = "node-[0-"
+
+			_, err := reservationWebhook.ValidateCreate(ctx, reservation)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should warn if the reservation has already ended", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			reservation := testutils.NewReservation("test-reservation", controller)
+			reservation.Spec.NodeList = "node-[0-3]"
+			reservation.Spec.StartTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+
+			warns, err := reservationWebhook.ValidateCreate(ctx, reservation)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).NotTo(BeEmpty())
+		})
+
+		It("Should admit if all required fields are provided", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			reservation := testutils.NewReservation("test-reservation", controller, nodeset)
+			reservation.Spec.NodeList = "node-[0-3]"
+			reservation.Spec.Accounts = []string{"physics"}
+			reservation.Spec.Flags = []string{"MAINT", "IGNORE_JOBS"}
+
+			warns, err := reservationWebhook.ValidateCreate(ctx, reservation)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(BeEmpty())
+		})
+	})
+
+	Context("When Updating a Reservation with Validating Webhook", func() {
+		It("Should reject changes to controllerRef", func(ctx SpecContext) {
+			oldController := testutils.NewController("old-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			newController := testutils.NewController("new-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", oldController, 1)
+			oldReservation := testutils.NewReservation("test-reservation", oldController, nodeset)
+			newReservation := testutils.NewReservation("test-reservation", newController, nodeset)
+
+			_, err := reservationWebhook.ValidateUpdate(ctx, oldReservation, newReservation)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit changes to the schedule", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			oldReservation := testutils.NewReservation("test-reservation", controller, nodeset)
+			newReservation := oldReservation.DeepCopy()
+			newReservation.Spec.Duration = &metav1.Duration{Duration: 2 * time.Hour}
+
+			_, err := reservationWebhook.ValidateUpdate(ctx, oldReservation, newReservation)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
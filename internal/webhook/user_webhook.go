@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/set"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=users,verbs=delete;create;update
+
+type UserWebhook struct{}
+
+// log is for logging in this package.
+var userlog = logf.Log.WithName("user-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *UserWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &slinkyv1beta1.User{}).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-user,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=users,verbs=create;update,versions=v1beta1,name=user-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ admission.Validator[*slinkyv1beta1.User] = &UserWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *UserWebhook) ValidateCreate(ctx context.Context, user *slinkyv1beta1.User) (admission.Warnings, error) {
+	userlog.Info("validate create", "user", klog.KObj(user))
+
+	warns, errs := r.validateUser(user)
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *UserWebhook) ValidateUpdate(ctx context.Context, oldUser, newUser *slinkyv1beta1.User) (admission.Warnings, error) {
+	userlog.Info("validate update", "newUser", klog.KObj(newUser))
+
+	warns, errs := r.validateUser(newUser)
+
+	if !apiequality.Semantic.DeepEqual(newUser.Spec.SlurmdbRef, oldUser.Spec.SlurmdbRef) {
+		errs = append(errs, errors.New("cannot change controllerRef or accountingRef after deployment"))
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *UserWebhook) ValidateDelete(ctx context.Context, user *slinkyv1beta1.User) (admission.Warnings, error) {
+	userlog.Info("validate delete", "user", klog.KObj(user))
+
+	return nil, nil
+}
+
+func (r *UserWebhook) validateUser(user *slinkyv1beta1.User) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	spec := user.Spec
+
+	errs = append(errs, validateSlurmdbRef(spec.SlurmdbRef)...)
+
+	if len(spec.Associations) == 0 {
+		errs = append(errs, errors.New("at least one association must be set"))
+	}
+
+	accounts := set.New[string]()
+	associations := set.New[string]()
+	for i, association := range spec.Associations {
+		if association.Account == "" {
+			errs = append(errs, fmt.Errorf("associations[%d].account must not be empty", i))
+		}
+		key := association.Account + "/" + association.Partition
+		if associations.Has(key) {
+			errs = append(errs, fmt.Errorf("associations[%d] duplicates account %q and partition %q",
+				i, association.Account, association.Partition))
+		}
+		associations.Insert(key)
+		accounts.Insert(association.Account)
+
+		errs = append(errs, validateQosList(association.DefaultQOS, association.QOS)...)
+		errs = append(errs, validateAssociationLimits(fmt.Sprintf("associations[%d].limits", i), association.Limits)...)
+	}
+
+	if spec.DefaultAccount != "" && !accounts.Has(spec.DefaultAccount) {
+		errs = append(errs, fmt.Errorf("defaultAccount %q must be the account of an association", spec.DefaultAccount))
+	}
+
+	return warns, errs
+}
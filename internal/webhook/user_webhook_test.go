@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+var _ = Describe("User Webhook", func() {
+	Context("When Creating a User with Validating Webhook", func() {
+		It("Should deny if neither controllerRef nor accountingRef are set", func(ctx SpecContext) {
+			account := testutils.NewAccount("physics", nil)
+			user := testutils.NewUser("alice", nil, account)
+
+			_, err := userWebhook.ValidateCreate(ctx, user)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if there are no associations", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			user := testutils.NewUser("alice", controller)
+
+			_, err := userWebhook.ValidateCreate(ctx, user)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if associations are duplicated", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewAccount("physics", controller)
+			user := testutils.NewUser("alice", controller, account, account)
+
+			_, err := userWebhook.ValidateCreate(ctx, user)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if defaultAccount is not an association", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewAccount("physics", controller)
+			user := testutils.NewUser("alice", controller, account)
+			user.Spec.DefaultAccount = "chemistry"
+
+			_, err := userWebhook.ValidateCreate(ctx, user)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit if all required fields are provided", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewAccount("physics", controller)
+			user := testutils.NewUser("alice", controller, account)
+			user.Spec.DefaultAccount = "physics"
+			user.Spec.Associations = append(user.Spec.Associations, slinkyv1beta1.UserAssociation{
+				Account:   "physics",
+				Partition: "gpu",
+			})
+
+			warns, err := userWebhook.ValidateCreate(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(BeEmpty())
+		})
+	})
+
+	Context("When Updating a User with Validating Webhook", func() {
+		It("Should reject changes to controllerRef", func(ctx SpecContext) {
+			oldController := testutils.NewController("old-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			newController := testutils.NewController("new-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewAccount("physics", oldController)
+			oldUser := testutils.NewUser("alice", oldController, account)
+			newUser := testutils.NewUser("alice", newController, account)
+
+			_, err := userWebhook.ValidateUpdate(ctx, oldUser, newUser)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit changes to the admin level", func(ctx SpecContext) {
+			controller := testutils.NewController("valid-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			account := testutils.NewAccount("physics", controller)
+			oldUser := testutils.NewUser("alice", controller, account)
+			newUser := oldUser.DeepCopy()
+			newUser.Spec.AdminLevel = slinkyv1beta1.AdminLevelOperator
+
+			_, err := userWebhook.ValidateUpdate(ctx, oldUser, newUser)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -41,12 +41,17 @@ var testEnv *envtest.Environment
 var ctx context.Context
 var cancel context.CancelFunc
 
+var accountWebhook AccountWebhook
 var accountingWebhook AccountingWebhook
 var controllerWebhook ControllerWebhook
 var loginSetWebhook LoginSetWebhook
 var nodeSetWebhook NodeSetWebhook
+var partitionWebhook PartitionWebhook
+var qosWebhook QOSWebhook
+var reservationWebhook ReservationWebhook
 var restapiWebhook RestapiWebhook
 var tokenWebhook TokenWebhook
+var userWebhook UserWebhook
 
 func TestAPIs(t *testing.T) {
 	RegisterFailHandler(Fail)
@@ -118,14 +123,40 @@ var _ = BeforeSuite(func() {
 	err = (&loginSetWebhook).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	nodeSetWebhook = NodeSetWebhook{
+		Client: mgr.GetClient(),
+	}
 	err = (&nodeSetWebhook).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	partitionWebhook = PartitionWebhook{
+		Client: mgr.GetClient(),
+	}
+	err = (&partitionWebhook).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&reservationWebhook).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&accountWebhook).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&qosWebhook).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&userWebhook).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = (&PodBindingWebhook{
 		Client: mgr.GetClient(),
 	}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = (&PodEvictionWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = (&restapiWebhook).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -37,6 +37,32 @@ const (
 	NodeSetConditionReservationCreated = "ReservationCreated"
 )
 
+const (
+	// Reservation Condition Type
+	ReservationConditionSynced = "Synced"
+)
+
+const (
+	// Account, User, and QOS Condition Type
+	SlurmdbConditionSynced = "Synced"
+)
+
+const (
+	// Controller Condition Type
+	ControllerConditionReady              = "Ready"
+	ControllerConditionConfigApplied      = "ConfigApplied"
+	ControllerConditionSlurmctldReachable = "SlurmctldReachable"
+	ControllerConditionBackupSucceeded    = "BackupSucceeded"
+)
+
+const (
+	// Accounting Condition Type
+	AccountingConditionDatabaseReady     = "DatabaseReady"
+	AccountingConditionDatabaseReachable = "DatabaseReachable"
+	AccountingConditionSchemaVersion     = "SchemaVersion"
+	AccountingConditionSlurmdbdReady     = "SlurmdbdReady"
+)
+
 func IsConditionTrue(status *corev1.PodStatus, condType corev1.PodConditionType) bool {
 	_, cond := podutil.GetPodCondition(status, condType)
 	return cond != nil && cond.Status == corev1.ConditionTrue